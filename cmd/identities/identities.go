@@ -0,0 +1,55 @@
+package identities
+
+import (
+	"log"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/database"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/mailer"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user_identity/storage"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user_identity/usecase"
+	"github.com/cesc1802/share-module/config"
+	"github.com/spf13/cobra"
+)
+
+var alertDays int
+
+var checkIdentitiesCmd = &cobra.Command{
+	Use:   "check-identities",
+	Short: "Invalidate expired identity documents and alert the owners of documents that expire soon",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if alertDays < 0 {
+			log.Fatalln("--alert-days must not be negative")
+		}
+
+		_, err := config.LoadAppConfig(".")
+		if err != nil {
+			log.Fatalln(err)
+			return err
+		}
+		db, err := database.OpenFromEnv()
+		if err != nil {
+			log.Fatalln(err)
+			return err
+		}
+
+		checker := usecase.NewExpiryChecker(
+			storage.NewUserIdentityRepository(db),
+			mailer.NewTemplateMailer(mailer.NewFromEnv()),
+		)
+		report, err := checker.Check(time.Now(), alertDays)
+		if report != nil {
+			log.Printf("%d document(s) expired, %d owner(s) alerted, %d alert(s) failed", report.Expired, report.Alerted, report.Failed)
+		}
+		if err != nil {
+			log.Fatalln(err)
+			return err
+		}
+		return nil
+	},
+}
+
+func RegisterCheckIdentities(root *cobra.Command) {
+	checkIdentitiesCmd.Flags().IntVar(&alertDays, "alert-days", 30, "alert owners of documents that expire within this many days")
+	root.AddCommand(checkIdentitiesCmd)
+}
@@ -0,0 +1,55 @@
+package identities
+
+import (
+	"log"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/database"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/encryption"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user_identity/storage"
+	"github.com/cesc1802/share-module/config"
+	"github.com/spf13/cobra"
+)
+
+var batchSize int
+
+var reencryptIdentitiesCmd = &cobra.Command{
+	Use:   "reencrypt-identities",
+	Short: "Seal identity document numbers with the current encryption key and fill in their blind indexes",
+	Long: "Seals the numbers stored before encryption was introduced, and the numbers sealed with an older key " +
+		"after ENCRYPTION_KEYS was rotated. Older keys can be removed from ENCRYPTION_KEYS once it has run.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if batchSize < 1 {
+			log.Fatalln("--batch-size must be at least 1")
+		}
+
+		_, err := config.LoadAppConfig(".")
+		if err != nil {
+			log.Fatalln(err)
+			return err
+		}
+		keyring, err := encryption.NewKeyringFromEnv()
+		if err != nil {
+			log.Fatalln(err)
+			return err
+		}
+		encryption.Use(keyring)
+		db, err := database.OpenFromEnv()
+		if err != nil {
+			log.Fatalln(err)
+			return err
+		}
+
+		saved, err := storage.NewUserIdentityRepository(db).ReencryptIdentities(batchSize)
+		log.Printf("%d identity document(s) sealed with the current key", saved)
+		if err != nil {
+			log.Fatalln(err)
+			return err
+		}
+		return nil
+	},
+}
+
+func RegisterReencryptIdentities(root *cobra.Command) {
+	reencryptIdentitiesCmd.Flags().IntVar(&batchSize, "batch-size", 500, "number of documents loaded at a time")
+	root.AddCommand(reencryptIdentitiesCmd)
+}
@@ -1,32 +1,68 @@
 package migrate
 
 import (
+	"fmt"
 	"log"
+	"strconv"
+	"text/tabwriter"
 
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/hasher"
+	authStorage "github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/storage"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/database"
 	"github.com/cesc1802/onboarding-and-volunteer-service/migration"
 	"github.com/cesc1802/share-module/config"
-	"github.com/cesc1802/share-module/system"
 	"github.com/spf13/cobra"
 )
 
 var migrate = &cobra.Command{
 	Use:   "migrate",
-	Short: "",
+	Short: "Apply, revert and inspect database migrations",
 }
 
 var up = &cobra.Command{
-	Use:   "up",
-	Short: "This will be use to migrate new change",
+	Use:   "up [N]",
+	Short: "Apply the N oldest pending migrations, or all of them",
+	Args:  cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		n, err := count(args, 0)
+		if err != nil {
+			return err
+		}
+		if err := newMigrator().Up(n); err != nil {
+			log.Fatalln(err)
+			return err
+		}
+		return nil
+	},
+}
 
-		cfg, err := config.LoadAppConfig(".")
+var down = &cobra.Command{
+	Use:   "down [N]",
+	Short: "Revert the N newest applied migrations, the newest one by default",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		n, err := count(args, 1)
 		if err != nil {
+			return err
+		}
+		if err := newMigrator().Down(n); err != nil {
 			log.Fatalln(err)
 			return err
 		}
-		sys := system.New(cfg, cmd.Parent().Name())
+		return nil
+	},
+}
 
-		if err := sys.MigrateDB(migration.FS); err != nil {
+var gotoVersion = &cobra.Command{
+	Use:   "goto V",
+	Short: "Apply or revert migrations until V is the newest applied one, 0 to revert them all",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		version, err := parseVersion(args[0])
+		if err != nil {
+			return err
+		}
+		if err := newMigrator().Goto(version); err != nil {
 			log.Fatalln(err)
 			return err
 		}
@@ -34,15 +70,123 @@ var up = &cobra.Command{
 	},
 }
 
-var down = &cobra.Command{
-	Use:   "down",
-	Short: "This will be use to rollback new change",
-	Run: func(cmd *cobra.Command, args []string) {
-		log.Println("run rollback")
+var force = &cobra.Command{
+	Use:   "force V",
+	Short: "Record the migrations up to V as applied without running them",
+	Long: "Record the migrations up to V as applied and the newer ones as pending, without running any SQL. " +
+		"Use it to clear a migration that failed halfway once the database was fixed by hand, " +
+		"or to adopt a database whose schema was created by other means.",
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		version, err := parseVersion(args[0])
+		if err != nil {
+			return err
+		}
+		if err := newMigrator().Force(version); err != nil {
+			log.Fatalln(err)
+			return err
+		}
+		return nil
 	},
 }
 
+var status = &cobra.Command{
+	Use:   "status",
+	Short: "List the migrations and whether they were applied",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		statuses, err := newMigrator().Status()
+		if err != nil {
+			log.Fatalln(err)
+			return err
+		}
+
+		w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+		for _, s := range statuses {
+			state, appliedAt := "pending", ""
+			switch {
+			case s.Dirty:
+				state = "dirty"
+			case s.Missing:
+				state = "applied, no file"
+			case s.Applied:
+				state = "applied"
+			}
+			if s.AppliedAt != nil {
+				appliedAt = s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(w, "%06d\t%s\t%s\t%s\n", s.Version, s.Name, state, appliedAt)
+		}
+		return w.Flush()
+	},
+}
+
+var passwords = &cobra.Command{
+	Use:   "passwords",
+	Short: "Report how many accounts still store an unhashed password",
+	RunE: func(cmd *cobra.Command, args []string) error {
+
+		_, err := config.LoadAppConfig(".")
+		if err != nil {
+			log.Fatalln(err)
+			return err
+		}
+		db, err := database.OpenFromEnv()
+		if err != nil {
+			log.Fatalln(err)
+			return err
+		}
+
+		repo := authStorage.NewAuthenticationRepository(db)
+		count, err := repo.CountUnhashedPasswords(hasher.Prefixes())
+		if err != nil {
+			log.Fatalln(err)
+			return err
+		}
+		log.Printf("%d account(s) still store an unhashed password, they are rehashed on their next successful login", count)
+		return nil
+	},
+}
+
+func newMigrator() *migration.Migrator {
+	_, err := config.LoadAppConfig(".")
+	if err != nil {
+		log.Fatalln(err)
+	}
+	db, err := database.OpenFromEnv()
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	migrator, err := migration.New(db, migration.FS)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	return migrator
+}
+
+// count parses the optional N of up and down.
+func count(args []string, fallback int) (int, error) {
+	if len(args) == 0 {
+		return fallback, nil
+	}
+	n, err := strconv.Atoi(args[0])
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("N must be a positive number, got %q", args[0])
+	}
+	return n, nil
+}
+
+func parseVersion(arg string) (int64, error) {
+	version, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil || version < 0 {
+		return 0, fmt.Errorf("V must be a migration version, got %q", arg)
+	}
+	return version, nil
+}
+
 func RegisterMigrate(root *cobra.Command) {
-	migrate.AddCommand(up, down)
+	migrate.AddCommand(up, down, gotoVersion, force, status, passwords)
 	root.AddCommand(migrate)
 }
@@ -0,0 +1,52 @@
+package purge
+
+import (
+	"log"
+	"time"
+
+	authStorage "github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/storage"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/blob"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/database"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/retention"
+	"github.com/cesc1802/share-module/config"
+	"github.com/spf13/cobra"
+)
+
+var retentionDays int
+
+var purgeCmd = &cobra.Command{
+	Use:   "purge",
+	Short: "Permanently remove rows that were deleted longer ago than the retention window",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if retentionDays < 1 {
+			log.Fatalln("--retention-days must be at least 1")
+		}
+
+		_, err := config.LoadAppConfig(".")
+		if err != nil {
+			log.Fatalln(err)
+			return err
+		}
+		db, err := database.OpenFromEnv()
+		if err != nil {
+			log.Fatalln(err)
+			return err
+		}
+
+		cutoff := time.Now().AddDate(0, 0, -retentionDays)
+		results, err := retention.NewPurger(db, blob.NewFromEnv(authStorage.GetSecretKey())).Purge(cutoff)
+		for _, result := range results {
+			log.Printf("%s: %d row(s) purged, %d skipped", result.Table, result.Purged, result.Skipped)
+		}
+		if err != nil {
+			log.Fatalln(err)
+			return err
+		}
+		return nil
+	},
+}
+
+func RegisterPurge(root *cobra.Command) {
+	purgeCmd.Flags().IntVar(&retentionDays, "retention-days", 90, "purge rows deleted more than this many days ago")
+	root.AddCommand(purgeCmd)
+}
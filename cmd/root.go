@@ -3,7 +3,10 @@ package cmd
 import (
 	"log"
 
+	"github.com/cesc1802/onboarding-and-volunteer-service/cmd/identities"
 	migrate "github.com/cesc1802/onboarding-and-volunteer-service/cmd/migration"
+	"github.com/cesc1802/onboarding-and-volunteer-service/cmd/purge"
+	"github.com/cesc1802/onboarding-and-volunteer-service/cmd/seed"
 	"github.com/cesc1802/onboarding-and-volunteer-service/cmd/server"
 	"github.com/spf13/cobra"
 )
@@ -17,6 +20,10 @@ var rootCmd = &cobra.Command{
 func init() {
 	server.RegisterServer(rootCmd)
 	migrate.RegisterMigrate(rootCmd)
+	purge.RegisterPurge(rootCmd)
+	seed.RegisterSeed(rootCmd)
+	identities.RegisterCheckIdentities(rootCmd)
+	identities.RegisterReencryptIdentities(rootCmd)
 }
 
 func Execute() {
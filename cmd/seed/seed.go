@@ -0,0 +1,119 @@
+package seed
+
+import (
+	"log"
+	"math/rand"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/hasher"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/database"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/encryption"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/seed"
+	"github.com/cesc1802/share-module/config"
+	"github.com/spf13/cobra"
+)
+
+var (
+	fixtures         bool
+	applicants       int
+	volunteers       int
+	fixturesPassword string
+	randSeed         int64
+)
+
+var seedCmd = &cobra.Command{
+	Use:   "seed",
+	Short: "Create the missing roles, countries, departments and initial admin account, and optionally fake users",
+	Long: "Creates the canonical roles (applicant, volunteer, admin, COM, CVL, MNVC), the ISO 3166-1 countries, " +
+		"the default departments and, when SEED_ADMIN_EMAIL is set, the initial admin account. Existing rows are " +
+		"left untouched, so it can run " +
+		"after every migration. With --fixtures, it also generates fake applicants and volunteers with their requests " +
+		"and identity documents, for demos and load tests.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if applicants < 0 || volunteers < 0 {
+			log.Fatalln("--applicants and --volunteers must not be negative")
+		}
+
+		_, err := config.LoadAppConfig(".")
+		if err != nil {
+			log.Fatalln(err)
+			return err
+		}
+		db, err := database.OpenFromEnv()
+		if err != nil {
+			log.Fatalln(err)
+			return err
+		}
+		seeder := seed.NewSeeder(db, hasher.NewFromEnv())
+
+		results, err := seeder.SeedRoles()
+		report(results...)
+		if err != nil {
+			log.Fatalln(err)
+			return err
+		}
+		countries, err := seeder.SeedCountries()
+		report(countries)
+		if err != nil {
+			log.Fatalln(err)
+			return err
+		}
+		departments, err := seeder.SeedDepartments()
+		report(departments)
+		if err != nil {
+			log.Fatalln(err)
+			return err
+		}
+		if admin, ok := seed.AdminFromEnv(); ok {
+			result, err := seeder.SeedAdmin(admin)
+			report(result)
+			if err != nil {
+				log.Fatalln(err)
+				return err
+			}
+		} else {
+			log.Println("SEED_ADMIN_EMAIL is not set, no admin account was created")
+		}
+
+		if !fixtures {
+			return nil
+		}
+		keyring, err := encryption.NewKeyringFromEnv()
+		if err != nil {
+			log.Fatalln(err)
+			return err
+		}
+		encryption.Use(keyring)
+		if randSeed == 0 {
+			randSeed = time.Now().UnixNano()
+		}
+		log.Printf("generating fixtures with --rand-seed %d", randSeed)
+		results, err = seeder.SeedFixtures(seed.Fixtures{
+			Applicants: applicants,
+			Volunteers: volunteers,
+			Password:   fixturesPassword,
+			Rand:       rand.New(rand.NewSource(randSeed)),
+		})
+		report(results...)
+		if err != nil {
+			log.Fatalln(err)
+			return err
+		}
+		return nil
+	},
+}
+
+func report(results ...seed.Result) {
+	for _, result := range results {
+		log.Println(result)
+	}
+}
+
+func RegisterSeed(root *cobra.Command) {
+	seedCmd.Flags().BoolVar(&fixtures, "fixtures", false, "also generate fake applicants and volunteers")
+	seedCmd.Flags().IntVar(&applicants, "applicants", 50, "number of fake applicants generated with --fixtures")
+	seedCmd.Flags().IntVar(&volunteers, "volunteers", 20, "number of fake volunteers generated with --fixtures")
+	seedCmd.Flags().StringVar(&fixturesPassword, "fixtures-password", "password", "password of the fake users")
+	seedCmd.Flags().Int64Var(&randSeed, "rand-seed", 0, "seed of the fake data, random when 0; the same seed generates the same data")
+	root.AddCommand(seedCmd)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/database"
 	"github.com/cesc1802/share-module/config"
 	"github.com/cesc1802/share-module/system"
 	"github.com/spf13/cobra"
@@ -15,8 +16,17 @@ func (Module) Startup(ctx context.Context, mono system.Service) (err error) {
 	return Root(ctx, mono)
 }
 
+// Root registers the API on the pool that mono opens for MySQL, and opens a
+// pool of its own only for the drivers mono does not support.
 func Root(ctx context.Context, mono system.Service) error {
-	feature.RegisterHandlerV1(mono)
+	db := mono.DB()
+	if database.ConfigFromEnv().Driver != database.DriverMySQL {
+		var err error
+		if db, err = database.OpenFromEnv(); err != nil {
+			return err
+		}
+	}
+	feature.RegisterHandlerV1(mono, db)
 	return nil
 }
 
@@ -15,21 +15,21 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/v1/admin/add-reject-notes/{id}": {
+        "/api/v1/admin/approve-request/{id}": {
             "post": {
                 "security": [
                     {
                         "bearerToken": []
                     }
                 ],
-                "description": "Add reject notes",
+                "description": "Approve request",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "Add reject notes",
+                "summary": "Approve request",
                 "parameters": [
                     {
                         "type": "integer",
@@ -37,42 +37,220 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Request not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Request cannot move to the target status, or the user already has a volunteer record",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     },
+                    "422": {
+                        "description": "Requesting user cannot be approved",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/audit": {
+            "get": {
+                "security": [
                     {
-                        "description": "Add Reject Note Request",
-                        "name": "notes",
-                        "in": "body",
-                        "required": true,
+                        "bearerToken": []
+                    }
+                ],
+                "description": "List the audit log with filters and cursor pagination, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List audit events",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Actor user ID",
+                        "name": "actor_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Action, e.g. request.approve or POST /api/v1/admin/approve-request/:id",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity type, e.g. request, volunteer or http",
+                        "name": "entity_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity ID",
+                        "name": "entity_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Request ID (X-Request-ID)",
+                        "name": "request_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created at or after (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created before (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 1 to 500 (default 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AuditPage"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query",
                         "schema": {
-                            "$ref": "#/definitions/dto.AddRejectNoteRequest"
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     }
+                }
+            }
+        },
+        "/api/v1/admin/audit/export": {
+            "get": {
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
+                ],
+                "description": "Download every audit event matching the filters, newest first, as CSV or newline-delimited JSON",
+                "produces": [
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Export audit events",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "csv (default) or ndjson",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Actor user ID",
+                        "name": "actor_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Action",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity type",
+                        "name": "entity_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity ID",
+                        "name": "entity_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Request ID (X-Request-ID)",
+                        "name": "request_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created at or after (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created before (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query or format",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     }
                 }
             }
         },
-        "/api/v1/admin/approve-request/{id}": {
-            "post": {
+        "/api/v1/admin/delete-request/{id}": {
+            "delete": {
                 "security": [
                     {
                         "bearerToken": []
                     }
                 ],
-                "description": "Approve request",
+                "description": "Delete request",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "Approve request",
+                "summary": "Delete request",
                 "parameters": [
                     {
                         "type": "integer",
@@ -92,25 +270,83 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/admin/delete-request/{id}": {
-            "delete": {
+        "/api/v1/admin/identities/{id}/reject": {
+            "post": {
                 "security": [
                     {
                         "bearerToken": []
                     }
                 ],
-                "description": "Delete request",
+                "description": "Turn down a pending identity document",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "Delete request",
+                "summary": "Reject user identity",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Request ID",
+                        "description": "Identity ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Why the document is rejected",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RejectUserIdentityRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User identity rejected successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "User identity not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Identity document was already reviewed",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/identities/{id}/reveal-number": {
+            "post": {
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
+                ],
+                "description": "Full number of an identity document. Every reveal is recorded in the audit log, and the number is not revealed when it cannot be recorded.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Reveal identity number",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Identity ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -119,9 +355,67 @@ const docTemplate = `{
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.IdentityNumberResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User identity not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/identities/{id}/verify": {
+            "post": {
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
+                ],
+                "description": "Accept a pending identity document that has not expired",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Verify user identity",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Identity ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User identity verified successfully",
                         "schema": {
                             "type": "string"
                         }
+                    },
+                    "404": {
+                        "description": "User identity not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Identity document was already reviewed",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Identity document has expired",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     }
                 }
             }
@@ -133,7 +427,7 @@ const docTemplate = `{
                         "bearerToken": []
                     }
                 ],
-                "description": "Get list pending request",
+                "description": "Get list pending request. Use GET /admin/requests?status=0 instead.",
                 "produces": [
                     "application/json"
                 ],
@@ -141,6 +435,7 @@ const docTemplate = `{
                     "admin"
                 ],
                 "summary": "Get list pending request",
+                "deprecated": true,
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -158,7 +453,7 @@ const docTemplate = `{
                         "bearerToken": []
                     }
                 ],
-                "description": "Get list request",
+                "description": "Get list request. Use GET /admin/requests instead.",
                 "produces": [
                     "application/json"
                 ],
@@ -166,6 +461,7 @@ const docTemplate = `{
                     "admin"
                 ],
                 "summary": "Get list request",
+                "deprecated": true,
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -240,6 +536,18 @@ const docTemplate = `{
                         "schema": {
                             "type": "string"
                         }
+                    },
+                    "404": {
+                        "description": "Request not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Request cannot move to the target status",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     }
                 }
             }
@@ -278,51 +586,118 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/applicant-identity/": {
-            "post": {
-                "description": "Create user identity",
+        "/api/v1/admin/requests": {
+            "get": {
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
+                ],
+                "description": "List requests with filters and cursor pagination, newest first by default",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user_identity"
+                    "admin"
                 ],
-                "summary": "Create user identity",
+                "summary": "List requests",
                 "parameters": [
                     {
-                        "description": "Create User Identity Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.CreateUserIdentityRequest"
-                        }
+                        "type": "integer",
+                        "description": "Request status (0 pending, 1 approved, 2 rejected, 3 cancelled)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Request type (registration, verification)",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Requester email",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created at or after (RFC 3339)",
+                        "name": "created_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created before (RFC 3339)",
+                        "name": "created_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Verifier user ID",
+                        "name": "verifier_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also list deleted requests",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created_at or -created_at",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 1 to 100 (default 20)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "User identity created successfully",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/dto.RequestPage"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     }
                 }
             }
         },
-        "/api/v1/applicant-identity/{id}": {
+        "/api/v1/admin/requests/{id}/messages": {
             "get": {
-                "description": "Find user identity",
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
+                ],
+                "description": "List the conversation of a request, oldest first",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user_identity"
+                    "admin"
                 ],
-                "summary": "Find user identity",
+                "summary": "List request messages",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Identity ID",
+                        "description": "Request ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -332,124 +707,130 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.UserIdentityResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.MessageResponseDTO"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Request not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     }
                 }
             },
-            "put": {
-                "description": "Update user identity",
+            "post": {
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
+                ],
+                "description": "Add an admin message to the conversation of a request. The requester is notified by email.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user_identity"
+                    "admin"
                 ],
-                "summary": "Update user identity",
+                "summary": "Send message to requester",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Identity ID",
+                        "description": "Request ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Update User Identity Request",
-                        "name": "request",
+                        "description": "Message",
+                        "name": "message",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UpdateUserIdentityRequest"
+                            "$ref": "#/definitions/dto.MessageCreateDTO"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "User identity updated successfully",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/dto.MessageResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Request not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     }
                 }
             }
         },
-        "/api/v1/applicant-request/": {
+        "/api/v1/admin/requests/{id}/restore": {
             "post": {
-                "description": "Create request",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "request"
-                ],
-                "summary": "Create request",
-                "parameters": [
+                "security": [
                     {
-                        "description": "Create Request Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.ApplicantRequestCreatingDTO"
-                        }
+                        "bearerToken": []
                     }
                 ],
-                "responses": {
-                    "201": {
-                        "description": "Request created successfully",
-                        "schema": {
-                            "type": "string"
-                        }
-                    }
-                }
-            }
-        },
-        "/api/v1/applicant/": {
-            "post": {
-                "description": "Create applicant",
+                "description": "Restore a deleted request",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "applicant"
+                    "admin"
                 ],
-                "summary": "Create applicant",
+                "summary": "Restore request",
                 "parameters": [
                     {
-                        "description": "Create Applicant Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.ApplicantCreateDTO"
-                        }
+                        "type": "integer",
+                        "description": "Request ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Applicant created successfully",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "type": "string"
                         }
+                    },
+                    "404": {
+                        "description": "No deleted request with this ID",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     }
                 }
             }
         },
-        "/api/v1/applicant/{id}": {
-            "get": {
-                "description": "Find applicant by ID",
+        "/api/v1/admin/users/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
+                ],
+                "description": "Delete a user, whose tokens stop working. The user can be restored until purged.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "applicant"
+                    "admin"
                 ],
-                "summary": "Find applicant by ID",
+                "summary": "Delete user",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Applicant ID",
+                        "description": "User ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -459,308 +840,502 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.ApplicantResponseDTO"
+                            "type": "string"
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Update applicant",
+            }
+        },
+        "/api/v1/admin/users/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
+                ],
+                "description": "Restore a deleted user",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "applicant"
+                    "admin"
                 ],
-                "summary": "Update applicant",
+                "summary": "Restore user",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Applicant ID",
+                        "description": "User ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Update Applicant Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.AppplicantUpdateDTO"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Applicant updated successfully",
+                        "description": "OK",
                         "schema": {
                             "type": "string"
                         }
+                    },
+                    "404": {
+                        "description": "No deleted user with this ID",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete applicant",
+            }
+        },
+        "/api/v1/admin/volunteers": {
+            "get": {
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
+                ],
+                "description": "Volunteer directory with search, filters, multi-column sorting and pagination",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "applicant"
+                    "admin"
                 ],
-                "summary": "Delete applicant",
+                "summary": "List volunteers",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search by name, surname or email, or by volunteer or user ID",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Gender",
+                        "name": "gender",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Role code, e.g. com, cvl or mnvc",
+                        "name": "role",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "Applicant ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Department ID",
+                        "name": "department_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Country ID",
+                        "name": "country_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Volunteer status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also list deleted volunteers",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated keys among id, name, surname, email, gender, role, department, country, status, created_at; prefix with - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number, starting at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 1 to 100 (default 20)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/dto.VolunteerPage"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     }
                 }
             }
         },
-        "/api/v1/auth/login": {
+        "/api/v1/admin/volunteers/onboard": {
             "post": {
-                "description": "Login",
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
+                ],
+                "description": "Make an existing user, or a new one, a volunteer without registration and verification requests. An approved verification request is recorded for the audit trail. A new user is emailed a link to choose a password.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "authentication"
+                    "admin"
                 ],
-                "summary": "Login",
+                "summary": "Onboard volunteer",
                 "parameters": [
                     {
-                        "description": "Login User Request",
-                        "name": "loginUserRequest",
+                        "description": "Existing user_id, or the new user",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.LoginUserRequest"
+                            "$ref": "#/definitions/dto.VolunteerOnboardDTO"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/dto.LoginUserResponse"
+                            "$ref": "#/definitions/dto.VolunteerResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input or unknown department",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "User is already a volunteer or email is taken",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     }
                 }
             }
         },
-        "/api/v1/auth/register": {
-            "post": {
-                "description": "Register",
+        "/api/v1/admin/volunteers/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
+                ],
+                "description": "Delete a volunteer, who can be restored until purged",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "authentication"
+                    "admin"
                 ],
-                "summary": "Register",
+                "summary": "Delete volunteer",
                 "parameters": [
                     {
-                        "description": "Register User Request",
-                        "name": "registerUserRequest",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.RegisterUserRequest"
-                        }
+                        "type": "integer",
+                        "description": "Volunteer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Volunteer deleted successfully",
                         "schema": {
-                            "$ref": "#/definitions/dto.RegisterUserResponse"
+                            "type": "string"
                         }
                     }
                 }
             }
         },
-        "/api/v1/countries": {
+        "/api/v1/admin/volunteers/{id}/deactivate": {
             "post": {
-                "description": "Create a new country",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
                 ],
+                "description": "Deactivate a volunteer and its user account, recording the reason",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "country"
+                    "admin"
                 ],
-                "summary": "Create a new country",
+                "summary": "Deactivate volunteer",
                 "parameters": [
                     {
-                        "description": "Country data",
-                        "name": "country",
+                        "type": "integer",
+                        "description": "Volunteer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Deactivation reason",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.CountryCreateDTO"
+                            "$ref": "#/definitions/dto.VolunteerStatusChangeDTO"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "Volunteer deactivated successfully",
                         "schema": {
-                            "$ref": "#/definitions/domain.Country"
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Volunteer not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Volunteer is already inactive",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     }
                 }
             }
         },
-        "/api/v1/countries/{id}": {
-            "get": {
-                "description": "Get country by ID",
+        "/api/v1/admin/volunteers/{id}/reactivate": {
+            "post": {
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
+                ],
+                "description": "Reactivate a volunteer and its user account, recording the reason",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "country"
+                    "admin"
                 ],
-                "summary": "Get country by ID",
+                "summary": "Reactivate volunteer",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Country ID",
+                        "description": "Volunteer ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Reactivation reason",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.VolunteerStatusChangeDTO"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Volunteer reactivated successfully",
                         "schema": {
-                            "$ref": "#/definitions/domain.Country"
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Volunteer not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Volunteer is already active",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Update country",
-                "consumes": [
-                    "application/json"
+            }
+        },
+        "/api/v1/admin/volunteers/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
                 ],
+                "description": "Restore a deleted volunteer",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "country"
+                    "admin"
                 ],
-                "summary": "Update country",
+                "summary": "Restore volunteer",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Country ID",
+                        "description": "Volunteer ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Country data",
-                        "name": "country",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.CountryUpdateDTO"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Volunteer restored successfully",
                         "schema": {
-                            "$ref": "#/definitions/domain.Country"
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "No deleted volunteer with this ID",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete country",
+            }
+        },
+        "/api/v1/admin/volunteers/{id}/status-history": {
+            "get": {
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
+                ],
+                "description": "Deactivations and reactivations of a volunteer, newest first",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "country"
+                    "admin"
                 ],
-                "summary": "Delete country",
+                "summary": "Volunteer status history",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Country ID",
+                        "description": "Volunteer ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.VolunteerStatusChangeResponseDTO"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Volunteer not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
                     }
                 }
             }
         },
-        "/api/v1/departments": {
+        "/api/v1/applicant-identity/": {
             "post": {
-                "description": "Create a new department",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
                 ],
+                "description": "Create user identity. Users can add documents for themselves, admins for anyone.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "department"
+                    "user_identity"
                 ],
-                "summary": "Create a new department",
+                "summary": "Create user identity",
                 "parameters": [
                     {
-                        "description": "Department data",
-                        "name": "department",
+                        "description": "Create User Identity Request",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.DepartmentCreateDTO"
+                            "$ref": "#/definitions/dto.CreateUserIdentityRequest"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Created",
+                        "description": "User identity created successfully",
                         "schema": {
-                            "$ref": "#/definitions/domain.Department"
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input, with the rejected fields in details",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the user nor an admin",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Identity document is already registered",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     }
                 }
             }
         },
-        "/api/v1/departments/{id}": {
+        "/api/v1/applicant-identity/{id}": {
             "get": {
-                "description": "Get department by ID",
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
+                ],
+                "description": "Find user identity. Users can read their own documents, admins anyone's.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "department"
+                    "user_identity"
                 ],
-                "summary": "Get department by ID",
+                "summary": "Find user identity",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Department ID",
+                        "description": "Identity ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -770,120 +1345,163 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Department"
+                            "$ref": "#/definitions/dto.UserIdentityResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the owner nor an admin",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     }
                 }
             },
             "put": {
-                "description": "Update department",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
                 ],
+                "description": "Update user identity. Users can update their own documents, admins anyone's.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "department"
+                    "user_identity"
                 ],
-                "summary": "Update department",
+                "summary": "Update user identity",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Department ID",
+                        "description": "Identity ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Department data",
-                        "name": "department",
+                        "description": "Update User Identity Request",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.DepartmentUpdateDTO"
+                            "$ref": "#/definitions/dto.UpdateUserIdentityRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "User identity updated successfully",
                         "schema": {
-                            "$ref": "#/definitions/domain.Department"
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input, with the rejected fields in details",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the owner nor an admin",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Identity document is already registered",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete department",
+            }
+        },
+        "/api/v1/applicant-request/": {
+            "post": {
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
+                ],
+                "description": "Create a registration request for the current user",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "department"
-                ],
-                "summary": "Delete department",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Department ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "request"
                 ],
+                "summary": "Create request",
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.RequestResponseDTO"
+                        }
                     }
                 }
             }
         },
-        "/api/v1/role/": {
+        "/api/v1/applicant/": {
             "post": {
-                "description": "Create role",
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
+                ],
+                "description": "Create applicant. Admins only; applicants sign up through /auth/register.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "role"
+                    "applicant"
                 ],
-                "summary": "Create role",
+                "summary": "Create applicant",
                 "parameters": [
                     {
-                        "description": "Create Role Request",
+                        "description": "Create Applicant Request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.RoleCreateDTO"
+                            "$ref": "#/definitions/dto.ApplicantCreateDTO"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Created",
+                        "description": "Applicant created successfully",
                         "schema": {
-                            "$ref": "#/definitions/domain.Role"
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     }
                 }
             }
         },
-        "/api/v1/role/{id}": {
+        "/api/v1/applicant/{id}": {
             "get": {
-                "description": "Get role by ID",
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
+                ],
+                "description": "Find applicant by ID. Users can read their own profile, admins anyone's.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "role"
+                    "applicant"
                 ],
-                "summary": "Get role by ID",
+                "summary": "Find applicant by ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Role ID",
+                        "description": "Applicant ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -893,507 +1511,2797 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Role"
+                            "$ref": "#/definitions/dto.ApplicantResponseDTO"
                         }
-                    }
-                }
+                    },
+                    "403": {
+                        "description": "Not the user nor an admin",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
             },
             "put": {
-                "description": "Update role",
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
+                ],
+                "description": "Update applicant. Users can update their own profile, admins anyone's. The role changes through request approval only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "role"
+                    "applicant"
                 ],
-                "summary": "Update role",
+                "summary": "Update applicant",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Role ID",
+                        "description": "Applicant ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Update Role Request",
+                        "description": "Update Applicant Request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.RoleUpdateDTO"
+                            "$ref": "#/definitions/dto.ApplicantUpdateDTO"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Applicant updated successfully",
                         "schema": {
-                            "$ref": "#/definitions/domain.Role"
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input, with the rejected fields in details",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the user nor an admin",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete role",
+            }
+        },
+        "/api/v1/auth/forgot-password": {
+            "post": {
+                "description": "Email a password reset token. The response does not tell whether the email is registered.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "role"
+                    "authentication"
                 ],
-                "summary": "Delete role",
+                "summary": "Forgot password",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Role ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Forgot Password Request",
+                        "name": "forgotPasswordRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ForgotPasswordRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PasswordResetResponse"
+                        }
                     }
                 }
             }
         },
-        "/api/v1/volunteer/": {
+        "/api/v1/auth/login": {
             "post": {
-                "description": "Create volunteer",
+                "description": "Login",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "volunteer"
+                    "authentication"
                 ],
-                "summary": "Create volunteer",
+                "summary": "Login",
                 "parameters": [
                     {
-                        "description": "Create Volunteer Request",
-                        "name": "request",
+                        "description": "Login User Request",
+                        "name": "loginUserRequest",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.VolunteerCreateDTO"
+                            "$ref": "#/definitions/dto.LoginUserRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Volunteer created successfully",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/dto.LoginUserResponse"
                         }
                     }
                 }
             }
         },
-        "/api/v1/volunteer/request": {
+        "/api/v1/auth/logout": {
             "post": {
-                "description": "Create a new volunteer request",
-                "consumes": [
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
+                ],
+                "description": "Revoke the current access token and its refresh token family",
+                "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "authentication"
+                ],
+                "summary": "Logout",
+                "parameters": [
+                    {
+                        "description": "Logout Request",
+                        "name": "logoutRequest",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.LogoutRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.LogoutResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/auth/refresh": {
+            "post": {
+                "description": "Exchange a refresh token for a new access and refresh token pair",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "volunteer"
+                    "authentication"
                 ],
-                "summary": "Create a new volunteer request",
+                "summary": "Refresh access token",
                 "parameters": [
                     {
-                        "description": "Request body",
-                        "name": "request",
+                        "description": "Refresh Token Request",
+                        "name": "refreshTokenRequest",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.VoluteerRequestCreatingDTO"
+                            "$ref": "#/definitions/dto.RefreshTokenRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/dto.LoginUserTokenResponse"
                         }
                     }
                 }
             }
         },
-        "/api/v1/volunteer/{id}": {
-            "get": {
-                "description": "Find volunteer by ID",
+        "/api/v1/auth/register": {
+            "post": {
+                "description": "Register",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "volunteer"
+                    "authentication"
                 ],
-                "summary": "Find volunteer by ID",
+                "summary": "Register",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Volunteer ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Register User Request",
+                        "name": "registerUserRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RegisterUserRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Volunteer"
+                            "$ref": "#/definitions/dto.RegisterUserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input, with the rejected fields in details",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Update volunteer",
+            }
+        },
+        "/api/v1/auth/resend-verification": {
+            "post": {
+                "description": "Send a new verification link. The response does not tell whether the email is registered.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "volunteer"
+                    "authentication"
                 ],
-                "summary": "Update volunteer",
+                "summary": "Resend verification email",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Volunteer ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Resend Verification Request",
+                        "name": "resendVerificationRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResendVerificationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/dto.VerificationResponse"
+                        }
                     },
+                    "429": {
+                        "description": "Too many verification emails",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/auth/reset-password": {
+            "post": {
+                "description": "Set a new password with a reset token. All sessions of the user are signed out.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "authentication"
+                ],
+                "summary": "Reset password",
+                "parameters": [
                     {
-                        "description": "Update Volunteer Request",
-                        "name": "request",
+                        "description": "Reset Password Request",
+                        "name": "resetPasswordRequest",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.VolunteerUpdateDTO"
+                            "$ref": "#/definitions/dto.ResetPasswordRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Volunteer updated successfully",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/dto.PasswordResetResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input or invalid or expired token",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete volunteer",
+            }
+        },
+        "/api/v1/auth/verify-email": {
+            "get": {
+                "description": "Verify the email address of a user with the single-use token sent by email",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "volunteer"
+                    "authentication"
                 ],
-                "summary": "Delete volunteer",
+                "summary": "Verify email",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Volunteer ID",
-                        "name": "id",
-                        "in": "path",
+                        "type": "string",
+                        "description": "Verification token",
+                        "name": "token",
+                        "in": "query",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Volunteer deleted successfully",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/dto.VerificationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid or expired token",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "410": {
+                        "description": "Token already used",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "domain.Country": {
-            "type": "object",
-            "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "status": {
-                    "type": "integer"
-                },
-                "updated_at": {
-                    "type": "string"
-                }
-            }
-        },
-        "domain.Department": {
-            "type": "object",
-            "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "location": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "status": {
-                    "type": "integer"
-                },
-                "updated_at": {
-                    "type": "string"
-                }
-            }
         },
-        "domain.Request": {
-            "type": "object",
-            "properties": {
-                "createdAt": {
+        "/api/v1/countries": {
+            "post": {
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
+                ],
+                "description": "Create a new country",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "country"
+                ],
+                "summary": "Create a new country",
+                "parameters": [
+                    {
+                        "description": "Country data",
+                        "name": "country",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CountryCreateDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Country"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/countries/{id}": {
+            "get": {
+                "description": "Get country by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "country"
+                ],
+                "summary": "Get country by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Country ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Country"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
+                ],
+                "description": "Update country",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "country"
+                ],
+                "summary": "Update country",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Country ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Country data",
+                        "name": "country",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CountryUpdateDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Country"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
+                ],
+                "description": "Delete country",
+                "tags": [
+                    "country"
+                ],
+                "summary": "Delete country",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Country ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/api/v1/country/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
+                ],
+                "description": "Restore a deleted country",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "country"
+                ],
+                "summary": "Restore country",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Country ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Country restored successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "No deleted country with this ID",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/department/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
+                ],
+                "description": "Restore a deleted department",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "department"
+                ],
+                "summary": "Restore department",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Department ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Department restored successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "No deleted department with this ID",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/departments": {
+            "post": {
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
+                ],
+                "description": "Create a new department",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "department"
+                ],
+                "summary": "Create a new department",
+                "parameters": [
+                    {
+                        "description": "Department data",
+                        "name": "department",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.DepartmentCreateDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Department"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/departments/{id}": {
+            "get": {
+                "description": "Get department by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "department"
+                ],
+                "summary": "Get department by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Department ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Department"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
+                ],
+                "description": "Update department",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "department"
+                ],
+                "summary": "Update department",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Department ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Department data",
+                        "name": "department",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.DepartmentUpdateDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Department"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
+                ],
+                "description": "Delete department",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "department"
+                ],
+                "summary": "Delete department",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Department ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/api/v1/me/permissions": {
+            "get": {
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
+                ],
+                "description": "Role and permissions of the current user, inherited ones included, to render menus",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "role"
+                ],
+                "summary": "My permissions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MyPermissionsDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/me/requests": {
+            "get": {
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
+                ],
+                "description": "List the requests of the current user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "request"
+                ],
+                "summary": "List requests",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.RequestResponseDTO"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
+                ],
+                "description": "Create a registration or verification request for the current user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "request"
+                ],
+                "summary": "Create request",
+                "parameters": [
+                    {
+                        "description": "Request data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RequestCreateDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.RequestResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/me/requests/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
+                ],
+                "description": "Get a request of the current user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "request"
+                ],
+                "summary": "Get request",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Request ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.RequestResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/me/requests/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
+                ],
+                "description": "Cancel a pending request of the current user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "request"
+                ],
+                "summary": "Cancel request",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Request ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Request cancelled successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/me/requests/{id}/messages": {
+            "get": {
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
+                ],
+                "description": "List the conversation of a request of the current user, oldest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "request"
+                ],
+                "summary": "List messages",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Request ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.MessageResponseDTO"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Request not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
+                ],
+                "description": "Add a reply to the conversation of a request of the current user. The admins taking part are notified by email.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "request"
+                ],
+                "summary": "Reply to admins",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Request ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Message",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.MessageCreateDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MessageResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Request not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/me/requests/{id}/resubmit": {
+            "post": {
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
+                ],
+                "description": "Resubmit a rejected request of the current user as a new pending revision",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "request"
+                ],
+                "summary": "Resubmit request",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Rejected request ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.RequestResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/permissions": {
+            "get": {
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
+                ],
+                "description": "List the permissions that roles can be granted",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "role"
+                ],
+                "summary": "List permissions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Permission"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/role/": {
+            "post": {
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
+                ],
+                "description": "Create role",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "role"
+                ],
+                "summary": "Create role",
+                "parameters": [
+                    {
+                        "description": "Create Role Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RoleCreateDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Role"
+                        }
+                    },
+                    "409": {
+                        "description": "Role code is already used",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/role/{id}": {
+            "get": {
+                "description": "Get role by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "role"
+                ],
+                "summary": "Get role by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Role ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Role"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
+                ],
+                "description": "Update role",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "role"
+                ],
+                "summary": "Update role",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Role ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update Role Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RoleUpdateDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Role"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
+                ],
+                "description": "Delete role",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "role"
+                ],
+                "summary": "Delete role",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Role ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/api/v1/role/{id}/permissions": {
+            "get": {
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
+                ],
+                "description": "Permissions granted to a role, and all those it holds including the ones inherited from its parents",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "role"
+                ],
+                "summary": "Get role permissions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Role ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.RolePermissionsDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Role not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
+                ],
+                "description": "Grant a permission to a role and to the roles inheriting from it. Granting a permission twice does nothing.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "role"
+                ],
+                "summary": "Grant permission",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Role ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Grant Permission Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PermissionGrantDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Permission granted successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Role or permission not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/role/{id}/permissions/{permission}": {
+            "delete": {
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
+                ],
+                "description": "Take back a permission granted to a role. Permissions inherited from its parents are not affected.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "role"
+                ],
+                "summary": "Revoke permission",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Role ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Permission code",
+                        "name": "permission",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Permission is not granted to the role",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/role/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
+                ],
+                "description": "Restore a deleted role",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "role"
+                ],
+                "summary": "Restore role",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Role ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Role restored successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "No deleted role with this ID",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{id}/avatar": {
+            "get": {
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
+                ],
+                "description": "Short-lived link to the avatar of a user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "applicant"
+                ],
+                "summary": "Get avatar",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_cesc1802_onboarding-and-volunteer-service_feature_user_dto.FileURLResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found or without avatar",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
+                ],
+                "description": "Replace the avatar of a user with a JPEG or PNG image of at most 2 MiB. The image is stripped of its metadata. Users can change their own avatar, admins any user's.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "applicant"
+                ],
+                "summary": "Upload avatar",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "JPEG or PNG image",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_cesc1802_onboarding-and-volunteer-service_feature_user_dto.FileURLResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "No file in the form",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the user nor an admin",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "413": {
+                        "description": "File is too large",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "415": {
+                        "description": "File is not a JPEG or PNG image",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{id}/identities": {
+            "get": {
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
+                ],
+                "description": "Identity documents of a user, oldest first. Users can list their own documents, admins any user's.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user_identity"
+                ],
+                "summary": "List user identities",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.UserIdentityResponse"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Not the user nor an admin",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{id}/identities/{identityId}/scans/{side}": {
+            "get": {
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
+                ],
+                "description": "Short-lived link to the scan of one side of an identity document. Users can see the scans of their own documents, admins of any user's.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user_identity"
+                ],
+                "summary": "Get identity document scan",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Identity ID",
+                        "name": "identityId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "front",
+                            "back"
+                        ],
+                        "type": "string",
+                        "description": "Side of the document",
+                        "name": "side",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_cesc1802_onboarding-and-volunteer-service_feature_user_identity_dto.FileURLResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the user nor an admin",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "User identity or scan not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
+                ],
+                "description": "Replace the scan of one side of an identity document with a JPEG, PNG or PDF file of at most 10 MiB. Images are stripped of their metadata. The document goes back to review. Users can upload scans of their own documents, admins of any user's.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user_identity"
+                ],
+                "summary": "Upload identity document scan",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Identity ID",
+                        "name": "identityId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "front",
+                            "back"
+                        ],
+                        "type": "string",
+                        "description": "Side of the document",
+                        "name": "side",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "JPEG, PNG or PDF file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_cesc1802_onboarding-and-volunteer-service_feature_user_identity_dto.FileURLResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid side or no file in the form",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the user nor an admin",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "User identity not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "413": {
+                        "description": "File is too large",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    },
+                    "415": {
+                        "description": "File is not a JPEG, PNG or PDF file",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/volunteer-request/": {
+            "post": {
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
+                ],
+                "description": "Create a verification request for the current user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "volunteer"
+                ],
+                "summary": "Create a new volunteer request",
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.RequestResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/volunteer/": {
+            "post": {
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
+                ],
+                "description": "Create volunteer. Admins only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "volunteer"
+                ],
+                "summary": "Create volunteer",
+                "parameters": [
+                    {
+                        "description": "Create Volunteer Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.VolunteerCreateDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Volunteer created successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/volunteer/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
+                ],
+                "description": "Find volunteer by ID. Volunteers can read their own record, admins anyone's.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "volunteer"
+                ],
+                "summary": "Find volunteer by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Volunteer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Volunteer"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the volunteer nor an admin",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "bearerToken": []
+                    }
+                ],
+                "description": "Update volunteer. Volunteers can update their own record, admins anyone's.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "volunteer"
+                ],
+                "summary": "Update volunteer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Volunteer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update Volunteer Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.VolunteerUpdateDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Volunteer updated successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the volunteer nor an admin",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.Response"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "apperror.Code": {
+            "type": "string",
+            "enum": [
+                "VALIDATION",
+                "UNAUTHORIZED",
+                "FORBIDDEN",
+                "NOT_FOUND",
+                "CONFLICT",
+                "GONE",
+                "UNPROCESSABLE",
+                "TOO_LARGE",
+                "UNSUPPORTED_MEDIA_TYPE",
+                "RATE_LIMITED",
+                "INTERNAL"
+            ],
+            "x-enum-varnames": [
+                "Validation",
+                "Unauthorized",
+                "Forbidden",
+                "NotFound",
+                "Conflict",
+                "Gone",
+                "Unprocessable",
+                "TooLarge",
+                "Unsupported",
+                "RateLimited",
+                "Internal"
+            ]
+        },
+        "apperror.Response": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/apperror.Code"
+                        }
+                    ],
+                    "example": "NOT_FOUND"
+                },
+                "details": {
+                    "type": "object"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "request not found"
+                },
+                "request_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.Country": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.Department": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.Permission": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.RequestStatus": {
+            "type": "integer",
+            "enum": [
+                0,
+                1,
+                2,
+                3
+            ],
+            "x-enum-varnames": [
+                "RequestStatusPending",
+                "RequestStatusApproved",
+                "RequestStatusRejected",
+                "RequestStatusCancelled"
+            ]
+        },
+        "domain.RequestType": {
+            "type": "string",
+            "enum": [
+                "registration",
+                "verification"
+            ],
+            "x-enum-varnames": [
+                "RequestTypeRegistration",
+                "RequestTypeVerification"
+            ]
+        },
+        "domain.Role": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.Volunteer": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "departmentID": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "userID": {
+                    "type": "integer"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
+                }
+            }
+        },
+        "dto.ApplicantCreateDTO": {
+            "type": "object",
+            "required": [
+                "email",
+                "name",
+                "surname"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "surname": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ApplicantResponseDTO": {
+            "type": "object",
+            "properties": {
+                "country_id": {
+                    "type": "integer"
+                },
+                "department_id": {
+                    "type": "integer"
+                },
+                "dob": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "gender": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "mobile": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "resident_country_id": {
+                    "type": "integer"
+                },
+                "role_id": {
+                    "type": "integer"
+                },
+                "surname": {
+                    "type": "string"
+                },
+                "has_avatar": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.ApplicantUpdateDTO": {
+            "type": "object",
+            "properties": {
+                "country_id": {
+                    "type": "integer"
+                },
+                "department_id": {
+                    "type": "integer"
+                },
+                "dob": {
+                    "type": "string",
+                    "example": "1990-04-23"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "gender": {
+                    "type": "string",
+                    "enum": [
+                        "male",
+                        "female",
+                        "other"
+                    ]
+                },
+                "mobile": {
+                    "type": "string",
+                    "example": "+14155552671"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "resident_country_id": {
+                    "type": "integer"
+                },
+                "surname": {
+                    "type": "string"
+                }
+            },
+            "required": [
+                "dob",
+                "email",
+                "name",
+                "surname"
+            ]
+        },
+        "dto.AuditEvent": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actor_id": {
+                    "type": "integer"
+                },
+                "actor_role": {
+                    "type": "string"
+                },
+                "after": {
+                    "type": "object"
+                },
+                "before": {
+                    "type": "object"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "entity_id": {
+                    "type": "string"
+                },
+                "entity_type": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "user_agent": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AuditPage": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.AuditEvent"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.CountryCreateDTO": {
+            "type": "object",
+            "required": [
+                "name",
+                "status"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.CountryUpdateDTO": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.CreateUserIdentityRequest": {
+            "type": "object",
+            "required": [
+                "expiry_date",
+                "number",
+                "place_issued",
+                "type",
+                "user_id"
+            ],
+            "properties": {
+                "expiry_date": {
+                    "type": "string",
+                    "example": "2030-01-31"
+                },
+                "number": {
+                    "type": "string"
+                },
+                "place_issued": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "passport",
+                        "national_id",
+                        "driver_licence"
+                    ]
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.DepartmentCreateDTO": {
+            "type": "object",
+            "required": [
+                "location",
+                "name",
+                "status"
+            ],
+            "properties": {
+                "location": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.DepartmentUpdateDTO": {
+            "type": "object",
+            "required": [
+                "location",
+                "name",
+                "status"
+            ],
+            "properties": {
+                "location": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ForgotPasswordRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.IdentityNumberResponse": {
+            "description": "IdentityNumberResponse is the full number of a document, for admins.",
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "number": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ListRequest": {
+            "type": "object",
+            "properties": {
+                "requests": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_cesc1802_onboarding-and-volunteer-service_feature_request_domain.Request"
+                    }
+                }
+            }
+        },
+        "dto.LoginUserRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.LoginUserResponse": {
+            "type": "object",
+            "properties": {
+                "avatar": {
+                    "type": "string"
+                },
+                "country_id": {
+                    "type": "integer"
+                },
+                "department_id": {
+                    "type": "integer"
+                },
+                "dob": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "gender": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "rejectNotes": {
+                "mobile": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "resident_country_id": {
+                    "type": "integer"
+                },
+                "role_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "surname": {
+                    "type": "string"
+                },
+                "verification_status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.LoginUserTokenResponse": {
+            "type": "object",
+            "properties": {
+                "expires_in": {
+                    "type": "integer"
+                },
+                "refresh_token": {
                     "type": "string"
                 },
-                "status": {
-                    "type": "integer"
-                },
-                "type": {
+                "token": {
                     "type": "string"
-                },
-                "updatedAt": {
+                }
+            }
+        },
+        "dto.LogoutRequest": {
+            "type": "object",
+            "properties": {
+                "refresh_token": {
                     "type": "string"
-                },
-                "userID": {
-                    "type": "integer"
-                },
-                "verifierID": {
-                    "type": "integer"
                 }
             }
         },
-        "domain.Role": {
+        "dto.LogoutResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.MessageCreateDTO": {
+            "type": "object",
+            "required": [
+                "body"
+            ],
+            "properties": {
+                "body": {
+                    "type": "string",
+                    "maxLength": 5000
+                }
+            }
+        },
+        "dto.MessageResponseDTO": {
             "type": "object",
             "properties": {
+                "body": {
+                    "type": "string"
+                },
                 "created_at": {
                     "type": "string"
                 },
+                "from_requester": {
+                    "type": "boolean"
+                },
                 "id": {
                     "type": "integer"
                 },
-                "name": {
-                    "type": "string"
+                "request_id": {
+                    "type": "integer"
                 },
-                "status": {
+                "sender_id": {
                     "type": "integer"
+                }
+            }
+        },
+        "dto.MyPermissionsDTO": {
+            "type": "object",
+            "properties": {
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "updated_at": {
+                "role": {
                     "type": "string"
                 }
             }
         },
-        "domain.Volunteer": {
+        "dto.PasswordResetResponse": {
             "type": "object",
             "properties": {
-                "createdAt": {
+                "message": {
                     "type": "string"
-                },
-                "departmentID": {
-                    "type": "integer"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "status": {
-                    "type": "integer"
-                },
-                "updatedAt": {
+                }
+            }
+        },
+        "dto.PermissionGrantDTO": {
+            "type": "object",
+            "required": [
+                "permission"
+            ],
+            "properties": {
+                "permission": {
                     "type": "string"
-                },
-                "userID": {
-                    "type": "integer"
                 }
             }
         },
-        "dto.AddRejectNoteRequest": {
+        "dto.RefreshTokenRequest": {
             "type": "object",
+            "required": [
+                "refresh_token"
+            ],
             "properties": {
-                "notes": {
+                "refresh_token": {
                     "type": "string"
                 }
             }
         },
-        "dto.ApplicantCreateDTO": {
+        "dto.RegisterUserRequest": {
             "type": "object",
             "required": [
+                "country_id",
                 "email",
                 "name",
-                "surname"
+                "password",
+                "re_password"
             ],
             "properties": {
+                "country_id": {
+                    "type": "integer"
+                },
                 "email": {
                     "type": "string"
                 },
                 "name": {
                     "type": "string"
                 },
-                "surname": {
+                "password": {
+                    "type": "string"
+                },
+                "re_password": {
+                    "type": "string"
+                },
+                "resident_country_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.RegisterUserResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
                     "type": "string"
                 }
             }
         },
-        "dto.ApplicantRequestCreatingDTO": {
+        "dto.RejectUserIdentityRequest": {
             "type": "object",
             "required": [
-                "status",
-                "type",
-                "user_id"
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.RequestCreateDTO": {
+            "type": "object",
+            "required": [
+                "type"
             ],
             "properties": {
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.RequestListItem": {
+            "type": "object",
+            "properties": {
+                "create_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "parent_id": {
+                    "type": "integer"
+                },
                 "status": {
                     "type": "integer"
                 },
                 "type": {
                     "type": "string"
                 },
+                "update_at": {
+                    "type": "string"
+                },
                 "user_id": {
                     "type": "integer"
+                },
+                "verifier_id": {
+                    "type": "integer"
+                },
+                "deleted_at": {
+                    "type": "string"
                 }
             }
         },
-        "dto.ApplicantResponseDTO": {
+        "dto.RequestPage": {
             "type": "object",
             "properties": {
-                "country_id": {
-                    "type": "integer"
-                },
-                "department_id": {
-                    "type": "integer"
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.RequestListItem"
+                    }
                 },
-                "dob": {
+                "next_cursor": {
                     "type": "string"
                 },
-                "email": {
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.RequestResponse": {
+            "type": "object",
+            "properties": {
+                "create_at": {
                     "type": "string"
                 },
-                "gender": {
-                    "type": "string"
+                "history": {
+                    "description": "History holds the earlier revisions of a resubmitted request, newest first.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.RequestRevision"
+                    }
                 },
                 "id": {
                     "type": "integer"
                 },
-                "mobile": {
+                "parent_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "type": {
                     "type": "string"
                 },
-                "name": {
+                "update_at": {
                     "type": "string"
                 },
-                "resident_country_id": {
+                "user_id": {
                     "type": "integer"
                 },
-                "role_id": {
+                "verifier_id": {
                     "type": "integer"
-                },
-                "surname": {
-                    "type": "string"
                 }
             }
         },
-        "dto.AppplicantUpdateDTO": {
+        "dto.RequestResponseDTO": {
             "type": "object",
             "properties": {
-                "country_id": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
                     "type": "integer"
                 },
-                "department_id": {
+                "parent_id": {
                     "type": "integer"
                 },
-                "dob": {
+                "status": {
                     "type": "string"
                 },
-                "email": {
+                "type": {
                     "type": "string"
                 },
-                "gender": {
+                "updated_at": {
                     "type": "string"
                 },
-                "mobile": {
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.RequestRevision": {
+            "type": "object",
+            "properties": {
+                "create_at": {
                     "type": "string"
                 },
-                "name": {
-                    "type": "string"
+                "id": {
+                    "type": "integer"
                 },
-                "resident_country_id": {
+                "status": {
                     "type": "integer"
                 },
-                "role_id": {
+                "update_at": {
+                    "type": "string"
+                },
+                "verifier_id": {
                     "type": "integer"
+                }
+            }
+        },
+        "dto.ResendVerificationRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ResetPasswordRequest": {
+            "type": "object",
+            "required": [
+                "password",
+                "re_password",
+                "token"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string"
                 },
-                "surname": {
+                "re_password": {
+                    "type": "string"
+                },
+                "token": {
                     "type": "string"
                 }
             }
         },
-        "dto.CountryCreateDTO": {
+        "dto.RoleCreateDTO": {
             "type": "object",
             "required": [
+                "code",
                 "name",
                 "status"
             ],
             "properties": {
+                "code": {
+                    "type": "string",
+                    "maxLength": 30
+                },
                 "name": {
                     "type": "string"
                 },
+                "parent_id": {
+                    "type": "integer"
+                },
                 "status": {
                     "type": "integer"
                 }
             }
         },
-        "dto.CountryUpdateDTO": {
+        "dto.RolePermissionsDTO": {
+            "type": "object",
+            "properties": {
+                "effective": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "granted": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "role_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.RoleUpdateDTO": {
             "type": "object",
+            "required": [
+                "name",
+                "status"
+            ],
             "properties": {
                 "name": {
                     "type": "string"
                 },
+                "parent_id": {
+                    "type": "integer"
+                },
                 "status": {
                     "type": "integer"
                 }
             }
         },
-        "dto.CreateUserIdentityRequest": {
+        "dto.UpdateUserIdentityRequest": {
             "type": "object",
+            "properties": {
+                "expiry_date": {
+                    "type": "string",
+                    "example": "2030-01-31"
+                },
+                "number": {
+                    "type": "string"
+                },
+                "place_issued": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "passport",
+                        "national_id",
+                        "driver_licence"
+                    ]
+                }
+            },
             "required": [
                 "expiry_date",
                 "number",
                 "place_issued",
-                "status",
-                "type",
-                "user_id"
+                "type"
             ],
+            "description": "UpdateUserIdentityRequest replaces the details of a document, which then goes back to review. The owner of a document cannot be changed."
+        },
+        "dto.UserIdentityResponse": {
+            "type": "object",
             "properties": {
                 "expiry_date": {
                     "type": "string"
                 },
+                "id": {
+                    "type": "integer"
+                },
                 "number": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "****1234"
                 },
                 "place_issued": {
                     "type": "string"
@@ -1406,86 +4314,68 @@ const docTemplate = `{
                 },
                 "user_id": {
                     "type": "integer"
-                }
-            }
-        },
-        "dto.DepartmentCreateDTO": {
-            "type": "object",
-            "required": [
-                "location",
-                "name",
-                "status"
-            ],
-            "properties": {
-                "location": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string"
                 },
-                "status": {
+                "verified_by": {
                     "type": "integer"
-                }
-            }
-        },
-        "dto.DepartmentUpdateDTO": {
-            "type": "object",
-            "required": [
-                "location",
-                "name",
-                "status"
-            ],
-            "properties": {
-                "location": {
+                },
+                "verified_at": {
                     "type": "string"
                 },
-                "name": {
+                "rejection_reason": {
                     "type": "string"
                 },
-                "status": {
-                    "type": "integer"
+                "has_front_scan": {
+                    "type": "boolean"
+                },
+                "has_back_scan": {
+                    "type": "boolean"
                 }
-            }
+            },
+            "description": "UserIdentityResponse shows the last four characters of the number only."
         },
-        "dto.ListRequest": {
+        "dto.VerificationResponse": {
             "type": "object",
             "properties": {
-                "requests": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/domain.Request"
-                    }
+                "message": {
+                    "type": "string"
                 }
             }
         },
-        "dto.LoginUserRequest": {
+        "dto.VolunteerCreateDTO": {
             "type": "object",
             "required": [
-                "email",
-                "password"
+                "department_id",
+                "status",
+                "user_id"
             ],
             "properties": {
-                "email": {
-                    "type": "string"
+                "department_id": {
+                    "type": "integer"
                 },
-                "password": {
-                    "type": "string"
+                "status": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
                 }
             }
         },
-        "dto.LoginUserResponse": {
+        "dto.VolunteerListItemDTO": {
             "type": "object",
             "properties": {
-                "avatar": {
-                    "type": "string"
-                },
                 "country_id": {
                     "type": "integer"
                 },
+                "country_name": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
                 "department_id": {
                     "type": "integer"
                 },
-                "dob": {
+                "department_name": {
                     "type": "string"
                 },
                 "email": {
@@ -1503,208 +4393,215 @@ const docTemplate = `{
                 "name": {
                     "type": "string"
                 },
-                "resident_country_id": {
-                    "type": "integer"
-                },
                 "role_id": {
                     "type": "integer"
                 },
+                "role_name": {
+                    "type": "string"
+                },
                 "status": {
                     "type": "integer"
                 },
                 "surname": {
                     "type": "string"
                 },
-                "verification_status": {
+                "user_id": {
                     "type": "integer"
+                },
+                "deleted_at": {
+                    "type": "string"
                 }
             }
         },
-        "dto.RegisterUserRequest": {
+        "dto.VolunteerOnboardDTO": {
             "type": "object",
             "required": [
-                "email",
-                "name",
-                "password",
-                "re_password"
+                "department_id"
             ],
             "properties": {
+                "country_id": {
+                    "type": "integer"
+                },
+                "department_id": {
+                    "type": "integer"
+                },
+                "dob": {
+                    "type": "string"
+                },
                 "email": {
                     "type": "string"
                 },
+                "gender": {
+                    "type": "string",
+                    "enum": [
+                        "male",
+                        "female",
+                        "other"
+                    ]
+                },
+                "mobile": {
+                    "type": "string",
+                    "example": "+14155552671"
+                },
                 "name": {
                     "type": "string"
                 },
-                "password": {
-                    "type": "string"
+                "resident_country_id": {
+                    "type": "integer"
                 },
-                "re_password": {
+                "surname": {
                     "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
                 }
             }
         },
-        "dto.RegisterUserResponse": {
+        "dto.VolunteerPage": {
             "type": "object",
             "properties": {
-                "message": {
-                    "type": "string"
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.VolunteerListItemDTO"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "dto.RequestResponse": {
+        "dto.VolunteerResponseDTO": {
             "type": "object",
             "properties": {
-                "create_at": {
-                    "type": "string"
+                "department_id": {
+                    "type": "integer"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "reject_notes": {
-                    "type": "string"
-                },
                 "status": {
                     "type": "integer"
                 },
-                "type": {
-                    "type": "string"
-                },
-                "update_at": {
-                    "type": "string"
-                },
                 "user_id": {
                     "type": "integer"
-                },
-                "verifier_id": {
-                    "type": "integer"
                 }
             }
         },
-        "dto.RoleCreateDTO": {
+        "dto.VolunteerStatusChangeDTO": {
             "type": "object",
             "required": [
-                "name",
-                "status"
+                "reason"
             ],
             "properties": {
-                "name": {
-                    "type": "string"
-                },
-                "status": {
-                    "type": "integer"
+                "reason": {
+                    "type": "string",
+                    "maxLength": 255
                 }
             }
         },
-        "dto.RoleUpdateDTO": {
+        "dto.VolunteerStatusChangeResponseDTO": {
             "type": "object",
-            "required": [
-                "name",
-                "status"
-            ],
             "properties": {
-                "name": {
-                    "type": "string"
-                },
-                "status": {
+                "changed_by": {
                     "type": "integer"
-                }
-            }
-        },
-        "dto.UpdateUserIdentityRequest": {
-            "type": "object",
-            "properties": {
-                "expiry_date": {
-                    "type": "string"
                 },
-                "number": {
+                "created_at": {
                     "type": "string"
                 },
-                "place_issued": {
-                    "type": "string"
+                "from_status": {
+                    "type": "integer"
                 },
-                "status": {
+                "id": {
                     "type": "integer"
                 },
-                "type": {
+                "reason": {
                     "type": "string"
                 },
-                "user_id": {
+                "to_status": {
                     "type": "integer"
                 }
             }
         },
-        "dto.UserIdentityResponse": {
+        "dto.VolunteerUpdateDTO": {
             "type": "object",
             "properties": {
-                "expiry_date": {
+                "department_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_cesc1802_onboarding-and-volunteer-service_feature_request_domain.Request": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "number": {
-                    "type": "string"
-                },
-                "place_issued": {
-                    "type": "string"
+                "parentID": {
+                    "type": "integer"
                 },
                 "status": {
-                    "type": "integer"
+                    "$ref": "#/definitions/domain.RequestStatus"
                 },
                 "type": {
+                    "$ref": "#/definitions/domain.RequestType"
+                },
+                "updatedAt": {
                     "type": "string"
                 },
-                "user_id": {
+                "userID": {
+                    "type": "integer"
+                },
+                "verifierID": {
                     "type": "integer"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/gorm.DeletedAt"
                 }
             }
         },
-        "dto.VolunteerCreateDTO": {
+        "github_com_cesc1802_onboarding-and-volunteer-service_feature_user_dto.FileURLResponse": {
             "type": "object",
-            "required": [
-                "department_id",
-                "status",
-                "user_id"
-            ],
             "properties": {
-                "department_id": {
-                    "type": "integer"
-                },
-                "status": {
-                    "type": "integer"
+                "expires_at": {
+                    "type": "string"
                 },
-                "user_id": {
-                    "type": "integer"
+                "url": {
+                    "type": "string"
                 }
-            }
+            },
+            "description": "FileURLResponse is a signed link to a private file."
         },
-        "dto.VolunteerUpdateDTO": {
+        "github_com_cesc1802_onboarding-and-volunteer-service_feature_user_identity_dto.FileURLResponse": {
             "type": "object",
             "properties": {
-                "department_id": {
-                    "type": "integer"
+                "expires_at": {
+                    "type": "string"
                 },
-                "status": {
-                    "type": "integer"
+                "url": {
+                    "type": "string"
                 }
-            }
+            },
+            "description": "FileURLResponse is a signed link to a private file."
         },
-        "dto.VoluteerRequestCreatingDTO": {
+        "gorm.DeletedAt": {
             "type": "object",
-            "required": [
-                "status",
-                "type",
-                "user_id"
-            ],
             "properties": {
-                "status": {
-                    "type": "integer"
-                },
-                "type": {
+                "time": {
                     "type": "string"
                 },
-                "user_id": {
-                    "type": "integer"
+                "valid": {
+                    "type": "boolean",
+                    "description": "Valid is true if Time is not NULL"
                 }
             }
         }
@@ -0,0 +1,92 @@
+package apperror
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Code classifies an error for clients. Every code maps to one HTTP status.
+type Code string
+
+const (
+	Validation    Code = "VALIDATION"
+	Unauthorized  Code = "UNAUTHORIZED"
+	Forbidden     Code = "FORBIDDEN"
+	NotFound      Code = "NOT_FOUND"
+	Conflict      Code = "CONFLICT"
+	Gone          Code = "GONE"
+	Unprocessable Code = "UNPROCESSABLE"
+	TooLarge      Code = "TOO_LARGE"
+	Unsupported   Code = "UNSUPPORTED_MEDIA_TYPE"
+	RateLimited   Code = "RATE_LIMITED"
+	Internal      Code = "INTERNAL"
+)
+
+var statuses = map[Code]int{
+	Validation:    http.StatusBadRequest,
+	Unauthorized:  http.StatusUnauthorized,
+	Forbidden:     http.StatusForbidden,
+	NotFound:      http.StatusNotFound,
+	Conflict:      http.StatusConflict,
+	Gone:          http.StatusGone,
+	Unprocessable: http.StatusUnprocessableEntity,
+	TooLarge:      http.StatusRequestEntityTooLarge,
+	Unsupported:   http.StatusUnsupportedMediaType,
+	RateLimited:   http.StatusTooManyRequests,
+	Internal:      http.StatusInternalServerError,
+}
+
+// Status returns the HTTP status of code. Unknown codes are internal errors.
+func (c Code) Status() int {
+	if status, ok := statuses[c]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// ErrUnauthorized is returned by handlers reached without an authenticated user.
+var ErrUnauthorized = New(Unauthorized, "Unauthorized")
+
+// Error is an error that is reported to clients with its code, message and
+// details. Domain sentinel errors are declared with New so that handlers
+// need no mapping of their own.
+type Error struct {
+	Code    Code
+	Message string
+	Details interface{}
+	Err     error
+}
+
+func New(code Code, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+// Wrap reports err with code. The message is the one of err, which stays
+// reachable through errors.Is and errors.As.
+func Wrap(code Code, err error) *Error {
+	return &Error{Code: code, Message: err.Error(), Err: err}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// WithDetails returns a copy of e carrying details. The copy matches e with
+// errors.Is, so it can be used on sentinel errors.
+func (e *Error) WithDetails(details interface{}) *Error {
+	return &Error{Code: e.Code, Message: e.Message, Details: details, Err: e}
+}
+
+// CodeOf returns the code of the first *Error in the chain of err, or
+// Internal when there is none.
+func CodeOf(err error) Code {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr.Code
+	}
+	return Internal
+}
@@ -0,0 +1,36 @@
+package apperror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCodeStatus(t *testing.T) {
+	assert.Equal(t, http.StatusBadRequest, Validation.Status())
+	assert.Equal(t, http.StatusConflict, Conflict.Status())
+	assert.Equal(t, http.StatusTooManyRequests, RateLimited.Status())
+	assert.Equal(t, http.StatusInternalServerError, Code("UNKNOWN").Status())
+}
+
+func TestErrorChain(t *testing.T) {
+	errMissing := New(NotFound, "thing not found")
+
+	wrapped := fmt.Errorf("load thing 3: %w", errMissing)
+	assert.ErrorIs(t, wrapped, errMissing)
+	assert.Equal(t, NotFound, CodeOf(wrapped))
+
+	detailed := errMissing.WithDetails(map[string]int{"id": 3})
+	assert.ErrorIs(t, detailed, errMissing)
+	assert.Equal(t, "thing not found", detailed.Error())
+
+	cause := errors.New("bad date")
+	validation := Wrap(Validation, cause)
+	assert.ErrorIs(t, validation, cause)
+	assert.Equal(t, "bad date", validation.Error())
+
+	assert.Equal(t, Internal, CodeOf(errors.New("db down")))
+}
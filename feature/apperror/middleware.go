@@ -0,0 +1,58 @@
+package apperror
+
+import (
+	"errors"
+	"log"
+
+	"github.com/gin-gonic/gin"
+)
+
+const internalMessage = "Internal server error"
+
+// Response is the JSON body of every error response.
+type Response struct {
+	Code      Code        `json:"code" example:"NOT_FOUND"`
+	Message   string      `json:"message" example:"request not found"`
+	Details   interface{} `json:"details,omitempty" swaggertype:"object"`
+	RequestID string      `json:"request_id,omitempty"`
+}
+
+// Abort records err on the context and stops the handler chain. The
+// response is written by Middleware.
+func Abort(c *gin.Context, err error) {
+	_ = c.Error(err)
+	c.Abort()
+}
+
+// Middleware renders the last error recorded on the context, unless a
+// response was written already. It must run after the audit middleware so
+// that the response carries the request id.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+		Render(c, c.Errors.Last().Err)
+	}
+}
+
+// Render writes err as a Response. Errors that are not an *Error are
+// internal; their text is logged and never sent to the client.
+func Render(c *gin.Context, err error) {
+	response := Response{Code: Internal, Message: internalMessage, RequestID: c.GetString("requestId")}
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		response.Code = appErr.Code
+		response.Details = appErr.Details
+		if appErr.Code == Internal {
+			response.Message = appErr.Message
+		} else {
+			response.Message = err.Error()
+		}
+	}
+	if response.Code == Internal {
+		log.Printf("%s %s: %v", c.Request.Method, c.Request.URL.Path, err)
+	}
+	c.AbortWithStatusJSON(response.Code.Status(), response)
+}
@@ -0,0 +1,61 @@
+package apperror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	errMissing := New(NotFound, "thing not found")
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("requestId", "req-1")
+	}, Middleware())
+	r.GET("/missing", func(c *gin.Context) {
+		Abort(c, fmt.Errorf("load thing 3: %w", errMissing))
+	})
+	r.GET("/invalid", func(c *gin.Context) {
+		Abort(c, New(Validation, "invalid thing").WithDetails(map[string]string{"name": "required"}))
+	})
+	r.GET("/internal", func(c *gin.Context) {
+		Abort(c, errors.New("dial tcp: connection refused"))
+	})
+	r.GET("/written", func(c *gin.Context) {
+		_ = c.Error(errors.New("already handled"))
+		c.JSON(http.StatusAccepted, gin.H{"message": "ok"})
+	})
+
+	serve := func(path string) (*httptest.ResponseRecorder, Response) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, path, nil)
+		r.ServeHTTP(w, req)
+		var response Response
+		_ = json.Unmarshal(w.Body.Bytes(), &response)
+		return w, response
+	}
+
+	w, response := serve("/missing")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, Response{Code: NotFound, Message: "load thing 3: thing not found", RequestID: "req-1"}, response)
+
+	w, response = serve("/invalid")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, Validation, response.Code)
+	assert.Equal(t, map[string]interface{}{"name": "required"}, response.Details)
+
+	w, response = serve("/internal")
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, Response{Code: Internal, Message: "Internal server error", RequestID: "req-1"}, response)
+
+	w, _ = serve("/written")
+	assert.Equal(t, http.StatusAccepted, w.Code)
+}
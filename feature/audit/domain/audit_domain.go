@@ -0,0 +1,26 @@
+package domain
+
+import "time"
+
+// Event is an append-only audit_events row recording one data-changing
+// action: who did it, on what, what changed and where the call came from.
+// Before and After are JSON objects holding only the fields that changed.
+type Event struct {
+	ID         int  `gorm:"primaryKey"`
+	ActorID    *int `gorm:"index"`
+	ActorRole  string
+	Action     string `gorm:"not null"`
+	EntityType string
+	EntityID   string
+	Before     *string
+	After      *string
+	Status     int
+	IP         string
+	UserAgent  string
+	RequestID  string    `gorm:"index"`
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+}
+
+func (Event) TableName() string {
+	return "audit_events"
+}
@@ -0,0 +1,87 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/audit/domain"
+	"gorm.io/gorm"
+)
+
+// AuditStore appends audit events and reads them back. Events are never
+// updated nor deleted.
+type AuditStore interface {
+	CreateEvent(event *domain.Event) error
+	ListEvents(filter EventFilter) ([]*domain.Event, int64, error)
+}
+
+// EventFilter narrows down the audit log. Zero values mean "no filter".
+// BeforeID is the id of the last event of the previous page.
+type EventFilter struct {
+	ActorID    *int
+	Action     string
+	EntityType string
+	EntityID   string
+	RequestID  string
+	From       *time.Time
+	To         *time.Time
+	BeforeID   int
+	Limit      int
+}
+
+type AuditRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditRepository(db *gorm.DB) *AuditRepository {
+	return &AuditRepository{db: db}
+}
+
+func (r *AuditRepository) CreateEvent(event *domain.Event) error {
+	return r.db.Create(event).Error
+}
+
+// ListEvents returns one page of events matching filter, newest first,
+// together with the number of matching events across all pages. The page
+// holds up to Limit+1 rows so that callers can tell whether another page
+// follows.
+func (r *AuditRepository) ListEvents(filter EventFilter) ([]*domain.Event, int64, error) {
+	var total int64
+	if err := r.filterEvents(filter).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	page := r.filterEvents(filter)
+	if filter.BeforeID > 0 {
+		page = page.Where("id < ?", filter.BeforeID)
+	}
+	events := []*domain.Event{}
+	if err := page.Order("id DESC").Limit(filter.Limit + 1).Find(&events).Error; err != nil {
+		return nil, 0, err
+	}
+	return events, total, nil
+}
+
+func (r *AuditRepository) filterEvents(filter EventFilter) *gorm.DB {
+	query := r.db.Model(&domain.Event{})
+	if filter.ActorID != nil {
+		query = query.Where("actor_id = ?", *filter.ActorID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != "" {
+		query = query.Where("entity_id = ?", filter.EntityID)
+	}
+	if filter.RequestID != "" {
+		query = query.Where("request_id = ?", filter.RequestID)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+	return query
+}
@@ -0,0 +1,37 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/audit/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/database/databasetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditRepositorySQLite(t *testing.T) {
+	db := databasetest.Open(t)
+	repo := NewAuditRepository(db)
+	actorID := 7
+	before := `{"status":0}`
+	for _, action := range []string{"request.approve", "request.reject", "request.approve"} {
+		require.NoError(t, repo.CreateEvent(&domain.Event{ActorID: &actorID, Action: action, EntityType: "request", EntityID: "1", Before: &before, Status: 200}))
+	}
+
+	from := time.Now().Add(-time.Hour)
+	events, total, err := repo.ListEvents(EventFilter{Action: "request.approve", From: &from, Limit: 1})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	require.Len(t, events, 2)
+	assert.Equal(t, 3, events[0].ID)
+	assert.Equal(t, before, *events[0].Before)
+
+	events, _, err = repo.ListEvents(EventFilter{BeforeID: 3, Limit: 10})
+	require.NoError(t, err)
+	assert.Len(t, events, 2)
+
+	// the audit log is append-only
+	assert.ErrorContains(t, db.Exec("UPDATE audit_events SET action = 'x'").Error, "append-only")
+	assert.ErrorContains(t, db.Exec("DELETE FROM audit_events").Error, "append-only")
+}
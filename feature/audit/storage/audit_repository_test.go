@@ -0,0 +1,92 @@
+package storage
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/audit/domain"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func setupSQLMock(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+	return gormDB, mock
+}
+
+func TestCreateEvent(t *testing.T) {
+	db, mock := setupSQLMock(t)
+	repo := NewAuditRepository(db)
+
+	actorID := 9
+	after := `{"status":1}`
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `audit_events` (`actor_id`,`actor_role`,`action`,`entity_type`,`entity_id`,`before`,`after`,`status`,`ip`,`user_agent`,`request_id`,`created_at`) VALUES (?,?,?,?,?,?,?,?,?,?,?,?)")).
+		WithArgs(actorID, "admin", "request.approve", "request", "5", nil, after, 0, "", "", "", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	event := &domain.Event{ActorID: &actorID, ActorRole: "admin", Action: "request.approve", EntityType: "request", EntityID: "5", After: &after}
+	assert.NoError(t, repo.CreateEvent(event))
+	assert.Equal(t, 1, event.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListEvents(t *testing.T) {
+	columns := []string{"id", "actor_id", "action", "entity_type", "entity_id", "created_at"}
+	createdAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("filters and first page", func(t *testing.T) {
+		db, mock := setupSQLMock(t)
+		repo := NewAuditRepository(db)
+
+		actorID := 9
+		filter := EventFilter{ActorID: &actorID, EntityType: "request", EntityID: "5", Limit: 2}
+		where := "WHERE actor_id = ? AND entity_type = ? AND entity_id = ?"
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `audit_events` "+where)).
+			WithArgs(actorID, "request", "5").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `audit_events` "+where+" ORDER BY id DESC LIMIT ?")).
+			WithArgs(actorID, "request", "5", 3).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(3, 9, "request.delete", "request", "5", createdAt).
+				AddRow(2, 9, "request.reject", "request", "5", createdAt).
+				AddRow(1, 9, "request.approve", "request", "5", createdAt))
+
+		events, total, err := repo.ListEvents(filter)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), total)
+		assert.Len(t, events, 3)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("time range after cursor", func(t *testing.T) {
+		db, mock := setupSQLMock(t)
+		repo := NewAuditRepository(db)
+
+		to := createdAt.Add(time.Hour)
+		filter := EventFilter{Action: "request.approve", From: &createdAt, To: &to, BeforeID: 10, Limit: 50}
+		where := "WHERE action = ? AND created_at >= ? AND created_at < ?"
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `audit_events` "+where)).
+			WithArgs("request.approve", createdAt, to).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `audit_events` "+where+" AND id < ? ORDER BY id DESC LIMIT ?")).
+			WithArgs("request.approve", createdAt, to, 10, 51).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(4, 9, "request.approve", "request", "2", createdAt))
+
+		events, total, err := repo.ListEvents(filter)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+		assert.Len(t, events, 1)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,174 @@
+package transport
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/audit/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/audit/usecase"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/validation"
+	"github.com/gin-gonic/gin"
+)
+
+var exportColumns = []string{
+	"id", "created_at", "actor_id", "actor_role", "action", "entity_type", "entity_id",
+	"before", "after", "status", "ip", "user_agent", "request_id",
+}
+
+type AuditHandler struct {
+	usecase usecase.AuditUsecaseInterface
+}
+
+func NewAuditHandler(usecase usecase.AuditUsecaseInterface) *AuditHandler {
+	return &AuditHandler{usecase: usecase}
+}
+
+// ListEvents godoc
+// @Summary List audit events
+// @Description List the audit log with filters and cursor pagination, newest first
+// @Produce json
+// @Tags admin
+// @Param actor_id query int false "Actor user ID"
+// @Param action query string false "Action, e.g. request.approve or POST /api/v1/admin/approve-request/:id"
+// @Param entity_type query string false "Entity type, e.g. request, volunteer or http"
+// @Param entity_id query string false "Entity ID"
+// @Param request_id query string false "Request ID (X-Request-ID)"
+// @Param from query string false "Created at or after (RFC 3339)"
+// @Param to query string false "Created before (RFC 3339)"
+// @Param limit query int false "Page size, 1 to 500 (default 50)"
+// @Param cursor query string false "next_cursor of the previous page"
+// @Success 200 {object} dto.AuditPage
+// @Failure 400 {object} apperror.Response "Invalid query"
+// @Security bearerToken
+// @Router /api/v1/admin/audit [get]
+func (h *AuditHandler) ListEvents(c *gin.Context) {
+	var query dto.AuditQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		apperror.Abort(c, validation.Error(err))
+		return
+	}
+	page, err := h.usecase.ListEvents(query)
+	if err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, page)
+}
+
+// ExportEvents godoc
+// @Summary Export audit events
+// @Description Download every audit event matching the filters, newest first, as CSV or newline-delimited JSON
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Tags admin
+// @Param format query string false "csv (default) or ndjson"
+// @Param actor_id query int false "Actor user ID"
+// @Param action query string false "Action"
+// @Param entity_type query string false "Entity type"
+// @Param entity_id query string false "Entity ID"
+// @Param request_id query string false "Request ID (X-Request-ID)"
+// @Param from query string false "Created at or after (RFC 3339)"
+// @Param to query string false "Created before (RFC 3339)"
+// @Success 200 {file} file
+// @Failure 400 {object} apperror.Response "Invalid query or format"
+// @Security bearerToken
+// @Router /api/v1/admin/audit/export [get]
+func (h *AuditHandler) ExportEvents(c *gin.Context) {
+	var query dto.AuditQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		apperror.Abort(c, validation.Error(err))
+		return
+	}
+
+	format := c.DefaultQuery("format", "csv")
+	var (
+		contentType string
+		header      func() error
+		write       func(dto.AuditEvent) error
+		flush       func() error
+	)
+	switch format {
+	case "csv":
+		writer := csv.NewWriter(c.Writer)
+		contentType = "text/csv"
+		header = func() error { return writer.Write(exportColumns) }
+		write = func(event dto.AuditEvent) error { return writer.Write(toCSVRecord(event)) }
+		flush = func() error {
+			writer.Flush()
+			return writer.Error()
+		}
+	case "ndjson":
+		encoder := json.NewEncoder(c.Writer)
+		contentType = "application/x-ndjson"
+		header = func() error { return nil }
+		write = func(event dto.AuditEvent) error { return encoder.Encode(event) }
+		flush = func() error { return nil }
+	default:
+		apperror.Abort(c, apperror.New(apperror.Validation, "format must be csv or ndjson"))
+		return
+	}
+
+	// The status line is only sent with the first event, or at the end of an
+	// empty export, so that a failing query still gets an error response.
+	started := false
+	start := func() error {
+		if started {
+			return nil
+		}
+		started = true
+		c.Header("Content-Type", contentType)
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="audit-%s.%s"`, time.Now().UTC().Format("20060102T150405Z"), format))
+		c.Status(http.StatusOK)
+		return header()
+	}
+	err := h.usecase.ExportEvents(query, func(event dto.AuditEvent) error {
+		if err := start(); err != nil {
+			return err
+		}
+		return write(event)
+	})
+	if err == nil {
+		err = start()
+	}
+	switch {
+	case err == nil:
+		if err := flush(); err != nil {
+			log.Printf("export audit events: %v", err)
+		}
+	case started:
+		// Part of the file is already sent; truncating it is the only
+		// signal left to the client.
+		log.Printf("export audit events: %v", err)
+		_ = flush()
+	default:
+		apperror.Abort(c, err)
+	}
+}
+
+func toCSVRecord(event dto.AuditEvent) []string {
+	actorID := ""
+	if event.ActorID != nil {
+		actorID = strconv.Itoa(*event.ActorID)
+	}
+	return []string{
+		strconv.Itoa(event.ID),
+		event.CreatedAt.UTC().Format(time.RFC3339),
+		actorID,
+		event.ActorRole,
+		event.Action,
+		event.EntityType,
+		event.EntityID,
+		string(event.Before),
+		string(event.After),
+		strconv.Itoa(event.Status),
+		event.IP,
+		event.UserAgent,
+		event.RequestID,
+	}
+}
@@ -0,0 +1,188 @@
+package transport
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/audit/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/audit/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAuditUsecase struct {
+	mock.Mock
+}
+
+func (m *MockAuditUsecase) Record(entry usecase.Entry) error {
+	args := m.Called(entry)
+	return args.Error(0)
+}
+
+func (m *MockAuditUsecase) ListEvents(query dto.AuditQuery) (*dto.AuditPage, error) {
+	args := m.Called(query)
+	if args.Get(0) != nil {
+		return args.Get(0).(*dto.AuditPage), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAuditUsecase) ExportEvents(query dto.AuditQuery, each func(dto.AuditEvent) error) error {
+	args := m.Called(query, each)
+	if events, ok := args.Get(0).([]dto.AuditEvent); ok {
+		for _, event := range events {
+			if err := each(event); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
+type MockRoleNamer struct {
+	mock.Mock
+}
+
+func (m *MockRoleNamer) RoleName(roleID int) (string, error) {
+	args := m.Called(roleID)
+	return args.String(0), args.Error(1)
+}
+
+func TestAuditMiddleware(t *testing.T) {
+	mockUsecase := new(MockAuditUsecase)
+	roles := new(MockRoleNamer)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(AuditMiddleware(mockUsecase, roles))
+	authenticated := func(c *gin.Context) {
+		c.Set("userId", 9)
+		c.Set("roleId", 2)
+	}
+	r.POST("/api/v1/admin/approve-request/:id", authenticated, func(c *gin.Context) {
+		c.JSON(http.StatusConflict, gin.H{"error": "invalid request status transition"})
+	})
+	r.GET("/api/v1/admin/request/:id", authenticated, func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{})
+	})
+
+	roles.On("RoleName", 2).Return("admin", nil)
+	mockUsecase.On("Record", mock.MatchedBy(func(entry usecase.Entry) bool {
+		return *entry.ActorID == 9 && entry.ActorRole == "admin" &&
+			entry.Action == "POST /api/v1/admin/approve-request/:id" &&
+			entry.EntityType == "http" && entry.EntityID == "5" &&
+			entry.Status == http.StatusConflict && entry.UserAgent == "test-agent" &&
+			entry.RequestID == "req-1"
+	})).Return(nil).Once()
+
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/admin/approve-request/5", nil)
+	req.Header.Set("User-Agent", "test-agent")
+	req.Header.Set(RequestIDHeader, "req-1")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	assert.Equal(t, "req-1", rr.Header().Get(RequestIDHeader))
+
+	req, _ = http.NewRequest(http.MethodGet, "/api/v1/admin/request/5", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Len(t, rr.Header().Get(RequestIDHeader), 32, "a request id is generated when none is given")
+
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestListEvents(t *testing.T) {
+	mockUsecase := new(MockAuditUsecase)
+	handler := NewAuditHandler(mockUsecase)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.Use(apperror.Middleware())
+	r.GET("/api/v1/admin/audit", handler.ListEvents)
+
+	actorID := 9
+	mockUsecase.On("ListEvents", dto.AuditQuery{ActorID: &actorID, EntityType: "request"}).
+		Return(&dto.AuditPage{Items: []dto.AuditEvent{{ID: 1, Action: "request.approve"}}, Total: 1}, nil)
+	mockUsecase.On("ListEvents", dto.AuditQuery{Limit: 1000}).Return(nil, usecase.ErrInvalidAuditQuery)
+
+	tests := []struct {
+		name   string
+		query  string
+		status int
+	}{
+		{"filtered", "actor_id=9&entity_type=request", http.StatusOK},
+		{"invalid limit", "limit=1000", http.StatusBadRequest},
+		{"malformed actor", "actor_id=abc", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, "/api/v1/admin/audit?"+tt.query, nil)
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+			assert.Equal(t, tt.status, rr.Code)
+		})
+	}
+}
+
+func TestExportEvents(t *testing.T) {
+	mockUsecase := new(MockAuditUsecase)
+	handler := NewAuditHandler(mockUsecase)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.Use(apperror.Middleware())
+	r.GET("/api/v1/admin/audit/export", handler.ExportEvents)
+
+	actorID := 9
+	createdAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	events := []dto.AuditEvent{{
+		ID: 1, ActorID: &actorID, Action: "request.approve", EntityType: "request", EntityID: "5",
+		Before: []byte(`{"status":0}`), After: []byte(`{"status":1}`), CreatedAt: createdAt,
+	}}
+	mockUsecase.On("ExportEvents", dto.AuditQuery{Action: "request.approve"}, mock.Anything).Return(events, nil)
+	mockUsecase.On("ExportEvents", dto.AuditQuery{Action: "broken"}, mock.Anything).Return(nil, errors.New("db down"))
+
+	t.Run("csv", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/admin/audit/export?action=request.approve", nil)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "text/csv", rr.Header().Get("Content-Type"))
+		lines := strings.Split(strings.TrimSpace(rr.Body.String()), "\n")
+		assert.Len(t, lines, 2)
+		assert.Equal(t, strings.Join(exportColumns, ","), lines[0])
+		assert.Equal(t, `1,2024-05-01T10:00:00Z,9,,request.approve,request,5,"{""status"":0}","{""status"":1}",0,,,`, lines[1])
+	})
+
+	t.Run("ndjson", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/admin/audit/export?action=request.approve&format=ndjson", nil)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "application/x-ndjson", rr.Header().Get("Content-Type"))
+		assert.Contains(t, rr.Body.String(), `"after":{"status":1}`)
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/admin/audit/export?format=xml", nil)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("failure before the first event", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/admin/audit/export?action=broken", nil)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+}
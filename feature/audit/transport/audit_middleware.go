@@ -0,0 +1,82 @@
+package transport
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log"
+	"net/http"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/audit/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// RequestIDHeader carries the id correlating a call with its audit events.
+const RequestIDHeader = "X-Request-ID"
+
+// Recorder appends entries to the audit log.
+type Recorder interface {
+	Record(entry usecase.Entry) error
+}
+
+// RoleNamer resolves the role stored in the access token.
+type RoleNamer interface {
+	RoleName(roleID int) (string, error)
+}
+
+// AuditMiddleware tags every call with a request id, taken from the
+// X-Request-ID header or generated, and records every data-changing call
+// (any method but GET, HEAD and OPTIONS) once its handler has run, whatever
+// its outcome. It must run before AuthMiddleware so that it sees the actor
+// that AuthMiddleware puts in the context.
+func AuditMiddleware(recorder Recorder, roles RoleNamer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if requestID == "" || len(requestID) > 64 {
+			requestID = newRequestID()
+		}
+		c.Set("requestId", requestID)
+		c.Header(RequestIDHeader, requestID)
+
+		c.Next()
+
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			return
+		}
+		route := c.FullPath()
+		if route == "" {
+			route = c.Request.URL.Path
+		}
+		entry := usecase.Entry{
+			Action:     c.Request.Method + " " + route,
+			EntityType: "http",
+			EntityID:   c.Param("id"),
+			Status:     c.Writer.Status(),
+			IP:         c.ClientIP(),
+			UserAgent:  c.Request.UserAgent(),
+			RequestID:  requestID,
+		}
+		if userID, ok := c.Get("userId"); ok {
+			actorID := userID.(int)
+			entry.ActorID = &actorID
+		}
+		if roleID, ok := c.Get("roleId"); ok {
+			name, err := roles.RoleName(roleID.(int))
+			if err != nil {
+				log.Printf("resolve role %d for audit: %v", roleID, err)
+			}
+			entry.ActorRole = name
+		}
+		if err := recorder.Record(entry); err != nil {
+			log.Printf("record audit event for %s: %v", entry.Action, err)
+		}
+	}
+}
+
+func newRequestID() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(buf)
+}
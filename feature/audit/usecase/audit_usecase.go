@@ -0,0 +1,201 @@
+package usecase
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/audit/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/audit/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/audit/storage"
+)
+
+const (
+	defaultEventPageSize = 50
+	maxEventPageSize     = 500
+)
+
+// ErrInvalidAuditQuery is returned when the audit query parameters are invalid.
+var ErrInvalidAuditQuery = apperror.New(apperror.Validation, "invalid audit query")
+
+// Entry is an action to record. Before and After are snapshots of the entity,
+// typically structs or maps; only the fields that differ are stored.
+type Entry struct {
+	ActorID    *int
+	ActorRole  string
+	Action     string
+	EntityType string
+	EntityID   string
+	Before     interface{}
+	After      interface{}
+	Status     int
+	IP         string
+	UserAgent  string
+	RequestID  string
+}
+
+type AuditUsecaseInterface interface {
+	Record(entry Entry) error
+	ListEvents(query dto.AuditQuery) (*dto.AuditPage, error)
+	ExportEvents(query dto.AuditQuery, each func(dto.AuditEvent) error) error
+}
+
+type AuditUsecase struct {
+	repo storage.AuditStore
+}
+
+func NewAuditUsecase(repo storage.AuditStore) *AuditUsecase {
+	return &AuditUsecase{repo: repo}
+}
+
+// Record appends entry to the audit log.
+func (u *AuditUsecase) Record(entry Entry) error {
+	before, after, err := diff(entry.Before, entry.After)
+	if err != nil {
+		return err
+	}
+	return u.repo.CreateEvent(&domain.Event{
+		ActorID:    entry.ActorID,
+		ActorRole:  entry.ActorRole,
+		Action:     entry.Action,
+		EntityType: entry.EntityType,
+		EntityID:   entry.EntityID,
+		Before:     before,
+		After:      after,
+		Status:     entry.Status,
+		IP:         entry.IP,
+		UserAgent:  truncate(entry.UserAgent, 255),
+		RequestID:  entry.RequestID,
+	})
+}
+
+// ListEvents returns one page of the audit log, newest first.
+func (u *AuditUsecase) ListEvents(query dto.AuditQuery) (*dto.AuditPage, error) {
+	filter, err := toEventFilter(query)
+	if err != nil {
+		return nil, err
+	}
+	events, total, err := u.repo.ListEvents(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &dto.AuditPage{Items: make([]dto.AuditEvent, 0, len(events)), Total: total}
+	if len(events) > filter.Limit {
+		events = events[:filter.Limit]
+		cursor := encodeEventCursor(events[len(events)-1].ID)
+		page.NextCursor = &cursor
+	}
+	for _, event := range events {
+		page.Items = append(page.Items, toAuditEvent(event))
+	}
+	return page, nil
+}
+
+// ExportEvents calls each with every event matching query, newest first,
+// reading the log page by page. The limit and cursor of query are ignored.
+func (u *AuditUsecase) ExportEvents(query dto.AuditQuery, each func(dto.AuditEvent) error) error {
+	query.Limit, query.Cursor = 0, ""
+	filter, err := toEventFilter(query)
+	if err != nil {
+		return err
+	}
+	filter.Limit = maxEventPageSize
+	for {
+		events, _, err := u.repo.ListEvents(filter)
+		if err != nil {
+			return err
+		}
+		last := len(events) <= filter.Limit
+		if !last {
+			events = events[:filter.Limit]
+		}
+		for _, event := range events {
+			if err := each(toAuditEvent(event)); err != nil {
+				return err
+			}
+		}
+		if last {
+			return nil
+		}
+		filter.BeforeID = events[len(events)-1].ID
+	}
+}
+
+func toEventFilter(query dto.AuditQuery) (storage.EventFilter, error) {
+	filter := storage.EventFilter{
+		ActorID:    query.ActorID,
+		Action:     query.Action,
+		EntityType: query.EntityType,
+		EntityID:   query.EntityID,
+		RequestID:  query.RequestID,
+		From:       query.From,
+		To:         query.To,
+		Limit:      query.Limit,
+	}
+	switch {
+	case filter.Limit == 0:
+		filter.Limit = defaultEventPageSize
+	case filter.Limit < 0 || filter.Limit > maxEventPageSize:
+		return filter, fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidAuditQuery, maxEventPageSize)
+	}
+	if query.Cursor != "" {
+		id, err := decodeEventCursor(query.Cursor)
+		if err != nil {
+			return filter, fmt.Errorf("%w: malformed cursor", ErrInvalidAuditQuery)
+		}
+		filter.BeforeID = id
+	}
+	return filter, nil
+}
+
+func toAuditEvent(event *domain.Event) dto.AuditEvent {
+	return dto.AuditEvent{
+		ID:         event.ID,
+		ActorID:    event.ActorID,
+		ActorRole:  event.ActorRole,
+		Action:     event.Action,
+		EntityType: event.EntityType,
+		EntityID:   event.EntityID,
+		Before:     rawJSON(event.Before),
+		After:      rawJSON(event.After),
+		Status:     event.Status,
+		IP:         event.IP,
+		UserAgent:  event.UserAgent,
+		RequestID:  event.RequestID,
+		CreatedAt:  event.CreatedAt,
+	}
+}
+
+func rawJSON(value *string) json.RawMessage {
+	if value == nil {
+		return json.RawMessage("null")
+	}
+	return json.RawMessage(*value)
+}
+
+func encodeEventCursor(id int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(id)))
+}
+
+func decodeEventCursor(value string) (int, error) {
+	payload, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return 0, err
+	}
+	id, err := strconv.Atoi(string(payload))
+	if err == nil && id <= 0 {
+		err = errors.New("cursor must be positive")
+	}
+	return id, err
+}
+
+func truncate(value string, max int) string {
+	if len(value) > max {
+		return value[:max]
+	}
+	return value
+}
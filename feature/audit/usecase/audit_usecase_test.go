@@ -0,0 +1,147 @@
+package usecase
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/audit/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/audit/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/audit/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAuditStore struct {
+	mock.Mock
+}
+
+func (m *MockAuditStore) CreateEvent(event *domain.Event) error {
+	args := m.Called(event)
+	return args.Error(0)
+}
+
+func (m *MockAuditStore) ListEvents(filter storage.EventFilter) ([]*domain.Event, int64, error) {
+	args := m.Called(filter)
+	if args.Get(0) != nil {
+		return args.Get(0).([]*domain.Event), args.Get(1).(int64), args.Error(2)
+	}
+	return nil, 0, args.Error(2)
+}
+
+func TestRecord(t *testing.T) {
+	type snapshot struct {
+		Status   int    `json:"status"`
+		Name     string `json:"name"`
+		Password string `json:"password"`
+	}
+
+	t.Run("stores only changed fields", func(t *testing.T) {
+		store := new(MockAuditStore)
+		usecase := NewAuditUsecase(store)
+
+		actorID := 9
+		store.On("CreateEvent", mock.MatchedBy(func(event *domain.Event) bool {
+			return *event.ActorID == 9 && event.Action == "user.update" &&
+				*event.Before == `{"password":"[REDACTED]","status":0}` &&
+				*event.After == `{"password":"[REDACTED]","status":1}`
+		})).Return(nil)
+
+		err := usecase.Record(Entry{
+			ActorID: &actorID,
+			Action:  "user.update",
+			Before:  snapshot{Status: 0, Name: "Jane", Password: "old"},
+			After:   snapshot{Status: 1, Name: "Jane", Password: "new"},
+		})
+		assert.NoError(t, err)
+		store.AssertExpectations(t)
+	})
+
+	t.Run("creation has no before", func(t *testing.T) {
+		store := new(MockAuditStore)
+		usecase := NewAuditUsecase(store)
+
+		store.On("CreateEvent", mock.MatchedBy(func(event *domain.Event) bool {
+			return event.Before == nil && *event.After == `{"name":"Jane","password":"[REDACTED]","status":1}`
+		})).Return(nil)
+
+		var before *snapshot
+		err := usecase.Record(Entry{Action: "user.create", Before: before, After: &snapshot{Status: 1, Name: "Jane", Password: "secret"}})
+		assert.NoError(t, err)
+		store.AssertExpectations(t)
+	})
+
+	t.Run("http event has no snapshots", func(t *testing.T) {
+		store := new(MockAuditStore)
+		usecase := NewAuditUsecase(store)
+
+		store.On("CreateEvent", mock.MatchedBy(func(event *domain.Event) bool {
+			return event.Before == nil && event.After == nil && event.Status == 200 && event.RequestID == "abc"
+		})).Return(nil)
+
+		assert.NoError(t, usecase.Record(Entry{Action: "POST /api/v1/country/", EntityType: "http", Status: 200, RequestID: "abc"}))
+		store.AssertExpectations(t)
+	})
+}
+
+func TestListEvents(t *testing.T) {
+	after := `{"status":1}`
+	events := []*domain.Event{
+		{ID: 5, Action: "request.approve", After: &after},
+		{ID: 4, Action: "request.reject"},
+		{ID: 3, Action: "request.delete"},
+	}
+
+	t.Run("first page", func(t *testing.T) {
+		store := new(MockAuditStore)
+		usecase := NewAuditUsecase(store)
+
+		store.On("ListEvents", storage.EventFilter{EntityType: "request", Limit: 2}).Return(events, int64(3), nil)
+
+		page, err := usecase.ListEvents(dto.AuditQuery{EntityType: "request", Limit: 2})
+		assert.NoError(t, err)
+		assert.Len(t, page.Items, 2)
+		assert.Equal(t, json.RawMessage(after), page.Items[0].After)
+		assert.Equal(t, json.RawMessage("null"), page.Items[1].After)
+		assert.Equal(t, int64(3), page.Total)
+		assert.NotNil(t, page.NextCursor)
+
+		store.On("ListEvents", storage.EventFilter{EntityType: "request", Limit: 2, BeforeID: 4}).Return(events[2:], int64(3), nil)
+
+		next, err := usecase.ListEvents(dto.AuditQuery{EntityType: "request", Limit: 2, Cursor: *page.NextCursor})
+		assert.NoError(t, err)
+		assert.Len(t, next.Items, 1)
+		assert.Nil(t, next.NextCursor)
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		usecase := NewAuditUsecase(new(MockAuditStore))
+
+		_, err := usecase.ListEvents(dto.AuditQuery{Limit: 1000})
+		assert.ErrorIs(t, err, ErrInvalidAuditQuery)
+		_, err = usecase.ListEvents(dto.AuditQuery{Cursor: "not a cursor"})
+		assert.ErrorIs(t, err, ErrInvalidAuditQuery)
+	})
+}
+
+func TestExportEvents(t *testing.T) {
+	store := new(MockAuditStore)
+	usecase := NewAuditUsecase(store)
+
+	full := make([]*domain.Event, maxEventPageSize+1)
+	for i := range full {
+		full[i] = &domain.Event{ID: 1000 - i}
+	}
+	store.On("ListEvents", storage.EventFilter{Action: "request.approve", Limit: maxEventPageSize}).Return(full, int64(maxEventPageSize+1), nil)
+	store.On("ListEvents", storage.EventFilter{Action: "request.approve", Limit: maxEventPageSize, BeforeID: 1000 - maxEventPageSize + 1}).
+		Return(full[maxEventPageSize:], int64(maxEventPageSize+1), nil)
+
+	var ids []int
+	err := usecase.ExportEvents(dto.AuditQuery{Action: "request.approve", Limit: 5, Cursor: "ignored"}, func(event dto.AuditEvent) error {
+		ids = append(ids, event.ID)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Len(t, ids, maxEventPageSize+1)
+	assert.Equal(t, 1000-maxEventPageSize, ids[len(ids)-1])
+	store.AssertExpectations(t)
+}
@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// redacted replaces the value of sensitive fields in the audit log.
+const redacted = "[REDACTED]"
+
+// sensitiveKeys are the field name fragments whose values are never stored.
+var sensitiveKeys = []string{"password", "token", "secret"}
+
+// diff returns the JSON objects of the fields of before and after whose
+// values differ. A nil snapshot, e.g. the before of a creation, yields null.
+// Values of sensitive fields are redacted.
+func diff(before, after interface{}) (*string, *string, error) {
+	from, err := toObject(before)
+	if err != nil {
+		return nil, nil, err
+	}
+	to, err := toObject(after)
+	if err != nil {
+		return nil, nil, err
+	}
+	if from != nil && to != nil {
+		for key, value := range from {
+			if other, ok := to[key]; ok && reflect.DeepEqual(value, other) {
+				delete(from, key)
+				delete(to, key)
+			}
+		}
+	}
+	beforeJSON, err := encodeObject(from)
+	if err != nil {
+		return nil, nil, err
+	}
+	afterJSON, err := encodeObject(to)
+	return beforeJSON, afterJSON, err
+}
+
+// toObject converts a snapshot to a JSON object. Snapshots that are not
+// objects, e.g. a string, are stored under the "value" key.
+func toObject(snapshot interface{}) (map[string]interface{}, error) {
+	if snapshot == nil {
+		return nil, nil
+	}
+	var payload []byte
+	switch value := snapshot.(type) {
+	case json.RawMessage:
+		payload = value
+	default:
+		var err error
+		if payload, err = json.Marshal(snapshot); err != nil {
+			return nil, err
+		}
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(payload, &decoded); err != nil {
+		return nil, err
+	}
+	switch value := decoded.(type) {
+	case nil:
+		return nil, nil
+	case map[string]interface{}:
+		return value, nil
+	default:
+		return map[string]interface{}{"value": value}, nil
+	}
+}
+
+func encodeObject(object map[string]interface{}) (*string, error) {
+	if object == nil {
+		return nil, nil
+	}
+	redact(object)
+	payload, err := json.Marshal(object)
+	if err != nil {
+		return nil, err
+	}
+	value := string(payload)
+	return &value, nil
+}
+
+func redact(value interface{}) {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, field := range value {
+			if isSensitive(key) {
+				value[key] = redacted
+				continue
+			}
+			redact(field)
+		}
+	case []interface{}:
+		for _, item := range value {
+			redact(item)
+		}
+	}
+}
+
+func isSensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, fragment := range sensitiveKeys {
+		if strings.Contains(key, fragment) {
+			return true
+		}
+	}
+	return false
+}
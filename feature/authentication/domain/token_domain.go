@@ -0,0 +1,48 @@
+package domain
+
+import "time"
+
+// RefreshToken is a single-use refresh token. Tokens rotated from the same
+// login share a FamilyID so the whole chain can be revoked at once.
+type RefreshToken struct {
+	ID              int       `gorm:"primaryKey"`
+	UserID          int       `gorm:"index;not null"`
+	FamilyID        string    `gorm:"index;not null"`
+	TokenHash       string    `gorm:"unique;not null"`
+	AccessJTI       string    `gorm:"column:access_jti;not null"`
+	AccessExpiresAt time.Time `gorm:"not null"`
+	ExpiresAt       time.Time `gorm:"not null"`
+	RevokedAt       *time.Time
+	ReplacedByID    *int
+	CreatedAt       time.Time `gorm:"autoCreateTime"`
+}
+
+// RevokedAccessToken is a denylist entry for an access token that must be
+// rejected before it expires.
+type RevokedAccessToken struct {
+	JTI       string    `gorm:"column:jti;primaryKey"`
+	UserID    int       `gorm:"not null"`
+	ExpiresAt time.Time `gorm:"not null"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+// EmailVerificationToken records an issued email verification token. The
+// token itself is a signed JWT; only its jti is stored so it can be used once.
+type EmailVerificationToken struct {
+	JTI       string    `gorm:"column:jti;primaryKey"`
+	UserID    int       `gorm:"index;not null"`
+	ExpiresAt time.Time `gorm:"not null"`
+	UsedAt    *time.Time
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+// PasswordResetToken is a single-use password reset token. Only the hash of
+// the token sent by email is stored.
+type PasswordResetToken struct {
+	ID        int       `gorm:"primaryKey"`
+	UserID    int       `gorm:"index;not null"`
+	TokenHash string    `gorm:"unique;not null"`
+	ExpiresAt time.Time `gorm:"not null"`
+	UsedAt    *time.Time
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
@@ -2,6 +2,8 @@ package domain
 
 import (
 	"time"
+
+	"gorm.io/gorm"
 )
 
 type User struct {
@@ -18,8 +20,9 @@ type User struct {
 	CountryID          int       `gorm:"index"`
 	ResidentCountryID  int       `gorm:"index"`
 	Avatar             *string
-	VerificationStatus int       `gorm:"default:0"`
-	Status             int       `gorm:"not null"`
-	CreatedAt          time.Time `gorm:"autoCreateTime"`
-	UpdatedAt          time.Time `gorm:"autoUpdateTime"`
+	VerificationStatus int            `gorm:"default:0"`
+	Status             int            `gorm:"not null"`
+	CreatedAt          time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt          time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt          gorm.DeletedAt `gorm:"index"`
 }
@@ -25,16 +25,56 @@ type LoginUserResponse struct {
 }
 
 type LoginUserTokenResponse struct {
-	Token string `json:"token"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"`
 }
 
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type LogoutResponse struct {
+	Message string `json:"message"`
+}
+
+// RegisterUserRequest signs up an applicant. ResidentCountryID defaults to
+// CountryID.
 type RegisterUserRequest struct {
-	Email      string `json:"email" binding:"required,email"`
-	Name       string `json:"name" binding:"required"`
-	Password   string `json:"password" binding:"required"`
-	RePassword string `json:"re_password" binding:"required"`
+	Email             string `json:"email" binding:"required,email"`
+	Name              string `json:"name" binding:"required"`
+	Password          string `json:"password" binding:"required,password"`
+	RePassword        string `json:"re_password" binding:"required,eqfield=Password"`
+	CountryID         int    `json:"country_id" binding:"required,exists=countries"`
+	ResidentCountryID int    `json:"resident_country_id" binding:"omitempty,exists=countries"`
 }
 
 type RegisterUserResponse struct {
 	Message string `json:"message"`
 }
+
+type ResendVerificationRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type VerificationResponse struct {
+	Message string `json:"message"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token      string `json:"token" binding:"required"`
+	Password   string `json:"password" binding:"required,password"`
+	RePassword string `json:"re_password" binding:"required,eqfield=Password"`
+}
+
+type PasswordResetResponse struct {
+	Message string `json:"message"`
+}
@@ -0,0 +1,77 @@
+package hasher
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHasher_HashAndVerify(t *testing.T) {
+	for _, algorithm := range []string{AlgorithmBcrypt, AlgorithmArgon2id} {
+		t.Run(algorithm, func(t *testing.T) {
+			h := New(algorithm)
+
+			hashed, err := h.Hash("s3cret")
+			assert.NoError(t, err)
+			assert.NotEqual(t, "s3cret", hashed)
+			assert.True(t, IsHashed(hashed))
+			assert.False(t, h.NeedsRehash(hashed))
+
+			ok, err := h.Verify(hashed, "s3cret")
+			assert.NoError(t, err)
+			assert.True(t, ok)
+
+			ok, err = h.Verify(hashed, "wrong")
+			assert.NoError(t, err)
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestHasher_LegacyPlaintext(t *testing.T) {
+	h := New(AlgorithmBcrypt)
+
+	ok, err := h.Verify("plaintext", "plaintext")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = h.Verify("plaintext", "other")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.True(t, h.NeedsRehash("plaintext"))
+	assert.False(t, IsHashed("plaintext"))
+}
+
+func TestHasher_VerifiesOtherSchemeAndAsksForRehash(t *testing.T) {
+	argonHash, err := New(AlgorithmArgon2id).Hash("s3cret")
+	assert.NoError(t, err)
+
+	h := New(AlgorithmBcrypt)
+	ok, err := h.Verify(argonHash, "s3cret")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.True(t, h.NeedsRehash(argonHash))
+}
+
+func TestHasher_UnknownAlgorithmFallsBackToBcrypt(t *testing.T) {
+	hashed, err := New("md5").Hash("s3cret")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hashed, "$2a$"))
+}
+
+func TestArgon2id_RejectsMalformedHash(t *testing.T) {
+	for _, hashed := range []string{
+		"$argon2id$v=19$broken",
+		"$argon2id$v=19$m=65536,t=0,p=4$c2FsdHNhbHRzYWx0c2FsdA$a2V5",
+		"$argon2id$v=19$m=65536,t=3,p=0$c2FsdHNhbHRzYWx0c2FsdA$a2V5",
+		"$argon2id$v=19$m=0,t=3,p=4$c2FsdHNhbHRzYWx0c2FsdA$a2V5",
+		"$argon2id$v=19$m=65536,t=3,p=4$c2FsdHNhbHRzYWx0c2FsdA$",
+	} {
+		ok, err := NewArgon2id().Verify(hashed, "s3cret")
+		assert.Error(t, err, hashed)
+		assert.False(t, ok, hashed)
+		assert.True(t, NewArgon2id().NeedsRehash(hashed), hashed)
+	}
+}
@@ -0,0 +1,62 @@
+package storage
+
+import (
+	"errors"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/domain"
+	"gorm.io/gorm"
+)
+
+var ErrPasswordResetTokenInvalid = errors.New("password reset token is invalid or expired")
+
+type PasswordResetStore interface {
+	CreatePasswordResetToken(token *domain.PasswordResetToken) error
+	ResetPassword(tokenHash string, passwordHash string) (int, error)
+}
+
+type PasswordResetRepository struct {
+	db *gorm.DB
+}
+
+func NewPasswordResetRepository(db *gorm.DB) *PasswordResetRepository {
+	return &PasswordResetRepository{db: db}
+}
+
+func (r *PasswordResetRepository) CreatePasswordResetToken(token *domain.PasswordResetToken) error {
+	return r.db.Create(token).Error
+}
+
+// ResetPassword consumes the token, stores the new password hash of its user
+// and revokes every session of the user in one transaction. Every other
+// unused reset token of the user is consumed too. It returns the id of the user, or ErrPasswordResetTokenInvalid
+// when the token is unknown, expired or was used already.
+func (r *PasswordResetRepository) ResetPassword(tokenHash string, passwordHash string) (int, error) {
+	var userID int
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		var token domain.PasswordResetToken
+		err := tx.Where("token_hash = ? AND used_at IS NULL AND expires_at > ?", tokenHash, now).First(&token).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrPasswordResetTokenInvalid
+		}
+		if err != nil {
+			return err
+		}
+		result := tx.Model(&domain.PasswordResetToken{}).
+			Where("user_id = ? AND used_at IS NULL", token.UserID).
+			Update("used_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrPasswordResetTokenInvalid
+		}
+		userID = token.UserID
+		if err := tx.Model(&domain.User{}).Where("id = ?", token.UserID).Update("password", passwordHash).Error; err != nil {
+			return err
+		}
+		return revokeSessions(tx, "user_id = ?", token.UserID)
+	})
+	return userID, err
+}
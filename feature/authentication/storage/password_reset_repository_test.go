@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResetPassword(t *testing.T) {
+	findToken := regexp.QuoteMeta("SELECT * FROM `password_reset_tokens` WHERE token_hash = ? AND used_at IS NULL AND expires_at > ? ORDER BY `password_reset_tokens`.`id` LIMIT ?")
+
+	t.Run("valid token", func(t *testing.T) {
+		db, mock, err := setupMockDB()
+		assert.NoError(t, err)
+		repo := NewPasswordResetRepository(db)
+		accessExpiresAt := time.Now().Add(10 * time.Minute)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(findToken).WithArgs("hash", sqlmock.AnyArg(), 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "token_hash"}).AddRow(3, 7, "hash"))
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `password_reset_tokens` SET `used_at`=? WHERE user_id = ? AND used_at IS NULL")).
+			WithArgs(sqlmock.AnyArg(), 7).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `users` SET `password`=?,`updated_at`=? WHERE id = ?")).
+			WithArgs("new-hash", sqlmock.AnyArg(), 7).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `refresh_tokens` WHERE user_id = ? AND access_expires_at > ?")).
+			WithArgs(7, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "family_id", "access_jti", "access_expires_at"}).
+				AddRow(1, 7, "family", "jti-1", accessExpiresAt))
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `revoked_access_tokens`")).
+			WithArgs("jti-1", 7, accessExpiresAt, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `refresh_tokens` SET `revoked_at`=? WHERE user_id = ? AND revoked_at IS NULL")).
+			WithArgs(sqlmock.AnyArg(), 7).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		userID, err := repo.ResetPassword("hash", "new-hash")
+		assert.NoError(t, err)
+		assert.Equal(t, 7, userID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("unknown, used or expired token", func(t *testing.T) {
+		db, mock, err := setupMockDB()
+		assert.NoError(t, err)
+		repo := NewPasswordResetRepository(db)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(findToken).WithArgs("hash", sqlmock.AnyArg(), 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "token_hash"}))
+		mock.ExpectRollback()
+
+		_, err = repo.ResetPassword("hash", "new-hash")
+		assert.ErrorIs(t, err, ErrPasswordResetTokenInvalid)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("sessions cannot be revoked", func(t *testing.T) {
+		db, mock, err := setupMockDB()
+		assert.NoError(t, err)
+		repo := NewPasswordResetRepository(db)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(findToken).WithArgs("hash", sqlmock.AnyArg(), 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "token_hash"}).AddRow(3, 7, "hash"))
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `password_reset_tokens`")).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `users`")).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `refresh_tokens`")).WillReturnError(errors.New("connection lost"))
+		mock.ExpectRollback()
+
+		_, err = repo.ResetPassword("hash", "new-hash")
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
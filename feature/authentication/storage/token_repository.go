@@ -0,0 +1,130 @@
+package storage
+
+import (
+	"errors"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrRefreshTokenAlreadyUsed = errors.New("refresh token already used")
+
+type TokenStore interface {
+	CreateRefreshToken(token *domain.RefreshToken) error
+	GetRefreshTokenByHash(tokenHash string) (*domain.RefreshToken, error)
+	RotateRefreshToken(current *domain.RefreshToken, next *domain.RefreshToken) error
+	RevokeTokenFamily(familyID string) error
+	RevokeUserTokens(userID int) error
+	RevokeAccessToken(jti string, userID int, expiresAt time.Time) error
+	IsAccessTokenRevoked(jti string, userID int) (bool, error)
+}
+
+type TokenRepository struct {
+	db *gorm.DB
+}
+
+func NewTokenRepository(db *gorm.DB) *TokenRepository {
+	return &TokenRepository{db: db}
+}
+
+func (r *TokenRepository) CreateRefreshToken(token *domain.RefreshToken) error {
+	return r.db.Create(token).Error
+}
+
+func (r *TokenRepository) GetRefreshTokenByHash(tokenHash string) (*domain.RefreshToken, error) {
+	var token domain.RefreshToken
+	if err := r.db.Where("token_hash = ?", tokenHash).First(&token).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// RotateRefreshToken stores next and marks current as used in one transaction.
+// It returns ErrRefreshTokenAlreadyUsed when current was consumed concurrently.
+func (r *TokenRepository) RotateRefreshToken(current *domain.RefreshToken, next *domain.RefreshToken) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(next).Error; err != nil {
+			return err
+		}
+		result := tx.Model(&domain.RefreshToken{}).
+			Where("id = ? AND revoked_at IS NULL", current.ID).
+			Updates(map[string]interface{}{"revoked_at": time.Now(), "replaced_by_id": next.ID})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrRefreshTokenAlreadyUsed
+		}
+		return nil
+	})
+}
+
+// RevokeTokenFamily revokes every refresh token rotated from the same login and
+// denylists the access tokens issued alongside them.
+func (r *TokenRepository) RevokeTokenFamily(familyID string) error {
+	return r.revoke("family_id = ?", familyID)
+}
+
+// RevokeUserTokens revokes every session of a user.
+func (r *TokenRepository) RevokeUserTokens(userID int) error {
+	return r.revoke("user_id = ?", userID)
+}
+
+func (r *TokenRepository) revoke(query string, arg interface{}) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return revokeSessions(tx, query, arg)
+	})
+}
+
+// revokeSessions revokes the refresh tokens matching query and denylists the
+// access tokens issued alongside them. It runs in the transaction of tx.
+func revokeSessions(tx *gorm.DB, query string, arg interface{}) error {
+	var tokens []domain.RefreshToken
+	if err := tx.Where(query, arg).Where("access_expires_at > ?", time.Now()).Find(&tokens).Error; err != nil {
+		return err
+	}
+	for _, token := range tokens {
+		if err := revokeAccessToken(tx, token.AccessJTI, token.UserID, token.AccessExpiresAt); err != nil {
+			return err
+		}
+	}
+	return tx.Model(&domain.RefreshToken{}).
+		Where(query, arg).
+		Where("revoked_at IS NULL").
+		Update("revoked_at", time.Now()).Error
+}
+
+func (r *TokenRepository) RevokeAccessToken(jti string, userID int, expiresAt time.Time) error {
+	return revokeAccessToken(r.db, jti, userID, expiresAt)
+}
+
+func revokeAccessToken(db *gorm.DB, jti string, userID int, expiresAt time.Time) error {
+	return db.Clauses(clause.OnConflict{DoNothing: true}).Create(&domain.RevokedAccessToken{
+		JTI:       jti,
+		UserID:    userID,
+		ExpiresAt: expiresAt,
+	}).Error
+}
+
+// IsAccessTokenRevoked reports whether the token was denylisted or its owner
+// has been deactivated since it was issued.
+func (r *TokenRepository) IsAccessTokenRevoked(jti string, userID int) (bool, error) {
+	var count int64
+	if err := r.db.Model(&domain.RevokedAccessToken{}).Where("jti = ?", jti).Count(&count).Error; err != nil {
+		return false, err
+	}
+	if count > 0 {
+		return true, nil
+	}
+	var user domain.User
+	err := r.db.Select("id", "status").First(&user, userID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return user.Status == 0, nil
+}
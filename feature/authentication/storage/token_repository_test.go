@@ -0,0 +1,125 @@
+package storage
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRotateRefreshToken(t *testing.T) {
+	current := &domain.RefreshToken{ID: 1, UserID: 1, FamilyID: "family"}
+	insert := regexp.QuoteMeta("INSERT INTO `refresh_tokens`")
+	update := regexp.QuoteMeta("UPDATE `refresh_tokens` SET `replaced_by_id`=?,`revoked_at`=? WHERE id = ? AND revoked_at IS NULL")
+
+	t.Run("successful rotation", func(t *testing.T) {
+		db, mock, err := setupMockDB()
+		assert.NoError(t, err)
+		repo := NewTokenRepository(db)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(insert).WillReturnResult(sqlmock.NewResult(2, 1))
+		mock.ExpectExec(update).
+			WithArgs(2, sqlmock.AnyArg(), 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		next := &domain.RefreshToken{UserID: 1, FamilyID: "family", TokenHash: "next"}
+		err = repo.RotateRefreshToken(current, next)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, next.ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("token consumed concurrently", func(t *testing.T) {
+		db, mock, err := setupMockDB()
+		assert.NoError(t, err)
+		repo := NewTokenRepository(db)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(insert).WillReturnResult(sqlmock.NewResult(3, 1))
+		mock.ExpectExec(update).
+			WithArgs(3, sqlmock.AnyArg(), 1).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		err = repo.RotateRefreshToken(current, &domain.RefreshToken{UserID: 1, FamilyID: "family", TokenHash: "other"})
+		assert.ErrorIs(t, err, ErrRefreshTokenAlreadyUsed)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRevokeTokenFamily(t *testing.T) {
+	db, mock, err := setupMockDB()
+	assert.NoError(t, err)
+	repo := NewTokenRepository(db)
+
+	accessExpiresAt := time.Now().Add(10 * time.Minute)
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `refresh_tokens` WHERE family_id = ? AND access_expires_at > ?")).
+		WithArgs("family", sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "family_id", "access_jti", "access_expires_at"}).
+			AddRow(1, 7, "family", "jti-1", accessExpiresAt))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `revoked_access_tokens`")).
+		WithArgs("jti-1", 7, accessExpiresAt, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `refresh_tokens` SET `revoked_at`=? WHERE family_id = ? AND revoked_at IS NULL")).
+		WithArgs(sqlmock.AnyArg(), "family").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err = repo.RevokeTokenFamily("family")
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIsAccessTokenRevoked(t *testing.T) {
+	denylist := regexp.QuoteMeta("SELECT count(*) FROM `revoked_access_tokens` WHERE jti = ?")
+	userStatus := regexp.QuoteMeta("SELECT `id`,`status` FROM `users` WHERE `users`.`id` = ? AND `users`.`deleted_at` IS NULL ORDER BY `users`.`id` LIMIT ?")
+
+	t.Run("denylisted token", func(t *testing.T) {
+		db, mock, err := setupMockDB()
+		assert.NoError(t, err)
+		repo := NewTokenRepository(db)
+
+		mock.ExpectQuery(denylist).WithArgs("jti").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+		revoked, err := repo.IsAccessTokenRevoked("jti", 1)
+		assert.NoError(t, err)
+		assert.True(t, revoked)
+	})
+
+	t.Run("deactivated user", func(t *testing.T) {
+		db, mock, err := setupMockDB()
+		assert.NoError(t, err)
+		repo := NewTokenRepository(db)
+
+		mock.ExpectQuery(denylist).WithArgs("jti").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectQuery(userStatus).WithArgs(1, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow(1, 0))
+
+		revoked, err := repo.IsAccessTokenRevoked("jti", 1)
+		assert.NoError(t, err)
+		assert.True(t, revoked)
+	})
+
+	t.Run("valid token", func(t *testing.T) {
+		db, mock, err := setupMockDB()
+		assert.NoError(t, err)
+		repo := NewTokenRepository(db)
+
+		mock.ExpectQuery(denylist).WithArgs("jti").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectQuery(userStatus).WithArgs(1, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow(1, 1))
+
+		revoked, err := repo.IsAccessTokenRevoked("jti", 1)
+		assert.NoError(t, err)
+		assert.False(t, revoked)
+	})
+}
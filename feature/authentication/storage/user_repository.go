@@ -1,14 +1,22 @@
 package storage
 
 import (
+	"errors"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/domain"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/dto"
 	"gorm.io/gorm"
 )
 
+var ErrUserNotFound = apperror.New(apperror.NotFound, "user not found")
+
 type AuthenticationStore interface {
-	GetUserByEmail(email string, password string) (*domain.User, string)
-	RegisterUser(request *dto.RegisterUserRequest) (*dto.RegisterUserResponse, error)
+	GetUserByEmail(email string) (*domain.User, error)
+	GetUserByID(id int) (*domain.User, error)
+	RegisterUser(request *dto.RegisterUserRequest, passwordHash string, roleID int) (*domain.User, error)
+	UpdatePassword(userID int, passwordHash string) error
+	CountUnhashedPasswords(hashPrefixes []string) (int64, error)
 }
 
 type AuthenticationRepository struct {
@@ -18,35 +26,64 @@ type AuthenticationRepository struct {
 func NewAuthenticationRepository(db *gorm.DB) *AuthenticationRepository {
 	return &AuthenticationRepository{db: db}
 }
-func (r *AuthenticationRepository) GetUserByEmail(email string, password string) (*domain.User, string) {
+func (r *AuthenticationRepository) GetUserByEmail(email string) (*domain.User, error) {
 	var user domain.User
 	err := r.db.Where("email = ?", email).First(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrUserNotFound
+	}
 	if err != nil {
-		return nil, err.Error()
+		return nil, err
 	}
-	if user.Status == 0 {
-		return nil, "User is inactive"
+	return &user, nil
+}
+
+func (r *AuthenticationRepository) GetUserByID(id int) (*domain.User, error) {
+	var user domain.User
+	err := r.db.First(&user, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrUserNotFound
 	}
-	if user.Password != password {
-		return nil, "Password is incorrect"
+	if err != nil {
+		return nil, err
 	}
-	return &user, ""
+	return &user, nil
 }
 
-func (r *AuthenticationRepository) RegisterUser(request *dto.RegisterUserRequest) (*dto.RegisterUserResponse, error) {
+// RegisterUser creates an active user with the role roleID.
+func (r *AuthenticationRepository) RegisterUser(request *dto.RegisterUserRequest, passwordHash string, roleID int) (*domain.User, error) {
+	residentCountryID := request.ResidentCountryID
+	if residentCountryID == 0 {
+		residentCountryID = request.CountryID
+	}
 	user := domain.User{
-		Email:    request.Email,
-		Name:     request.Name,
-		Password: request.Password,
-		Status:   1,
+		RoleID:            roleID,
+		Email:             request.Email,
+		Name:              request.Name,
+		Password:          passwordHash,
+		CountryID:         request.CountryID,
+		ResidentCountryID: residentCountryID,
+		Status:            1,
 	}
 
 	if err := r.db.Create(&user).Error; err != nil {
 		return nil, err
 	}
+	return &user, nil
+}
+
+// UpdatePassword replaces the stored password hash of a user.
+func (r *AuthenticationRepository) UpdatePassword(userID int, passwordHash string) error {
+	return r.db.Model(&domain.User{}).Where("id = ?", userID).Update("password", passwordHash).Error
+}
 
-	response := &dto.RegisterUserResponse{
-		Message: "User registered successfully",
+// CountUnhashedPasswords counts users whose stored password starts with none of the given hash prefixes.
+func (r *AuthenticationRepository) CountUnhashedPasswords(hashPrefixes []string) (int64, error) {
+	var count int64
+	query := r.db.Model(&domain.User{})
+	for _, prefix := range hashPrefixes {
+		query = query.Where("password NOT LIKE ?", prefix+"%")
 	}
-	return response, nil
+	err := query.Count(&count).Error
+	return count, err
 }
@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/database/databasetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterUserSQLite(t *testing.T) {
+	db := databasetest.Open(t)
+	databasetest.Exec(t, db,
+		"INSERT INTO roles (id, code, name) VALUES (6, 'applicant', 'Applicant')",
+		"INSERT INTO countries (id, name, status) VALUES (1, 'Vietnam', 1), (2, 'France', 1)",
+	)
+	repo := NewAuthenticationRepository(db)
+
+	t.Run("resident country defaults to the country", func(t *testing.T) {
+		user, err := repo.RegisterUser(&dto.RegisterUserRequest{Email: "jane@example.com", Name: "Jane", CountryID: 1}, "$2a$10$hash", 6)
+		require.NoError(t, err)
+
+		stored, err := repo.GetUserByID(user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 6, stored.RoleID)
+		assert.Equal(t, 1, stored.CountryID)
+		assert.Equal(t, 1, stored.ResidentCountryID)
+		assert.Equal(t, 1, stored.Status)
+	})
+
+	t.Run("resident country", func(t *testing.T) {
+		user, err := repo.RegisterUser(&dto.RegisterUserRequest{Email: "john@example.com", Name: "John", CountryID: 1, ResidentCountryID: 2}, "$2a$10$hash", 6)
+		require.NoError(t, err)
+
+		stored, err := repo.GetUserByID(user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, stored.ResidentCountryID)
+	})
+
+	t.Run("unknown role", func(t *testing.T) {
+		_, err := repo.RegisterUser(&dto.RegisterUserRequest{Email: "jim@example.com", Name: "Jim", CountryID: 1}, "$2a$10$hash", 99)
+		assert.Error(t, err)
+	})
+}
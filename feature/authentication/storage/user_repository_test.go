@@ -2,6 +2,7 @@ package storage
 
 import (
 	"errors"
+	"regexp"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
@@ -18,7 +19,8 @@ func setupMockDB() (*gorm.DB, sqlmock.Sqlmock, error) {
 	}
 
 	gormDB, err := gorm.Open(mysql.New(mysql.Config{
-		Conn: db,
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
 	}), &gorm.Config{})
 	if err != nil {
 		return nil, nil, err
@@ -33,53 +35,65 @@ func TestGetUserByEmail(t *testing.T) {
 	defer db.DB()
 
 	repo := NewAuthenticationRepository(db)
+	query := regexp.QuoteMeta("SELECT * FROM `users` WHERE email = ? AND `users`.`deleted_at` IS NULL ORDER BY `users`.`id` LIMIT ?")
 
 	t.Run("successful retrieval", func(t *testing.T) {
 		rows := sqlmock.NewRows([]string{"id", "email", "password", "status"}).
-			AddRow(1, "test@example.com", "password123", 1)
-		mock.ExpectQuery("^SELECT (.+) FROM `users` WHERE email = ? ORDER BY `users`.`id` LIMIT 1$").
-			WithArgs("test@example.com").
+			AddRow(1, "test@example.com", "$2a$10$hash", 1)
+		mock.ExpectQuery(query).
+			WithArgs("test@example.com", 1).
 			WillReturnRows(rows)
 
-		user, errMsg := repo.GetUserByEmail("test@example.com", "password123")
-		assert.Empty(t, errMsg)
+		user, err := repo.GetUserByEmail("test@example.com")
+		assert.NoError(t, err)
 		assert.NotNil(t, user)
 		assert.Equal(t, "test@example.com", user.Email)
 	})
 
 	t.Run("user not found", func(t *testing.T) {
-		mock.ExpectQuery("^SELECT (.+) FROM `users` WHERE email = ? ORDER BY `users`.`id` LIMIT 1$").
-			WithArgs("unknown@example.com").
+		mock.ExpectQuery(query).
+			WithArgs("unknown@example.com", 1).
 			WillReturnError(gorm.ErrRecordNotFound)
 
-		user, errMsg := repo.GetUserByEmail("unknown@example.com", "password123")
-		assert.Equal(t, gorm.ErrRecordNotFound.Error(), errMsg)
+		user, err := repo.GetUserByEmail("unknown@example.com")
+		assert.ErrorIs(t, err, ErrUserNotFound)
 		assert.Nil(t, user)
 	})
+}
 
-	t.Run("inactive user", func(t *testing.T) {
-		rows := sqlmock.NewRows([]string{"id", "email", "password", "status"}).
-			AddRow(1, "inactive@example.com", "password123", 0)
-		mock.ExpectQuery("^SELECT (.+) FROM `users` WHERE email = ? ORDER BY `users`.`id` LIMIT 1$").
-			WithArgs("inactive@example.com").
-			WillReturnRows(rows)
+func TestUpdatePassword(t *testing.T) {
+	db, mock, err := setupMockDB()
+	assert.NoError(t, err)
+	defer db.DB()
 
-		user, errMsg := repo.GetUserByEmail("inactive@example.com", "password123")
-		assert.Equal(t, "User is inactive", errMsg)
-		assert.Nil(t, user)
-	})
+	repo := NewAuthenticationRepository(db)
 
-	t.Run("incorrect password", func(t *testing.T) {
-		rows := sqlmock.NewRows([]string{"id", "email", "password", "status"}).
-			AddRow(1, "test@example.com", "password123", 1)
-		mock.ExpectQuery("^SELECT (.+) FROM `users` WHERE email = ? ORDER BY `users`.`id` LIMIT 1$").
-			WithArgs("test@example.com").
-			WillReturnRows(rows)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `users` SET `password`=?,`updated_at`=? WHERE id = ?")).
+		WithArgs("$2a$10$hash", sqlmock.AnyArg(), 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
 
-		user, errMsg := repo.GetUserByEmail("test@example.com", "wrongpassword")
-		assert.Equal(t, "Password is incorrect", errMsg)
-		assert.Nil(t, user)
-	})
+	err = repo.UpdatePassword(1, "$2a$10$hash")
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCountUnhashedPasswords(t *testing.T) {
+	db, mock, err := setupMockDB()
+	assert.NoError(t, err)
+	defer db.DB()
+
+	repo := NewAuthenticationRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `users` WHERE password NOT LIKE ? AND password NOT LIKE ?")).
+		WithArgs("$2a$%", "$argon2id$%").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	count, err := repo.CountUnhashedPasswords([]string{"$2a$", "$argon2id$"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestRegisterUser(t *testing.T) {
@@ -101,9 +115,10 @@ func TestRegisterUser(t *testing.T) {
 			Password: "newpassword",
 		}
 
-		response, err := repo.RegisterUser(request)
+		user, err := repo.RegisterUser(request, "$2a$10$hash", 6)
 		assert.NoError(t, err)
-		assert.Equal(t, "User registered successfully", response.Message)
+		assert.Equal(t, 1, user.ID)
+		assert.Equal(t, "$2a$10$hash", user.Password)
 	})
 
 	t.Run("registration error", func(t *testing.T) {
@@ -118,7 +133,7 @@ func TestRegisterUser(t *testing.T) {
 			Password: "newpassword",
 		}
 
-		_, err := repo.RegisterUser(request)
+		_, err := repo.RegisterUser(request, "$2a$10$hash", 6)
 		assert.Error(t, err)
 	})
 }
@@ -0,0 +1,71 @@
+package storage
+
+import (
+	"errors"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/domain"
+	"gorm.io/gorm"
+)
+
+var ErrVerificationTokenUsed = apperror.New(apperror.Gone, "verification token already used")
+
+type VerificationStore interface {
+	CreateVerificationToken(token *domain.EmailVerificationToken) error
+	LatestVerificationToken(userID int) (*domain.EmailVerificationToken, error)
+	CountVerificationTokensSince(userID int, since time.Time) (int64, error)
+	ConsumeVerificationToken(jti string, userID int) error
+}
+
+type VerificationRepository struct {
+	db *gorm.DB
+}
+
+func NewVerificationRepository(db *gorm.DB) *VerificationRepository {
+	return &VerificationRepository{db: db}
+}
+
+func (r *VerificationRepository) CreateVerificationToken(token *domain.EmailVerificationToken) error {
+	return r.db.Create(token).Error
+}
+
+// LatestVerificationToken returns the most recently issued token of the user,
+// or nil when none was issued yet.
+func (r *VerificationRepository) LatestVerificationToken(userID int) (*domain.EmailVerificationToken, error) {
+	var token domain.EmailVerificationToken
+	err := r.db.Where("user_id = ?", userID).Order("created_at DESC").First(&token).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *VerificationRepository) CountVerificationTokensSince(userID int, since time.Time) (int64, error) {
+	var count int64
+	err := r.db.Model(&domain.EmailVerificationToken{}).
+		Where("user_id = ? AND created_at >= ?", userID, since).
+		Count(&count).Error
+	return count, err
+}
+
+// ConsumeVerificationToken marks the token as used and the user's email as
+// verified in one transaction. It returns ErrVerificationTokenUsed when the
+// token is unknown or was used already.
+func (r *VerificationRepository) ConsumeVerificationToken(jti string, userID int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.EmailVerificationToken{}).
+			Where("jti = ? AND user_id = ? AND used_at IS NULL", jti, userID).
+			Update("used_at", time.Now())
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrVerificationTokenUsed
+		}
+		return tx.Model(&domain.User{}).Where("id = ?", userID).Update("verification_status", 1).Error
+	})
+}
@@ -0,0 +1,58 @@
+package storage
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConsumeVerificationToken(t *testing.T) {
+	markUsed := regexp.QuoteMeta("UPDATE `email_verification_tokens` SET `used_at`=? WHERE jti = ? AND user_id = ? AND used_at IS NULL")
+
+	t.Run("first use", func(t *testing.T) {
+		db, mock, err := setupMockDB()
+		assert.NoError(t, err)
+		repo := NewVerificationRepository(db)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(markUsed).WithArgs(sqlmock.AnyArg(), "jti", 7).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `users` SET `verification_status`=?,`updated_at`=? WHERE id = ?")).
+			WithArgs(1, sqlmock.AnyArg(), 7).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		assert.NoError(t, repo.ConsumeVerificationToken("jti", 7))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("already used", func(t *testing.T) {
+		db, mock, err := setupMockDB()
+		assert.NoError(t, err)
+		repo := NewVerificationRepository(db)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(markUsed).WithArgs(sqlmock.AnyArg(), "jti", 7).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		assert.ErrorIs(t, repo.ConsumeVerificationToken("jti", 7), ErrVerificationTokenUsed)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestLatestVerificationToken(t *testing.T) {
+	db, mock, err := setupMockDB()
+	assert.NoError(t, err)
+	repo := NewVerificationRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `email_verification_tokens` WHERE user_id = ? ORDER BY created_at DESC,`email_verification_tokens`.`jti` LIMIT ?")).
+		WithArgs(7, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"jti", "user_id"}))
+
+	token, err := repo.LatestVerificationToken(7)
+	assert.NoError(t, err)
+	assert.Nil(t, token)
+}
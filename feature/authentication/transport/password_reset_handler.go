@@ -0,0 +1,67 @@
+package transport
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/usecase"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/validation"
+	"github.com/gin-gonic/gin"
+)
+
+type PasswordResetHandler struct {
+	usecase usecase.PasswordResetUsecaseInterface
+}
+
+func NewPasswordResetHandler(usecase usecase.PasswordResetUsecaseInterface) *PasswordResetHandler {
+	return &PasswordResetHandler{usecase: usecase}
+}
+
+// ForgotPassword godoc
+// @Summary Forgot password
+// @Description Email a password reset token. The response does not tell whether the email is registered.
+// @Accept json
+// @Produce json
+// @Tags authentication
+// @Param forgotPasswordRequest body dto.ForgotPasswordRequest true "Forgot Password Request"
+// @Success 202 {object} dto.PasswordResetResponse{}
+// @Router /api/v1/auth/forgot-password [post]
+func (h *PasswordResetHandler) ForgotPassword(c *gin.Context) {
+	var req dto.ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperror.Abort(c, validation.Error(err))
+		return
+	}
+
+	// failures are logged only, so that the response never depends on the account
+	if err := h.usecase.ForgotPassword(req.Email); err != nil {
+		log.Printf("could not send password reset email: %v", err)
+	}
+	c.JSON(http.StatusAccepted, dto.PasswordResetResponse{Message: "If the account exists, a password reset email has been sent"})
+}
+
+// ResetPassword godoc
+// @Summary Reset password
+// @Description Set a new password with a reset token. All sessions of the user are signed out.
+// @Accept json
+// @Produce json
+// @Tags authentication
+// @Param resetPasswordRequest body dto.ResetPasswordRequest true "Reset Password Request"
+// @Success 200 {object} dto.PasswordResetResponse{}
+// @Failure 400 {object} apperror.Response "Invalid input or invalid or expired token"
+// @Router /api/v1/auth/reset-password [post]
+func (h *PasswordResetHandler) ResetPassword(c *gin.Context) {
+	var req dto.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperror.Abort(c, validation.Error(err))
+		return
+	}
+
+	if err := h.usecase.ResetPassword(req.Token, req.Password); err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.PasswordResetResponse{Message: "Password reset successfully"})
+}
@@ -0,0 +1,83 @@
+package transport
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPasswordResetUsecase struct {
+	mock.Mock
+}
+
+func (m *MockPasswordResetUsecase) ForgotPassword(email string) error {
+	args := m.Called(email)
+	return args.Error(0)
+}
+
+func (m *MockPasswordResetUsecase) ResetPassword(token string, password string) error {
+	args := m.Called(token, password)
+	return args.Error(0)
+}
+
+func postJSON(router *gin.Engine, path string, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestPasswordResetHandler_ForgotPassword(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockUsecase := new(MockPasswordResetUsecase)
+	handler := NewPasswordResetHandler(mockUsecase)
+	router := gin.Default()
+	router.Use(apperror.Middleware())
+	router.POST("/api/v1/auth/forgot-password", handler.ForgotPassword)
+
+	mockUsecase.On("ForgotPassword", "jane@example.com").Return(nil)
+	mockUsecase.On("ForgotPassword", "john@example.com").Return(errors.New("smtp down"))
+
+	known := postJSON(router, "/api/v1/auth/forgot-password", `{"email":"jane@example.com"}`)
+	failed := postJSON(router, "/api/v1/auth/forgot-password", `{"email":"john@example.com"}`)
+	assert.Equal(t, http.StatusAccepted, known.Code)
+	assert.Equal(t, http.StatusAccepted, failed.Code)
+	assert.Equal(t, known.Body.String(), failed.Body.String())
+
+	assert.Equal(t, http.StatusBadRequest, postJSON(router, "/api/v1/auth/forgot-password", `{"email":"not-an-email"}`).Code)
+}
+
+func TestPasswordResetHandler_ResetPassword(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockUsecase := new(MockPasswordResetUsecase)
+	handler := NewPasswordResetHandler(mockUsecase)
+	router := gin.Default()
+	router.Use(apperror.Middleware())
+	router.POST("/api/v1/auth/reset-password", handler.ResetPassword)
+
+	mockUsecase.On("ResetPassword", "good", "new-password1").Return(nil)
+	mockUsecase.On("ResetPassword", "bad", "new-password1").Return(usecase.ErrInvalidResetToken)
+
+	w := postJSON(router, "/api/v1/auth/reset-password", `{"token":"good","password":"new-password1","re_password":"new-password1"}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = postJSON(router, "/api/v1/auth/reset-password", `{"token":"bad","password":"new-password1","re_password":"new-password1"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = postJSON(router, "/api/v1/auth/reset-password", `{"token":"good","password":"new-password1","re_password":"other"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = postJSON(router, "/api/v1/auth/reset-password", `{"token":"good","password":"short","re_password":"short"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), `"rule":"password"`)
+	mockUsecase.AssertNumberOfCalls(t, "ResetPassword", 2)
+}
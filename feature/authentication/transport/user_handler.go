@@ -3,8 +3,10 @@ package transport
 import (
 	"net/http"
 
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/dto"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/usecase"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/validation"
 	"github.com/gin-gonic/gin"
 )
 
@@ -27,13 +29,13 @@ func NewAuthenticationHandler(usecase usecase.UserUsecaseInterface) *Authenticat
 func (h *AuthenticationHandler) Login(c *gin.Context) {
 	var req dto.LoginUserRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apperror.Abort(c, validation.Error(err))
 		return
 	}
 
-	resp, msg := h.usecase.Login(req)
-	if msg != "" {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": msg})
+	resp, err := h.usecase.Login(req)
+	if err != nil {
+		apperror.Abort(c, err)
 		return
 	}
 
@@ -47,19 +49,75 @@ func (h *AuthenticationHandler) Login(c *gin.Context) {
 // @Tags authentication
 // @Param registerUserRequest body dto.RegisterUserRequest true "Register User Request"
 // @Success 200 {object} dto.RegisterUserResponse{}
+// @Failure 400 {object} apperror.Response "Invalid input, with the rejected fields in details"
 // @Router /api/v1/auth/register [post]
 func (h *AuthenticationHandler) Register(c *gin.Context) {
 	var req dto.RegisterUserRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apperror.Abort(c, validation.Error(err))
 		return
 	}
 
-	resp, msg := h.usecase.RegisterUser(req)
-	if msg != "" {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": msg})
+	resp, err := h.usecase.RegisterUser(req)
+	if err != nil {
+		apperror.Abort(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, resp)
 }
+
+// Refresh godoc
+// @Summary Refresh access token
+// @Description Exchange a refresh token for a new access and refresh token pair
+// @Produce json
+// @Tags authentication
+// @Param refreshTokenRequest body dto.RefreshTokenRequest true "Refresh Token Request"
+// @Success 200 {object} dto.LoginUserTokenResponse{}
+// @Router /api/v1/auth/refresh [post]
+func (h *AuthenticationHandler) Refresh(c *gin.Context) {
+	var req dto.RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperror.Abort(c, validation.Error(err))
+		return
+	}
+
+	resp, err := h.usecase.Refresh(req)
+	if err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// Logout godoc
+// @Summary Logout
+// @Description Revoke the current access token and its refresh token family
+// @Produce json
+// @Tags authentication
+// @Security bearerToken
+// @Param logoutRequest body dto.LogoutRequest false "Logout Request"
+// @Success 200 {object} dto.LogoutResponse{}
+// @Router /api/v1/auth/logout [post]
+func (h *AuthenticationHandler) Logout(c *gin.Context) {
+	var req dto.LogoutRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			apperror.Abort(c, validation.Error(err))
+			return
+		}
+	}
+	userId, exists := c.Get("userId")
+	if !exists {
+		apperror.Abort(c, apperror.ErrUnauthorized)
+		return
+	}
+
+	if err := h.usecase.Logout(userId.(int), c.GetString("jti"), c.GetTime("tokenExpiresAt"), req); err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.LogoutResponse{Message: "Logout success"})
+}
@@ -6,8 +6,11 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/usecase"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -18,20 +21,33 @@ type MockUserUsecase struct {
 	mock.Mock
 }
 
-func (m *MockUserUsecase) Login(req dto.LoginUserRequest) (*dto.LoginUserTokenResponse, string) {
+func (m *MockUserUsecase) Login(req dto.LoginUserRequest) (*dto.LoginUserTokenResponse, error) {
 	args := m.Called(req)
 	if args.Get(0) != nil {
-		return args.Get(0).(*dto.LoginUserTokenResponse), args.String(1)
+		return args.Get(0).(*dto.LoginUserTokenResponse), args.Error(1)
 	}
-	return nil, args.String(1)
+	return nil, args.Error(1)
 }
 
-func (m *MockUserUsecase) RegisterUser(req dto.RegisterUserRequest) (*dto.RegisterUserResponse, string) {
+func (m *MockUserUsecase) RegisterUser(req dto.RegisterUserRequest) (*dto.RegisterUserResponse, error) {
 	args := m.Called(req)
 	if args.Get(0) != nil {
-		return args.Get(0).(*dto.RegisterUserResponse), args.String(1)
+		return args.Get(0).(*dto.RegisterUserResponse), args.Error(1)
 	}
-	return nil, args.String(1)
+	return nil, args.Error(1)
+}
+
+func (m *MockUserUsecase) Refresh(req dto.RefreshTokenRequest) (*dto.LoginUserTokenResponse, error) {
+	args := m.Called(req)
+	if args.Get(0) != nil {
+		return args.Get(0).(*dto.LoginUserTokenResponse), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserUsecase) Logout(userID int, jti string, accessExpiresAt time.Time, req dto.LogoutRequest) error {
+	args := m.Called(userID, jti, accessExpiresAt, req)
+	return args.Error(0)
 }
 func TestAuthenticationHandler_Login(t *testing.T) {
 	gin.SetMode(gin.TestMode)
@@ -39,6 +55,7 @@ func TestAuthenticationHandler_Login(t *testing.T) {
 	handler := NewAuthenticationHandler(mockUsecase)
 
 	router := gin.Default()
+	router.Use(apperror.Middleware())
 	router.POST("/api/v1/auth/login", handler.Login)
 
 	t.Run("successful login", func(t *testing.T) {
@@ -49,7 +66,7 @@ func TestAuthenticationHandler_Login(t *testing.T) {
 		loginResp := &dto.LoginUserTokenResponse{
 			Token: "mock-token",
 		}
-		mockUsecase.On("Login", loginReq).Return(loginResp, "")
+		mockUsecase.On("Login", loginReq).Return(loginResp, nil)
 
 		w := httptest.NewRecorder()
 		body, _ := json.Marshal(loginReq)
@@ -73,10 +90,11 @@ func TestAuthenticationHandler_Login(t *testing.T) {
 		router.ServeHTTP(w, req)
 
 		assert.Equal(t, http.StatusBadRequest, w.Code)
-		var response map[string]string
+		var response apperror.Response
 		err := json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err)
-		assert.Equal(t, "invalid character 'i' looking for beginning of value", response["error"])
+		assert.Equal(t, apperror.Validation, response.Code)
+		assert.Equal(t, "invalid character 'i' looking for beginning of value", response.Message)
 	})
 
 	t.Run("login with incorrect credentials", func(t *testing.T) {
@@ -84,7 +102,7 @@ func TestAuthenticationHandler_Login(t *testing.T) {
 			Email:    "test@example.com",
 			Password: "wrong-password",
 		}
-		mockUsecase.On("Login", loginReq).Return(nil, "invalid credentials")
+		mockUsecase.On("Login", loginReq).Return(nil, usecase.ErrInvalidCredentials)
 
 		w := httptest.NewRecorder()
 		body, _ := json.Marshal(loginReq)
@@ -94,10 +112,11 @@ func TestAuthenticationHandler_Login(t *testing.T) {
 		router.ServeHTTP(w, req)
 
 		assert.Equal(t, http.StatusUnauthorized, w.Code)
-		var response map[string]string
+		var response apperror.Response
 		err := json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err)
-		assert.Equal(t, "invalid credentials", response["error"])
+		assert.Equal(t, apperror.Unauthorized, response.Code)
+		assert.Equal(t, usecase.ErrInvalidCredentials.Message, response.Message)
 	})
 }
 
@@ -107,18 +126,22 @@ func TestAuthenticationHandler_Register(t *testing.T) {
 	handler := NewAuthenticationHandler(mockUsecase)
 
 	router := gin.Default()
+	router.Use(apperror.Middleware())
 	router.POST("/api/v1/auth/register", handler.Register)
 
 	t.Run("successful registration", func(t *testing.T) {
 		registerReq := dto.RegisterUserRequest{
-			Email:    "test@example.com",
-			Password: "password",
+			Email:      "test@example.com",
+			Name:       "Test",
+			Password:   "password1",
+			RePassword: "password1",
+			CountryID:  1,
 		}
 		registerResp := &dto.RegisterUserResponse{
 
 			Message: registerReq.Email,
 		}
-		mockUsecase.On("RegisterUser", registerReq).Return(registerResp, "")
+		mockUsecase.On("RegisterUser", registerReq).Return(registerResp, nil)
 
 		w := httptest.NewRecorder()
 		body, _ := json.Marshal(registerReq)
@@ -142,18 +165,46 @@ func TestAuthenticationHandler_Register(t *testing.T) {
 		router.ServeHTTP(w, req)
 
 		assert.Equal(t, http.StatusBadRequest, w.Code)
-		var response map[string]string
+		var response apperror.Response
 		err := json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err)
-		assert.Equal(t, "invalid character 'i' looking for beginning of value", response["error"])
+		assert.Equal(t, apperror.Validation, response.Code)
+		assert.Equal(t, "invalid character 'i' looking for beginning of value", response.Message)
+	})
+
+	t.Run("register with mismatched passwords", func(t *testing.T) {
+		registerReq := dto.RegisterUserRequest{
+			Email:      "test@example.com",
+			Name:       "Test",
+			Password:   "password1",
+			RePassword: "password2",
+			CountryID:  1,
+		}
+
+		w := httptest.NewRecorder()
+		body, _ := json.Marshal(registerReq)
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/auth/register", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, `{
+			"code": "VALIDATION",
+			"message": "invalid input",
+			"details": {"fields": [{"field": "re_password", "rule": "eqfield", "message": "must match password"}]}
+		}`, w.Body.String())
 	})
 
 	t.Run("register with existing user", func(t *testing.T) {
 		registerReq := dto.RegisterUserRequest{
-			Email:    "test@example.com",
-			Password: "password",
+			Email:      "existing@example.com",
+			Name:       "Test",
+			Password:   "password1",
+			RePassword: "password1",
+			CountryID:  1,
 		}
-		mockUsecase.On("RegisterUser", registerReq).Return(nil, "user already exists")
+		mockUsecase.On("RegisterUser", registerReq).Return(nil, usecase.ErrUserExists)
 
 		w := httptest.NewRecorder()
 		body, _ := json.Marshal(registerReq)
@@ -162,10 +213,80 @@ func TestAuthenticationHandler_Register(t *testing.T) {
 
 		router.ServeHTTP(w, req)
 
-		assert.Equal(t, http.StatusUnauthorized, w.Code)
-		var response map[string]string
+		assert.Equal(t, http.StatusConflict, w.Code)
+		var response apperror.Response
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, apperror.Conflict, response.Code)
+	})
+}
+
+func TestAuthenticationHandler_Refresh(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockUsecase := new(MockUserUsecase)
+	handler := NewAuthenticationHandler(mockUsecase)
+
+	router := gin.Default()
+	router.Use(apperror.Middleware())
+	router.POST("/api/v1/auth/refresh", handler.Refresh)
+
+	t.Run("successful refresh", func(t *testing.T) {
+		refreshReq := dto.RefreshTokenRequest{RefreshToken: "refresh"}
+		mockUsecase.On("Refresh", refreshReq).Return(&dto.LoginUserTokenResponse{Token: "access", RefreshToken: "next"}, nil)
+
+		w := httptest.NewRecorder()
+		body, _ := json.Marshal(refreshReq)
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/auth/refresh", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response dto.LoginUserTokenResponse
 		err := json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err)
-		assert.Equal(t, "user already exists", response["error"])
+		assert.Equal(t, "next", response.RefreshToken)
+	})
+
+	t.Run("reused refresh token", func(t *testing.T) {
+		refreshReq := dto.RefreshTokenRequest{RefreshToken: "stolen"}
+		mockUsecase.On("Refresh", refreshReq).Return(nil, usecase.ErrRefreshTokenReused)
+
+		w := httptest.NewRecorder()
+		body, _ := json.Marshal(refreshReq)
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/auth/refresh", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
 	})
 }
+
+func TestAuthenticationHandler_Logout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockUsecase := new(MockUserUsecase)
+	handler := NewAuthenticationHandler(mockUsecase)
+
+	expiresAt := time.Unix(1700000000, 0)
+	router := gin.Default()
+	router.Use(apperror.Middleware())
+	router.POST("/api/v1/auth/logout", func(c *gin.Context) {
+		c.Set("userId", 1)
+		c.Set("jti", "jti")
+		c.Set("tokenExpiresAt", expiresAt)
+	}, handler.Logout)
+
+	logoutReq := dto.LogoutRequest{RefreshToken: "refresh"}
+	mockUsecase.On("Logout", 1, "jti", expiresAt, logoutReq).Return(nil)
+
+	w := httptest.NewRecorder()
+	body, _ := json.Marshal(logoutReq)
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/auth/logout", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockUsecase.AssertExpectations(t)
+}
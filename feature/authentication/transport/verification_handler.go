@@ -0,0 +1,75 @@
+package transport
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/usecase"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/validation"
+	"github.com/gin-gonic/gin"
+)
+
+type VerificationHandler struct {
+	usecase usecase.VerificationUsecaseInterface
+}
+
+func NewVerificationHandler(usecase usecase.VerificationUsecaseInterface) *VerificationHandler {
+	return &VerificationHandler{usecase: usecase}
+}
+
+// VerifyEmail godoc
+// @Summary Verify email
+// @Description Verify the email address of a user with the single-use token sent by email
+// @Produce json
+// @Tags authentication
+// @Param token query string true "Verification token"
+// @Success 200 {object} dto.VerificationResponse{}
+// @Failure 400 {object} apperror.Response "Invalid or expired token"
+// @Failure 410 {object} apperror.Response "Token already used"
+// @Router /api/v1/auth/verify-email [get]
+func (h *VerificationHandler) VerifyEmail(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		apperror.Abort(c, apperror.New(apperror.Validation, "Missing token"))
+		return
+	}
+
+	if err := h.usecase.VerifyEmail(token); err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.VerificationResponse{Message: "Email verified successfully"})
+}
+
+// ResendVerification godoc
+// @Summary Resend verification email
+// @Description Send a new verification link. The response does not tell whether the email is registered.
+// @Accept json
+// @Produce json
+// @Tags authentication
+// @Param resendVerificationRequest body dto.ResendVerificationRequest true "Resend Verification Request"
+// @Success 202 {object} dto.VerificationResponse{}
+// @Failure 429 {object} apperror.Response "Too many verification emails"
+// @Router /api/v1/auth/resend-verification [post]
+func (h *VerificationHandler) ResendVerification(c *gin.Context) {
+	var req dto.ResendVerificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperror.Abort(c, validation.Error(err))
+		return
+	}
+
+	err := h.usecase.ResendVerificationEmail(req.Email)
+	var throttled *usecase.ThrottledError
+	if errors.As(err, &throttled) {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+	}
+	if err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+	c.JSON(http.StatusAccepted, dto.VerificationResponse{Message: "If the account exists and is not verified, a new email has been sent"})
+}
@@ -0,0 +1,85 @@
+package transport
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/storage"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockVerificationUsecase struct {
+	mock.Mock
+}
+
+func (m *MockVerificationUsecase) SendVerificationEmail(userID int) error {
+	args := m.Called(userID)
+	return args.Error(0)
+}
+
+func (m *MockVerificationUsecase) VerifyEmail(token string) error {
+	args := m.Called(token)
+	return args.Error(0)
+}
+
+func (m *MockVerificationUsecase) ResendVerificationEmail(email string) error {
+	args := m.Called(email)
+	return args.Error(0)
+}
+
+func TestVerificationHandler_VerifyEmail(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockUsecase := new(MockVerificationUsecase)
+	handler := NewVerificationHandler(mockUsecase)
+	router := gin.Default()
+	router.Use(apperror.Middleware())
+	router.GET("/api/v1/auth/verify-email", handler.VerifyEmail)
+
+	mockUsecase.On("VerifyEmail", "good").Return(nil)
+	mockUsecase.On("VerifyEmail", "bad").Return(usecase.ErrInvalidVerificationToken)
+	mockUsecase.On("VerifyEmail", "used").Return(storage.ErrVerificationTokenUsed)
+
+	for query, code := range map[string]int{
+		"?token=good": http.StatusOK,
+		"?token=bad":  http.StatusBadRequest,
+		"?token=used": http.StatusGone,
+		"":            http.StatusBadRequest,
+	} {
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/auth/verify-email"+query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, code, w.Code, query)
+	}
+}
+
+func TestVerificationHandler_ResendVerification(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockUsecase := new(MockVerificationUsecase)
+	handler := NewVerificationHandler(mockUsecase)
+	router := gin.Default()
+	router.Use(apperror.Middleware())
+	router.POST("/api/v1/auth/resend-verification", handler.ResendVerification)
+
+	mockUsecase.On("ResendVerificationEmail", "jane@example.com").Return(nil)
+	mockUsecase.On("ResendVerificationEmail", "john@example.com").Return(&usecase.ThrottledError{RetryAfter: 1500 * time.Millisecond})
+
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/auth/resend-verification", strings.NewReader(`{"email":"jane@example.com"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusAccepted, w.Code)
+
+	req, _ = http.NewRequest(http.MethodPost, "/api/v1/auth/resend-verification", strings.NewReader(`{"email":"john@example.com"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "2", w.Header().Get("Retry-After"))
+}
@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/hasher"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/storage"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/mailer"
+)
+
+const PasswordResetTokenTTL = time.Hour
+
+var ErrInvalidResetToken = apperror.New(apperror.Validation, "invalid or expired password reset token")
+
+type PasswordResetUsecaseInterface interface {
+	ForgotPassword(email string) error
+	ResetPassword(token string, password string) error
+}
+
+type PasswordResetUsecase struct {
+	repo     storage.AuthenticationStore
+	resets   storage.PasswordResetStore
+	hasher   hasher.PasswordHasher
+	mailer   mailer.Mailer
+	resetURL string
+	// background runs the work that must not delay the response.
+	background func(func())
+}
+
+// NewPasswordResetUsecase creates a PasswordResetUsecase. resetURL is the page
+// of the client application that submits the new password; the token is
+// appended as a query parameter. When empty, the email only holds the token.
+func NewPasswordResetUsecase(repo storage.AuthenticationStore, resets storage.PasswordResetStore, hasher hasher.PasswordHasher, mailer mailer.Mailer, resetURL string) *PasswordResetUsecase {
+	return &PasswordResetUsecase{
+		repo:       repo,
+		resets:     resets,
+		hasher:     hasher,
+		mailer:     mailer,
+		resetURL:   resetURL,
+		background: func(work func()) { go work() },
+	}
+}
+
+// ForgotPassword emails a reset token to the account registered with email.
+// Unknown emails are ignored, and the token is issued and sent in the
+// background, so that callers cannot tell which accounts exist, not even from
+// the response time.
+func (u *PasswordResetUsecase) ForgotPassword(email string) error {
+	user, _ := u.repo.GetUserByEmail(email)
+	if user == nil {
+		return nil
+	}
+	u.background(func() {
+		if err := u.sendResetToken(user); err != nil {
+			log.Printf("send password reset token to user %d: %v", user.ID, err)
+		}
+	})
+	return nil
+}
+
+func (u *PasswordResetUsecase) sendResetToken(user *domain.User) error {
+	token, err := newOpaqueToken()
+	if err != nil {
+		return err
+	}
+	err = u.resets.CreatePasswordResetToken(&domain.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: hashToken(token),
+		ExpiresAt: time.Now().Add(PasswordResetTokenTTL),
+	})
+	if err != nil {
+		return err
+	}
+	return u.mailer.Send(mailer.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body:    u.resetBody(user, token),
+	})
+}
+
+// ResetPassword sets a new password for the owner of token and signs the user
+// out of every session, in the same transaction.
+func (u *PasswordResetUsecase) ResetPassword(token string, password string) error {
+	passwordHash, err := u.hasher.Hash(password)
+	if err != nil {
+		return err
+	}
+	_, err = u.resets.ResetPassword(hashToken(token), passwordHash)
+	if errors.Is(err, storage.ErrPasswordResetTokenInvalid) {
+		return ErrInvalidResetToken
+	}
+	return err
+}
+
+func (u *PasswordResetUsecase) resetBody(user *domain.User, token string) string {
+	instructions := "Use the following token to choose a new password:\n\n" + token
+	if u.resetURL != "" {
+		instructions = "Open the link below to choose a new password:\n\n" + u.resetURL + "?token=" + url.QueryEscape(token)
+	}
+	return fmt.Sprintf("Hello %s,\n\nWe received a request to reset your password. %s\n\nThe token expires in %d minutes and can be used once. If you did not ask for a reset, you can ignore this email.\n",
+		user.Name, instructions, int(PasswordResetTokenTTL/time.Minute))
+}
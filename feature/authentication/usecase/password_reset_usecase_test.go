@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/hasher"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPasswordResetStore struct {
+	mock.Mock
+}
+
+func (m *MockPasswordResetStore) CreatePasswordResetToken(token *domain.PasswordResetToken) error {
+	args := m.Called(token)
+	return args.Error(0)
+}
+
+func (m *MockPasswordResetStore) ResetPassword(tokenHash string, passwordHash string) (int, error) {
+	args := m.Called(tokenHash, passwordHash)
+	return args.Int(0), args.Error(1)
+}
+
+func TestPasswordResetUsecase_ForgotPassword(t *testing.T) {
+	repo := new(MockAuthenticationStore)
+	resets := new(MockPasswordResetStore)
+	outbox := &captureMailer{}
+	usecase := NewPasswordResetUsecase(repo, resets, hasher.New(hasher.AlgorithmBcrypt), outbox, "http://localhost:3000/reset-password")
+	var pending []func()
+	usecase.background = func(work func()) { pending = append(pending, work) }
+
+	repo.On("GetUserByEmail", "jane@example.com").Return(&domain.User{ID: 7, Email: "jane@example.com", Name: "Jane"}, nil)
+	repo.On("GetUserByEmail", "nobody@example.com").Return(nil, storage.ErrUserNotFound)
+	var issued *domain.PasswordResetToken
+	resets.On("CreatePasswordResetToken", mock.Anything).Run(func(args mock.Arguments) {
+		issued = args.Get(0).(*domain.PasswordResetToken)
+	}).Return(nil).Once()
+
+	assert.NoError(t, usecase.ForgotPassword("nobody@example.com"))
+	assert.Empty(t, pending)
+
+	assert.NoError(t, usecase.ForgotPassword("jane@example.com"))
+	assert.Empty(t, outbox.sent, "the email is sent after the response")
+	assert.Len(t, pending, 1)
+	pending[0]()
+	assert.Len(t, outbox.sent, 1)
+	assert.Equal(t, "jane@example.com", outbox.sent[0].To)
+	token := tokenFromLink(t, outbox.sent[0].Body)
+	assert.True(t, strings.Contains(outbox.sent[0].Body, "http://localhost:3000/reset-password?token="))
+	assert.Equal(t, 7, issued.UserID)
+	assert.Equal(t, hashToken(token), issued.TokenHash, "only the hash of the token is stored")
+	resets.AssertExpectations(t)
+}
+
+func TestPasswordResetUsecase_ResetPassword(t *testing.T) {
+	newHash := mock.MatchedBy(func(hash string) bool { return hasher.IsHashed(hash) })
+
+	t.Run("success", func(t *testing.T) {
+		resets := new(MockPasswordResetStore)
+		usecase := NewPasswordResetUsecase(new(MockAuthenticationStore), resets, hasher.New(hasher.AlgorithmBcrypt), &captureMailer{}, "")
+
+		resets.On("ResetPassword", hashToken("token"), newHash).Return(7, nil)
+
+		assert.NoError(t, usecase.ResetPassword("token", "new-password"))
+		resets.AssertExpectations(t)
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		resets := new(MockPasswordResetStore)
+		usecase := NewPasswordResetUsecase(new(MockAuthenticationStore), resets, hasher.New(hasher.AlgorithmBcrypt), &captureMailer{}, "")
+
+		resets.On("ResetPassword", hashToken("token"), newHash).Return(0, storage.ErrPasswordResetTokenInvalid)
+
+		assert.ErrorIs(t, usecase.ResetPassword("token", "new-password"), ErrInvalidResetToken)
+	})
+
+	t.Run("store failure", func(t *testing.T) {
+		resets := new(MockPasswordResetStore)
+		usecase := NewPasswordResetUsecase(new(MockAuthenticationStore), resets, hasher.New(hasher.AlgorithmBcrypt), &captureMailer{}, "")
+
+		resets.On("ResetPassword", hashToken("token"), newHash).Return(0, errors.New("db down"))
+
+		err := usecase.ResetPassword("token", "new-password")
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, ErrInvalidResetToken)
+	})
+}
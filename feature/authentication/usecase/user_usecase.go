@@ -1,61 +1,249 @@
 package usecase
 
 import (
+	"errors"
+	"log"
 	"time"
 
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/domain"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/hasher"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/storage"
-	"github.com/golang-jwt/jwt/v4"
+	roleDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/role/domain"
+)
+
+var (
+	ErrInvalidCredentials  = apperror.New(apperror.Unauthorized, "Invalid email or password")
+	ErrUserInactive        = apperror.New(apperror.Forbidden, "User is inactive")
+	ErrUserExists          = apperror.New(apperror.Conflict, "User already exists")
+	ErrInvalidRefreshToken = apperror.New(apperror.Unauthorized, "Invalid refresh token")
+	ErrRefreshTokenExpired = apperror.New(apperror.Unauthorized, "Refresh token expired")
+	ErrRefreshTokenReused  = apperror.New(apperror.Unauthorized, "Refresh token reuse detected")
 )
 
 type UserUsecaseInterface interface {
-	Login(req dto.LoginUserRequest) (*dto.LoginUserTokenResponse, string)
-	RegisterUser(req dto.RegisterUserRequest) (*dto.RegisterUserResponse, string)
+	Login(req dto.LoginUserRequest) (*dto.LoginUserTokenResponse, error)
+	RegisterUser(req dto.RegisterUserRequest) (*dto.RegisterUserResponse, error)
+	Refresh(req dto.RefreshTokenRequest) (*dto.LoginUserTokenResponse, error)
+	Logout(userID int, jti string, accessExpiresAt time.Time, req dto.LogoutRequest) error
+}
+
+// RoleResolver finds the role given to new users by its code, and returns
+// the permissions of a role, those it inherits included. Permissions are
+// embedded in access tokens for clients to adapt their UI; the API still
+// checks permissions on every request.
+type RoleResolver interface {
+	RoleID(code string) (int, error)
+	Permissions(roleID int) ([]string, error)
 }
 
 type UserUsecase struct {
-	repo      storage.AuthenticationStore
-	secretKey string
+	repo         storage.AuthenticationStore
+	tokens       storage.TokenStore
+	hasher       hasher.PasswordHasher
+	secretKey    string
+	verification EmailVerificationSender
+	roles        RoleResolver
 }
 
-func NewUserUsecase(repo storage.AuthenticationStore, secretKey string) *UserUsecase {
+func NewUserUsecase(repo storage.AuthenticationStore, tokens storage.TokenStore, hasher hasher.PasswordHasher, secretKey string, verification EmailVerificationSender, roles RoleResolver) *UserUsecase {
 	return &UserUsecase{
-		repo:      repo,
-		secretKey: secretKey,
+		repo:         repo,
+		tokens:       tokens,
+		hasher:       hasher,
+		secretKey:    secretKey,
+		verification: verification,
+		roles:        roles,
 	}
 }
-func (u *UserUsecase) Login(req dto.LoginUserRequest) (*dto.LoginUserTokenResponse, string) {
-	user, msg := u.repo.GetUserByEmail(req.Email, req.Password)
-	if user != nil {
-		claims := jwt.MapClaims{
-			"userId": user.ID,
-			"roleId": user.RoleID,
-			"exp":    time.Now().Add(time.Hour * 72).Unix(),
-		}
+func (u *UserUsecase) Login(req dto.LoginUserRequest) (*dto.LoginUserTokenResponse, error) {
+	user, err := u.repo.GetUserByEmail(req.Email)
+	if errors.Is(err, storage.ErrUserNotFound) {
+		return nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+	if user.Status == 0 {
+		return nil, ErrUserInactive
+	}
+	ok, err := u.hasher.Verify(user.Password, req.Password)
+	if err != nil || !ok {
+		return nil, ErrInvalidCredentials
+	}
+	// upgrade legacy plaintext rows and outdated hashes now that we know the password
+	if u.hasher.NeedsRehash(user.Password) {
+		u.rehashPassword(user.ID, req.Password)
+	}
 
-		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-		tokenString, err := token.SignedString([]byte(u.secretKey))
-		if err != nil {
-			return nil, "Could not generate token"
-		}
-		return &dto.LoginUserTokenResponse{
-			Token: tokenString,
-		}, ""
+	pair, err := u.newTokenPair(user, newTokenID())
+	if err != nil {
+		return nil, err
+	}
+	if err := u.tokens.CreateRefreshToken(pair.record); err != nil {
+		return nil, err
 	}
-	return nil, msg
+	return pair.response(), nil
 }
 
-func (u *UserUsecase) RegisterUser(req dto.RegisterUserRequest) (*dto.RegisterUserResponse, string) {
+func (u *UserUsecase) RegisterUser(req dto.RegisterUserRequest) (*dto.RegisterUserResponse, error) {
 	// check existed user
-	user, _ := u.repo.GetUserByEmail(req.Email, "")
-	if user != nil {
-		return nil, "User existed"
+	_, err := u.repo.GetUserByEmail(req.Email)
+	if err == nil {
+		return nil, ErrUserExists
+	}
+	if !errors.Is(err, storage.ErrUserNotFound) {
+		return nil, err
+	}
+	roleID, err := u.roles.RoleID(roleDomain.RoleCodeApplicant)
+	if err != nil {
+		return nil, err
+	}
+	passwordHash, err := u.hasher.Hash(req.Password)
+	if err != nil {
+		return nil, err
 	}
 	// register user
-	registerUser, err := u.repo.RegisterUser(&req)
+	registered, err := u.repo.RegisterUser(&req, passwordHash, roleID)
+	if err != nil {
+		return nil, err
+	}
+	// the user can ask for a new link, so a delivery failure does not fail the registration
+	if err := u.verification.SendVerificationEmail(registered.ID); err != nil {
+		log.Printf("could not send verification email to user %d: %v", registered.ID, err)
+	}
+
+	return &dto.RegisterUserResponse{Message: "User registered successfully"}, nil
+}
+
+// Refresh exchanges a refresh token for a new access/refresh pair. Presenting
+// a refresh token that was already used revokes the whole token family, since
+// it means the token has leaked.
+func (u *UserUsecase) Refresh(req dto.RefreshTokenRequest) (*dto.LoginUserTokenResponse, error) {
+	current, err := u.tokens.GetRefreshTokenByHash(hashToken(req.RefreshToken))
 	if err != nil {
-		return nil, "Register failed"
+		return nil, ErrInvalidRefreshToken
+	}
+	if current.RevokedAt != nil {
+		u.revokeFamily(current.FamilyID)
+		return nil, ErrRefreshTokenReused
+	}
+	if time.Now().After(current.ExpiresAt) {
+		return nil, ErrRefreshTokenExpired
+	}
+	user, err := u.repo.GetUserByID(current.UserID)
+	if err != nil && !errors.Is(err, storage.ErrUserNotFound) {
+		return nil, err
+	}
+	if user == nil || user.Status == 0 {
+		u.revokeFamily(current.FamilyID)
+		return nil, ErrUserInactive
 	}
 
-	return registerUser, ""
+	pair, err := u.newTokenPair(user, current.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+	err = u.tokens.RotateRefreshToken(current, pair.record)
+	if errors.Is(err, storage.ErrRefreshTokenAlreadyUsed) {
+		u.revokeFamily(current.FamilyID)
+		return nil, ErrRefreshTokenReused
+	}
+	if err != nil {
+		return nil, err
+	}
+	return pair.response(), nil
+}
+
+// Logout denylists the presented access token and, when given, revokes the
+// refresh token family it belongs to.
+func (u *UserUsecase) Logout(userID int, jti string, accessExpiresAt time.Time, req dto.LogoutRequest) error {
+	if err := u.tokens.RevokeAccessToken(jti, userID, accessExpiresAt); err != nil {
+		return err
+	}
+	if req.RefreshToken == "" {
+		return nil
+	}
+	current, err := u.tokens.GetRefreshTokenByHash(hashToken(req.RefreshToken))
+	if err != nil || current.UserID != userID {
+		return ErrInvalidRefreshToken
+	}
+	return u.tokens.RevokeTokenFamily(current.FamilyID)
+}
+
+// rehashPassword stores a fresh hash for the user. A failure only delays the
+// upgrade until the next login, so it is logged rather than failing the login.
+func (u *UserUsecase) rehashPassword(userID int, password string) {
+	passwordHash, err := u.hasher.Hash(password)
+	if err == nil {
+		err = u.repo.UpdatePassword(userID, passwordHash)
+	}
+	if err != nil {
+		log.Printf("could not rehash password of user %d: %v", userID, err)
+	}
+}
+
+func (u *UserUsecase) revokeFamily(familyID string) {
+	if err := u.tokens.RevokeTokenFamily(familyID); err != nil {
+		log.Printf("could not revoke refresh token family %s: %v", familyID, err)
+	}
+}
+
+// tokenPair is an issued access/refresh pair together with the refresh token row to persist.
+type tokenPair struct {
+	accessToken  string
+	refreshToken string
+	record       *domain.RefreshToken
+}
+
+func (p *tokenPair) response() *dto.LoginUserTokenResponse {
+	return &dto.LoginUserTokenResponse{
+		Token:        p.accessToken,
+		RefreshToken: p.refreshToken,
+		ExpiresIn:    int64(AccessTokenTTL / time.Second),
+	}
+}
+
+func (u *UserUsecase) newTokenPair(user *domain.User, familyID string) (*tokenPair, error) {
+	now := time.Now()
+	jti := newTokenID()
+	accessExpiresAt := now.Add(AccessTokenTTL)
+	permissions, err := u.rolePermissions(user.RoleID)
+	if err != nil {
+		return nil, err
+	}
+	accessToken, err := signAccessToken(u.secretKey, user, permissions, jti, now, accessExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	refreshToken, err := newOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+	return &tokenPair{
+		accessToken:  accessToken,
+		refreshToken: refreshToken,
+		record: &domain.RefreshToken{
+			UserID:          user.ID,
+			FamilyID:        familyID,
+			TokenHash:       hashToken(refreshToken),
+			AccessJTI:       jti,
+			AccessExpiresAt: accessExpiresAt,
+			ExpiresAt:       now.Add(RefreshTokenTTL),
+		},
+	}, nil
+}
+
+// rolePermissions returns the permissions to embed in an access token. A
+// user whose role no longer exists gets none.
+func (u *UserUsecase) rolePermissions(roleID int) ([]string, error) {
+	permissions, err := u.roles.Permissions(roleID)
+	if apperror.CodeOf(err) == apperror.NotFound {
+		return []string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return permissions, nil
 }
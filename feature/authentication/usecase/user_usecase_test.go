@@ -1,11 +1,16 @@
 package usecase
 
 import (
+	"errors"
 	"testing"
 	"time"
 
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/domain"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/hasher"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/storage"
+	roleDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/role/domain"
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -16,72 +21,397 @@ type MockAuthenticationStore struct {
 	mock.Mock
 }
 
-func (m *MockAuthenticationStore) GetUserByEmail(email, password string) (*domain.User, string) {
-	args := m.Called(email, password)
+func (m *MockAuthenticationStore) GetUserByEmail(email string) (*domain.User, error) {
+	args := m.Called(email)
 	if args.Get(0) != nil {
-		return args.Get(0).(*domain.User), args.String(1)
+		return args.Get(0).(*domain.User), args.Error(1)
 	}
-	return nil, args.String(1)
+	return nil, args.Error(1)
+}
+
+func (m *MockAuthenticationStore) GetUserByID(id int) (*domain.User, error) {
+	args := m.Called(id)
+	if args.Get(0) != nil {
+		return args.Get(0).(*domain.User), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAuthenticationStore) RegisterUser(req *dto.RegisterUserRequest, passwordHash string, roleID int) (*domain.User, error) {
+	args := m.Called(req, passwordHash, roleID)
+	if args.Get(0) != nil {
+		return args.Get(0).(*domain.User), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAuthenticationStore) UpdatePassword(userID int, passwordHash string) error {
+	args := m.Called(userID, passwordHash)
+	return args.Error(0)
+}
+
+func (m *MockAuthenticationStore) CountUnhashedPasswords(hashPrefixes []string) (int64, error) {
+	args := m.Called(hashPrefixes)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// MockTokenStore is a mock implementation of the TokenStore interface
+type MockTokenStore struct {
+	mock.Mock
+}
+
+type MockVerificationSender struct {
+	mock.Mock
+}
+
+func newMockVerificationSender() *MockVerificationSender {
+	m := new(MockVerificationSender)
+	m.On("SendVerificationEmail", mock.Anything).Return(nil).Maybe()
+	return m
+}
+
+func (m *MockVerificationSender) SendVerificationEmail(userID int) error {
+	args := m.Called(userID)
+	return args.Error(0)
+}
+
+// fakeRoles resolves the permissions of the roles it holds and
+// reports any other role as not found. The applicant role has id 6.
+type fakeRoles map[int][]string
+
+func (f fakeRoles) RoleID(code string) (int, error) {
+	if code != roleDomain.RoleCodeApplicant {
+		return 0, apperror.New(apperror.NotFound, "role not found")
+	}
+	return 6, nil
+}
+
+func (f fakeRoles) Permissions(roleID int) ([]string, error) {
+	permissions, ok := f[roleID]
+	if !ok {
+		return nil, apperror.New(apperror.NotFound, "role not found")
+	}
+	return permissions, nil
+}
+
+// newMockTokenStore returns a token store that accepts any refresh token it is asked to persist
+func newMockTokenStore() *MockTokenStore {
+	m := new(MockTokenStore)
+	m.On("CreateRefreshToken", mock.Anything).Return(nil).Maybe()
+	return m
+}
+
+func (m *MockTokenStore) CreateRefreshToken(token *domain.RefreshToken) error {
+	args := m.Called(token)
+	return args.Error(0)
 }
 
-func (m *MockAuthenticationStore) RegisterUser(req *dto.RegisterUserRequest) (*dto.RegisterUserResponse, error) {
-	args := m.Called(req)
+func (m *MockTokenStore) GetRefreshTokenByHash(tokenHash string) (*domain.RefreshToken, error) {
+	args := m.Called(tokenHash)
 	if args.Get(0) != nil {
-		return args.Get(0).(*dto.RegisterUserResponse), args.Error(1)
+		return args.Get(0).(*domain.RefreshToken), args.Error(1)
 	}
 	return nil, args.Error(1)
 }
 
+func (m *MockTokenStore) RotateRefreshToken(current *domain.RefreshToken, next *domain.RefreshToken) error {
+	args := m.Called(current, next)
+	return args.Error(0)
+}
+
+func (m *MockTokenStore) RevokeTokenFamily(familyID string) error {
+	args := m.Called(familyID)
+	return args.Error(0)
+}
+
+func (m *MockTokenStore) RevokeUserTokens(userID int) error {
+	args := m.Called(userID)
+	return args.Error(0)
+}
+
+func (m *MockTokenStore) RevokeAccessToken(jti string, userID int, expiresAt time.Time) error {
+	args := m.Called(jti, userID, expiresAt)
+	return args.Error(0)
+}
+
+func (m *MockTokenStore) IsAccessTokenRevoked(jti string, userID int) (bool, error) {
+	args := m.Called(jti, userID)
+	return args.Bool(0), args.Error(1)
+}
+
 func TestUserUsecase_Login(t *testing.T) {
 	mockRepo := new(MockAuthenticationStore)
 	secretKey := "secret"
-	usecase := NewUserUsecase(mockRepo, secretKey)
+	passwordHasher := hasher.New(hasher.AlgorithmBcrypt)
+	permissions := fakeRoles{456: {"country.write", "request.review"}}
+	usecase := NewUserUsecase(mockRepo, newMockTokenStore(), passwordHasher, secretKey, newMockVerificationSender(), permissions)
 
 	req := dto.LoginUserRequest{
 		Email:    "test@example.com",
 		Password: "password",
 	}
-	mockUser := domain.User{
-		ID:     123,
-		RoleID: 456,
+	passwordHash, err := passwordHasher.Hash(req.Password)
+	assert.NoError(t, err)
+	mockUser := &domain.User{
+		ID:       123,
+		RoleID:   456,
+		Password: passwordHash,
+		Status:   1,
 	}
-	mockRepo.On("GetUserByEmail", req.Email, req.Password).Return(mockUser, "")
+	mockRepo.On("GetUserByEmail", req.Email).Return(mockUser, nil)
 
-	resp, msg := usecase.Login(req)
+	resp, err := usecase.Login(req)
 
-	assert.Equal(t, "", msg)
+	assert.NoError(t, err)
 	assert.NotNil(t, resp)
 	assert.NotEmpty(t, resp.Token)
+	mockRepo.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything)
 
 	claims := jwt.MapClaims{}
-	_, err := jwt.ParseWithClaims(resp.Token, claims, func(token *jwt.Token) (interface{}, error) {
+	_, err = jwt.ParseWithClaims(resp.Token, claims, func(token *jwt.Token) (interface{}, error) {
 		return []byte(secretKey), nil
 	})
 	assert.NoError(t, err)
 
-	assert.Equal(t, mockUser.ID, claims["userId"])
-	assert.Equal(t, mockUser.RoleID, claims["roleId"])
-	assert.True(t, claims.VerifyExpiresAt(time.Now().Add(time.Hour*72).Unix(), true))
+	assert.Equal(t, float64(mockUser.ID), claims["userId"])
+	assert.Equal(t, float64(mockUser.RoleID), claims["roleId"])
+	assert.Equal(t, []interface{}{"country.write", "request.review"}, claims["permissions"])
+	assert.NotEmpty(t, claims["jti"])
+	assert.True(t, claims.VerifyExpiresAt(time.Now().Add(AccessTokenTTL-time.Minute).Unix(), true))
+	assert.False(t, claims.VerifyExpiresAt(time.Now().Add(AccessTokenTTL+time.Minute).Unix(), true))
+	assert.NotEmpty(t, resp.RefreshToken)
+	assert.Equal(t, int64(AccessTokenTTL/time.Second), resp.ExpiresIn)
+}
+
+func TestUserUsecase_Login_UnknownRoleHasNoPermissions(t *testing.T) {
+	mockRepo := new(MockAuthenticationStore)
+	passwordHasher := hasher.New(hasher.AlgorithmBcrypt)
+	usecase := NewUserUsecase(mockRepo, newMockTokenStore(), passwordHasher, "secret", newMockVerificationSender(), fakeRoles{})
+	passwordHash, err := passwordHasher.Hash("password")
+	assert.NoError(t, err)
+	mockRepo.On("GetUserByEmail", "test@example.com").Return(&domain.User{ID: 1, RoleID: 99, Password: passwordHash, Status: 1}, nil)
+
+	resp, err := usecase.Login(dto.LoginUserRequest{Email: "test@example.com", Password: "password"})
+
+	assert.NoError(t, err)
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(resp.Token, claims, func(token *jwt.Token) (interface{}, error) {
+		return []byte("secret"), nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{}, claims["permissions"])
+}
+
+func TestUserUsecase_Login_RehashesLegacyPassword(t *testing.T) {
+	mockRepo := new(MockAuthenticationStore)
+	usecase := NewUserUsecase(mockRepo, newMockTokenStore(), hasher.New(hasher.AlgorithmBcrypt), "secret", newMockVerificationSender(), fakeRoles{})
+
+	req := dto.LoginUserRequest{
+		Email:    "legacy@example.com",
+		Password: "plaintext",
+	}
+	mockRepo.On("GetUserByEmail", req.Email).Return(&domain.User{ID: 7, Password: "plaintext", Status: 1}, nil)
+	mockRepo.On("UpdatePassword", 7, mock.MatchedBy(hasher.IsHashed)).Return(nil)
+
+	resp, err := usecase.Login(req)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, resp)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUserUsecase_Login_Failures(t *testing.T) {
+	passwordHasher := hasher.New(hasher.AlgorithmBcrypt)
+	passwordHash, err := passwordHasher.Hash("password")
+	assert.NoError(t, err)
+
+	t.Run("inactive user", func(t *testing.T) {
+		mockRepo := new(MockAuthenticationStore)
+		usecase := NewUserUsecase(mockRepo, newMockTokenStore(), passwordHasher, "secret", newMockVerificationSender(), fakeRoles{})
+		mockRepo.On("GetUserByEmail", "inactive@example.com").Return(&domain.User{ID: 1, Password: passwordHash, Status: 0}, nil)
+
+		resp, err := usecase.Login(dto.LoginUserRequest{Email: "inactive@example.com", Password: "password"})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, ErrUserInactive)
+	})
+
+	t.Run("incorrect password", func(t *testing.T) {
+		mockRepo := new(MockAuthenticationStore)
+		usecase := NewUserUsecase(mockRepo, newMockTokenStore(), passwordHasher, "secret", newMockVerificationSender(), fakeRoles{})
+		mockRepo.On("GetUserByEmail", "test@example.com").Return(&domain.User{ID: 1, Password: passwordHash, Status: 1}, nil)
+
+		resp, err := usecase.Login(dto.LoginUserRequest{Email: "test@example.com", Password: "wrong"})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+		mockRepo.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything)
+	})
+
+	t.Run("unknown email", func(t *testing.T) {
+		mockRepo := new(MockAuthenticationStore)
+		usecase := NewUserUsecase(mockRepo, newMockTokenStore(), passwordHasher, "secret", newMockVerificationSender(), fakeRoles{})
+		mockRepo.On("GetUserByEmail", "nobody@example.com").Return(nil, storage.ErrUserNotFound)
+
+		resp, err := usecase.Login(dto.LoginUserRequest{Email: "nobody@example.com", Password: "password"})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+	})
 }
 
 func TestUserUsecase_RegisterUser(t *testing.T) {
 	mockRepo := new(MockAuthenticationStore)
 	secretKey := "secret"
-	usecase := NewUserUsecase(mockRepo, secretKey)
+	usecase := NewUserUsecase(mockRepo, newMockTokenStore(), hasher.New(hasher.AlgorithmBcrypt), secretKey, newMockVerificationSender(), fakeRoles{})
 
 	req := dto.RegisterUserRequest{
 		Email:    "test@example.com",
 		Password: "password",
 	}
-	mockRepo.On("GetUserByEmail", req.Email, "").Return(nil, "")
-	mockResponse := &dto.RegisterUserResponse{
-		Message: req.Email,
-	}
-	mockRepo.On("RegisterUser", &req).Return(mockResponse, nil)
+	mockRepo.On("GetUserByEmail", req.Email).Return(nil, storage.ErrUserNotFound)
+	mockRepo.On("RegisterUser", &req, mock.MatchedBy(func(hash string) bool {
+		return hasher.IsHashed(hash) && hash != req.Password
+	}), 6).Return(&domain.User{ID: 42, Email: req.Email}, nil)
+	verification := new(MockVerificationSender)
+	verification.On("SendVerificationEmail", 42).Return(errors.New("smtp down"))
+	usecase.verification = verification
 
-	resp, msg := usecase.RegisterUser(req)
+	resp, err := usecase.RegisterUser(req)
 
-	assert.Equal(t, "", msg)
-	assert.NotNil(t, resp)
-	assert.Equal(t, mockResponse, resp)
+	assert.NoError(t, err)
+	assert.Equal(t, &dto.RegisterUserResponse{Message: "User registered successfully"}, resp)
+	verification.AssertExpectations(t)
+}
+
+func TestUserUsecase_RegisterUser_Existing(t *testing.T) {
+	mockRepo := new(MockAuthenticationStore)
+	usecase := NewUserUsecase(mockRepo, newMockTokenStore(), hasher.New(hasher.AlgorithmBcrypt), "secret", newMockVerificationSender(), fakeRoles{})
+
+	mockRepo.On("GetUserByEmail", "test@example.com").Return(&domain.User{ID: 1}, nil)
+
+	resp, err := usecase.RegisterUser(dto.RegisterUserRequest{Email: "test@example.com", Password: "password"})
+
+	assert.Nil(t, resp)
+	assert.ErrorIs(t, err, ErrUserExists)
+	mockRepo.AssertNotCalled(t, "RegisterUser", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUserUsecase_Refresh(t *testing.T) {
+	activeUser := &domain.User{ID: 1, RoleID: 2, Status: 1}
+
+	t.Run("rotates a valid refresh token", func(t *testing.T) {
+		mockRepo := new(MockAuthenticationStore)
+		tokens := new(MockTokenStore)
+		usecase := NewUserUsecase(mockRepo, tokens, hasher.New(hasher.AlgorithmBcrypt), "secret", newMockVerificationSender(), fakeRoles{})
+
+		current := &domain.RefreshToken{ID: 10, UserID: 1, FamilyID: "family", ExpiresAt: time.Now().Add(time.Hour)}
+		tokens.On("GetRefreshTokenByHash", hashToken("refresh")).Return(current, nil)
+		mockRepo.On("GetUserByID", 1).Return(activeUser, nil)
+		tokens.On("RotateRefreshToken", current, mock.MatchedBy(func(next *domain.RefreshToken) bool {
+			return next.FamilyID == "family" && next.UserID == 1 && next.TokenHash != current.TokenHash
+		})).Return(nil)
+
+		resp, err := usecase.Refresh(dto.RefreshTokenRequest{RefreshToken: "refresh"})
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, resp.Token)
+		assert.NotEqual(t, "refresh", resp.RefreshToken)
+		tokens.AssertExpectations(t)
+	})
+
+	t.Run("reuse of a rotated token revokes the family", func(t *testing.T) {
+		tokens := new(MockTokenStore)
+		usecase := NewUserUsecase(new(MockAuthenticationStore), tokens, hasher.New(hasher.AlgorithmBcrypt), "secret", newMockVerificationSender(), fakeRoles{})
+
+		usedAt := time.Now().Add(-time.Minute)
+		tokens.On("GetRefreshTokenByHash", hashToken("stolen")).
+			Return(&domain.RefreshToken{ID: 10, UserID: 1, FamilyID: "family", RevokedAt: &usedAt, ExpiresAt: time.Now().Add(time.Hour)}, nil)
+		tokens.On("RevokeTokenFamily", "family").Return(nil)
+
+		resp, err := usecase.Refresh(dto.RefreshTokenRequest{RefreshToken: "stolen"})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, ErrRefreshTokenReused)
+		tokens.AssertExpectations(t)
+	})
+
+	t.Run("concurrent rotation is treated as reuse", func(t *testing.T) {
+		mockRepo := new(MockAuthenticationStore)
+		tokens := new(MockTokenStore)
+		usecase := NewUserUsecase(mockRepo, tokens, hasher.New(hasher.AlgorithmBcrypt), "secret", newMockVerificationSender(), fakeRoles{})
+
+		current := &domain.RefreshToken{ID: 10, UserID: 1, FamilyID: "family", ExpiresAt: time.Now().Add(time.Hour)}
+		tokens.On("GetRefreshTokenByHash", hashToken("refresh")).Return(current, nil)
+		mockRepo.On("GetUserByID", 1).Return(activeUser, nil)
+		tokens.On("RotateRefreshToken", current, mock.Anything).Return(storage.ErrRefreshTokenAlreadyUsed)
+		tokens.On("RevokeTokenFamily", "family").Return(nil)
+
+		resp, err := usecase.Refresh(dto.RefreshTokenRequest{RefreshToken: "refresh"})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, ErrRefreshTokenReused)
+		tokens.AssertExpectations(t)
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		tokens := new(MockTokenStore)
+		usecase := NewUserUsecase(new(MockAuthenticationStore), tokens, hasher.New(hasher.AlgorithmBcrypt), "secret", newMockVerificationSender(), fakeRoles{})
+
+		tokens.On("GetRefreshTokenByHash", hashToken("old")).
+			Return(&domain.RefreshToken{ID: 10, UserID: 1, FamilyID: "family", ExpiresAt: time.Now().Add(-time.Hour)}, nil)
+
+		resp, err := usecase.Refresh(dto.RefreshTokenRequest{RefreshToken: "old"})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, ErrRefreshTokenExpired)
+	})
+
+	t.Run("deactivated user", func(t *testing.T) {
+		mockRepo := new(MockAuthenticationStore)
+		tokens := new(MockTokenStore)
+		usecase := NewUserUsecase(mockRepo, tokens, hasher.New(hasher.AlgorithmBcrypt), "secret", newMockVerificationSender(), fakeRoles{})
+
+		tokens.On("GetRefreshTokenByHash", hashToken("refresh")).
+			Return(&domain.RefreshToken{ID: 10, UserID: 1, FamilyID: "family", ExpiresAt: time.Now().Add(time.Hour)}, nil)
+		mockRepo.On("GetUserByID", 1).Return(&domain.User{ID: 1, Status: 0}, nil)
+		tokens.On("RevokeTokenFamily", "family").Return(nil)
+
+		resp, err := usecase.Refresh(dto.RefreshTokenRequest{RefreshToken: "refresh"})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, ErrUserInactive)
+		tokens.AssertExpectations(t)
+	})
+}
+
+func TestUserUsecase_Logout(t *testing.T) {
+	expiresAt := time.Now().Add(AccessTokenTTL)
+
+	t.Run("revokes access token and refresh family", func(t *testing.T) {
+		tokens := new(MockTokenStore)
+		usecase := NewUserUsecase(new(MockAuthenticationStore), tokens, hasher.New(hasher.AlgorithmBcrypt), "secret", newMockVerificationSender(), fakeRoles{})
+
+		tokens.On("RevokeAccessToken", "jti", 1, expiresAt).Return(nil)
+		tokens.On("GetRefreshTokenByHash", hashToken("refresh")).Return(&domain.RefreshToken{UserID: 1, FamilyID: "family"}, nil)
+		tokens.On("RevokeTokenFamily", "family").Return(nil)
+
+		err := usecase.Logout(1, "jti", expiresAt, dto.LogoutRequest{RefreshToken: "refresh"})
+
+		assert.NoError(t, err)
+		tokens.AssertExpectations(t)
+	})
+
+	t.Run("refuses a refresh token of another user", func(t *testing.T) {
+		tokens := new(MockTokenStore)
+		usecase := NewUserUsecase(new(MockAuthenticationStore), tokens, hasher.New(hasher.AlgorithmBcrypt), "secret", newMockVerificationSender(), fakeRoles{})
+
+		tokens.On("RevokeAccessToken", "jti", 1, expiresAt).Return(nil)
+		tokens.On("GetRefreshTokenByHash", hashToken("refresh")).Return(&domain.RefreshToken{UserID: 2, FamilyID: "family"}, nil)
+
+		err := usecase.Logout(1, "jti", expiresAt, dto.LogoutRequest{RefreshToken: "refresh"})
+
+		assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+		tokens.AssertNotCalled(t, "RevokeTokenFamily", mock.Anything)
+	})
 }
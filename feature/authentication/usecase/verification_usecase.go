@@ -0,0 +1,171 @@
+package usecase
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/storage"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/mailer"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	VerificationTokenTTL = 24 * time.Hour
+	// VerificationResendCooldown is the minimum delay between two emails to
+	// the same user, and VerificationResendLimit caps the emails per hour.
+	VerificationResendCooldown = time.Minute
+	VerificationResendLimit    = 5
+
+	verifyEmailPurpose = "verify_email"
+)
+
+var (
+	ErrInvalidVerificationToken = apperror.New(apperror.Validation, "invalid or expired verification token")
+	ErrVerificationThrottled    = apperror.New(apperror.RateLimited, "too many verification emails, try again later")
+)
+
+// ThrottledError is returned when a resend is refused. It wraps
+// ErrVerificationThrottled.
+type ThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *ThrottledError) Error() string {
+	return ErrVerificationThrottled.Error()
+}
+
+func (e *ThrottledError) Unwrap() error {
+	return ErrVerificationThrottled
+}
+
+// EmailVerificationSender issues a verification email to a user. It is
+// used on registration and when an admin approves a request.
+type EmailVerificationSender interface {
+	SendVerificationEmail(userID int) error
+}
+
+type VerificationUsecaseInterface interface {
+	EmailVerificationSender
+	VerifyEmail(token string) error
+	ResendVerificationEmail(email string) error
+}
+
+type VerificationUsecase struct {
+	repo      storage.AuthenticationStore
+	tokens    storage.VerificationStore
+	mailer    mailer.Mailer
+	secretKey string
+	baseURL   string
+	now       func() time.Time
+}
+
+// NewVerificationUsecase creates a VerificationUsecase. baseURL is the public
+// URL of the API, used to build the link sent by email.
+func NewVerificationUsecase(repo storage.AuthenticationStore, tokens storage.VerificationStore, mailer mailer.Mailer, secretKey string, baseURL string) *VerificationUsecase {
+	return &VerificationUsecase{
+		repo:      repo,
+		tokens:    tokens,
+		mailer:    mailer,
+		secretKey: secretKey,
+		baseURL:   strings.TrimRight(baseURL, "/"),
+		now:       time.Now,
+	}
+}
+
+// SendVerificationEmail issues a new token and emails the verification link.
+// Users whose email is verified already are skipped.
+func (u *VerificationUsecase) SendVerificationEmail(userID int) error {
+	user, err := u.repo.GetUserByID(userID)
+	if err != nil {
+		return fmt.Errorf("load user %d: %w", userID, err)
+	}
+	return u.send(user)
+}
+
+// VerifyEmail checks the signature and expiry of token, consumes it and marks
+// the email of its user as verified.
+func (u *VerificationUsecase) VerifyEmail(token string) error {
+	claims := jwt.MapClaims{}
+	parsed, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method %v", t.Header["alg"])
+		}
+		return []byte(u.secretKey), nil
+	})
+	if err != nil || !parsed.Valid {
+		return ErrInvalidVerificationToken
+	}
+	purpose, _ := claims["purpose"].(string)
+	jti, _ := claims["jti"].(string)
+	sub, ok := claims["sub"].(float64)
+	if purpose != verifyEmailPurpose || jti == "" || !ok {
+		return ErrInvalidVerificationToken
+	}
+	return u.tokens.ConsumeVerificationToken(jti, int(sub))
+}
+
+// ResendVerificationEmail sends a new link to the account registered with
+// email. Unknown and already verified accounts are ignored so that the
+// endpoint does not reveal which emails are registered.
+func (u *VerificationUsecase) ResendVerificationEmail(email string) error {
+	user, _ := u.repo.GetUserByEmail(email)
+	if user == nil || user.VerificationStatus == 1 {
+		return nil
+	}
+
+	now := u.now()
+	latest, err := u.tokens.LatestVerificationToken(user.ID)
+	if err != nil {
+		return err
+	}
+	if latest != nil {
+		if wait := latest.CreatedAt.Add(VerificationResendCooldown).Sub(now); wait > 0 {
+			return &ThrottledError{RetryAfter: wait}
+		}
+	}
+	sent, err := u.tokens.CountVerificationTokensSince(user.ID, now.Add(-time.Hour))
+	if err != nil {
+		return err
+	}
+	if sent >= VerificationResendLimit {
+		return &ThrottledError{RetryAfter: time.Hour}
+	}
+	return u.send(user)
+}
+
+func (u *VerificationUsecase) send(user *domain.User) error {
+	if user.VerificationStatus == 1 {
+		return nil
+	}
+	now := u.now()
+	record := &domain.EmailVerificationToken{
+		JTI:       newTokenID(),
+		UserID:    user.ID,
+		ExpiresAt: now.Add(VerificationTokenTTL),
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub":     user.ID,
+		"purpose": verifyEmailPurpose,
+		"jti":     record.JTI,
+		"iat":     now.Unix(),
+		"exp":     record.ExpiresAt.Unix(),
+	}).SignedString([]byte(u.secretKey))
+	if err != nil {
+		return err
+	}
+	if err := u.tokens.CreateVerificationToken(record); err != nil {
+		return err
+	}
+
+	link := u.baseURL + "/api/v1/auth/verify-email?token=" + url.QueryEscape(token)
+	return u.mailer.Send(mailer.Message{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Hello %s,\n\nPlease confirm your email address by opening the link below:\n\n%s\n\nThe link expires in %d hours and can be used once.\n",
+			user.Name, link, int(VerificationTokenTTL/time.Hour)),
+	})
+}
@@ -0,0 +1,180 @@
+package usecase
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/storage"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/mailer"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockVerificationStore struct {
+	mock.Mock
+}
+
+func (m *MockVerificationStore) CreateVerificationToken(token *domain.EmailVerificationToken) error {
+	args := m.Called(token)
+	return args.Error(0)
+}
+
+func (m *MockVerificationStore) LatestVerificationToken(userID int) (*domain.EmailVerificationToken, error) {
+	args := m.Called(userID)
+	if args.Get(0) != nil {
+		return args.Get(0).(*domain.EmailVerificationToken), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockVerificationStore) CountVerificationTokensSince(userID int, since time.Time) (int64, error) {
+	args := m.Called(userID, since)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockVerificationStore) ConsumeVerificationToken(jti string, userID int) error {
+	args := m.Called(jti, userID)
+	return args.Error(0)
+}
+
+// captureMailer keeps sent messages in memory.
+type captureMailer struct {
+	sent []mailer.Message
+}
+
+func (m *captureMailer) Send(msg mailer.Message) error {
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+func tokenFromLink(t *testing.T, body string) string {
+	start := strings.Index(body, "http://")
+	assert.NotEqual(t, -1, start)
+	link, err := url.Parse(strings.Fields(body[start:])[0])
+	assert.NoError(t, err)
+	return link.Query().Get("token")
+}
+
+func TestSendAndVerifyEmail(t *testing.T) {
+	repo := new(MockAuthenticationStore)
+	tokens := new(MockVerificationStore)
+	outbox := &captureMailer{}
+	usecase := NewVerificationUsecase(repo, tokens, outbox, "secret", "http://localhost:8080/")
+
+	repo.On("GetUserByID", 7).Return(&domain.User{ID: 7, Email: "jane@example.com", Name: "Jane"}, nil)
+	var issued *domain.EmailVerificationToken
+	tokens.On("CreateVerificationToken", mock.Anything).Run(func(args mock.Arguments) {
+		issued = args.Get(0).(*domain.EmailVerificationToken)
+	}).Return(nil)
+
+	assert.NoError(t, usecase.SendVerificationEmail(7))
+	assert.Len(t, outbox.sent, 1)
+	assert.Equal(t, "jane@example.com", outbox.sent[0].To)
+	assert.Contains(t, outbox.sent[0].Body, "http://localhost:8080/api/v1/auth/verify-email?token=")
+
+	token := tokenFromLink(t, outbox.sent[0].Body)
+	tokens.On("ConsumeVerificationToken", issued.JTI, 7).Return(nil).Once()
+	assert.NoError(t, usecase.VerifyEmail(token))
+
+	tokens.On("ConsumeVerificationToken", issued.JTI, 7).Return(storage.ErrVerificationTokenUsed).Once()
+	assert.ErrorIs(t, usecase.VerifyEmail(token), storage.ErrVerificationTokenUsed)
+	tokens.AssertExpectations(t)
+}
+
+func TestSendVerificationEmail_AlreadyVerified(t *testing.T) {
+	repo := new(MockAuthenticationStore)
+	tokens := new(MockVerificationStore)
+	outbox := &captureMailer{}
+	usecase := NewVerificationUsecase(repo, tokens, outbox, "secret", "http://localhost:8080")
+
+	repo.On("GetUserByID", 7).Return(&domain.User{ID: 7, VerificationStatus: 1}, nil)
+
+	assert.NoError(t, usecase.SendVerificationEmail(7))
+	assert.Empty(t, outbox.sent)
+	tokens.AssertNotCalled(t, "CreateVerificationToken", mock.Anything)
+}
+
+func TestVerifyEmail_InvalidTokens(t *testing.T) {
+	usecase := NewVerificationUsecase(new(MockAuthenticationStore), new(MockVerificationStore), &captureMailer{}, "secret", "")
+	sign := func(claims jwt.MapClaims, key string) string {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(key))
+		assert.NoError(t, err)
+		return token
+	}
+	valid := func() jwt.MapClaims {
+		return jwt.MapClaims{"sub": 7, "purpose": verifyEmailPurpose, "jti": "abc", "exp": time.Now().Add(time.Hour).Unix()}
+	}
+
+	expired := valid()
+	expired["exp"] = time.Now().Add(-time.Minute).Unix()
+	accessToken := valid()
+	delete(accessToken, "purpose")
+
+	for name, token := range map[string]string{
+		"garbage":       "not-a-token",
+		"wrong key":     sign(valid(), "other"),
+		"expired":       sign(expired, "secret"),
+		"wrong purpose": sign(accessToken, "secret"),
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.ErrorIs(t, usecase.VerifyEmail(token), ErrInvalidVerificationToken)
+		})
+	}
+}
+
+func TestResendVerificationEmail(t *testing.T) {
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	user := &domain.User{ID: 7, Email: "jane@example.com"}
+
+	t.Run("cooldown", func(t *testing.T) {
+		repo := new(MockAuthenticationStore)
+		tokens := new(MockVerificationStore)
+		usecase := NewVerificationUsecase(repo, tokens, &captureMailer{}, "secret", "")
+		usecase.now = func() time.Time { return now }
+		repo.On("GetUserByEmail", user.Email).Return(user, nil)
+		tokens.On("LatestVerificationToken", 7).Return(&domain.EmailVerificationToken{CreatedAt: now.Add(-20 * time.Second)}, nil)
+
+		err := usecase.ResendVerificationEmail(user.Email)
+		assert.ErrorIs(t, err, ErrVerificationThrottled)
+		assert.Equal(t, 40*time.Second, err.(*ThrottledError).RetryAfter)
+	})
+
+	t.Run("hourly limit", func(t *testing.T) {
+		repo := new(MockAuthenticationStore)
+		tokens := new(MockVerificationStore)
+		usecase := NewVerificationUsecase(repo, tokens, &captureMailer{}, "secret", "")
+		usecase.now = func() time.Time { return now }
+		repo.On("GetUserByEmail", user.Email).Return(user, nil)
+		tokens.On("LatestVerificationToken", 7).Return(&domain.EmailVerificationToken{CreatedAt: now.Add(-10 * time.Minute)}, nil)
+		tokens.On("CountVerificationTokensSince", 7, now.Add(-time.Hour)).Return(int64(VerificationResendLimit), nil)
+
+		assert.ErrorIs(t, usecase.ResendVerificationEmail(user.Email), ErrVerificationThrottled)
+	})
+
+	t.Run("sends a new link", func(t *testing.T) {
+		repo := new(MockAuthenticationStore)
+		tokens := new(MockVerificationStore)
+		outbox := &captureMailer{}
+		usecase := NewVerificationUsecase(repo, tokens, outbox, "secret", "")
+		usecase.now = func() time.Time { return now }
+		repo.On("GetUserByEmail", user.Email).Return(user, nil)
+		tokens.On("LatestVerificationToken", 7).Return(nil, nil)
+		tokens.On("CountVerificationTokensSince", 7, now.Add(-time.Hour)).Return(int64(0), nil)
+		tokens.On("CreateVerificationToken", mock.Anything).Return(nil)
+
+		assert.NoError(t, usecase.ResendVerificationEmail(user.Email))
+		assert.Len(t, outbox.sent, 1)
+	})
+
+	t.Run("unknown email", func(t *testing.T) {
+		repo := new(MockAuthenticationStore)
+		usecase := NewVerificationUsecase(repo, new(MockVerificationStore), &captureMailer{}, "secret", "")
+		repo.On("GetUserByEmail", "nobody@example.com").Return(nil, storage.ErrUserNotFound)
+
+		assert.NoError(t, usecase.ResendVerificationEmail("nobody@example.com"))
+	})
+}
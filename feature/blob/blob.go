@@ -0,0 +1,64 @@
+// Package blob stores uploaded files. Files are private: clients download
+// them through short-lived signed URLs handed out by the API once it has
+// checked who is asking.
+package blob
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	DriverLocal = "local"
+	DriverS3    = "s3"
+)
+
+// BlobStore keeps files under keys chosen by the caller.
+type BlobStore interface {
+	// Put writes data under key, replacing any file with the same key.
+	Put(key string, data []byte, contentType string) error
+	// Delete removes the file under key. Deleting a missing file is not an
+	// error.
+	Delete(key string) error
+	// SignedURL returns a URL that downloads the file under key until ttl
+	// has passed.
+	SignedURL(key string, ttl time.Duration) (string, error)
+}
+
+// NewFromEnv builds a BlobStore from the BLOB_DRIVER environment variable.
+// "s3" stores files in S3_BUCKET; anything else stores them under BLOB_DIR
+// and signs URLs to the API itself with BLOB_SIGNING_KEY, or signingKey
+// when it is not set.
+func NewFromEnv(signingKey string) BlobStore {
+	if strings.EqualFold(os.Getenv("BLOB_DRIVER"), DriverS3) {
+		return NewS3Store(S3Config{
+			Endpoint:        os.Getenv("S3_ENDPOINT"),
+			Region:          os.Getenv("S3_REGION"),
+			Bucket:          os.Getenv("S3_BUCKET"),
+			AccessKeyID:     os.Getenv("S3_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("S3_SECRET_ACCESS_KEY"),
+			PathStyle:       strings.EqualFold(os.Getenv("S3_PATH_STYLE"), "true"),
+		})
+	}
+	if key := os.Getenv("BLOB_SIGNING_KEY"); key != "" {
+		signingKey = key
+	}
+	dir := os.Getenv("BLOB_DIR")
+	if dir == "" {
+		dir = "uploads"
+	}
+	return NewLocalStore(dir, strings.TrimRight(os.Getenv("APP_BASE_URL"), "/")+FilesPath, signingKey)
+}
+
+// NewKey returns a key under prefix that cannot be guessed, so that a
+// replaced file never shares the key of the file it replaces.
+func NewKey(prefix string, ext string) (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return prefix + "/" + hex.EncodeToString(buf) + ext, nil
+}
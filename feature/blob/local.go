@@ -0,0 +1,119 @@
+package blob
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+	"github.com/gin-gonic/gin"
+)
+
+// FilesPath is the route that serves the files of a LocalStore.
+const FilesPath = "/api/v1/files"
+
+var ErrInvalidSignature = apperror.New(apperror.Forbidden, "invalid or expired file link")
+
+// LocalStore keeps files in a directory and signs URLs to the route that
+// serves them with HMAC-SHA256.
+type LocalStore struct {
+	dir        string
+	baseURL    string
+	signingKey []byte
+	now        func() time.Time
+}
+
+func NewLocalStore(dir string, baseURL string, signingKey string) *LocalStore {
+	return &LocalStore{dir: dir, baseURL: baseURL, signingKey: []byte(signingKey), now: time.Now}
+}
+
+func (s *LocalStore) Put(key string, data []byte, contentType string) error {
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(name), 0o750); err != nil {
+		return err
+	}
+	return os.WriteFile(name, data, 0o640)
+}
+
+func (s *LocalStore) Delete(key string) error {
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(name); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (s *LocalStore) SignedURL(key string, ttl time.Duration) (string, error) {
+	if _, err := s.path(key); err != nil {
+		return "", err
+	}
+	expires := strconv.FormatInt(s.now().Add(ttl).Unix(), 10)
+	query := url.Values{"expires": {expires}, "signature": {s.sign(key, expires)}}
+	return s.baseURL + "/" + key + "?" + query.Encode(), nil
+}
+
+// Handler serves the file named by the key path parameter when the link is
+// signed and has not expired. It is mounted at FilesPath + "/*key".
+func (s *LocalStore) Handler(c *gin.Context) {
+	key := strings.TrimPrefix(c.Param("key"), "/")
+	expires := c.Query("expires")
+	if !s.valid(key, expires, c.Query("signature")) {
+		apperror.Abort(c, ErrInvalidSignature)
+		return
+	}
+	name, err := s.path(key)
+	if err != nil {
+		apperror.Abort(c, ErrInvalidSignature)
+		return
+	}
+	data, err := os.ReadFile(name)
+	if errors.Is(err, os.ErrNotExist) {
+		apperror.Abort(c, apperror.New(apperror.NotFound, "file not found"))
+		return
+	}
+	if err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+	c.Header("Cache-Control", "private, no-store")
+	c.Data(http.StatusOK, mime.TypeByExtension(path.Ext(key)), data)
+}
+
+func (s *LocalStore) valid(key string, expires string, signature string) bool {
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || s.now().Unix() > unix {
+		return false
+	}
+	return hmac.Equal([]byte(signature), []byte(s.sign(key, expires)))
+}
+
+func (s *LocalStore) sign(key string, expires string) string {
+	mac := hmac.New(sha256.New, s.signingKey)
+	mac.Write([]byte(key + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// path maps key to a file under dir, rejecting keys that would escape it.
+func (s *LocalStore) path(key string) (string, error) {
+	if key == "" || path.Clean("/"+key) != "/"+key {
+		return "", fmt.Errorf("blob: invalid key %q", key)
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
@@ -2,13 +2,20 @@ package domain
 
 import (
 	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+	"gorm.io/gorm"
 )
 
+// ErrCountryNotFound is returned when a country is missing or, when restoring, not deleted.
+var ErrCountryNotFound = apperror.New(apperror.NotFound, "country not found")
+
 // Country struct that interacts with databases (GORM)
 type Country struct {
-	Id        uint      `gorm:"primaryKey" json:"id"`
-	Name      string    `gorm:"size:255;not null;unique" json:"name"`
-	Status    uint      `gorm:"not null" json:"status"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	Id        uint           `gorm:"primaryKey" json:"id"`
+	Name      string         `gorm:"size:255;not null;unique" json:"name"`
+	Status    uint           `gorm:"not null" json:"status"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at" swaggertype:"string"`
 }
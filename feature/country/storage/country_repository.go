@@ -1,6 +1,8 @@
 package storage
 
 import (
+	"errors"
+
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/country/domain"
 	"gorm.io/gorm"
 )
@@ -11,6 +13,7 @@ type CountryRepositoryInterface interface {
 	GetByID(id uint) (*domain.Country, error)
 	Update(country *domain.Country) error
 	Delete(id uint) error
+	Restore(id uint) error
 }
 
 // CountryRepository handles the CRUD operations with the database.
@@ -32,6 +35,9 @@ func (r *CountryRepository) Create(country *domain.Country) error {
 func (r *CountryRepository) GetByID(id uint) (*domain.Country, error) {
 	var country domain.Country
 	err := r.DB.First(&country, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrCountryNotFound
+	}
 	return &country, err
 }
 
@@ -44,3 +50,17 @@ func (r *CountryRepository) Update(country *domain.Country) error {
 func (r *CountryRepository) Delete(id uint) error {
 	return r.DB.Delete(&domain.Country{}, id).Error
 }
+
+// Restore clears deleted_at of a deleted country record.
+func (r *CountryRepository) Restore(id uint) error {
+	result := r.DB.Unscoped().Model(&domain.Country{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrCountryNotFound
+	}
+	return nil
+}
@@ -2,12 +2,14 @@ package storage
 
 import (
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/country/domain"
 	"github.com/stretchr/testify/assert"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
 )
 
 func setupMockDB() (*gorm.DB, sqlmock.Sqlmock, error) {
@@ -16,9 +18,10 @@ func setupMockDB() (*gorm.DB, sqlmock.Sqlmock, error) {
 		return nil, nil, err
 	}
 	dialector := mysql.New(mysql.Config{
-		Conn: db,
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
 	})
-	gormDB, err := gorm.Open(dialector, &gorm.Config{})
+	gormDB, err := gorm.Open(dialector, &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
 	if err != nil {
 		return nil, nil, err
 	}
@@ -38,17 +41,19 @@ func TestCountryRepository_Create(t *testing.T) {
 	repo := NewCountryRepository(gormDB)
 
 	country := &domain.Country{
-		Name: "Test Country",
+		Name:   "Test Country",
+		Status: 1,
 	}
 
 	mock.ExpectBegin()
-	mock.ExpectExec(`INSERT INTO countries`).
-		WithArgs(sqlmock.AnyArg(), country.Name).
+	mock.ExpectExec("INSERT INTO `countries`").
+		WithArgs(country.Name, country.Status, sqlmock.AnyArg(), sqlmock.AnyArg(), nil).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
 	err = repo.Create(country)
 	assert.NoError(t, err)
+	assert.Equal(t, uint(1), country.Id)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -73,13 +78,20 @@ func TestCountryRepository_GetByID(t *testing.T) {
 	rows := sqlmock.NewRows([]string{"id", "name"}).
 		AddRow(country.Id, country.Name)
 
-	mock.ExpectQuery(`SELECT \* FROM countries WHERE id = \?`).
-		WithArgs(countryID).
+	mock.ExpectQuery("SELECT \\* FROM `countries` WHERE `countries`.`id` = \\? AND `countries`.`deleted_at` IS NULL").
+		WithArgs(countryID, 1).
 		WillReturnRows(rows)
 
 	result, err := repo.GetByID(countryID)
 	assert.NoError(t, err)
 	assert.Equal(t, country, result)
+
+	mock.ExpectQuery("SELECT \\* FROM `countries`").
+		WithArgs(uint(2), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+
+	_, err = repo.GetByID(2)
+	assert.ErrorIs(t, err, domain.ErrCountryNotFound)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -96,14 +108,16 @@ func TestCountryRepository_Update(t *testing.T) {
 	repo := NewCountryRepository(gormDB)
 
 	country := &domain.Country{
-		Id:   1,
-		Name: "Updated Country",
+		Id:        1,
+		Name:      "Updated Country",
+		Status:    1,
+		CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
 	}
 
 	mock.ExpectBegin()
-	mock.ExpectExec(`UPDATE countries SET name = \?, code = \? WHERE id = \?`).
-		WithArgs(country.Name, country.Id).
-		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("UPDATE `countries` SET `name`=\\?,`status`=\\?,`created_at`=\\?,`updated_at`=\\?,`deleted_at`=\\? WHERE `countries`.`deleted_at` IS NULL AND `id` = \\?").
+		WithArgs(country.Name, country.Status, country.CreatedAt, sqlmock.AnyArg(), nil, country.Id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
 	mock.ExpectCommit()
 
 	err = repo.Update(country)
@@ -126,9 +140,9 @@ func TestCountryRepository_Delete(t *testing.T) {
 	countryID := uint(1)
 
 	mock.ExpectBegin()
-	mock.ExpectExec(`DELETE FROM countries WHERE id = \?`).
-		WithArgs(countryID).
-		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("UPDATE `countries` SET `deleted_at`=\\? WHERE `countries`.`id` = \\? AND `countries`.`deleted_at` IS NULL").
+		WithArgs(sqlmock.AnyArg(), countryID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
 	mock.ExpectCommit()
 
 	err = repo.Delete(countryID)
@@ -4,8 +4,10 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/country/dto"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/country/usecase"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/validation"
 	"github.com/gin-gonic/gin"
 )
 
@@ -26,19 +28,20 @@ func NewCountryHandler(usecase usecase.CountryUsecaseInterface) *CountryHandler
 // @Accept json
 // @Produce json
 // @Tags country
+// @Security bearerToken
 // @Param country body dto.CountryCreateDTO true "Country data"
 // @Success 201 {object} domain.Country
 // @Router /api/v1/countries [post]
 func (h *CountryHandler) CreateCountry(c *gin.Context) {
 	var input dto.CountryCreateDTO
 	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apperror.Abort(c, validation.Error(err))
 		return
 	}
 
 	err := h.usecase.CreateCountry(input)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apperror.Abort(c, err)
 		return
 	}
 
@@ -57,13 +60,13 @@ func (h *CountryHandler) CreateCountry(c *gin.Context) {
 func (h *CountryHandler) GetCountryByID(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid country ID"})
+		apperror.Abort(c, apperror.New(apperror.Validation, "Invalid country ID"))
 		return
 	}
 
 	country, err := h.usecase.GetCountryByID(uint(id))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Country not found"})
+		apperror.Abort(c, err)
 		return
 	}
 
@@ -77,6 +80,7 @@ func (h *CountryHandler) GetCountryByID(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Tags country
+// @Security bearerToken
 // @Param id path int true "Country ID"
 // @Param country body dto.CountryUpdateDTO true "Country data"
 // @Success 200 {object} domain.Country
@@ -84,18 +88,18 @@ func (h *CountryHandler) GetCountryByID(c *gin.Context) {
 func (h *CountryHandler) UpdateCountry(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid country ID"})
+		apperror.Abort(c, apperror.New(apperror.Validation, "Invalid country ID"))
 		return
 	}
 
 	var input dto.CountryUpdateDTO
 	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apperror.Abort(c, validation.Error(err))
 		return
 	}
 
 	if err := h.usecase.UpdateCountry(uint(id), input); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apperror.Abort(c, err)
 		return
 	}
 
@@ -107,21 +111,49 @@ func (h *CountryHandler) UpdateCountry(c *gin.Context) {
 // @Summary Delete country
 // @Description Delete country
 // @Tags country
+// @Security bearerToken
 // @Param id path int true "Country ID"
 // @Success 204
 // @Router /api/v1/countries/{id} [delete]
 func (h *CountryHandler) DeleteCountry(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid country ID"})
+		apperror.Abort(c, apperror.New(apperror.Validation, "Invalid country ID"))
 		return
 	}
 
 	err = h.usecase.DeleteCountry(uint(id))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apperror.Abort(c, err)
 		return
 	}
 
 	c.JSON(http.StatusNoContent, nil)
 }
+
+// RestoreCountry handles the HTTP POST request to restore a deleted country.
+// RestoreCountry godoc
+// @Summary Restore country
+// @Description Restore a deleted country
+// @Produce json
+// @Tags country
+// @Security bearerToken
+// @Param id path int true "Country ID"
+// @Success 200 {string} message "Country restored successfully"
+// @Failure 404 {object} apperror.Response "No deleted country with this ID"
+// @Router /api/v1/country/{id}/restore [post]
+func (h *CountryHandler) RestoreCountry(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apperror.Abort(c, apperror.New(apperror.Validation, "Invalid country ID"))
+		return
+	}
+
+	err = h.usecase.RestoreCountry(uint(id))
+	if err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "country restored successfully"})
+}
@@ -7,10 +7,12 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/country/domain"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/country/dto"
 )
 
@@ -26,7 +28,10 @@ func (m *MockCountryUsecase) CreateCountry(input dto.CountryCreateDTO) error {
 
 func (m *MockCountryUsecase) GetCountryByID(id uint) (*dto.CountryResponseDTO, error) {
 	args := m.Called(id)
-	return args.Get(0).(*dto.CountryResponseDTO), args.Error(1)
+	if args.Get(0) != nil {
+		return args.Get(0).(*dto.CountryResponseDTO), args.Error(1)
+	}
+	return nil, args.Error(1)
 }
 
 func (m *MockCountryUsecase) UpdateCountry(id uint, input dto.CountryUpdateDTO) error {
@@ -39,18 +44,23 @@ func (m *MockCountryUsecase) DeleteCountry(id uint) error {
 	return args.Error(0)
 }
 
+func (m *MockCountryUsecase) RestoreCountry(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
 func TestCreateCountry(t *testing.T) {
 	mockUsecase := new(MockCountryUsecase)
 	handler := NewCountryHandler(mockUsecase)
 
 	gin.SetMode(gin.TestMode)
 	r := gin.Default()
+	r.Use(apperror.Middleware())
 	r.POST("/api/v1/countries", handler.CreateCountry)
 
-	input := dto.CountryCreateDTO{Name: "Test Country"}
-	response := dto.CountryResponseDTO{Name: "Test Country"}
+	input := dto.CountryCreateDTO{Name: "Test Country", Status: 1}
 
-	mockUsecase.On("CreateCountry", input).Return(response, nil)
+	mockUsecase.On("CreateCountry", input).Return(nil)
 
 	body, _ := json.Marshal(input)
 	req, _ := http.NewRequest(http.MethodPost, "/api/v1/countries", bytes.NewBuffer(body))
@@ -60,9 +70,7 @@ func TestCreateCountry(t *testing.T) {
 	r.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusCreated, w.Code)
-	var result dto.CountryResponseDTO
-	json.Unmarshal(w.Body.Bytes(), &result)
-	assert.Equal(t, response, result)
+	assert.JSONEq(t, `{"message": "Country created successfully"}`, w.Body.String())
 
 	mockUsecase.AssertExpectations(t)
 }
@@ -73,11 +81,13 @@ func TestGetCountryByID(t *testing.T) {
 
 	gin.SetMode(gin.TestMode)
 	r := gin.Default()
+	r.Use(apperror.Middleware())
 	r.GET("/countries/:id", handler.GetCountryByID)
 
-	response := dto.CountryResponseDTO{Name: "Test Country"}
+	response := dto.CountryResponseDTO{Name: "Test Country", Status: 1}
 
-	mockUsecase.On("GetCountryByID", uint(1)).Return(response, nil)
+	mockUsecase.On("GetCountryByID", uint(1)).Return(&response, nil)
+	mockUsecase.On("GetCountryByID", uint(2)).Return(nil, domain.ErrCountryNotFound)
 
 	req, _ := http.NewRequest(http.MethodGet, "/countries/1", nil)
 
@@ -89,6 +99,11 @@ func TestGetCountryByID(t *testing.T) {
 	json.Unmarshal(w.Body.Bytes(), &result)
 	assert.Equal(t, response, result)
 
+	req, _ = http.NewRequest(http.MethodGet, "/countries/2", nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
 	mockUsecase.AssertExpectations(t)
 }
 
@@ -98,12 +113,12 @@ func TestUpdateCountry(t *testing.T) {
 
 	gin.SetMode(gin.TestMode)
 	r := gin.Default()
+	r.Use(apperror.Middleware())
 	r.PUT("/countries/:id", handler.UpdateCountry)
 
 	input := dto.CountryUpdateDTO{Name: "Updated Country"}
-	response := dto.CountryResponseDTO{Name: "Updated Country"}
 
-	mockUsecase.On("UpdateCountry", uint(1), input).Return(response, nil)
+	mockUsecase.On("UpdateCountry", uint(1), input).Return(nil)
 
 	body, _ := json.Marshal(input)
 	req, _ := http.NewRequest(http.MethodPut, "/countries/1", bytes.NewBuffer(body))
@@ -113,9 +128,7 @@ func TestUpdateCountry(t *testing.T) {
 	r.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusOK, w.Code)
-	var result dto.CountryResponseDTO
-	json.Unmarshal(w.Body.Bytes(), &result)
-	assert.Equal(t, response, result)
+	assert.JSONEq(t, `{"message": "Country updated successfully"}`, w.Body.String())
 
 	mockUsecase.AssertExpectations(t)
 }
@@ -126,6 +139,7 @@ func TestDeleteCountry(t *testing.T) {
 
 	gin.SetMode(gin.TestMode)
 	r := gin.Default()
+	r.Use(apperror.Middleware())
 	r.DELETE("/countries/:id", handler.DeleteCountry)
 
 	mockUsecase.On("DeleteCountry", uint(1)).Return(nil)
@@ -140,3 +154,28 @@ func TestDeleteCountry(t *testing.T) {
 
 	mockUsecase.AssertExpectations(t)
 }
+
+func TestRestoreCountry(t *testing.T) {
+	mockUsecase := new(MockCountryUsecase)
+	handler := NewCountryHandler(mockUsecase)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.Use(apperror.Middleware())
+	r.POST("/countries/:id/restore", handler.RestoreCountry)
+
+	mockUsecase.On("RestoreCountry", uint(1)).Return(nil)
+	mockUsecase.On("RestoreCountry", uint(2)).Return(domain.ErrCountryNotFound)
+
+	req, _ := http.NewRequest(http.MethodPost, "/countries/1/restore", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	req, _ = http.NewRequest(http.MethodPost, "/countries/2/restore", nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	mockUsecase.AssertExpectations(t)
+}
@@ -12,6 +12,7 @@ type CountryUsecaseInterface interface {
 	GetCountryByID(id uint) (*dto.CountryResponseDTO, error)
 	UpdateCountry(id uint, input dto.CountryUpdateDTO) error
 	DeleteCountry(id uint) error
+	RestoreCountry(id uint) error
 }
 
 // CountryUsecase handles the business logic for countries.
@@ -62,3 +63,8 @@ func (u *CountryUsecase) UpdateCountry(id uint, input dto.CountryUpdateDTO) erro
 func (u *CountryUsecase) DeleteCountry(id uint) error {
 	return u.CountryRepo.Delete(id)
 }
+
+// RestoreCountry restores a deleted country by its ID.
+func (u *CountryUsecase) RestoreCountry(id uint) error {
+	return u.CountryRepo.Restore(id)
+}
@@ -24,7 +24,10 @@ func (m *MockCountryRepository) Create(country *domain.Country) error {
 // GetByID is a mock method for getting a country by ID.
 func (m *MockCountryRepository) GetByID(id uint) (*domain.Country, error) {
 	args := m.Called(id)
-	return args.Get(0).(*domain.Country), args.Error(1)
+	if args.Get(0) != nil {
+		return args.Get(0).(*domain.Country), args.Error(1)
+	}
+	return nil, args.Error(1)
 }
 
 // Update is a mock method for updating a country.
@@ -39,6 +42,11 @@ func (m *MockCountryRepository) Delete(id uint) error {
 	return args.Error(0)
 }
 
+func (m *MockCountryRepository) Restore(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
 func TestCreateCountry(t *testing.T) {
 	mockRepo := new(MockCountryRepository)
 	usecase := NewCountryUsecase(mockRepo)
@@ -74,7 +82,7 @@ func TestGetCountryByID(t *testing.T) {
 	country, err := usecase.GetCountryByID(1)
 
 	assert.NoError(t, err)
-	assert.Equal(t, expectedCountry, country)
+	assert.Equal(t, &dto.CountryResponseDTO{Name: "TestCountry"}, country)
 	mockRepo.AssertExpectations(t)
 }
 
@@ -0,0 +1,124 @@
+// Package database opens the database selected by DB.DRIVER: MySQL,
+// PostgreSQL or SQLite. Repositories work with any of them through GORM;
+// the few queries that differ per dialect switch on Dialect.
+package database
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// Drivers, named after their GORM dialectors and migration directories.
+const (
+	DriverMySQL    = "mysql"
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+)
+
+// Config locates a database. With the sqlite driver, Name is the path of the
+// database file.
+type Config struct {
+	Driver   string
+	Host     string
+	Port     string
+	User     string
+	Password string
+	Name     string
+	SSLMode  string
+}
+
+// ConfigFromEnv reads DB.DRIVER, mysql by default, DB.HOST, DB.PORT,
+// DB.USER, DB.PASS, DB.NAME and, for PostgreSQL, DB.SSLMODE.
+func ConfigFromEnv() Config {
+	driver := strings.ToLower(os.Getenv("DB.DRIVER"))
+	if driver == "" {
+		driver = DriverMySQL
+	}
+	return Config{
+		Driver:   driver,
+		Host:     os.Getenv("DB.HOST"),
+		Port:     os.Getenv("DB.PORT"),
+		User:     os.Getenv("DB.USER"),
+		Password: os.Getenv("DB.PASS"),
+		Name:     os.Getenv("DB.NAME"),
+		SSLMode:  os.Getenv("DB.SSLMODE"),
+	}
+}
+
+// Dialector returns the GORM dialector of the driver. Times are stored in
+// UTC on every database.
+func (c Config) Dialector() (gorm.Dialector, error) {
+	switch c.Driver {
+	case DriverMySQL:
+		port := c.Port
+		if port == "" {
+			port = "3306"
+		}
+		dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=UTC", c.User, c.Password, c.Host, port, c.Name)
+		return mysql.Open(dsn), nil
+	case DriverPostgres:
+		dsn := url.URL{
+			Scheme:   "postgres",
+			User:     url.UserPassword(c.User, c.Password),
+			Host:     c.Host,
+			Path:     "/" + c.Name,
+			RawQuery: url.Values{"sslmode": {c.sslMode()}, "TimeZone": {"UTC"}}.Encode(),
+		}
+		if c.Port != "" {
+			dsn.Host += ":" + c.Port
+		}
+		return postgres.Open(dsn.String()), nil
+	case DriverSQLite:
+		if c.Name == "" {
+			return nil, fmt.Errorf("database: DB.NAME must be the path of the sqlite database")
+		}
+		// SQLite only enforces foreign keys when asked to.
+		return sqlite.Open("file:" + c.Name + "?_foreign_keys=on&_busy_timeout=5000"), nil
+	}
+	return nil, fmt.Errorf("database: unsupported driver %q, use %s, %s or %s", c.Driver, DriverMySQL, DriverPostgres, DriverSQLite)
+}
+
+func (c Config) sslMode() string {
+	if c.SSLMode == "" {
+		return "disable"
+	}
+	return c.SSLMode
+}
+
+// Open connects to the database of cfg.
+func Open(cfg Config) (*gorm.DB, error) {
+	dialector, err := cfg.Dialector()
+	if err != nil {
+		return nil, err
+	}
+	db, err := gorm.Open(dialector, &gorm.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("database: open %s: %w", cfg.Driver, err)
+	}
+	if cfg.Driver == DriverSQLite {
+		// SQLite allows a single writer at a time.
+		sqlDB, err := db.DB()
+		if err != nil {
+			return nil, err
+		}
+		sqlDB.SetMaxOpenConns(1)
+	}
+	return db, nil
+}
+
+// OpenFromEnv connects to the database configured by ConfigFromEnv.
+func OpenFromEnv() (*gorm.DB, error) {
+	return Open(ConfigFromEnv())
+}
+
+// Dialect returns the driver db was opened with.
+func Dialect(db *gorm.DB) string {
+	return db.Dialector.Name()
+}
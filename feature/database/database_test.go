@@ -0,0 +1,73 @@
+package database
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+)
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Setenv("DB.DRIVER", "")
+	t.Setenv("DB.HOST", "db")
+	t.Setenv("DB.PORT", "")
+	t.Setenv("DB.USER", "app")
+	t.Setenv("DB.PASS", "secret")
+	t.Setenv("DB.NAME", "volunteers")
+	t.Setenv("DB.SSLMODE", "")
+	assert.Equal(t, Config{Driver: DriverMySQL, Host: "db", User: "app", Password: "secret", Name: "volunteers"}, ConfigFromEnv())
+
+	t.Setenv("DB.DRIVER", "Postgres")
+	assert.Equal(t, DriverPostgres, ConfigFromEnv().Driver)
+}
+
+func TestDialector(t *testing.T) {
+	cfg := Config{Host: "db", User: "app", Password: "p@ss word", Name: "volunteers"}
+
+	t.Run("mysql", func(t *testing.T) {
+		cfg := cfg
+		cfg.Driver = DriverMySQL
+		dialector, err := cfg.Dialector()
+		require.NoError(t, err)
+		assert.Equal(t, "app:p@ss word@tcp(db:3306)/volunteers?charset=utf8mb4&parseTime=True&loc=UTC", dialector.(*mysql.Dialector).DSN)
+	})
+
+	t.Run("postgres", func(t *testing.T) {
+		cfg := cfg
+		cfg.Driver = DriverPostgres
+		cfg.Port = "5433"
+		dialector, err := cfg.Dialector()
+		require.NoError(t, err)
+		assert.Equal(t, "postgres://app:p%40ss%20word@db:5433/volunteers?TimeZone=UTC&sslmode=disable", dialector.(*postgres.Dialector).DSN)
+
+		cfg.SSLMode = "require"
+		dialector, err = cfg.Dialector()
+		require.NoError(t, err)
+		assert.Contains(t, dialector.(*postgres.Dialector).DSN, "sslmode=require")
+	})
+
+	t.Run("sqlite", func(t *testing.T) {
+		_, err := Config{Driver: DriverSQLite}.Dialector()
+		assert.Error(t, err)
+
+		db, err := Open(Config{Driver: DriverSQLite, Name: filepath.Join(t.TempDir(), "app.db")})
+		if err != nil {
+			t.Skipf("sqlite is not available: %v", err)
+		}
+		sqlDB, err := db.DB()
+		require.NoError(t, err)
+		t.Cleanup(func() { sqlDB.Close() })
+		assert.Equal(t, DriverSQLite, Dialect(db))
+		var foreignKeys int
+		require.NoError(t, db.Raw("PRAGMA foreign_keys").Scan(&foreignKeys).Error)
+		assert.Equal(t, 1, foreignKeys)
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := Config{Driver: "oracle"}.Dialector()
+		assert.ErrorContains(t, err, "unsupported driver")
+	})
+}
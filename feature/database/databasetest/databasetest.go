@@ -0,0 +1,51 @@
+// Package databasetest opens in-memory SQLite databases holding the schema of
+// the migrations, to test repositories against a real database next to their
+// sqlmock tests.
+package databasetest
+
+import (
+	"testing"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/migration"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Open returns an empty database on which every migration is applied. It is
+// closed when the test ends. SQLite needs cgo, so the test is skipped when it
+// is disabled.
+func Open(t *testing.T) *gorm.DB {
+	t.Helper()
+	// Each connection to :memory: opens a distinct database, hence a single
+	// connection.
+	db, err := gorm.Open(sqlite.Open("file::memory:?_foreign_keys=on"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Skipf("sqlite is not available: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	migrator, err := migration.New(db, migration.FS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := migrator.Up(0); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+// Exec runs statements that seed the database.
+func Exec(t *testing.T, db *gorm.DB, statements ...string) {
+	t.Helper()
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			t.Fatalf("%s: %v", statement, err)
+		}
+	}
+}
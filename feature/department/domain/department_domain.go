@@ -2,14 +2,21 @@ package domain
 
 import (
 	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+	"gorm.io/gorm"
 )
 
+// ErrDepartmentNotFound is returned when a department is missing or, when restoring, not deleted.
+var ErrDepartmentNotFound = apperror.New(apperror.NotFound, "department not found")
+
 // Department struct that interacts with databases (GORM)
 type Department struct {
-	Id        uint      `gorm:"primaryKey" json:"id"`
-	Name      string    `gorm:"size:255;not null;unique" json:"name"`
-	Address   string    `json:"location"`
-	Status    uint      `gorm:"not null" json:"status"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	Id        uint           `gorm:"primaryKey" json:"id"`
+	Name      string         `gorm:"size:255;not null;unique" json:"name"`
+	Address   string         `json:"location"`
+	Status    uint           `gorm:"not null" json:"status"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at" swaggertype:"string"`
 }
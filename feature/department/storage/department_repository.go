@@ -1,6 +1,8 @@
 package storage
 
 import (
+	"errors"
+
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/department/domain"
 	"gorm.io/gorm"
 )
@@ -11,6 +13,7 @@ type DepartmentRepositoryInterface interface {
 	GetByID(id uint) (*domain.Department, error)
 	Update(department *domain.Department) error
 	Delete(id uint) error
+	Restore(id uint) error
 }
 
 // DepartmentRepository handles the CRUD operations with the database.
@@ -32,6 +35,9 @@ func (r *DepartmentRepository) Create(department *domain.Department) error {
 func (r *DepartmentRepository) GetByID(id uint) (*domain.Department, error) {
 	var department domain.Department
 	err := r.DB.First(&department, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrDepartmentNotFound
+	}
 	return &department, err
 }
 
@@ -44,3 +50,17 @@ func (r *DepartmentRepository) Update(department *domain.Department) error {
 func (r *DepartmentRepository) Delete(id uint) error {
 	return r.DB.Delete(&domain.Department{}, id).Error
 }
+
+// Restore clears deleted_at of a deleted department record.
+func (r *DepartmentRepository) Restore(id uint) error {
+	result := r.DB.Unscoped().Model(&domain.Department{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrDepartmentNotFound
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
@@ -8,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
 )
 
 func setupMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
@@ -20,7 +22,7 @@ func setupMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
 		Conn:                      db,
 		SkipInitializeWithVersion: true,
 	})
-	gormDB, err := gorm.Open(dialector, &gorm.Config{})
+	gormDB, err := gorm.Open(dialector, &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
 	assert.NoError(t, err)
 
 	return gormDB, mock
@@ -38,11 +40,12 @@ func TestCreateDepartment(t *testing.T) {
 	}
 
 	mock.ExpectBegin()
-	mock.ExpectExec("INSERT INTO `departments`").WithArgs(department.Name, department.Address, department.Status).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO `departments`").WithArgs(department.Name, department.Address, department.Status, sqlmock.AnyArg(), sqlmock.AnyArg(), nil).WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
 	err := repo.Create(department)
 	assert.NoError(t, err)
+	assert.Equal(t, uint(1), department.Id)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -52,6 +55,7 @@ func TestGetDepartmentByID(t *testing.T) {
 	repo := NewDepartmentRepository(gormDB)
 
 	department := &domain.Department{
+		Id:      1,
 		Name:    "Finance",
 		Address: "456 Finance Street",
 		Status:  456,
@@ -60,7 +64,7 @@ func TestGetDepartmentByID(t *testing.T) {
 	rows := sqlmock.NewRows([]string{"id", "name", "address", "status"}).
 		AddRow(department.Id, department.Name, department.Address, department.Status)
 
-	mock.ExpectQuery("SELECT * FROM `departments` WHERE `departments`.`id` = ?").WithArgs(department.Id).WillReturnRows(rows)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `departments` WHERE `departments`.`id` = ? AND `departments`.`deleted_at` IS NULL")).WithArgs(department.Id, 1).WillReturnRows(rows)
 
 	result, err := repo.GetByID(department.Id)
 	assert.NoError(t, err)
@@ -68,6 +72,11 @@ func TestGetDepartmentByID(t *testing.T) {
 	assert.Equal(t, department.Name, result.Name)
 	assert.Equal(t, department.Address, result.Address)
 	assert.Equal(t, department.Status, result.Status)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `departments`")).WithArgs(uint(2), 1).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	_, err = repo.GetByID(2)
+	assert.ErrorIs(t, err, domain.ErrDepartmentNotFound)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -77,13 +86,14 @@ func TestUpdateDepartment(t *testing.T) {
 	repo := NewDepartmentRepository(gormDB)
 
 	department := &domain.Department{
+		Id:      1,
 		Name:    "IT",
 		Address: "789 IT Street",
 		Status:  789,
 	}
 
 	mock.ExpectBegin()
-	mock.ExpectExec("UPDATE `departments` SET `name`=?,`address`=?,`status`=? WHERE `id` = ?").WithArgs(department.Name, department.Address, department.Status, department.Id).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `departments` SET `name`=?,`address`=?,`status`=?,`created_at`=?,`updated_at`=?,`deleted_at`=? WHERE `departments`.`deleted_at` IS NULL AND `id` = ?")).WithArgs(department.Name, department.Address, department.Status, sqlmock.AnyArg(), sqlmock.AnyArg(), nil, department.Id).WillReturnResult(sqlmock.NewResult(0, 1))
 	mock.ExpectCommit()
 
 	err := repo.Update(department)
@@ -99,10 +109,28 @@ func TestDeleteDepartment(t *testing.T) {
 	departmentID := uint(1)
 
 	mock.ExpectBegin()
-	mock.ExpectExec("DELETE FROM `departments` WHERE `departments`.`id` = ?").WithArgs(departmentID).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `departments` SET `deleted_at`=? WHERE `departments`.`id` = ? AND `departments`.`deleted_at` IS NULL")).WithArgs(sqlmock.AnyArg(), departmentID).WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
 	err := repo.Delete(departmentID)
 	assert.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestRestoreDepartment(t *testing.T) {
+	gormDB, mock := setupMockDB(t)
+
+	repo := NewDepartmentRepository(gormDB)
+
+	restore := regexp.QuoteMeta("UPDATE `departments` SET `deleted_at`=?,`updated_at`=? WHERE id = ? AND deleted_at IS NOT NULL")
+	mock.ExpectBegin()
+	mock.ExpectExec(restore).WithArgs(nil, sqlmock.AnyArg(), 1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec(restore).WithArgs(nil, sqlmock.AnyArg(), 2).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	assert.NoError(t, repo.Restore(1))
+	assert.ErrorIs(t, repo.Restore(2), domain.ErrDepartmentNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -4,8 +4,10 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/department/dto"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/department/usecase"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/validation"
 	"github.com/gin-gonic/gin"
 )
 
@@ -26,19 +28,20 @@ func NewDepartmentHandler(usecase usecase.DepartmentUsecaseInterface) *Departmen
 // @Accept json
 // @Produce json
 // @Tags department
+// @Security bearerToken
 // @Param department body dto.DepartmentCreateDTO true "Department data"
 // @Success 201 {object} domain.Department
 // @Router /api/v1/departments [post]
 func (h *DepartmentHandler) CreateDepartment(c *gin.Context) {
 	var input dto.DepartmentCreateDTO
 	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apperror.Abort(c, validation.Error(err))
 		return
 	}
 
 	err := h.usecase.CreateDepartment(input)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apperror.Abort(c, err)
 		return
 	}
 
@@ -57,13 +60,13 @@ func (h *DepartmentHandler) CreateDepartment(c *gin.Context) {
 func (h *DepartmentHandler) GetDepartmentByID(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid department ID"})
+		apperror.Abort(c, apperror.New(apperror.Validation, "Invalid department ID"))
 		return
 	}
 
 	department, err := h.usecase.GetDepartmentByID(uint(id))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Department not found"})
+		apperror.Abort(c, err)
 		return
 	}
 
@@ -77,6 +80,7 @@ func (h *DepartmentHandler) GetDepartmentByID(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Tags department
+// @Security bearerToken
 // @Param id path int true "Department ID"
 // @Param department body dto.DepartmentUpdateDTO true "Department data"
 // @Success 200 {object} domain.Department
@@ -84,18 +88,18 @@ func (h *DepartmentHandler) GetDepartmentByID(c *gin.Context) {
 func (h *DepartmentHandler) UpdateDepartment(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid department ID"})
+		apperror.Abort(c, apperror.New(apperror.Validation, "Invalid department ID"))
 		return
 	}
 
 	var input dto.DepartmentUpdateDTO
 	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apperror.Abort(c, validation.Error(err))
 		return
 	}
 
 	if err := h.usecase.UpdateDepartment(uint(id), input); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apperror.Abort(c, err)
 		return
 	}
 
@@ -108,21 +112,49 @@ func (h *DepartmentHandler) UpdateDepartment(c *gin.Context) {
 // @Description Delete department
 // @Produce json
 // @Tags department
+// @Security bearerToken
 // @Param id path int true "Department ID"
 // @Success 204
 // @Router /api/v1/departments/{id} [delete]
 func (h *DepartmentHandler) DeleteDepartment(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid department ID"})
+		apperror.Abort(c, apperror.New(apperror.Validation, "Invalid department ID"))
 		return
 	}
 
 	err = h.usecase.DeleteDepartment(uint(id))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apperror.Abort(c, err)
 		return
 	}
 
 	c.JSON(http.StatusNoContent, nil)
 }
+
+// RestoreDepartment handles the HTTP POST request to restore a deleted department.
+// RestoreDepartment godoc
+// @Summary Restore department
+// @Description Restore a deleted department
+// @Produce json
+// @Tags department
+// @Security bearerToken
+// @Param id path int true "Department ID"
+// @Success 200 {string} message "Department restored successfully"
+// @Failure 404 {object} apperror.Response "No deleted department with this ID"
+// @Router /api/v1/department/{id}/restore [post]
+func (h *DepartmentHandler) RestoreDepartment(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apperror.Abort(c, apperror.New(apperror.Validation, "Invalid department ID"))
+		return
+	}
+
+	err = h.usecase.RestoreDepartment(uint(id))
+	if err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "department restored successfully"})
+}
@@ -8,6 +8,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/department/domain"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/department/dto"
 	"github.com/gin-gonic/gin"
@@ -27,7 +28,10 @@ func (m *MockDepartmentUsecase) CreateDepartment(input dto.DepartmentCreateDTO)
 
 func (m *MockDepartmentUsecase) GetDepartmentByID(id uint) (*domain.Department, error) {
 	args := m.Called(id)
-	return args.Get(0).(*domain.Department), args.Error(1)
+	if args.Get(0) != nil {
+		return args.Get(0).(*domain.Department), args.Error(1)
+	}
+	return nil, args.Error(1)
 }
 
 func (m *MockDepartmentUsecase) UpdateDepartment(id uint, input dto.DepartmentUpdateDTO) error {
@@ -40,12 +44,18 @@ func (m *MockDepartmentUsecase) DeleteDepartment(id uint) error {
 	return args.Error(0)
 }
 
+func (m *MockDepartmentUsecase) RestoreDepartment(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
 func TestCreateDepartment(t *testing.T) {
 	mockUsecase := new(MockDepartmentUsecase)
 	handler := NewDepartmentHandler(mockUsecase)
 
 	gin.SetMode(gin.TestMode)
 	r := gin.Default()
+	r.Use(apperror.Middleware())
 	r.POST("/api/v1/departments", handler.CreateDepartment)
 
 	input := dto.DepartmentCreateDTO{
@@ -53,13 +63,8 @@ func TestCreateDepartment(t *testing.T) {
 		Address: "123 HR Street",
 		Status:  123,
 	}
-	response := dto.DepartmentCreateDTO{
-		Name:    "HR",
-		Address: "123 HR Street",
-		Status:  123,
-	}
 
-	mockUsecase.On("CreateDepartment", input).Return(response, nil)
+	mockUsecase.On("CreateDepartment", input).Return(nil)
 
 	body, _ := json.Marshal(input)
 	req, _ := http.NewRequest("POST", "/api/v1/departments", bytes.NewBuffer(body))
@@ -69,10 +74,7 @@ func TestCreateDepartment(t *testing.T) {
 	r.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusCreated, w.Code)
-	var result dto.DepartmentResponseDTO
-	json.Unmarshal(w.Body.Bytes(), &result)
-	assert.Equal(t, response, result)
-	assert.JSONEq(t, `{"Name":"HR","Address":"123 HR Street","Status":123}`, w.Body.String())
+	assert.JSONEq(t, `{"message":"department created successfully"}`, w.Body.String())
 
 	mockUsecase.AssertExpectations(t)
 }
@@ -83,21 +85,29 @@ func TestGetDepartmentByID(t *testing.T) {
 
 	gin.SetMode(gin.TestMode)
 	r := gin.Default()
+	r.Use(apperror.Middleware())
 	r.GET("/api/v1/departments/:id", handler.GetDepartmentByID)
 
-	response := dto.DepartmentResponseDTO{Name: "Test Department"}
+	department := &domain.Department{Id: 1, Name: "Finance", Address: "456 Finance Street", Status: 1}
 
-	mockUsecase.On("GetDepartmentByID", uint(1)).Return(response, nil)
+	mockUsecase.On("GetDepartmentByID", uint(1)).Return(department, nil)
+	mockUsecase.On("GetDepartmentByID", uint(2)).Return(nil, domain.ErrDepartmentNotFound)
 
 	req, _ := http.NewRequest("GET", "/api/v1/departments/1", nil)
 	w := httptest.NewRecorder()
 	r.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusOK, w.Code)
-	var result dto.DepartmentResponseDTO
+	var result domain.Department
 	json.Unmarshal(w.Body.Bytes(), &result)
-	assert.Equal(t, response, result)
-	assert.JSONEq(t, `{"ID":1,"Name":"Finance","Address":"456 Finance Street","Status":456}`, w.Body.String())
+	assert.Equal(t, department.Id, result.Id)
+	assert.Equal(t, department.Name, result.Name)
+	assert.Equal(t, department.Address, result.Address)
+
+	req, _ = http.NewRequest("GET", "/api/v1/departments/2", nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
 
 	mockUsecase.AssertExpectations(t)
 }
@@ -108,6 +118,7 @@ func TestUpdateDepartment(t *testing.T) {
 
 	gin.SetMode(gin.TestMode)
 	r := gin.Default()
+	r.Use(apperror.Middleware())
 	r.PUT("/api/v1/departments/:id", handler.UpdateDepartment)
 
 	input := dto.DepartmentUpdateDTO{
@@ -115,7 +126,7 @@ func TestUpdateDepartment(t *testing.T) {
 		Address: "789 IT Street Updated",
 		Status:  789,
 	}
-	mockUsecase.On("UpdateDepartment", uint(1), input).Return(nil, nil)
+	mockUsecase.On("UpdateDepartment", uint(1), input).Return(nil)
 
 	body, _ := json.Marshal(input)
 	req, _ := http.NewRequest("PUT", "/api/v1/departments/1", bytes.NewBuffer(body))
@@ -125,10 +136,7 @@ func TestUpdateDepartment(t *testing.T) {
 	r.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusOK, w.Code)
-	var result dto.DepartmentResponseDTO
-	json.Unmarshal(w.Body.Bytes(), &result)
-	assert.Equal(t, nil, result)
-	assert.JSONEq(t, `{"ID":1,"Name":"IT Updated","Address":"789 IT Street Updated","Status":"Inactive"}`, w.Body.String())
+	assert.JSONEq(t, `{"message":"department updated successfully"}`, w.Body.String())
 
 	mockUsecase.AssertExpectations(t)
 }
@@ -139,6 +147,7 @@ func TestDeleteDepartment(t *testing.T) {
 
 	gin.SetMode(gin.TestMode)
 	r := gin.Default()
+	r.Use(apperror.Middleware())
 	r.DELETE("/api/v1/departments/:id", handler.DeleteDepartment)
 
 	mockUsecase.On("DeleteDepartment", uint(1)).Return(nil)
@@ -12,6 +12,7 @@ type DepartmentUsecaseInterface interface {
 	GetDepartmentByID(id uint) (*domain.Department, error)
 	UpdateDepartment(id uint, input dto.DepartmentUpdateDTO) error
 	DeleteDepartment(id uint) error
+	RestoreDepartment(id uint) error
 }
 
 // DepartmentUsecase handles the business logic for departments.
@@ -56,3 +57,8 @@ func (u *DepartmentUsecase) UpdateDepartment(id uint, input dto.DepartmentUpdate
 func (u *DepartmentUsecase) DeleteDepartment(id uint) error {
 	return u.repo.Delete(id)
 }
+
+// RestoreDepartment restores a deleted department by its ID.
+func (u *DepartmentUsecase) RestoreDepartment(id uint) error {
+	return u.repo.Restore(id)
+}
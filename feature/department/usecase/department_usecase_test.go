@@ -39,6 +39,11 @@ func (m *MockDepartmentRepository) Delete(id uint) error {
 	return args.Error(0)
 }
 
+func (m *MockDepartmentRepository) Restore(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
 func TestCreateDepartment(t *testing.T) {
 	mockRepo := new(MockDepartmentRepository)
 	usecase := NewDepartmentUsecase(mockRepo)
@@ -0,0 +1,198 @@
+// Package encryption encrypts sensitive columns at rest.
+//
+// Values are sealed with envelope encryption: each value gets its own random
+// data key, which encrypts the value with AES-256-GCM and is itself
+// encrypted with a key encryption key from the Keyring. A stored value reads
+//
+//	enc:v1:<key id>:<encrypted data key>:<encrypted value>
+//
+// so that keys can be rotated: new values are sealed with the current key
+// while values sealed with older keys still open as long as those keys stay
+// in the keyring.
+//
+// Encrypted values cannot be searched, so columns that must be looked up by
+// value carry a blind index next to them: an HMAC of the value under a key
+// of its own.
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	prefix  = "enc:v1:"
+	keySize = 32
+)
+
+var (
+	ErrNoKeyring   = errors.New("encryption: no keyring configured")
+	ErrUnknownKey  = errors.New("encryption: value sealed with an unknown key")
+	ErrInvalidData = errors.New("encryption: invalid sealed value")
+)
+
+// Keyring holds the key encryption keys by id, the id of the key that
+// seals new values, and the key of blind indexes.
+type Keyring struct {
+	current    string
+	keys       map[string][]byte
+	blindIndex []byte
+}
+
+// NewKeyring builds a keyring from 32-byte keys. current must be one of
+// keys. The blind index key must never change, or the indexes computed
+// with it no longer match.
+func NewKeyring(current string, keys map[string][]byte, blindIndexKey []byte) (*Keyring, error) {
+	if _, ok := keys[current]; !ok {
+		return nil, fmt.Errorf("encryption: current key %q is not in the keyring", current)
+	}
+	for id, key := range keys {
+		if id == "" || strings.Contains(id, ":") {
+			return nil, fmt.Errorf("encryption: invalid key id %q", id)
+		}
+		if len(key) != keySize {
+			return nil, fmt.Errorf("encryption: key %q must be %d bytes long", id, keySize)
+		}
+	}
+	if len(blindIndexKey) < keySize {
+		return nil, fmt.Errorf("encryption: blind index key must be at least %d bytes long", keySize)
+	}
+	return &Keyring{current: current, keys: keys, blindIndex: blindIndexKey}, nil
+}
+
+// NewKeyringFromEnv reads ENCRYPTION_KEYS, a comma separated list of
+// id:base64 keys whose first key is the current one, and
+// BLIND_INDEX_KEY, a base64 key.
+func NewKeyringFromEnv() (*Keyring, error) {
+	var current string
+	keys := map[string][]byte{}
+	for _, entry := range strings.Split(os.Getenv("ENCRYPTION_KEYS"), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		id, encoded, found := strings.Cut(entry, ":")
+		if !found {
+			return nil, fmt.Errorf("encryption: ENCRYPTION_KEYS entry %q is not id:base64", id)
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("encryption: key %q: %w", id, err)
+		}
+		if current == "" {
+			current = id
+		}
+		keys[id] = key
+	}
+	if current == "" {
+		return nil, errors.New("encryption: ENCRYPTION_KEYS is not set")
+	}
+	blindIndexKey, err := base64.StdEncoding.DecodeString(os.Getenv("BLIND_INDEX_KEY"))
+	if err != nil {
+		return nil, fmt.Errorf("encryption: BLIND_INDEX_KEY: %w", err)
+	}
+	return NewKeyring(current, keys, blindIndexKey)
+}
+
+// Seal encrypts plaintext with a fresh data key. aad binds the sealed value
+// to its context, such as its column, so that it cannot be moved elsewhere.
+func (k *Keyring) Seal(plaintext []byte, aad []byte) (string, error) {
+	dataKey := make([]byte, keySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return "", err
+	}
+	wrappedKey, err := seal(k.keys[k.current], dataKey, []byte(k.current))
+	if err != nil {
+		return "", err
+	}
+	ciphertext, err := seal(dataKey, plaintext, aad)
+	if err != nil {
+		return "", err
+	}
+	return prefix + k.current + ":" +
+		base64.RawStdEncoding.EncodeToString(wrappedKey) + ":" +
+		base64.RawStdEncoding.EncodeToString(ciphertext), nil
+}
+
+// Open decrypts a value sealed by Seal with the same aad.
+func (k *Keyring) Open(sealed string, aad []byte) ([]byte, error) {
+	parts := strings.Split(strings.TrimPrefix(sealed, prefix), ":")
+	if !IsSealed(sealed) || len(parts) != 3 {
+		return nil, ErrInvalidData
+	}
+	kek, ok := k.keys[parts[0]]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownKey, parts[0])
+	}
+	wrappedKey, err := base64.RawStdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrInvalidData
+	}
+	ciphertext, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrInvalidData
+	}
+	dataKey, err := open(kek, wrappedKey, []byte(parts[0]))
+	if err != nil {
+		return nil, err
+	}
+	return open(dataKey, ciphertext, aad)
+}
+
+// BlindIndex returns the blind index of value in the given domain, such as
+// a column name, so that equal values of different columns do not match.
+func (k *Keyring) BlindIndex(domain string, value string) string {
+	mac := hmac.New(sha256.New, k.blindIndex)
+	mac.Write([]byte(domain + "\x00" + value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// IsSealed reports whether value was sealed by a Keyring, as opposed to a
+// plaintext value stored before its column was encrypted.
+func IsSealed(value string) bool {
+	return strings.HasPrefix(value, prefix)
+}
+
+func seal(key []byte, plaintext []byte, aad []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, aad), nil
+}
+
+func open(key []byte, sealed []byte, aad []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return nil, ErrInvalidData
+	}
+	plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], aad)
+	if err != nil {
+		return nil, ErrInvalidData
+	}
+	return plaintext, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,155 @@
+package encryption
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm/schema"
+)
+
+type record struct {
+	ID     int
+	Number string `gorm:"serializer:encrypted"`
+}
+
+func newKeyring(t *testing.T, current string, ids ...string) *Keyring {
+	keys := map[string][]byte{}
+	for _, id := range ids {
+		keys[id] = bytes.Repeat([]byte(id[:1]), keySize)
+	}
+	k, err := NewKeyring(current, keys, bytes.Repeat([]byte("i"), keySize))
+	assert.NoError(t, err)
+	return k
+}
+
+func TestSealOpen(t *testing.T) {
+	k := newKeyring(t, "k1", "k1")
+
+	sealed, err := k.Seal([]byte("B1234567"), []byte("user_identities.number"))
+	assert.NoError(t, err)
+	assert.True(t, IsSealed(sealed))
+	assert.True(t, strings.HasPrefix(sealed, "enc:v1:k1:"))
+	assert.NotContains(t, sealed, "B1234567")
+
+	again, err := k.Seal([]byte("B1234567"), []byte("user_identities.number"))
+	assert.NoError(t, err)
+	assert.NotEqual(t, sealed, again, "each value gets its own data key and nonce")
+
+	plaintext, err := k.Open(sealed, []byte("user_identities.number"))
+	assert.NoError(t, err)
+	assert.Equal(t, "B1234567", string(plaintext))
+
+	_, err = k.Open(sealed, []byte("users.email"))
+	assert.ErrorIs(t, err, ErrInvalidData)
+
+	tampered := sealed[:len(sealed)-2] + "AA"
+	if tampered == sealed {
+		tampered = sealed[:len(sealed)-2] + "BB"
+	}
+	_, err = k.Open(tampered, []byte("user_identities.number"))
+	assert.ErrorIs(t, err, ErrInvalidData)
+
+	_, err = k.Open("enc:v1:k1:garbage", []byte("user_identities.number"))
+	assert.ErrorIs(t, err, ErrInvalidData)
+}
+
+func TestRotation(t *testing.T) {
+	old := newKeyring(t, "k1", "k1")
+	sealed, err := old.Seal([]byte("B1234567"), nil)
+	assert.NoError(t, err)
+
+	rotated := newKeyring(t, "k2", "k1", "k2")
+	plaintext, err := rotated.Open(sealed, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "B1234567", string(plaintext))
+
+	resealed, err := rotated.Seal(plaintext, nil)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(resealed, "enc:v1:k2:"))
+
+	retired := newKeyring(t, "k2", "k2")
+	_, err = retired.Open(sealed, nil)
+	assert.ErrorIs(t, err, ErrUnknownKey)
+	_, err = retired.Open(resealed, nil)
+	assert.NoError(t, err)
+}
+
+func TestBlindIndex(t *testing.T) {
+	k := newKeyring(t, "k1", "k1")
+	rotated := newKeyring(t, "k2", "k1", "k2")
+
+	index := k.BlindIndex("user_identities.number", "B1234567")
+	assert.Len(t, index, 64)
+	assert.Equal(t, index, rotated.BlindIndex("user_identities.number", "B1234567"), "rotating keys keeps the index")
+	assert.NotEqual(t, index, k.BlindIndex("user_identities.number", "B1234568"))
+	assert.NotEqual(t, index, k.BlindIndex("users.email", "B1234567"))
+}
+
+func TestNewKeyring(t *testing.T) {
+	key := bytes.Repeat([]byte("k"), keySize)
+	indexKey := bytes.Repeat([]byte("i"), keySize)
+
+	_, err := NewKeyring("k2", map[string][]byte{"k1": key}, indexKey)
+	assert.Error(t, err)
+	_, err = NewKeyring("k1", map[string][]byte{"k1": key[:16]}, indexKey)
+	assert.Error(t, err)
+	_, err = NewKeyring("k:1", map[string][]byte{"k:1": key}, indexKey)
+	assert.Error(t, err)
+	_, err = NewKeyring("k1", map[string][]byte{"k1": key}, indexKey[:16])
+	assert.Error(t, err)
+}
+
+func TestNewKeyringFromEnv(t *testing.T) {
+	encode := func(b byte) string { return base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{b}, keySize)) }
+	t.Setenv("ENCRYPTION_KEYS", "2024:"+encode('b')+", 2023:"+encode('a'))
+	t.Setenv("BLIND_INDEX_KEY", encode('i'))
+
+	k, err := NewKeyringFromEnv()
+	assert.NoError(t, err)
+	assert.Equal(t, "2024", k.current)
+	assert.Len(t, k.keys, 2)
+
+	t.Setenv("ENCRYPTION_KEYS", "")
+	_, err = NewKeyringFromEnv()
+	assert.Error(t, err)
+
+	t.Setenv("ENCRYPTION_KEYS", encode('a'))
+	_, err = NewKeyringFromEnv()
+	assert.Error(t, err)
+}
+
+func TestSerializer(t *testing.T) {
+	s, err := schema.Parse(&record{}, &sync.Map{}, schema.NamingStrategy{})
+	assert.NoError(t, err)
+	field := s.LookUpField("Number")
+	ctx := context.Background()
+
+	Use(nil)
+	_, err = Serializer{}.Value(ctx, field, reflect.ValueOf(&record{}), "B1234567")
+	assert.ErrorIs(t, err, ErrNoKeyring)
+
+	Use(newKeyring(t, "k1", "k1"))
+	defer Use(nil)
+
+	stored, err := Serializer{}.Value(ctx, field, reflect.ValueOf(&record{}), "B1234567")
+	assert.NoError(t, err)
+	assert.True(t, IsSealed(stored.(string)))
+
+	var read record
+	assert.NoError(t, Serializer{}.Scan(ctx, field, reflect.ValueOf(&read), []byte(stored.(string))))
+	assert.Equal(t, "B1234567", read.Number)
+
+	var legacy record
+	assert.NoError(t, Serializer{}.Scan(ctx, field, reflect.ValueOf(&legacy), []byte("C7654321")))
+	assert.Equal(t, "C7654321", legacy.Number, "plaintext stored before encryption is read as is")
+
+	index, err := BlindIndex("user_identities.number", "B1234567")
+	assert.NoError(t, err)
+	assert.Len(t, index, 64)
+}
@@ -0,0 +1,86 @@
+package encryption
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+
+	"gorm.io/gorm/schema"
+)
+
+// SerializerName is the GORM serializer of encrypted string columns:
+//
+//	Number string `gorm:"serializer:encrypted"`
+const SerializerName = "encrypted"
+
+var keyring atomic.Pointer[Keyring]
+
+func init() {
+	schema.RegisterSerializer(SerializerName, Serializer{})
+}
+
+// Use sets the keyring of the serializer and of BlindIndex.
+func Use(k *Keyring) {
+	keyring.Store(k)
+}
+
+// BlindIndex returns the blind index of value with the keyring set by Use.
+func BlindIndex(domain string, value string) (string, error) {
+	k := keyring.Load()
+	if k == nil {
+		return "", ErrNoKeyring
+	}
+	return k.BlindIndex(domain, value), nil
+}
+
+// Serializer seals string fields on write and opens them on read. Values
+// stored before the column was encrypted are read as they are, and sealed
+// the next time the row is saved.
+type Serializer struct{}
+
+func (Serializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue interface{}) error {
+	var stored string
+	switch v := dbValue.(type) {
+	case nil:
+		return nil
+	case []byte:
+		stored = string(v)
+	case string:
+		stored = v
+	default:
+		return fmt.Errorf("encryption: cannot read %T from %s", dbValue, field.DBName)
+	}
+
+	value := stored
+	if IsSealed(stored) {
+		k := keyring.Load()
+		if k == nil {
+			return ErrNoKeyring
+		}
+		plaintext, err := k.Open(stored, aad(field))
+		if err != nil {
+			return fmt.Errorf("%s: %w", field.DBName, err)
+		}
+		value = string(plaintext)
+	}
+	field.ReflectValueOf(ctx, dst).SetString(value)
+	return nil
+}
+
+func (Serializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue interface{}) (interface{}, error) {
+	value, ok := fieldValue.(string)
+	if !ok {
+		return nil, fmt.Errorf("encryption: %s must be a string", field.DBName)
+	}
+	k := keyring.Load()
+	if k == nil {
+		return nil, ErrNoKeyring
+	}
+	return k.Seal([]byte(value), aad(field))
+}
+
+// aad binds sealed values to their column.
+func aad(field *schema.Field) []byte {
+	return []byte(field.Schema.Table + "." + field.DBName)
+}
@@ -0,0 +1,41 @@
+package mailer
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	DriverSMTP = "smtp"
+	DriverFile = "file"
+)
+
+// Message is a plain text email.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer delivers outgoing emails.
+type Mailer interface {
+	Send(msg Message) error
+}
+
+// NewFromEnv builds a Mailer from the MAIL_DRIVER environment variable.
+// "smtp" sends through SMTP_HOST; anything else writes messages to MAIL_DIR,
+// or to the log when MAIL_DIR is empty.
+func NewFromEnv() Mailer {
+	if strings.EqualFold(os.Getenv("MAIL_DRIVER"), DriverSMTP) {
+		port, _ := strconv.Atoi(os.Getenv("SMTP_PORT"))
+		return NewSMTPMailer(SMTPConfig{
+			Host:     os.Getenv("SMTP_HOST"),
+			Port:     port,
+			Username: os.Getenv("SMTP_USERNAME"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+			From:     os.Getenv("MAIL_FROM"),
+		})
+	}
+	return NewFileMailer(os.Getenv("MAIL_DIR"))
+}
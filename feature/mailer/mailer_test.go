@@ -0,0 +1,86 @@
+package mailer
+
+import (
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildMessage(t *testing.T) {
+	date := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	raw := string(buildMessage("noreply@example.com", Message{To: "jane@example.com", Subject: "Xác nhận", Body: "hello"}, date))
+
+	assert.Contains(t, raw, "From: noreply@example.com\r\n")
+	assert.Contains(t, raw, "To: jane@example.com\r\n")
+	assert.Contains(t, raw, "Subject: =?utf-8?q?X=C3=A1c_nh=E1=BA=ADn?=\r\n")
+	assert.Contains(t, raw, "Date: Wed, 01 May 2024 10:00:00 +0000\r\n")
+	assert.True(t, strings.HasSuffix(raw, "\r\n\r\nhello"))
+}
+
+func TestSMTPMailerSend(t *testing.T) {
+	mailer := NewSMTPMailer(SMTPConfig{Host: "smtp.example.com", Username: "user", Password: "secret", From: "noreply@example.com"})
+	var gotAddr, gotFrom string
+	var gotTo []string
+	var gotAuth smtp.Auth
+	mailer.sendMail = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		gotAddr, gotAuth, gotFrom, gotTo = addr, a, from, to
+		return nil
+	}
+
+	err := mailer.Send(Message{To: "jane@example.com", Subject: "Hi", Body: "hello"})
+	assert.NoError(t, err)
+	assert.Equal(t, "smtp.example.com:587", gotAddr)
+	assert.NotNil(t, gotAuth)
+	assert.Equal(t, "noreply@example.com", gotFrom)
+	assert.Equal(t, []string{"jane@example.com"}, gotTo)
+}
+
+func TestFileMailerSend(t *testing.T) {
+	dir := t.TempDir()
+	mailer := NewFileMailer(dir)
+
+	assert.NoError(t, mailer.Send(Message{To: "jane@example.com", Subject: "First", Body: "one"}))
+	assert.NoError(t, mailer.Send(Message{To: "john@example.com", Subject: "Second", Body: "two"}))
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	assert.NoError(t, err)
+	assert.Len(t, files, 2)
+	raw, err := os.ReadFile(files[0])
+	assert.NoError(t, err)
+	assert.Contains(t, string(raw), "To: jane@example.com")
+}
+
+func TestTemplateMailer(t *testing.T) {
+	outbox := &recordingMailer{}
+	mailer := NewTemplateMailer(outbox)
+
+	err := mailer.SendTemplate("jane@example.com", "request_message", map[string]interface{}{
+		"RecipientName": "Jane",
+		"SenderName":    "Admin",
+		"RequestID":     12,
+		"RequestType":   "verification",
+		"Body":          "Please upload a clearer photo.",
+	})
+	assert.NoError(t, err)
+	assert.Len(t, outbox.sent, 1)
+	assert.Equal(t, "jane@example.com", outbox.sent[0].To)
+	assert.Equal(t, "New message about request #12", outbox.sent[0].Subject)
+	assert.Contains(t, outbox.sent[0].Body, "Hello Jane,")
+	assert.Contains(t, outbox.sent[0].Body, "Please upload a clearer photo.")
+
+	assert.Error(t, mailer.SendTemplate("jane@example.com", "missing", nil))
+}
+
+type recordingMailer struct {
+	sent []Message
+}
+
+func (m *recordingMailer) Send(msg Message) error {
+	m.sent = append(m.sent, msg)
+	return nil
+}
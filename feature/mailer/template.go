@@ -0,0 +1,69 @@
+package mailer
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+// templates holds one template set per file in templates/, keyed by file name
+// without extension. Each file defines a "subject" and a "body" template, so
+// the files cannot share a single set.
+var templates = parseTemplates()
+
+func parseTemplates() map[string]*template.Template {
+	files, err := fs.Glob(templateFS, "templates/*.tmpl")
+	if err != nil {
+		panic(err)
+	}
+	sets := make(map[string]*template.Template, len(files))
+	for _, file := range files {
+		name := strings.TrimSuffix(path.Base(file), ".tmpl")
+		sets[name] = template.Must(template.ParseFS(templateFS, file))
+	}
+	return sets
+}
+
+// TemplateMailer renders named templates into messages and sends them through
+// an underlying Mailer.
+type TemplateMailer struct {
+	mailer Mailer
+}
+
+func NewTemplateMailer(mailer Mailer) *TemplateMailer {
+	return &TemplateMailer{mailer: mailer}
+}
+
+// SendTemplate renders the template name with data and sends it to to.
+func (m *TemplateMailer) SendTemplate(to string, name string, data interface{}) error {
+	msg, err := Render(name, data)
+	if err != nil {
+		return err
+	}
+	msg.To = to
+	return m.mailer.Send(*msg)
+}
+
+// Render builds the subject and body of the template name. The returned
+// message has no recipient.
+func Render(name string, data interface{}) (*Message, error) {
+	set, ok := templates[name]
+	if !ok {
+		return nil, fmt.Errorf("mail template %q not found", name)
+	}
+	var subject, body bytes.Buffer
+	if err := set.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return nil, err
+	}
+	if err := set.ExecuteTemplate(&body, "body", data); err != nil {
+		return nil, err
+	}
+	return &Message{Subject: strings.TrimSpace(subject.String()), Body: body.String()}, nil
+}
@@ -0,0 +1,129 @@
+package middleware
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+	"github.com/gin-gonic/gin"
+)
+
+// RoleResolver resolves the code of the role stored in the access token.
+type RoleResolver interface {
+	RoleCode(roleID int) (string, error)
+}
+
+// PermissionChecker tells whether a role holds a permission, directly or
+// through its parents.
+type PermissionChecker interface {
+	HasPermission(roleID int, permission string) (bool, error)
+}
+
+// RequireRole only lets through users whose role code is one of roles.
+// It must run after AuthMiddleware.
+func RequireRole(authorizer RoleResolver, roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		roleID, ok := roleFromContext(c)
+		if !ok {
+			return
+		}
+		requireRole(c, authorizer, roleID, roles)
+	}
+}
+
+// RequireSelfOrRole lets users through when the path parameter param holds
+// their own id, and otherwise only if their role code is one of roles.
+// It must run after AuthMiddleware.
+func RequireSelfOrRole(authorizer RoleResolver, param string, roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		roleID, ok := roleFromContext(c)
+		if !ok {
+			return
+		}
+		if userID, exists := c.Get("userId"); exists && strconv.Itoa(userID.(int)) == c.Param(param) {
+			c.Next()
+			return
+		}
+		requireRole(c, authorizer, roleID, roles)
+	}
+}
+
+// OwnerResolver finds the id of the user owning the resource a request
+// targets.
+type OwnerResolver func(c *gin.Context) (int, error)
+
+// RequireOwnerOrRole lets users through when they own the resource found by
+// owner, and otherwise only if their role code is one of roles. Resources
+// that do not exist are left to the role check, so that only those roles
+// learn about them. It must run after AuthMiddleware.
+func RequireOwnerOrRole(authorizer RoleResolver, owner OwnerResolver, roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		roleID, ok := roleFromContext(c)
+		if !ok {
+			return
+		}
+		ownerID, err := owner(c)
+		if err != nil {
+			if code := apperror.CodeOf(err); code != apperror.NotFound && code != apperror.Validation {
+				apperror.Abort(c, fmt.Errorf("resolve owner: %w", err))
+				return
+			}
+		} else if userID, exists := c.Get("userId"); exists && userID.(int) == ownerID {
+			c.Next()
+			return
+		}
+		requireRole(c, authorizer, roleID, roles)
+	}
+}
+
+func requireRole(c *gin.Context, authorizer RoleResolver, roleID int, roles []string) {
+	code, err := authorizer.RoleCode(roleID)
+	// a role deleted since the token was issued grants nothing
+	if err != nil && apperror.CodeOf(err) != apperror.NotFound {
+		apperror.Abort(c, fmt.Errorf("resolve role %d: %w", roleID, err))
+		return
+	}
+	if err == nil {
+		for _, role := range roles {
+			if code == role {
+				c.Next()
+				return
+			}
+		}
+	}
+	forbidden(c, "Role is not allowed to access this resource", gin.H{"required_roles": roles})
+}
+
+// RequirePermission only lets through users whose role holds permission.
+// It must run after AuthMiddleware.
+func RequirePermission(authorizer PermissionChecker, permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		roleID, ok := roleFromContext(c)
+		if !ok {
+			return
+		}
+		allowed, err := authorizer.HasPermission(roleID, permission)
+		if err != nil && apperror.CodeOf(err) != apperror.NotFound {
+			apperror.Abort(c, fmt.Errorf("resolve permissions of role %d: %w", roleID, err))
+			return
+		}
+		if !allowed {
+			forbidden(c, "Missing permission to access this resource", gin.H{"required_permission": permission})
+			return
+		}
+		c.Next()
+	}
+}
+
+func roleFromContext(c *gin.Context) (int, bool) {
+	roleID, exists := c.Get("roleId")
+	if !exists {
+		apperror.Abort(c, apperror.ErrUnauthorized)
+		return 0, false
+	}
+	return roleID.(int), true
+}
+
+func forbidden(c *gin.Context, message string, details gin.H) {
+	apperror.Abort(c, apperror.New(apperror.Forbidden, message).WithDetails(details))
+}
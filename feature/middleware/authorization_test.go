@@ -0,0 +1,168 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeAuthorizer struct {
+	roles       map[int]string
+	permissions map[int][]string
+}
+
+func (f *fakeAuthorizer) RoleCode(roleID int) (string, error) {
+	if roleID < 0 {
+		return "", errors.New("connection refused")
+	}
+	code, ok := f.roles[roleID]
+	if !ok {
+		return "", apperror.New(apperror.NotFound, "role not found")
+	}
+	return code, nil
+}
+
+func (f *fakeAuthorizer) HasPermission(roleID int, permission string) (bool, error) {
+	if roleID < 0 {
+		return false, errors.New("connection refused")
+	}
+	permissions, ok := f.permissions[roleID]
+	if !ok {
+		return false, apperror.New(apperror.NotFound, "role not found")
+	}
+	for _, p := range permissions {
+		if p == permission {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func newAuthorizationRouter(roleID *int, guard gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(apperror.Middleware())
+	router.GET("/guarded", func(c *gin.Context) {
+		if roleID != nil {
+			c.Set("roleId", *roleID)
+		}
+	}, guard, func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "ok"})
+	})
+	return router
+}
+
+func serve(router *gin.Engine) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/guarded", nil)
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestRequireRole(t *testing.T) {
+	authorizer := &fakeAuthorizer{roles: map[int]string{1: "applicant", 3: "admin"}}
+	admin, applicant, unknown, broken := 3, 1, 99, -1
+
+	assert.Equal(t, http.StatusOK, serve(newAuthorizationRouter(&admin, RequireRole(authorizer, "admin"))).Code)
+	assert.Equal(t, http.StatusUnauthorized, serve(newAuthorizationRouter(nil, RequireRole(authorizer, "admin"))).Code)
+	assert.Equal(t, http.StatusForbidden, serve(newAuthorizationRouter(&unknown, RequireRole(authorizer, "admin"))).Code)
+	assert.Equal(t, http.StatusInternalServerError, serve(newAuthorizationRouter(&broken, RequireRole(authorizer, "admin"))).Code)
+
+	w := serve(newAuthorizationRouter(&applicant, RequireRole(authorizer, "admin")))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	var response map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "FORBIDDEN", response["code"])
+	assert.Equal(t, []interface{}{"admin"}, response["details"].(map[string]interface{})["required_roles"])
+}
+
+func TestRequirePermission(t *testing.T) {
+	authorizer := &fakeAuthorizer{permissions: map[int][]string{1: {}, 3: {"country.write"}}}
+	admin, applicant, unknown, broken := 3, 1, 99, -1
+
+	assert.Equal(t, http.StatusOK, serve(newAuthorizationRouter(&admin, RequirePermission(authorizer, "country.write"))).Code)
+	assert.Equal(t, http.StatusForbidden, serve(newAuthorizationRouter(&unknown, RequirePermission(authorizer, "country.write"))).Code)
+	assert.Equal(t, http.StatusInternalServerError, serve(newAuthorizationRouter(&broken, RequirePermission(authorizer, "country.write"))).Code)
+
+	w := serve(newAuthorizationRouter(&applicant, RequirePermission(authorizer, "country.write")))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	var response map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "country.write", response["details"].(map[string]interface{})["required_permission"])
+}
+
+func TestRequireSelfOrRole(t *testing.T) {
+	authorizer := &fakeAuthorizer{roles: map[int]string{1: "applicant", 3: "admin"}}
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(apperror.Middleware())
+	router.GET("/users/:id", func(c *gin.Context) {
+		c.Set("userId", 7)
+		if c.Query("admin") != "" {
+			c.Set("roleId", 3)
+		} else {
+			c.Set("roleId", 1)
+		}
+	}, RequireSelfOrRole(authorizer, "id", "admin"), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "ok"})
+	})
+
+	for path, status := range map[string]int{
+		"/users/7":         http.StatusOK,
+		"/users/8":         http.StatusForbidden,
+		"/users/8?admin=1": http.StatusOK,
+	} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, path, nil)
+		router.ServeHTTP(w, req)
+		assert.Equal(t, status, w.Code, path)
+	}
+}
+
+func TestRequireOwnerOrRole(t *testing.T) {
+	authorizer := &fakeAuthorizer{roles: map[int]string{1: "applicant", 3: "admin"}}
+	owners := map[string]int{"10": 7, "11": 8}
+	owner := func(c *gin.Context) (int, error) {
+		if c.Param("id") == "broken" {
+			return 0, errors.New("connection refused")
+		}
+		userID, ok := owners[c.Param("id")]
+		if !ok {
+			return 0, apperror.New(apperror.NotFound, "volunteer not found")
+		}
+		return userID, nil
+	}
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(apperror.Middleware())
+	router.GET("/volunteers/:id", func(c *gin.Context) {
+		c.Set("userId", 7)
+		if c.Query("admin") != "" {
+			c.Set("roleId", 3)
+		} else {
+			c.Set("roleId", 1)
+		}
+	}, RequireOwnerOrRole(authorizer, owner, "admin"), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "ok"})
+	})
+
+	for path, status := range map[string]int{
+		"/volunteers/10":         http.StatusOK,
+		"/volunteers/11":         http.StatusForbidden,
+		"/volunteers/11?admin=1": http.StatusOK,
+		"/volunteers/12":         http.StatusForbidden,
+		"/volunteers/12?admin=1": http.StatusOK,
+		"/volunteers/broken":     http.StatusInternalServerError,
+	} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, path, nil)
+		router.ServeHTTP(w, req)
+		assert.Equal(t, status, w.Code, path)
+	}
+}
@@ -2,45 +2,74 @@ package middleware
 
 import (
 	"fmt"
-	"net/http"
 	"strings"
+	"time"
 
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v4"
 )
 
-func AuthMiddleware(secretKey string) gin.HandlerFunc {
+// TokenRevocationChecker reports whether an otherwise valid access token must
+// be rejected, either because it was denylisted or its owner was deactivated.
+type TokenRevocationChecker interface {
+	IsAccessTokenRevoked(jti string, userID int) (bool, error)
+}
+
+func AuthMiddleware(secretKey string, revocations TokenRevocationChecker) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header required"})
-			c.Abort()
+			apperror.Abort(c, apperror.New(apperror.Unauthorized, "Authorization header required"))
 			return
 		}
 
 		parts := strings.Split(authHeader, " ")
 		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
-			c.Abort()
+			apperror.Abort(c, apperror.New(apperror.Unauthorized, "Unauthorized"))
 			return
 		}
 
 		tokenString := parts[1]
-		token, _ := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
 			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 			}
 			return []byte(secretKey), nil
 		})
+		if err != nil || token == nil || !token.Valid {
+			apperror.Abort(c, apperror.New(apperror.Unauthorized, "Invalid token"))
+			return
+		}
+
+		claims, ok := token.Claims.(jwt.MapClaims)
+		if !ok {
+			apperror.Abort(c, apperror.New(apperror.Unauthorized, "Invalid token"))
+			return
+		}
+		userID, okUser := claims["userId"].(float64)
+		roleID, okRole := claims["roleId"].(float64)
+		jti, okJTI := claims["jti"].(string)
+		exp, okExp := claims["exp"].(float64)
+		if !okUser || !okRole || !okJTI || !okExp {
+			apperror.Abort(c, apperror.New(apperror.Unauthorized, "Invalid token"))
+			return
+		}
 
-		if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-			c.Set("userId", int(claims["userId"].(float64)))
-			c.Set("roleId", int(claims["roleId"].(float64)))
-		} else {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
-			c.Abort()
+		revoked, err := revocations.IsAccessTokenRevoked(jti, int(userID))
+		if err != nil {
+			apperror.Abort(c, fmt.Errorf("verify token: %w", err))
 			return
 		}
+		if revoked {
+			apperror.Abort(c, apperror.New(apperror.Unauthorized, "Token has been revoked"))
+			return
+		}
+
+		c.Set("userId", int(userID))
+		c.Set("roleId", int(roleID))
+		c.Set("jti", jti)
+		c.Set("tokenExpiresAt", time.Unix(int64(exp), 0))
 
 		c.Next()
 	}
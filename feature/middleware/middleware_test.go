@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeRevocations map[string]bool
+
+func (f fakeRevocations) IsAccessTokenRevoked(jti string, userID int) (bool, error) {
+	return f[jti], nil
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(apperror.Middleware())
+	router.GET("/me", AuthMiddleware("secret", fakeRevocations{"revoked": true}), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"user_id": c.GetInt("userId"), "role_id": c.GetInt("roleId")})
+	})
+	sign := func(key string, jti string) string {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"userId": 7,
+			"roleId": 3,
+			"jti":    jti,
+			"exp":    time.Now().Add(time.Minute).Unix(),
+		}).SignedString([]byte(key))
+		assert.NoError(t, err)
+		return token
+	}
+
+	for name, tc := range map[string]struct {
+		header string
+		status int
+	}{
+		"valid":          {"Bearer " + sign("secret", "a"), http.StatusOK},
+		"missing":        {"", http.StatusUnauthorized},
+		"not bearer":     {"Basic abc", http.StatusUnauthorized},
+		"malformed":      {"Bearer not-a-jwt", http.StatusUnauthorized},
+		"wrong key":      {"Bearer " + sign("other", "a"), http.StatusUnauthorized},
+		"revoked":        {"Bearer " + sign("secret", "revoked"), http.StatusUnauthorized},
+		"empty segments": {"Bearer ..", http.StatusUnauthorized},
+	} {
+		t.Run(name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/me", nil)
+			if tc.header != "" {
+				req.Header.Set("Authorization", tc.header)
+			}
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tc.status, w.Code)
+		})
+	}
+}
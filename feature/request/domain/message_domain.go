@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+)
+
+var ErrEmptyMessage = apperror.New(apperror.Validation, "message body is empty")
+
+// Message is one entry of the conversation between the admins and the
+// requester of a request.
+type Message struct {
+	ID        int       `gorm:"primaryKey"`
+	RequestID int       `gorm:"index;not null"`
+	SenderID  int       `gorm:"not null"`
+	Body      string    `gorm:"type:text;not null"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+func (Message) TableName() string {
+	return "request_messages"
+}
+
+// Participant is the contact information of a user taking part in a
+// conversation, read from the users table.
+type Participant struct {
+	ID    int
+	Name  string
+	Email string
+}
+
+func (Participant) TableName() string {
+	return "users"
+}
@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+	"gorm.io/gorm"
+)
+
+// RequestType tells what a request asks the admins to do.
+type RequestType string
+
+const (
+	// RequestTypeRegistration asks to become an applicant.
+	RequestTypeRegistration RequestType = "registration"
+	// RequestTypeVerification asks an applicant to be verified as a volunteer.
+	RequestTypeVerification RequestType = "verification"
+)
+
+func (t RequestType) IsValid() bool {
+	return t == RequestTypeRegistration || t == RequestTypeVerification
+}
+
+// RequestStatus is stored as a TINYINT in the requests table.
+type RequestStatus int
+
+const (
+	RequestStatusPending RequestStatus = iota
+	RequestStatusApproved
+	RequestStatusRejected
+	RequestStatusCancelled
+)
+
+func (s RequestStatus) String() string {
+	switch s {
+	case RequestStatusPending:
+		return "pending"
+	case RequestStatusApproved:
+		return "approved"
+	case RequestStatusRejected:
+		return "rejected"
+	case RequestStatusCancelled:
+		return "cancelled"
+	}
+	return "unknown"
+}
+
+// transitions lists the statuses a request may move to from each status.
+// Approved, rejected and cancelled requests are final; a rejected request is
+// resubmitted as a new pending revision instead.
+var transitions = map[RequestStatus][]RequestStatus{
+	RequestStatusPending: {RequestStatusApproved, RequestStatusRejected, RequestStatusCancelled},
+}
+
+// CanTransition reports whether a request in status s may move to status to.
+func (s RequestStatus) CanTransition(to RequestStatus) bool {
+	for _, next := range transitions[s] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	ErrRequestNotFound      = apperror.New(apperror.NotFound, "request not found")
+	ErrInvalidRequestType   = apperror.New(apperror.Validation, "invalid request type")
+	ErrPendingRequestExists = apperror.New(apperror.Conflict, "a pending request of this type already exists")
+	ErrInvalidTransition    = apperror.New(apperror.Conflict, "invalid request status transition")
+	ErrRequestNotRejected   = apperror.New(apperror.Conflict, "only rejected requests can be resubmitted")
+	ErrAlreadyResubmitted   = apperror.New(apperror.Conflict, "request has already been resubmitted")
+	// ErrRequesterNotFound, ErrRequesterHasNoDepartment,
+	// ErrRequesterDepartmentDeleted and ErrRequesterHasNoValidIdentity mean the
+	// request itself is valid but the requesting user cannot be approved as is.
+	ErrRequesterNotFound           = apperror.New(apperror.Unprocessable, "requesting user not found")
+	ErrRequesterHasNoDepartment    = apperror.New(apperror.Unprocessable, "requesting user has no department")
+	ErrRequesterDepartmentDeleted  = apperror.New(apperror.Unprocessable, "department of the requesting user has been deleted")
+	ErrRequesterHasNoValidIdentity = apperror.New(apperror.Unprocessable, "requesting user has no verified identity document that is still valid")
+	// ErrRequesterAlreadyVolunteer means the user has a volunteer record,
+	// possibly deleted, which has to be restored instead.
+	ErrRequesterAlreadyVolunteer = apperror.New(apperror.Conflict, "requesting user already has a volunteer record")
+)
+
+// TransitionError is returned when a request is moved along a transition
+// that is not in the transition table. It wraps ErrInvalidTransition.
+type TransitionError struct {
+	From RequestStatus
+	To   RequestStatus
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("cannot move request from %s to %s", e.From, e.To)
+}
+
+func (e *TransitionError) Unwrap() error {
+	return ErrInvalidTransition
+}
+
+// Request is a registration or verification request reviewed by an admin.
+// A resubmitted request points to the rejected revision it replaces
+// through ParentID.
+type Request struct {
+	ID         int            `gorm:"primaryKey"`
+	UserID     int            `gorm:"index;not null"`
+	Type       RequestType    `gorm:"not null"`
+	Status     RequestStatus  `gorm:"not null"`
+	VerifierID *int           `gorm:"index"`
+	ParentID   *int           `gorm:"index"`
+	CreatedAt  time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt  time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt  gorm.DeletedAt `gorm:"index"`
+}
+
+// TransitionTo moves the request to status to, or returns a *TransitionError
+// when the move is not allowed.
+func (r *Request) TransitionTo(to RequestStatus) error {
+	if !r.Status.CanTransition(to) {
+		return &TransitionError{From: r.Status, To: to}
+	}
+	r.Status = to
+	return nil
+}
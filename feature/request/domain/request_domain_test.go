@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransitionTo(t *testing.T) {
+	cases := []struct {
+		from, to RequestStatus
+		allowed  bool
+	}{
+		{RequestStatusPending, RequestStatusApproved, true},
+		{RequestStatusPending, RequestStatusRejected, true},
+		{RequestStatusPending, RequestStatusCancelled, true},
+		{RequestStatusRejected, RequestStatusPending, false},
+		{RequestStatusRejected, RequestStatusApproved, false},
+		{RequestStatusApproved, RequestStatusRejected, false},
+		{RequestStatusApproved, RequestStatusPending, false},
+		{RequestStatusCancelled, RequestStatusPending, false},
+		{RequestStatusPending, RequestStatusPending, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.from.String()+"->"+tc.to.String(), func(t *testing.T) {
+			request := &Request{Status: tc.from}
+			err := request.TransitionTo(tc.to)
+			if tc.allowed {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.to, request.Status)
+				return
+			}
+			assert.ErrorIs(t, err, ErrInvalidTransition)
+			var transitionErr *TransitionError
+			assert.True(t, errors.As(err, &transitionErr))
+			assert.Equal(t, tc.from, request.Status)
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package dto
+
+import "time"
+
+type RequestCreateDTO struct {
+	Type string `json:"type" binding:"required"`
+}
+
+type RequestResponseDTO struct {
+	ID        int       `json:"id"`
+	UserID    int       `json:"user_id"`
+	Type      string    `json:"type"`
+	Status    string    `json:"status"`
+	ParentID  *int      `json:"parent_id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type MessageCreateDTO struct {
+	Body string `json:"body" binding:"required,max=5000"`
+}
+
+// MessageResponseDTO is one message of a request conversation. FromRequester
+// tells replies of the requester apart from messages of the admins.
+type MessageResponseDTO struct {
+	ID            int       `json:"id"`
+	RequestID     int       `json:"request_id"`
+	SenderID      int       `json:"sender_id"`
+	FromRequester bool      `json:"from_requester"`
+	Body          string    `json:"body"`
+	CreatedAt     time.Time `json:"created_at"`
+}
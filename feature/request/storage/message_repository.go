@@ -0,0 +1,39 @@
+package storage
+
+import (
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/request/domain"
+)
+
+type MessageRepositoryInterface interface {
+	CreateMessage(message *domain.Message) error
+	ListMessages(requestID int) ([]*domain.Message, error)
+	GetParticipants(ids []int) (map[int]*domain.Participant, error)
+}
+
+func (r *RequestRepository) CreateMessage(message *domain.Message) error {
+	return r.DB.Create(message).Error
+}
+
+// ListMessages returns the conversation of a request, oldest first.
+func (r *RequestRepository) ListMessages(requestID int) ([]*domain.Message, error) {
+	messages := []*domain.Message{}
+	err := r.DB.Where("request_id = ?", requestID).Order("created_at ASC").Order("id ASC").Find(&messages).Error
+	return messages, err
+}
+
+// GetParticipants returns the contact information of the given users by id.
+// Unknown ids are left out.
+func (r *RequestRepository) GetParticipants(ids []int) (map[int]*domain.Participant, error) {
+	participants := map[int]*domain.Participant{}
+	if len(ids) == 0 {
+		return participants, nil
+	}
+	var rows []*domain.Participant
+	if err := r.DB.Select("id", "name", "email").Where("id IN ?", ids).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		participants[row.ID] = row
+	}
+	return participants, nil
+}
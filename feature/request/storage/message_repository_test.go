@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/request/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateMessage(t *testing.T) {
+	db, mock, err := setupMockDB()
+	assert.NoError(t, err)
+	repo := NewRequestRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `request_messages` (`request_id`,`sender_id`,`body`,`created_at`) VALUES (?,?,?,?)")).
+		WithArgs(3, 9, "Please upload a clearer photo.", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	message := &domain.Message{RequestID: 3, SenderID: 9, Body: "Please upload a clearer photo."}
+	assert.NoError(t, repo.CreateMessage(message))
+	assert.Equal(t, 1, message.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListMessages(t *testing.T) {
+	db, mock, err := setupMockDB()
+	assert.NoError(t, err)
+	repo := NewRequestRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `request_messages` WHERE request_id = ? ORDER BY created_at ASC,id ASC")).
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "request_id", "sender_id", "body"}).
+			AddRow(1, 3, 9, "Please upload a clearer photo.").
+			AddRow(2, 3, 4, "Done."))
+
+	messages, err := repo.ListMessages(3)
+	assert.NoError(t, err)
+	assert.Len(t, messages, 2)
+	assert.Equal(t, "Done.", messages[1].Body)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetParticipants(t *testing.T) {
+	db, mock, err := setupMockDB()
+	assert.NoError(t, err)
+	repo := NewRequestRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `id`,`name`,`email` FROM `users` WHERE id IN (?,?)")).
+		WithArgs(4, 9).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email"}).AddRow(4, "Jane", "jane@example.com"))
+
+	participants, err := repo.GetParticipants([]int{4, 9})
+	assert.NoError(t, err)
+	assert.Len(t, participants, 1)
+	assert.Equal(t, "jane@example.com", participants[4].Email)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"errors"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/request/domain"
+	"gorm.io/gorm"
+)
+
+type RequestRepositoryInterface interface {
+	Create(request *domain.Request) error
+	GetByID(id int) (*domain.Request, error)
+	ListByUser(userID int) ([]*domain.Request, error)
+	Update(request *domain.Request) error
+	HasPendingRequest(userID int, requestType domain.RequestType) (bool, error)
+	HasRevision(id int) (bool, error)
+}
+
+type RequestRepository struct {
+	DB *gorm.DB
+}
+
+func NewRequestRepository(db *gorm.DB) *RequestRepository {
+	return &RequestRepository{DB: db}
+}
+
+func (r *RequestRepository) Create(request *domain.Request) error {
+	return r.DB.Create(request).Error
+}
+
+func (r *RequestRepository) GetByID(id int) (*domain.Request, error) {
+	var request domain.Request
+	err := r.DB.First(&request, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrRequestNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+func (r *RequestRepository) ListByUser(userID int) ([]*domain.Request, error) {
+	requests := []*domain.Request{}
+	err := r.DB.Where("user_id = ?", userID).Order("created_at DESC").Find(&requests).Error
+	return requests, err
+}
+
+func (r *RequestRepository) Update(request *domain.Request) error {
+	return r.DB.Save(request).Error
+}
+
+func (r *RequestRepository) HasPendingRequest(userID int, requestType domain.RequestType) (bool, error) {
+	var count int64
+	err := r.DB.Model(&domain.Request{}).
+		Where("user_id = ? AND type = ? AND status = ?", userID, requestType, domain.RequestStatusPending).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// HasRevision reports whether the request has been resubmitted already.
+func (r *RequestRepository) HasRevision(id int) (bool, error) {
+	var count int64
+	err := r.DB.Model(&domain.Request{}).Where("parent_id = ?", id).Count(&count).Error
+	return count > 0, err
+}
@@ -0,0 +1,123 @@
+package storage
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/request/domain"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func setupMockDB() (*gorm.DB, sqlmock.Sqlmock, error) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return gormDB, mock, nil
+}
+
+func TestCreate(t *testing.T) {
+	db, mock, err := setupMockDB()
+	assert.NoError(t, err)
+	repo := NewRequestRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `requests`")).
+		WithArgs(1, domain.RequestTypeRegistration, domain.RequestStatusPending, nil, nil, sqlmock.AnyArg(), sqlmock.AnyArg(), nil).
+		WillReturnResult(sqlmock.NewResult(5, 1))
+	mock.ExpectCommit()
+
+	request := &domain.Request{UserID: 1, Type: domain.RequestTypeRegistration, Status: domain.RequestStatusPending}
+	err = repo.Create(request)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, request.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetByID(t *testing.T) {
+	query := regexp.QuoteMeta("SELECT * FROM `requests` WHERE `requests`.`id` = ? AND `requests`.`deleted_at` IS NULL ORDER BY `requests`.`id` LIMIT ?")
+
+	t.Run("found", func(t *testing.T) {
+		db, mock, err := setupMockDB()
+		assert.NoError(t, err)
+		repo := NewRequestRepository(db)
+
+		mock.ExpectQuery(query).WithArgs(1, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "type", "status"}).
+				AddRow(1, 2, "verification", 2))
+
+		request, err := repo.GetByID(1)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.RequestTypeVerification, request.Type)
+		assert.Equal(t, domain.RequestStatusRejected, request.Status)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		db, mock, err := setupMockDB()
+		assert.NoError(t, err)
+		repo := NewRequestRepository(db)
+
+		mock.ExpectQuery(query).WithArgs(9, 1).WillReturnError(gorm.ErrRecordNotFound)
+
+		request, err := repo.GetByID(9)
+		assert.Nil(t, request)
+		assert.ErrorIs(t, err, domain.ErrRequestNotFound)
+	})
+}
+
+func TestListByUser(t *testing.T) {
+	db, mock, err := setupMockDB()
+	assert.NoError(t, err)
+	repo := NewRequestRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `requests` WHERE user_id = ? AND `requests`.`deleted_at` IS NULL ORDER BY created_at DESC")).
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "type", "status"}).
+			AddRow(2, 2, "verification", 0).
+			AddRow(1, 2, "registration", 1))
+
+	requests, err := repo.ListByUser(2)
+	assert.NoError(t, err)
+	assert.Len(t, requests, 2)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestHasPendingRequest(t *testing.T) {
+	db, mock, err := setupMockDB()
+	assert.NoError(t, err)
+	repo := NewRequestRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `requests` WHERE (user_id = ? AND type = ? AND status = ?) AND `requests`.`deleted_at` IS NULL")).
+		WithArgs(2, domain.RequestTypeVerification, domain.RequestStatusPending).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	pending, err := repo.HasPendingRequest(2, domain.RequestTypeVerification)
+	assert.NoError(t, err)
+	assert.True(t, pending)
+}
+
+func TestHasRevision(t *testing.T) {
+	db, mock, err := setupMockDB()
+	assert.NoError(t, err)
+	repo := NewRequestRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `requests` WHERE parent_id = ?")).
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+	resubmitted, err := repo.HasRevision(3)
+	assert.NoError(t, err)
+	assert.False(t, resubmitted)
+}
@@ -0,0 +1,128 @@
+package transport
+
+import (
+	"net/http"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/request/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/request/usecase"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/validation"
+	"github.com/gin-gonic/gin"
+)
+
+// MessageHandler handles the conversation attached to a request, from both
+// the admin and the requester side.
+type MessageHandler struct {
+	usecase usecase.MessageUsecaseInterface
+}
+
+// NewMessageHandler creates a new instance of MessageHandler.
+func NewMessageHandler(usecase usecase.MessageUsecaseInterface) *MessageHandler {
+	return &MessageHandler{usecase: usecase}
+}
+
+// PostMessage godoc
+// @Summary Send message to requester
+// @Description Add an admin message to the conversation of a request. The requester is notified by email.
+// @Accept json
+// @Produce json
+// @Tags admin
+// @Security bearerToken
+// @Param id path int true "Request ID"
+// @Param message body dto.MessageCreateDTO true "Message"
+// @Success 201 {object} dto.MessageResponseDTO
+// @Failure 404 {object} apperror.Response "Request not found"
+// @Router /api/v1/admin/requests/{id}/messages [post]
+func (h *MessageHandler) PostMessage(c *gin.Context) {
+	adminID, id, ok := requestParams(c)
+	if !ok {
+		return
+	}
+	var input dto.MessageCreateDTO
+	if err := c.ShouldBindJSON(&input); err != nil {
+		apperror.Abort(c, validation.Error(err))
+		return
+	}
+	message, err := h.usecase.PostMessage(adminID, id, input)
+	if err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, message)
+}
+
+// ListMessages godoc
+// @Summary List request messages
+// @Description List the conversation of a request, oldest first
+// @Produce json
+// @Tags admin
+// @Security bearerToken
+// @Param id path int true "Request ID"
+// @Success 200 {array} dto.MessageResponseDTO
+// @Failure 404 {object} apperror.Response "Request not found"
+// @Router /api/v1/admin/requests/{id}/messages [get]
+func (h *MessageHandler) ListMessages(c *gin.Context) {
+	_, id, ok := requestParams(c)
+	if !ok {
+		return
+	}
+	messages, err := h.usecase.ListMessages(id)
+	if err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, messages)
+}
+
+// PostOwnMessage godoc
+// @Summary Reply to admins
+// @Description Add a reply to the conversation of a request of the current user. The admins taking part are notified by email.
+// @Accept json
+// @Produce json
+// @Tags request
+// @Security bearerToken
+// @Param id path int true "Request ID"
+// @Param message body dto.MessageCreateDTO true "Message"
+// @Success 201 {object} dto.MessageResponseDTO
+// @Failure 404 {object} apperror.Response "Request not found"
+// @Router /api/v1/me/requests/{id}/messages [post]
+func (h *MessageHandler) PostOwnMessage(c *gin.Context) {
+	userID, id, ok := requestParams(c)
+	if !ok {
+		return
+	}
+	var input dto.MessageCreateDTO
+	if err := c.ShouldBindJSON(&input); err != nil {
+		apperror.Abort(c, validation.Error(err))
+		return
+	}
+	message, err := h.usecase.PostOwnMessage(userID, id, input)
+	if err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, message)
+}
+
+// ListOwnMessages godoc
+// @Summary List messages
+// @Description List the conversation of a request of the current user, oldest first
+// @Produce json
+// @Tags request
+// @Security bearerToken
+// @Param id path int true "Request ID"
+// @Success 200 {array} dto.MessageResponseDTO
+// @Failure 404 {object} apperror.Response "Request not found"
+// @Router /api/v1/me/requests/{id}/messages [get]
+func (h *MessageHandler) ListOwnMessages(c *gin.Context) {
+	userID, id, ok := requestParams(c)
+	if !ok {
+		return
+	}
+	messages, err := h.usecase.ListOwnMessages(userID, id)
+	if err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, messages)
+}
@@ -0,0 +1,84 @@
+package transport
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/request/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/request/dto"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockMessageUsecase struct {
+	mock.Mock
+}
+
+func (m *MockMessageUsecase) PostMessage(adminID int, requestID int, input dto.MessageCreateDTO) (*dto.MessageResponseDTO, error) {
+	args := m.Called(adminID, requestID, input)
+	if args.Get(0) != nil {
+		return args.Get(0).(*dto.MessageResponseDTO), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockMessageUsecase) ListMessages(requestID int) ([]dto.MessageResponseDTO, error) {
+	args := m.Called(requestID)
+	return args.Get(0).([]dto.MessageResponseDTO), args.Error(1)
+}
+
+func (m *MockMessageUsecase) PostOwnMessage(userID int, requestID int, input dto.MessageCreateDTO) (*dto.MessageResponseDTO, error) {
+	args := m.Called(userID, requestID, input)
+	if args.Get(0) != nil {
+		return args.Get(0).(*dto.MessageResponseDTO), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockMessageUsecase) ListOwnMessages(userID int, requestID int) ([]dto.MessageResponseDTO, error) {
+	args := m.Called(userID, requestID)
+	return args.Get(0).([]dto.MessageResponseDTO), args.Error(1)
+}
+
+func setupMessageRouter(handler *MessageHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.Use(apperror.Middleware())
+	setUser := func(c *gin.Context) { c.Set("userId", 1) }
+	r.POST("/api/v1/admin/requests/:id/messages", setUser, handler.PostMessage)
+	r.GET("/api/v1/admin/requests/:id/messages", setUser, handler.ListMessages)
+	r.POST("/api/v1/me/requests/:id/messages", setUser, handler.PostOwnMessage)
+	r.GET("/api/v1/me/requests/:id/messages", setUser, handler.ListOwnMessages)
+	return r
+}
+
+func TestPostMessage(t *testing.T) {
+	usecase := new(MockMessageUsecase)
+	r := setupMessageRouter(NewMessageHandler(usecase))
+
+	usecase.On("PostMessage", 1, 3, dto.MessageCreateDTO{Body: "hello"}).Return(&dto.MessageResponseDTO{ID: 1, RequestID: 3}, nil)
+	usecase.On("PostMessage", 1, 4, dto.MessageCreateDTO{Body: "hello"}).Return(nil, domain.ErrRequestNotFound)
+	usecase.On("PostMessage", 1, 5, dto.MessageCreateDTO{Body: " "}).Return(nil, domain.ErrEmptyMessage)
+
+	assert.Equal(t, http.StatusCreated, serve(r, http.MethodPost, "/api/v1/admin/requests/3/messages", `{"body":"hello"}`).Code)
+	assert.Equal(t, http.StatusNotFound, serve(r, http.MethodPost, "/api/v1/admin/requests/4/messages", `{"body":"hello"}`).Code)
+	assert.Equal(t, http.StatusBadRequest, serve(r, http.MethodPost, "/api/v1/admin/requests/5/messages", `{"body":" "}`).Code)
+	assert.Equal(t, http.StatusBadRequest, serve(r, http.MethodPost, "/api/v1/admin/requests/3/messages", `{}`).Code)
+}
+
+func TestOwnMessages(t *testing.T) {
+	usecase := new(MockMessageUsecase)
+	r := setupMessageRouter(NewMessageHandler(usecase))
+
+	usecase.On("ListOwnMessages", 1, 3).Return([]dto.MessageResponseDTO{{ID: 1}, {ID: 2, FromRequester: true}}, nil)
+	usecase.On("ListOwnMessages", 1, 4).Return([]dto.MessageResponseDTO(nil), domain.ErrRequestNotFound)
+	usecase.On("PostOwnMessage", 1, 3, dto.MessageCreateDTO{Body: "Uploaded."}).Return(&dto.MessageResponseDTO{ID: 3, FromRequester: true}, nil)
+
+	w := serve(r, http.MethodGet, "/api/v1/me/requests/3/messages", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"from_requester":true`)
+	assert.Equal(t, http.StatusNotFound, serve(r, http.MethodGet, "/api/v1/me/requests/4/messages", "").Code)
+	assert.Equal(t, http.StatusCreated, serve(r, http.MethodPost, "/api/v1/me/requests/3/messages", `{"body":"Uploaded."}`).Code)
+}
@@ -0,0 +1,165 @@
+package transport
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/request/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/request/usecase"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/validation"
+	"github.com/gin-gonic/gin"
+)
+
+// RequestHandler handles the requester side of the request lifecycle.
+// Every route acts on the requests of the authenticated user.
+type RequestHandler struct {
+	usecase usecase.RequestUsecaseInterface
+}
+
+// NewRequestHandler creates a new instance of RequestHandler.
+func NewRequestHandler(usecase usecase.RequestUsecaseInterface) *RequestHandler {
+	return &RequestHandler{usecase: usecase}
+}
+
+// CreateRequest godoc
+// @Summary Create request
+// @Description Create a registration or verification request for the current user
+// @Accept json
+// @Produce json
+// @Tags request
+// @Security bearerToken
+// @Param request body dto.RequestCreateDTO true "Request data"
+// @Success 201 {object} dto.RequestResponseDTO
+// @Router /api/v1/me/requests [post]
+func (h *RequestHandler) CreateRequest(c *gin.Context) {
+	userID, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	var input dto.RequestCreateDTO
+	if err := c.ShouldBindJSON(&input); err != nil {
+		apperror.Abort(c, validation.Error(err))
+		return
+	}
+	CreateFor(c, h.usecase, userID, input)
+}
+
+// ListRequests godoc
+// @Summary List requests
+// @Description List the requests of the current user
+// @Produce json
+// @Tags request
+// @Security bearerToken
+// @Success 200 {array} dto.RequestResponseDTO
+// @Router /api/v1/me/requests [get]
+func (h *RequestHandler) ListRequests(c *gin.Context) {
+	userID, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	requests, err := h.usecase.ListRequests(userID)
+	if err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, requests)
+}
+
+// GetRequest godoc
+// @Summary Get request
+// @Description Get a request of the current user
+// @Produce json
+// @Tags request
+// @Security bearerToken
+// @Param id path int true "Request ID"
+// @Success 200 {object} dto.RequestResponseDTO
+// @Router /api/v1/me/requests/{id} [get]
+func (h *RequestHandler) GetRequest(c *gin.Context) {
+	userID, id, ok := requestParams(c)
+	if !ok {
+		return
+	}
+	request, err := h.usecase.GetRequest(userID, id)
+	if err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, request)
+}
+
+// CancelRequest godoc
+// @Summary Cancel request
+// @Description Cancel a pending request of the current user
+// @Produce json
+// @Tags request
+// @Security bearerToken
+// @Param id path int true "Request ID"
+// @Success 200 {string} message "Request cancelled successfully"
+// @Router /api/v1/me/requests/{id}/cancel [post]
+func (h *RequestHandler) CancelRequest(c *gin.Context) {
+	userID, id, ok := requestParams(c)
+	if !ok {
+		return
+	}
+	if err := h.usecase.CancelRequest(userID, id); err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Request cancelled successfully"})
+}
+
+// ResubmitRequest godoc
+// @Summary Resubmit request
+// @Description Resubmit a rejected request of the current user as a new pending revision
+// @Produce json
+// @Tags request
+// @Security bearerToken
+// @Param id path int true "Rejected request ID"
+// @Success 201 {object} dto.RequestResponseDTO
+// @Router /api/v1/me/requests/{id}/resubmit [post]
+func (h *RequestHandler) ResubmitRequest(c *gin.Context) {
+	userID, id, ok := requestParams(c)
+	if !ok {
+		return
+	}
+	revision, err := h.usecase.ResubmitRequest(userID, id)
+	if err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, revision)
+}
+
+// CreateFor files a request for userID and writes the response. It is shared
+// with the applicant-request and volunteer-request handlers.
+func CreateFor(c *gin.Context, requests usecase.RequestUsecaseInterface, userID int, input dto.RequestCreateDTO) {
+	request, err := requests.CreateRequest(userID, input)
+	if err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, request)
+}
+
+func currentUser(c *gin.Context) (int, bool) {
+	userID, exists := c.Get("userId")
+	if !exists {
+		apperror.Abort(c, apperror.ErrUnauthorized)
+		return 0, false
+	}
+	return userID.(int), true
+}
+
+func requestParams(c *gin.Context) (int, int, bool) {
+	userID, ok := currentUser(c)
+	if !ok {
+		return 0, 0, false
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apperror.Abort(c, apperror.New(apperror.Validation, "Invalid request ID"))
+		return 0, 0, false
+	}
+	return userID, id, true
+}
@@ -0,0 +1,139 @@
+package transport
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/request/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/request/dto"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRequestUsecase struct {
+	mock.Mock
+}
+
+func (m *MockRequestUsecase) CreateRequest(userID int, input dto.RequestCreateDTO) (*dto.RequestResponseDTO, error) {
+	args := m.Called(userID, input)
+	if args.Get(0) != nil {
+		return args.Get(0).(*dto.RequestResponseDTO), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockRequestUsecase) ListRequests(userID int) ([]dto.RequestResponseDTO, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]dto.RequestResponseDTO), args.Error(1)
+}
+
+func (m *MockRequestUsecase) GetRequest(userID int, id int) (*dto.RequestResponseDTO, error) {
+	args := m.Called(userID, id)
+	if args.Get(0) != nil {
+		return args.Get(0).(*dto.RequestResponseDTO), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockRequestUsecase) CancelRequest(userID int, id int) error {
+	args := m.Called(userID, id)
+	return args.Error(0)
+}
+
+func (m *MockRequestUsecase) ResubmitRequest(userID int, id int) (*dto.RequestResponseDTO, error) {
+	args := m.Called(userID, id)
+	if args.Get(0) != nil {
+		return args.Get(0).(*dto.RequestResponseDTO), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func setupRouter(handler *RequestHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.Use(apperror.Middleware())
+	requests := r.Group("/api/v1/me/requests", func(c *gin.Context) {
+		c.Set("userId", 1)
+	})
+	requests.POST("", handler.CreateRequest)
+	requests.GET("", handler.ListRequests)
+	requests.GET("/:id", handler.GetRequest)
+	requests.POST("/:id/cancel", handler.CancelRequest)
+	requests.POST("/:id/resubmit", handler.ResubmitRequest)
+	return r
+}
+
+func serve(r *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestCreateRequest(t *testing.T) {
+	mockUsecase := new(MockRequestUsecase)
+	r := setupRouter(NewRequestHandler(mockUsecase))
+
+	mockUsecase.On("CreateRequest", 1, dto.RequestCreateDTO{Type: "registration"}).
+		Return(&dto.RequestResponseDTO{ID: 1, UserID: 1, Type: "registration", Status: "pending"}, nil)
+	mockUsecase.On("CreateRequest", 1, dto.RequestCreateDTO{Type: "promotion"}).
+		Return(nil, domain.ErrInvalidRequestType)
+
+	rr := serve(r, http.MethodPost, "/api/v1/me/requests", `{"type":"registration"}`)
+	assert.Equal(t, http.StatusCreated, rr.Code)
+
+	rr = serve(r, http.MethodPost, "/api/v1/me/requests", `{"type":"promotion"}`)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	rr = serve(r, http.MethodPost, "/api/v1/me/requests", `{}`)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestListRequests(t *testing.T) {
+	mockUsecase := new(MockRequestUsecase)
+	r := setupRouter(NewRequestHandler(mockUsecase))
+
+	mockUsecase.On("ListRequests", 1).Return([]dto.RequestResponseDTO{{ID: 1}, {ID: 2}}, nil)
+
+	rr := serve(r, http.MethodGet, "/api/v1/me/requests", "")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestGetRequest(t *testing.T) {
+	mockUsecase := new(MockRequestUsecase)
+	r := setupRouter(NewRequestHandler(mockUsecase))
+
+	mockUsecase.On("GetRequest", 1, 1).Return(&dto.RequestResponseDTO{ID: 1}, nil)
+	mockUsecase.On("GetRequest", 1, 2).Return(nil, domain.ErrRequestNotFound)
+
+	assert.Equal(t, http.StatusOK, serve(r, http.MethodGet, "/api/v1/me/requests/1", "").Code)
+	assert.Equal(t, http.StatusNotFound, serve(r, http.MethodGet, "/api/v1/me/requests/2", "").Code)
+	assert.Equal(t, http.StatusBadRequest, serve(r, http.MethodGet, "/api/v1/me/requests/abc", "").Code)
+}
+
+func TestCancelAndResubmitRequest(t *testing.T) {
+	mockUsecase := new(MockRequestUsecase)
+	r := setupRouter(NewRequestHandler(mockUsecase))
+
+	mockUsecase.On("CancelRequest", 1, 1).Return(nil)
+	mockUsecase.On("CancelRequest", 1, 2).Return(domain.ErrInvalidTransition)
+	parentID := 3
+	mockUsecase.On("ResubmitRequest", 1, 3).Return(&dto.RequestResponseDTO{ID: 5, ParentID: &parentID, Status: "pending"}, nil)
+	mockUsecase.On("ResubmitRequest", 1, 4).Return(nil, domain.ErrRequestNotRejected)
+	mockUsecase.On("ResubmitRequest", 1, 6).Return(nil, domain.ErrAlreadyResubmitted)
+
+	assert.Equal(t, http.StatusOK, serve(r, http.MethodPost, "/api/v1/me/requests/1/cancel", "").Code)
+	assert.Equal(t, http.StatusConflict, serve(r, http.MethodPost, "/api/v1/me/requests/2/cancel", "").Code)
+
+	rr := serve(r, http.MethodPost, "/api/v1/me/requests/3/resubmit", "")
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"parent_id":3`)
+	assert.Equal(t, http.StatusConflict, serve(r, http.MethodPost, "/api/v1/me/requests/4/resubmit", "").Code)
+	assert.Equal(t, http.StatusConflict, serve(r, http.MethodPost, "/api/v1/me/requests/6/resubmit", "").Code)
+}
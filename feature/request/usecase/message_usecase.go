@@ -0,0 +1,171 @@
+package usecase
+
+import (
+	"log"
+	"strings"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/request/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/request/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/request/storage"
+)
+
+const requestMessageTemplate = "request_message"
+
+// TemplateSender renders a named mail template and sends it.
+type TemplateSender interface {
+	SendTemplate(to string, name string, data interface{}) error
+}
+
+// MessageUsecaseInterface manages the conversation attached to a request.
+// Admins can write on any request; requesters only on their own.
+type MessageUsecaseInterface interface {
+	PostMessage(adminID int, requestID int, input dto.MessageCreateDTO) (*dto.MessageResponseDTO, error)
+	ListMessages(requestID int) ([]dto.MessageResponseDTO, error)
+	PostOwnMessage(userID int, requestID int, input dto.MessageCreateDTO) (*dto.MessageResponseDTO, error)
+	ListOwnMessages(userID int, requestID int) ([]dto.MessageResponseDTO, error)
+}
+
+type MessageUsecase struct {
+	requests storage.RequestRepositoryInterface
+	messages storage.MessageRepositoryInterface
+	mailer   TemplateSender
+}
+
+func NewMessageUsecase(requests storage.RequestRepositoryInterface, messages storage.MessageRepositoryInterface, mailer TemplateSender) *MessageUsecase {
+	return &MessageUsecase{requests: requests, messages: messages, mailer: mailer}
+}
+
+// PostMessage adds a message from an admin and emails it to the requester.
+func (u *MessageUsecase) PostMessage(adminID int, requestID int, input dto.MessageCreateDTO) (*dto.MessageResponseDTO, error) {
+	request, err := u.requests.GetByID(requestID)
+	if err != nil {
+		return nil, err
+	}
+	message, err := u.post(request, adminID, input)
+	if err != nil {
+		return nil, err
+	}
+	u.notify(request, message, []int{request.UserID})
+	return toMessageResponse(request, message), nil
+}
+
+func (u *MessageUsecase) ListMessages(requestID int) ([]dto.MessageResponseDTO, error) {
+	request, err := u.requests.GetByID(requestID)
+	if err != nil {
+		return nil, err
+	}
+	return u.list(request)
+}
+
+// PostOwnMessage adds a reply from the requester and emails it to the
+// reviewer of the request and to every admin who wrote in the conversation.
+func (u *MessageUsecase) PostOwnMessage(userID int, requestID int, input dto.MessageCreateDTO) (*dto.MessageResponseDTO, error) {
+	request, err := findOwnRequest(u.requests, userID, requestID)
+	if err != nil {
+		return nil, err
+	}
+	thread, err := u.messages.ListMessages(request.ID)
+	if err != nil {
+		return nil, err
+	}
+	message, err := u.post(request, userID, input)
+	if err != nil {
+		return nil, err
+	}
+
+	var recipients []int
+	if request.VerifierID != nil {
+		recipients = append(recipients, *request.VerifierID)
+	}
+	for _, earlier := range thread {
+		if earlier.SenderID != userID {
+			recipients = append(recipients, earlier.SenderID)
+		}
+	}
+	u.notify(request, message, recipients)
+	return toMessageResponse(request, message), nil
+}
+
+func (u *MessageUsecase) ListOwnMessages(userID int, requestID int) ([]dto.MessageResponseDTO, error) {
+	request, err := findOwnRequest(u.requests, userID, requestID)
+	if err != nil {
+		return nil, err
+	}
+	return u.list(request)
+}
+
+func (u *MessageUsecase) post(request *domain.Request, senderID int, input dto.MessageCreateDTO) (*domain.Message, error) {
+	body := strings.TrimSpace(input.Body)
+	if body == "" {
+		return nil, domain.ErrEmptyMessage
+	}
+	message := &domain.Message{RequestID: request.ID, SenderID: senderID, Body: body}
+	if err := u.messages.CreateMessage(message); err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+func (u *MessageUsecase) list(request *domain.Request) ([]dto.MessageResponseDTO, error) {
+	messages, err := u.messages.ListMessages(request.ID)
+	if err != nil {
+		return nil, err
+	}
+	response := make([]dto.MessageResponseDTO, 0, len(messages))
+	for _, message := range messages {
+		response = append(response, *toMessageResponse(request, message))
+	}
+	return response, nil
+}
+
+// notify emails message to each recipient once. The message is stored
+// already, so delivery failures are logged only.
+func (u *MessageUsecase) notify(request *domain.Request, message *domain.Message, recipients []int) {
+	ids := []int{message.SenderID}
+	seen := map[int]bool{message.SenderID: true}
+	for _, id := range recipients {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 1 {
+		return
+	}
+	participants, err := u.messages.GetParticipants(ids)
+	if err != nil {
+		log.Printf("could not load participants of request %d: %v", request.ID, err)
+		return
+	}
+	senderName := "The onboarding team"
+	if sender, ok := participants[message.SenderID]; ok && message.SenderID == request.UserID {
+		senderName = sender.Name
+	}
+	for _, id := range ids[1:] {
+		recipient, ok := participants[id]
+		if !ok {
+			continue
+		}
+		err := u.mailer.SendTemplate(recipient.Email, requestMessageTemplate, map[string]interface{}{
+			"RecipientName": recipient.Name,
+			"SenderName":    senderName,
+			"RequestID":     request.ID,
+			"RequestType":   string(request.Type),
+			"Body":          message.Body,
+		})
+		if err != nil {
+			log.Printf("could not email message %d to user %d: %v", message.ID, id, err)
+		}
+	}
+}
+
+func toMessageResponse(request *domain.Request, message *domain.Message) *dto.MessageResponseDTO {
+	return &dto.MessageResponseDTO{
+		ID:            message.ID,
+		RequestID:     message.RequestID,
+		SenderID:      message.SenderID,
+		FromRequester: message.SenderID == request.UserID,
+		Body:          message.Body,
+		CreatedAt:     message.CreatedAt,
+	}
+}
@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/request/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/request/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockMessageRepository struct {
+	mock.Mock
+}
+
+func (m *MockMessageRepository) CreateMessage(message *domain.Message) error {
+	args := m.Called(message)
+	return args.Error(0)
+}
+
+func (m *MockMessageRepository) ListMessages(requestID int) ([]*domain.Message, error) {
+	args := m.Called(requestID)
+	return args.Get(0).([]*domain.Message), args.Error(1)
+}
+
+func (m *MockMessageRepository) GetParticipants(ids []int) (map[int]*domain.Participant, error) {
+	args := m.Called(ids)
+	return args.Get(0).(map[int]*domain.Participant), args.Error(1)
+}
+
+type sentTemplate struct {
+	to   string
+	name string
+	data map[string]interface{}
+}
+
+// captureTemplates keeps rendered template calls in memory.
+type captureTemplates struct {
+	sent []sentTemplate
+}
+
+func (c *captureTemplates) SendTemplate(to string, name string, data interface{}) error {
+	c.sent = append(c.sent, sentTemplate{to: to, name: name, data: data.(map[string]interface{})})
+	return nil
+}
+
+var participants = map[int]*domain.Participant{
+	1: {ID: 1, Name: "Jane", Email: "jane@example.com"},
+	8: {ID: 8, Name: "Reviewer", Email: "reviewer@example.com"},
+	9: {ID: 9, Name: "Admin", Email: "admin@example.com"},
+}
+
+func TestPostMessage(t *testing.T) {
+	t.Run("admin message is emailed to the requester", func(t *testing.T) {
+		requests := new(MockRequestRepository)
+		messages := new(MockMessageRepository)
+		outbox := &captureTemplates{}
+		usecase := NewMessageUsecase(requests, messages, outbox)
+
+		requests.On("GetByID", 3).Return(&domain.Request{ID: 3, UserID: 1, Type: domain.RequestTypeVerification}, nil)
+		messages.On("CreateMessage", mock.MatchedBy(func(m *domain.Message) bool {
+			return m.RequestID == 3 && m.SenderID == 9 && m.Body == "Please upload a clearer photo."
+		})).Return(nil)
+		messages.On("GetParticipants", []int{9, 1}).Return(participants, nil)
+
+		message, err := usecase.PostMessage(9, 3, dto.MessageCreateDTO{Body: "  Please upload a clearer photo.\n"})
+		assert.NoError(t, err)
+		assert.False(t, message.FromRequester)
+		assert.Len(t, outbox.sent, 1)
+		assert.Equal(t, "jane@example.com", outbox.sent[0].to)
+		assert.Equal(t, "request_message", outbox.sent[0].name)
+		assert.Equal(t, "The onboarding team", outbox.sent[0].data["SenderName"])
+		messages.AssertExpectations(t)
+	})
+
+	t.Run("blank body", func(t *testing.T) {
+		requests := new(MockRequestRepository)
+		messages := new(MockMessageRepository)
+		usecase := NewMessageUsecase(requests, messages, &captureTemplates{})
+		requests.On("GetByID", 3).Return(&domain.Request{ID: 3, UserID: 1}, nil)
+
+		_, err := usecase.PostMessage(9, 3, dto.MessageCreateDTO{Body: "   "})
+		assert.ErrorIs(t, err, domain.ErrEmptyMessage)
+		messages.AssertNotCalled(t, "CreateMessage", mock.Anything)
+	})
+
+	t.Run("unknown request", func(t *testing.T) {
+		requests := new(MockRequestRepository)
+		usecase := NewMessageUsecase(requests, new(MockMessageRepository), &captureTemplates{})
+		requests.On("GetByID", 3).Return(nil, domain.ErrRequestNotFound)
+
+		_, err := usecase.PostMessage(9, 3, dto.MessageCreateDTO{Body: "hello"})
+		assert.ErrorIs(t, err, domain.ErrRequestNotFound)
+	})
+}
+
+func TestPostOwnMessage(t *testing.T) {
+	t.Run("reply is emailed to the reviewer and the admins in the thread", func(t *testing.T) {
+		requests := new(MockRequestRepository)
+		messages := new(MockMessageRepository)
+		outbox := &captureTemplates{}
+		usecase := NewMessageUsecase(requests, messages, outbox)
+
+		verifierID := 8
+		requests.On("GetByID", 3).Return(&domain.Request{ID: 3, UserID: 1, VerifierID: &verifierID}, nil)
+		messages.On("ListMessages", 3).Return([]*domain.Message{
+			{ID: 1, RequestID: 3, SenderID: 9},
+			{ID: 2, RequestID: 3, SenderID: 1},
+			{ID: 3, RequestID: 3, SenderID: 8},
+		}, nil)
+		messages.On("CreateMessage", mock.Anything).Return(nil)
+		messages.On("GetParticipants", []int{1, 8, 9}).Return(participants, nil)
+
+		message, err := usecase.PostOwnMessage(1, 3, dto.MessageCreateDTO{Body: "Uploaded."})
+		assert.NoError(t, err)
+		assert.True(t, message.FromRequester)
+		assert.Len(t, outbox.sent, 2)
+		assert.Equal(t, "reviewer@example.com", outbox.sent[0].to)
+		assert.Equal(t, "admin@example.com", outbox.sent[1].to)
+		assert.Equal(t, "Jane", outbox.sent[0].data["SenderName"])
+	})
+
+	t.Run("request of another user", func(t *testing.T) {
+		requests := new(MockRequestRepository)
+		messages := new(MockMessageRepository)
+		usecase := NewMessageUsecase(requests, messages, &captureTemplates{})
+		requests.On("GetByID", 3).Return(&domain.Request{ID: 3, UserID: 2}, nil)
+
+		_, err := usecase.PostOwnMessage(1, 3, dto.MessageCreateDTO{Body: "hello"})
+		assert.ErrorIs(t, err, domain.ErrRequestNotFound)
+		messages.AssertNotCalled(t, "CreateMessage", mock.Anything)
+	})
+}
+
+func TestListOwnMessages(t *testing.T) {
+	requests := new(MockRequestRepository)
+	messages := new(MockMessageRepository)
+	usecase := NewMessageUsecase(requests, messages, &captureTemplates{})
+
+	requests.On("GetByID", 3).Return(&domain.Request{ID: 3, UserID: 1}, nil)
+	messages.On("ListMessages", 3).Return([]*domain.Message{
+		{ID: 1, RequestID: 3, SenderID: 9, Body: "Please upload a clearer photo."},
+		{ID: 2, RequestID: 3, SenderID: 1, Body: "Uploaded."},
+	}, nil)
+
+	thread, err := usecase.ListOwnMessages(1, 3)
+	assert.NoError(t, err)
+	assert.Len(t, thread, 2)
+	assert.False(t, thread[0].FromRequester)
+	assert.True(t, thread[1].FromRequester)
+
+	_, err = usecase.ListOwnMessages(2, 3)
+	assert.ErrorIs(t, err, domain.ErrRequestNotFound)
+}
@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/request/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/request/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/request/storage"
+)
+
+type RequestUsecaseInterface interface {
+	CreateRequest(userID int, input dto.RequestCreateDTO) (*dto.RequestResponseDTO, error)
+	ListRequests(userID int) ([]dto.RequestResponseDTO, error)
+	GetRequest(userID int, id int) (*dto.RequestResponseDTO, error)
+	CancelRequest(userID int, id int) error
+	ResubmitRequest(userID int, id int) (*dto.RequestResponseDTO, error)
+}
+
+type RequestUsecase struct {
+	RequestRepo storage.RequestRepositoryInterface
+}
+
+func NewRequestUsecase(requestRepo storage.RequestRepositoryInterface) *RequestUsecase {
+	return &RequestUsecase{RequestRepo: requestRepo}
+}
+
+// CreateRequest files a new pending request for the user. A user can only have
+// one pending request of each type.
+func (u *RequestUsecase) CreateRequest(userID int, input dto.RequestCreateDTO) (*dto.RequestResponseDTO, error) {
+	requestType := domain.RequestType(input.Type)
+	if !requestType.IsValid() {
+		return nil, domain.ErrInvalidRequestType
+	}
+	pending, err := u.RequestRepo.HasPendingRequest(userID, requestType)
+	if err != nil {
+		return nil, err
+	}
+	if pending {
+		return nil, domain.ErrPendingRequestExists
+	}
+
+	request := &domain.Request{
+		UserID: userID,
+		Type:   requestType,
+		Status: domain.RequestStatusPending,
+	}
+	if err := u.RequestRepo.Create(request); err != nil {
+		return nil, err
+	}
+	return toResponse(request), nil
+}
+
+func (u *RequestUsecase) ListRequests(userID int) ([]dto.RequestResponseDTO, error) {
+	requests, err := u.RequestRepo.ListByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	response := make([]dto.RequestResponseDTO, 0, len(requests))
+	for _, request := range requests {
+		response = append(response, *toResponse(request))
+	}
+	return response, nil
+}
+
+func (u *RequestUsecase) GetRequest(userID int, id int) (*dto.RequestResponseDTO, error) {
+	request, err := findOwnRequest(u.RequestRepo, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	return toResponse(request), nil
+}
+
+// CancelRequest withdraws a pending request.
+func (u *RequestUsecase) CancelRequest(userID int, id int) error {
+	request, err := findOwnRequest(u.RequestRepo, userID, id)
+	if err != nil {
+		return err
+	}
+	if err := request.TransitionTo(domain.RequestStatusCancelled); err != nil {
+		return err
+	}
+	return u.RequestRepo.Update(request)
+}
+
+// ResubmitRequest files a new pending revision of a rejected request. The
+// rejected request is kept as is so that its conversation stays visible.
+func (u *RequestUsecase) ResubmitRequest(userID int, id int) (*dto.RequestResponseDTO, error) {
+	rejected, err := findOwnRequest(u.RequestRepo, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if rejected.Status != domain.RequestStatusRejected {
+		return nil, domain.ErrRequestNotRejected
+	}
+	resubmitted, err := u.RequestRepo.HasRevision(rejected.ID)
+	if err != nil {
+		return nil, err
+	}
+	if resubmitted {
+		return nil, domain.ErrAlreadyResubmitted
+	}
+	pending, err := u.RequestRepo.HasPendingRequest(userID, rejected.Type)
+	if err != nil {
+		return nil, err
+	}
+	if pending {
+		return nil, domain.ErrPendingRequestExists
+	}
+
+	revision := &domain.Request{
+		UserID:   userID,
+		Type:     rejected.Type,
+		Status:   domain.RequestStatusPending,
+		ParentID: &rejected.ID,
+	}
+	if err := u.RequestRepo.Create(revision); err != nil {
+		return nil, err
+	}
+	return toResponse(revision), nil
+}
+
+// findOwnRequest hides requests of other users behind ErrRequestNotFound.
+func findOwnRequest(repo storage.RequestRepositoryInterface, userID int, id int) (*domain.Request, error) {
+	request, err := repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if request.UserID != userID {
+		return nil, domain.ErrRequestNotFound
+	}
+	return request, nil
+}
+
+func toResponse(request *domain.Request) *dto.RequestResponseDTO {
+	return &dto.RequestResponseDTO{
+		ID:        request.ID,
+		UserID:    request.UserID,
+		Type:      string(request.Type),
+		Status:    request.Status.String(),
+		ParentID:  request.ParentID,
+		CreatedAt: request.CreatedAt,
+		UpdatedAt: request.UpdatedAt,
+	}
+}
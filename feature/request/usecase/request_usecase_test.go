@@ -0,0 +1,171 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/request/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/request/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRequestRepository struct {
+	mock.Mock
+}
+
+func (m *MockRequestRepository) Create(request *domain.Request) error {
+	args := m.Called(request)
+	return args.Error(0)
+}
+
+func (m *MockRequestRepository) GetByID(id int) (*domain.Request, error) {
+	args := m.Called(id)
+	if args.Get(0) != nil {
+		return args.Get(0).(*domain.Request), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockRequestRepository) ListByUser(userID int) ([]*domain.Request, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]*domain.Request), args.Error(1)
+}
+
+func (m *MockRequestRepository) Update(request *domain.Request) error {
+	args := m.Called(request)
+	return args.Error(0)
+}
+
+func (m *MockRequestRepository) HasRevision(id int) (bool, error) {
+	args := m.Called(id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRequestRepository) HasPendingRequest(userID int, requestType domain.RequestType) (bool, error) {
+	args := m.Called(userID, requestType)
+	return args.Bool(0), args.Error(1)
+}
+
+func TestCreateRequest(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		repo := new(MockRequestRepository)
+		usecase := NewRequestUsecase(repo)
+		repo.On("HasPendingRequest", 1, domain.RequestTypeRegistration).Return(false, nil)
+		repo.On("Create", mock.MatchedBy(func(r *domain.Request) bool {
+			return r.UserID == 1 && r.Status == domain.RequestStatusPending
+		})).Return(nil)
+
+		response, err := usecase.CreateRequest(1, dto.RequestCreateDTO{Type: "registration"})
+		assert.NoError(t, err)
+		assert.Equal(t, "pending", response.Status)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("invalid type", func(t *testing.T) {
+		usecase := NewRequestUsecase(new(MockRequestRepository))
+
+		_, err := usecase.CreateRequest(1, dto.RequestCreateDTO{Type: "promotion"})
+		assert.ErrorIs(t, err, domain.ErrInvalidRequestType)
+	})
+
+	t.Run("pending request exists", func(t *testing.T) {
+		repo := new(MockRequestRepository)
+		usecase := NewRequestUsecase(repo)
+		repo.On("HasPendingRequest", 1, domain.RequestTypeVerification).Return(true, nil)
+
+		_, err := usecase.CreateRequest(1, dto.RequestCreateDTO{Type: "verification"})
+		assert.ErrorIs(t, err, domain.ErrPendingRequestExists)
+		repo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+}
+
+func TestGetRequest(t *testing.T) {
+	repo := new(MockRequestRepository)
+	usecase := NewRequestUsecase(repo)
+	repo.On("GetByID", 3).Return(&domain.Request{ID: 3, UserID: 2, Type: domain.RequestTypeRegistration}, nil)
+
+	response, err := usecase.GetRequest(2, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, response.ID)
+
+	_, err = usecase.GetRequest(1, 3)
+	assert.ErrorIs(t, err, domain.ErrRequestNotFound)
+}
+
+func TestCancelRequest(t *testing.T) {
+	t.Run("pending request", func(t *testing.T) {
+		repo := new(MockRequestRepository)
+		usecase := NewRequestUsecase(repo)
+		repo.On("GetByID", 1).Return(&domain.Request{ID: 1, UserID: 1, Status: domain.RequestStatusPending}, nil)
+		repo.On("Update", mock.MatchedBy(func(r *domain.Request) bool {
+			return r.Status == domain.RequestStatusCancelled
+		})).Return(nil)
+
+		assert.NoError(t, usecase.CancelRequest(1, 1))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("approved request", func(t *testing.T) {
+		repo := new(MockRequestRepository)
+		usecase := NewRequestUsecase(repo)
+		repo.On("GetByID", 1).Return(&domain.Request{ID: 1, UserID: 1, Status: domain.RequestStatusApproved}, nil)
+
+		assert.ErrorIs(t, usecase.CancelRequest(1, 1), domain.ErrInvalidTransition)
+	})
+}
+
+func TestResubmitRequest(t *testing.T) {
+	rejected := func() *domain.Request {
+		return &domain.Request{ID: 1, UserID: 1, Type: domain.RequestTypeVerification, Status: domain.RequestStatusRejected}
+	}
+
+	t.Run("rejected request", func(t *testing.T) {
+		repo := new(MockRequestRepository)
+		usecase := NewRequestUsecase(repo)
+		repo.On("GetByID", 1).Return(rejected(), nil)
+		repo.On("HasRevision", 1).Return(false, nil)
+		repo.On("HasPendingRequest", 1, domain.RequestTypeVerification).Return(false, nil)
+		repo.On("Create", mock.MatchedBy(func(r *domain.Request) bool {
+			return r.Status == domain.RequestStatusPending && r.ParentID != nil && *r.ParentID == 1 &&
+				r.Type == domain.RequestTypeVerification
+		})).Return(nil)
+
+		revision, err := usecase.ResubmitRequest(1, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, *revision.ParentID)
+		assert.Equal(t, "pending", revision.Status)
+		repo.AssertNotCalled(t, "Update", mock.Anything)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("pending request", func(t *testing.T) {
+		repo := new(MockRequestRepository)
+		usecase := NewRequestUsecase(repo)
+		repo.On("GetByID", 1).Return(&domain.Request{ID: 1, UserID: 1, Status: domain.RequestStatusPending}, nil)
+
+		_, err := usecase.ResubmitRequest(1, 1)
+		assert.ErrorIs(t, err, domain.ErrRequestNotRejected)
+	})
+
+	t.Run("already resubmitted", func(t *testing.T) {
+		repo := new(MockRequestRepository)
+		usecase := NewRequestUsecase(repo)
+		repo.On("GetByID", 1).Return(rejected(), nil)
+		repo.On("HasRevision", 1).Return(true, nil)
+
+		_, err := usecase.ResubmitRequest(1, 1)
+		assert.ErrorIs(t, err, domain.ErrAlreadyResubmitted)
+		repo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("other pending request", func(t *testing.T) {
+		repo := new(MockRequestRepository)
+		usecase := NewRequestUsecase(repo)
+		repo.On("GetByID", 1).Return(rejected(), nil)
+		repo.On("HasRevision", 1).Return(false, nil)
+		repo.On("HasPendingRequest", 1, domain.RequestTypeVerification).Return(true, nil)
+
+		_, err := usecase.ResubmitRequest(1, 1)
+		assert.ErrorIs(t, err, domain.ErrPendingRequestExists)
+	})
+}
@@ -0,0 +1,121 @@
+package retention
+
+import (
+	"log"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/blob"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Tables lists the soft-deleted tables in the order they are purged. Rows
+// that reference other tables come first so that the rows they point to can
+// be purged in the same run.
+var Tables = []string{"requests", "volunteer_details", "users", "departments", "countries", "roles"}
+
+// dependents lists, for each table, the tables whose rows only make sense
+// with their parent row and are purged together with it.
+var dependents = map[string][]string{
+	"users": {"refresh_tokens", "email_verification_tokens", "password_reset_tokens", "user_identities"},
+}
+
+// files lists, for each table, the queries that return the blob store keys
+// of the files a row and its dependents own. The files are deleted once the
+// row is purged.
+var files = map[string][]string{
+	"users": {
+		"SELECT avatar FROM users WHERE id = ? AND avatar IS NOT NULL AND avatar <> ''",
+		"SELECT front_scan FROM user_identities WHERE user_id = ? AND front_scan IS NOT NULL",
+		"SELECT back_scan FROM user_identities WHERE user_id = ? AND back_scan IS NOT NULL",
+	},
+}
+
+// Result counts the rows of a table that were purged, and those that were
+// skipped because they could not be deleted, usually because a row that is
+// kept still references them.
+type Result struct {
+	Table   string
+	Purged  int
+	Skipped int
+}
+
+type Purger struct {
+	DB    *gorm.DB
+	Files blob.BlobStore
+}
+
+func NewPurger(db *gorm.DB, files blob.BlobStore) *Purger {
+	return &Purger{DB: db, Files: files}
+}
+
+// Purge permanently deletes the rows of Tables that were soft-deleted before
+// cutoff. Each row is deleted in its own transaction so that a row that is
+// still referenced does not keep the others from being purged.
+func (p *Purger) Purge(cutoff time.Time) ([]Result, error) {
+	results := make([]Result, 0, len(Tables))
+	for _, table := range Tables {
+		var ids []int
+		err := p.DB.Table(table).Where("deleted_at < ?", cutoff).Order("id").Pluck("id", &ids).Error
+		if err != nil {
+			return results, err
+		}
+		result := Result{Table: table}
+		for _, id := range ids {
+			purged, err := p.purgeRow(table, id, cutoff)
+			if err != nil {
+				log.Printf("purge %s %d: %v", table, id, err)
+				result.Skipped++
+				continue
+			}
+			if purged {
+				result.Purged++
+			}
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// purgeRow deletes the row, its dependents and their files. It reports false
+// when the row was restored since the ids were listed.
+func (p *Purger) purgeRow(table string, id int, cutoff time.Time) (bool, error) {
+	purged := false
+	var keys []string
+	err := p.DB.Transaction(func(tx *gorm.DB) error {
+		var locked []int
+		err := tx.Table(table).Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND deleted_at < ?", id, cutoff).
+			Pluck("id", &locked).Error
+		if err != nil || len(locked) == 0 {
+			return err
+		}
+		for _, query := range files[table] {
+			var found []string
+			if err := tx.Raw(query, id).Scan(&found).Error; err != nil {
+				return err
+			}
+			keys = append(keys, found...)
+		}
+		for _, dependent := range dependents[table] {
+			if err := tx.Exec("DELETE FROM "+dependent+" WHERE user_id = ?", id).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Exec("DELETE FROM "+table+" WHERE id = ?", id).Error; err != nil {
+			return err
+		}
+		purged = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	// The rows are gone, so a file that cannot be deleted is only logged.
+	for _, key := range keys {
+		if err := p.Files.Delete(key); err != nil {
+			log.Printf("delete file %s of %s %d: %v", key, table, id, err)
+		}
+	}
+	return purged, nil
+}
@@ -0,0 +1,51 @@
+package retention
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/database/databasetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPurgeSQLite(t *testing.T) {
+	db := databasetest.Open(t)
+	old := time.Now().AddDate(0, 0, -100)
+	recent := time.Now().AddDate(0, 0, -1)
+	databasetest.Exec(t, db,
+		"INSERT INTO roles (id, code, name) VALUES (1, 'applicant', 'applicant')",
+		"INSERT INTO countries (id, name, status) VALUES (1, 'Vietnam', 1)",
+		`INSERT INTO users (id, role_id, email, password, name, surname, gender, dob, mobile, country_id, resident_country_id, status) VALUES
+			(1, 1, 'a@example.com', 'x', 'A', 'A', 'female', '1990-01-01', '1', 1, 1, 1),
+			(2, 1, 'b@example.com', 'x', 'B', 'B', 'female', '1990-01-01', '2', 1, 1, 1),
+			(3, 1, 'c@example.com', 'x', 'C', 'C', 'female', '1990-01-01', '3', 1, 1, 1)`,
+		"UPDATE users SET avatar = 'avatars/' || id WHERE id IN (1, 3)",
+		`INSERT INTO user_identities (user_id, number, type, status, expiry_date, place_issued, front_scan, back_scan) VALUES
+			(1, 'enc:v1:test', 'passport', 1, '2099-01-01', 'Hanoi', 'identities/1/front', NULL),
+			(1, 'enc:v1:test', 'national_id', 1, '2099-01-01', 'Hanoi', 'identities/2/front', 'identities/2/back')`,
+		// the request of user 3 is kept, so user 3 cannot be purged
+		"INSERT INTO requests (id, user_id, type, status) VALUES (1, 3, 'registration', 0)",
+	)
+	require.NoError(t, db.Exec("UPDATE users SET deleted_at = ? WHERE id IN (1, 3)", old).Error)
+	require.NoError(t, db.Exec("UPDATE users SET deleted_at = ? WHERE id = 2", recent).Error)
+
+	blobs := new(MockBlobStore)
+	for _, key := range []string{"avatars/1", "identities/1/front", "identities/2/front", "identities/2/back"} {
+		blobs.On("Delete", key).Return(nil).Once()
+	}
+
+	results, err := NewPurger(db, blobs).Purge(time.Now().AddDate(0, 0, -90))
+	require.NoError(t, err)
+	assert.Contains(t, results, Result{Table: "users", Purged: 1, Skipped: 1})
+
+	var ids []int
+	require.NoError(t, db.Table("users").Order("id").Pluck("id", &ids).Error)
+	assert.Equal(t, []int{2, 3}, ids)
+	var identities int64
+	require.NoError(t, db.Table("user_identities").Count(&identities).Error)
+	assert.Zero(t, identities)
+	// the files of user 3 are kept with the user
+	blobs.AssertExpectations(t)
+	blobs.AssertNotCalled(t, "Delete", "avatars/3")
+}
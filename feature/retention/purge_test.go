@@ -0,0 +1,113 @@
+package retention
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type MockBlobStore struct {
+	mock.Mock
+}
+
+func (m *MockBlobStore) Put(key string, data []byte, contentType string) error {
+	args := m.Called(key, data, contentType)
+	return args.Error(0)
+}
+
+func (m *MockBlobStore) Delete(key string) error {
+	args := m.Called(key)
+	return args.Error(0)
+}
+
+func (m *MockBlobStore) SignedURL(key string, ttl time.Duration) (string, error) {
+	args := m.Called(key, ttl)
+	return args.String(0), args.Error(1)
+}
+
+func setupSQLMock(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	assert.NoError(t, err)
+	return gormDB, mock
+}
+
+func TestPurge(t *testing.T) {
+	db, mock := setupSQLMock(t)
+	blobs := new(MockBlobStore)
+	purger := NewPurger(db, blobs)
+	cutoff := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	list := func(table string, ids ...int) {
+		rows := sqlmock.NewRows([]string{"id"})
+		for _, id := range ids {
+			rows.AddRow(id)
+		}
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT `id` FROM `" + table + "` WHERE deleted_at < ? ORDER BY id")).
+			WithArgs(cutoff).WillReturnRows(rows)
+	}
+	lock := func(table string, id int, found bool) {
+		rows := sqlmock.NewRows([]string{"id"})
+		if found {
+			rows.AddRow(id)
+		}
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT `id` FROM `"+table+"` WHERE id = ? AND deleted_at < ? FOR UPDATE")).
+			WithArgs(id, cutoff).WillReturnRows(rows)
+	}
+
+	list("requests", 4)
+	mock.ExpectBegin()
+	lock("requests", 4, true)
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM requests WHERE id = ?")).WithArgs(4).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	list("volunteer_details")
+
+	// user 7 was restored meanwhile, user 8 still verifies a request
+	list("users", 7, 8)
+	mock.ExpectBegin()
+	lock("users", 7, false)
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	lock("users", 8, true)
+	for _, query := range files["users"] {
+		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(8).
+			WillReturnRows(sqlmock.NewRows([]string{"key"}).AddRow("files/8"))
+	}
+	for _, dependent := range dependents["users"] {
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM " + dependent + " WHERE user_id = ?")).WithArgs(8).WillReturnResult(sqlmock.NewResult(0, 0))
+	}
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM users WHERE id = ?")).WithArgs(8).
+		WillReturnError(errors.New("Cannot delete or update a parent row: a foreign key constraint fails"))
+	mock.ExpectRollback()
+
+	list("departments")
+	list("countries")
+	list("roles")
+
+	results, err := purger.Purge(cutoff)
+	assert.NoError(t, err)
+	assert.Equal(t, []Result{
+		{Table: "requests", Purged: 1},
+		{Table: "volunteer_details"},
+		{Table: "users", Skipped: 1},
+		{Table: "departments"},
+		{Table: "countries"},
+		{Table: "roles"},
+	}, results)
+	assert.NoError(t, mock.ExpectationsWereMet())
+	// user 8 was not purged, so its files are kept
+	blobs.AssertNotCalled(t, "Delete", "files/8")
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+)
+
+// Permissions checked by the authorization middleware.
+const (
+	PermissionRequestReview   = "request.review"
+	PermissionCountryWrite    = "country.write"
+	PermissionDepartmentWrite = "department.write"
+	PermissionRoleWrite       = "role.write"
+)
+
+// Permissions describes the permissions above. They are the ones the
+// permissions table starts with.
+var Permissions = []Permission{
+	{Code: PermissionRequestReview, Description: "Review registration and verification requests"},
+	{Code: PermissionCountryWrite, Description: "Create, update and delete countries"},
+	{Code: PermissionDepartmentWrite, Description: "Create, update and delete departments"},
+	{Code: PermissionRoleWrite, Description: "Create, update and delete roles and grant them permissions"},
+}
+
+var (
+	ErrPermissionNotFound   = apperror.New(apperror.NotFound, "permission not found")
+	ErrPermissionNotGranted = apperror.New(apperror.NotFound, "permission is not granted to the role")
+)
+
+// Permission is a permission that roles can be granted.
+type Permission struct {
+	Id          uint      `gorm:"primaryKey" json:"id"`
+	Code        string    `gorm:"size:100;not null;uniqueIndex:uq_permissions_code" json:"code"`
+	Description string    `gorm:"size:255;not null" json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// RolePermission grants a permission to every user holding the role, or a
+// role that inherits from it.
+type RolePermission struct {
+	Id         uint      `gorm:"primaryKey" json:"id"`
+	RoleId     uint      `gorm:"not null;uniqueIndex:uq_role_permissions_role_permission" json:"role_id"`
+	Permission string    `gorm:"size:100;not null;uniqueIndex:uq_role_permissions_role_permission" json:"permission"`
+	CreatedAt  time.Time `json:"created_at"`
+}
@@ -2,13 +2,42 @@ package domain
 
 import (
 	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+	"gorm.io/gorm"
+)
+
+// Codes of the roles the application assigns or checks. Codes are the same
+// in every environment, unlike ids, and never change, unlike names.
+const (
+	RoleCodeApplicant = "applicant"
+	RoleCodeVolunteer = "volunteer"
+	RoleCodeAdmin     = "admin"
+	RoleCodeCOM       = "com"
+	RoleCodeCVL       = "cvl"
+	RoleCodeMNVC      = "mnvc"
+)
+
+var (
+	// ErrRoleNotFound is returned when a role is missing or, when restoring, not deleted.
+	ErrRoleNotFound  = apperror.New(apperror.NotFound, "role not found")
+	ErrRoleCodeTaken = apperror.New(apperror.Conflict, "role code is already used")
+	// ErrRoleCodeUnknown is returned when a role the application relies on
+	// has not been created, usually because the roles were not seeded.
+	ErrRoleCodeUnknown    = apperror.New(apperror.Internal, "role is not configured")
+	ErrParentRoleNotFound = apperror.New(apperror.Validation, "parent role not found")
+	ErrRoleCycle          = apperror.New(apperror.Validation, "a role cannot inherit from itself or from a role inheriting from it")
 )
 
 // Role struct represents the role entity interacting with the database using GORM.
+// A role inherits the permissions of its parent, if any.
 type Role struct {
-	Id        uint      `gorm:"primaryKey" json:"id"`
-	Name      string    `gorm:"size:255;not null;unique" json:"name"`
-	Status    uint      `gorm:"not null" json:"status"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	Id        uint           `gorm:"primaryKey" json:"id"`
+	Code      string         `gorm:"size:30;not null;uniqueIndex:uq_roles_code" json:"code"`
+	ParentId  *uint          `json:"parent_id"`
+	Name      string         `gorm:"size:255;not null;unique" json:"name"`
+	Status    uint           `gorm:"not null" json:"status"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at" swaggertype:"string"`
 }
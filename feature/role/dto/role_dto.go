@@ -1,15 +1,20 @@
 package dto
 
 // RoleCreateDTO represents the data transfer object for creating a role.
+// The code identifies the role in the code base and cannot be changed.
 type RoleCreateDTO struct {
-	Name   string `json:"name" binding:"required"`
-	Status uint   `json:"status" binding:"required"`
+	Code     string `json:"code" binding:"required,max=30"`
+	Name     string `json:"name" binding:"required"`
+	Status   uint   `json:"status" binding:"required"`
+	ParentID *uint  `json:"parent_id"`
 }
 
 // RoleUpdateDTO represents the data transfer object for updating a role.
+// Leaving out the parent removes it.
 type RoleUpdateDTO struct {
-	Name   string `json:"name" binding:"required"`
-	Status uint   `json:"status" binding:"required"`
+	Name     string `json:"name" binding:"required"`
+	Status   uint   `json:"status" binding:"required"`
+	ParentID *uint  `json:"parent_id"`
 }
 
 // RoleResponseDTO represents the data transfer object for updating a role.
@@ -17,3 +22,22 @@ type RoleResponseDTO struct {
 	Name   string `json:"name" binding:"required"`
 	Status uint   `json:"status" binding:"required"`
 }
+
+// PermissionGrantDTO represents the data transfer object for granting a permission to a role.
+type PermissionGrantDTO struct {
+	Permission string `json:"permission" binding:"required"`
+}
+
+// RolePermissionsDTO represents the permissions of a role: those granted to
+// the role itself, and all those it holds, inherited ones included.
+type RolePermissionsDTO struct {
+	RoleID    uint     `json:"role_id"`
+	Granted   []string `json:"granted"`
+	Effective []string `json:"effective"`
+}
+
+// MyPermissionsDTO represents the role and the permissions of the current user.
+type MyPermissionsDTO struct {
+	Role        string   `json:"role"`
+	Permissions []string `json:"permissions"`
+}
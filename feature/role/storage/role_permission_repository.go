@@ -0,0 +1,83 @@
+package storage
+
+import (
+	"errors"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/role/domain"
+	"gorm.io/gorm"
+)
+
+// RolePermissionRepositoryInterface defines the methods to manage the permissions granted to roles.
+type RolePermissionRepositoryInterface interface {
+	ListPermissions() ([]domain.Permission, error)
+	ListGrants() ([]domain.RolePermission, error)
+	Grant(roleID uint, permission string) error
+	Revoke(roleID uint, permission string) error
+}
+
+// RolePermissionRepository handles the permissions and their grants in the database.
+type RolePermissionRepository struct {
+	DB *gorm.DB
+}
+
+// NewRolePermissionRepository creates a new instance of RolePermissionRepository.
+func NewRolePermissionRepository(db *gorm.DB) *RolePermissionRepository {
+	return &RolePermissionRepository{DB: db}
+}
+
+// ListPermissions retrieves the permissions that roles can be granted.
+func (r *RolePermissionRepository) ListPermissions() ([]domain.Permission, error) {
+	var permissions []domain.Permission
+	err := r.DB.Order("code").Find(&permissions).Error
+	return permissions, err
+}
+
+// ListGrants retrieves the permissions granted to every role, deleted roles included.
+func (r *RolePermissionRepository) ListGrants() ([]domain.RolePermission, error) {
+	var grants []domain.RolePermission
+	err := r.DB.Order("role_id, permission").Find(&grants).Error
+	return grants, err
+}
+
+// Grant grants the permission to the role. Granting a permission the role
+// already holds does nothing.
+func (r *RolePermissionRepository) Grant(roleID uint, permission string) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		var known int64
+		if err := tx.Model(&domain.Permission{}).Where("code = ?", permission).Count(&known).Error; err != nil {
+			return err
+		}
+		if known == 0 {
+			return domain.ErrPermissionNotFound
+		}
+		err := tx.Select("id").First(&domain.Role{}, roleID).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.ErrRoleNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		var granted int64
+		err = tx.Model(&domain.RolePermission{}).
+			Where("role_id = ? AND permission = ?", roleID, permission).
+			Count(&granted).Error
+		if err != nil || granted > 0 {
+			return err
+		}
+		return tx.Create(&domain.RolePermission{RoleId: roleID, Permission: permission}).Error
+	})
+}
+
+// Revoke takes the permission back from the role. Permissions the role
+// inherits from its parent are not affected.
+func (r *RolePermissionRepository) Revoke(roleID uint, permission string) error {
+	result := r.DB.Where("role_id = ? AND permission = ?", roleID, permission).Delete(&domain.RolePermission{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrPermissionNotGranted
+	}
+	return nil
+}
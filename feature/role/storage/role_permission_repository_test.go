@@ -0,0 +1,135 @@
+package storage
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/role/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRolePermissionRepository_ListGrants(t *testing.T) {
+	gormDB, mock, err := setupMockDB()
+	if err != nil {
+		t.Fatalf("failed to setup mock db: %v", err)
+	}
+	defer func() {
+		sqlDB, _ := gormDB.DB()
+		sqlDB.Close()
+	}()
+
+	repo := NewRolePermissionRepository(gormDB)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `role_permissions` ORDER BY role_id, permission")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "role_id", "permission"}).
+			AddRow(1, 3, domain.PermissionCountryWrite).
+			AddRow(2, 3, domain.PermissionRoleWrite))
+
+	grants, err := repo.ListGrants()
+	assert.NoError(t, err)
+	assert.Len(t, grants, 2)
+	assert.Equal(t, uint(3), grants[1].RoleId)
+	assert.Equal(t, domain.PermissionRoleWrite, grants[1].Permission)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRolePermissionRepository_Grant(t *testing.T) {
+	knownPermission := regexp.QuoteMeta("SELECT count(*) FROM `permissions` WHERE code = ?")
+	role := regexp.QuoteMeta("SELECT `id` FROM `roles` WHERE `roles`.`id` = ? AND `roles`.`deleted_at` IS NULL ORDER BY `roles`.`id` LIMIT ?")
+	granted := regexp.QuoteMeta("SELECT count(*) FROM `role_permissions` WHERE role_id = ? AND permission = ?")
+
+	t.Run("new grant", func(t *testing.T) {
+		gormDB, mock, err := setupMockDB()
+		if err != nil {
+			t.Fatalf("failed to setup mock db: %v", err)
+		}
+		repo := NewRolePermissionRepository(gormDB)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(knownPermission).WithArgs(domain.PermissionCountryWrite).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectQuery(role).WithArgs(4, 1).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+		mock.ExpectQuery(granted).WithArgs(4, domain.PermissionCountryWrite).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `role_permissions` (`role_id`,`permission`,`created_at`) VALUES (?,?,?)")).
+			WithArgs(4, domain.PermissionCountryWrite, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(5, 1))
+		mock.ExpectCommit()
+
+		assert.NoError(t, repo.Grant(4, domain.PermissionCountryWrite))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("already granted", func(t *testing.T) {
+		gormDB, mock, err := setupMockDB()
+		if err != nil {
+			t.Fatalf("failed to setup mock db: %v", err)
+		}
+		repo := NewRolePermissionRepository(gormDB)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(knownPermission).WithArgs(domain.PermissionCountryWrite).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectQuery(role).WithArgs(4, 1).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+		mock.ExpectQuery(granted).WithArgs(4, domain.PermissionCountryWrite).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectCommit()
+
+		assert.NoError(t, repo.Grant(4, domain.PermissionCountryWrite))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("unknown permission", func(t *testing.T) {
+		gormDB, mock, err := setupMockDB()
+		if err != nil {
+			t.Fatalf("failed to setup mock db: %v", err)
+		}
+		repo := NewRolePermissionRepository(gormDB)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(knownPermission).WithArgs("country.burn").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectRollback()
+
+		assert.ErrorIs(t, repo.Grant(4, "country.burn"), domain.ErrPermissionNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("unknown role", func(t *testing.T) {
+		gormDB, mock, err := setupMockDB()
+		if err != nil {
+			t.Fatalf("failed to setup mock db: %v", err)
+		}
+		repo := NewRolePermissionRepository(gormDB)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(knownPermission).WithArgs(domain.PermissionCountryWrite).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectQuery(role).WithArgs(9, 1).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectRollback()
+
+		assert.ErrorIs(t, repo.Grant(9, domain.PermissionCountryWrite), domain.ErrRoleNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRolePermissionRepository_Revoke(t *testing.T) {
+	revoke := regexp.QuoteMeta("DELETE FROM `role_permissions` WHERE role_id = ? AND permission = ?")
+	gormDB, mock, err := setupMockDB()
+	if err != nil {
+		t.Fatalf("failed to setup mock db: %v", err)
+	}
+	repo := NewRolePermissionRepository(gormDB)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(revoke).WithArgs(4, domain.PermissionCountryWrite).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec(revoke).WithArgs(4, domain.PermissionCountryWrite).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	assert.NoError(t, repo.Revoke(4, domain.PermissionCountryWrite))
+	assert.ErrorIs(t, repo.Revoke(4, domain.PermissionCountryWrite), domain.ErrPermissionNotGranted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -1,6 +1,8 @@
 package storage
 
 import (
+	"errors"
+
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/role/domain"
 	"gorm.io/gorm"
 )
@@ -9,8 +11,10 @@ import (
 type RoleRepositoryInterface interface {
 	Create(role *domain.Role) error
 	GetByID(id uint) (*domain.Role, error)
+	List() ([]domain.Role, error)
 	Update(role *domain.Role) error
 	Delete(id uint) error
+	Restore(id uint) error
 }
 
 // RoleRepository handles the CRUD operations with the database.
@@ -23,8 +27,16 @@ func NewRoleRepository(db *gorm.DB) *RoleRepository {
 	return &RoleRepository{DB: db}
 }
 
-// Create inserts a new role record into the database.
+// Create inserts a new role record into the database. Codes of deleted
+// roles stay taken so that a restored role keeps its code.
 func (r *RoleRepository) Create(role *domain.Role) error {
+	var count int64
+	if err := r.DB.Unscoped().Model(&domain.Role{}).Where("code = ?", role.Code).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return domain.ErrRoleCodeTaken
+	}
 	return r.DB.Create(role).Error
 }
 
@@ -32,9 +44,19 @@ func (r *RoleRepository) Create(role *domain.Role) error {
 func (r *RoleRepository) GetByID(id uint) (*domain.Role, error) {
 	var role domain.Role
 	err := r.DB.First(&role, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrRoleNotFound
+	}
 	return &role, err
 }
 
+// List retrieves the roles that are not deleted.
+func (r *RoleRepository) List() ([]domain.Role, error) {
+	var roles []domain.Role
+	err := r.DB.Order("id").Find(&roles).Error
+	return roles, err
+}
+
 // Update updates a role record in the database.
 func (r *RoleRepository) Update(role *domain.Role) error {
 	return r.DB.Save(role).Error
@@ -44,3 +66,17 @@ func (r *RoleRepository) Update(role *domain.Role) error {
 func (r *RoleRepository) Delete(id uint) error {
 	return r.DB.Delete(&domain.Role{}, id).Error
 }
+
+// Restore clears deleted_at of a deleted role record.
+func (r *RoleRepository) Restore(id uint) error {
+	result := r.DB.Unscoped().Model(&domain.Role{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrRoleNotFound
+	}
+	return nil
+}
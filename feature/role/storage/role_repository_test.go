@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
@@ -8,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
 )
 
 func setupMockDB() (*gorm.DB, sqlmock.Sqlmock, error) {
@@ -17,8 +19,9 @@ func setupMockDB() (*gorm.DB, sqlmock.Sqlmock, error) {
 	}
 
 	gormDB, err := gorm.Open(mysql.New(mysql.Config{
-		Conn: db,
-	}), &gorm.Config{})
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
 	if err != nil {
 		return nil, nil, err
 	}
@@ -39,16 +42,67 @@ func TestRoleRepository_Create(t *testing.T) {
 	repo := NewRoleRepository(gormDB)
 
 	role := &domain.Role{
+		Code:   "admin",
 		Name:   "Admin",
 		Status: 123,
 	}
 
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `roles` WHERE code = ?")).
+		WithArgs(role.Code).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
 	mock.ExpectBegin()
-	mock.ExpectExec("INSERT INTO `roles`").WithArgs(role.Name, role.Status).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO `roles`").WithArgs(role.Code, nil, role.Name, role.Status, sqlmock.AnyArg(), sqlmock.AnyArg(), nil).WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
 	err = repo.Create(role)
 	assert.NoError(t, err)
+	assert.Equal(t, uint(1), role.Id)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRoleRepository_CreateCodeTaken(t *testing.T) {
+	gormDB, mock, err := setupMockDB()
+	if err != nil {
+		t.Fatalf("failed to setup mock db: %v", err)
+	}
+	defer func() {
+		sqlDB, _ := gormDB.DB()
+		sqlDB.Close()
+	}()
+
+	repo := NewRoleRepository(gormDB)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `roles` WHERE code = ?")).
+		WithArgs("admin").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	err = repo.Create(&domain.Role{Code: "admin", Name: "Administrators", Status: 1})
+	assert.ErrorIs(t, err, domain.ErrRoleCodeTaken)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRoleRepository_List(t *testing.T) {
+	gormDB, mock, err := setupMockDB()
+	if err != nil {
+		t.Fatalf("failed to setup mock db: %v", err)
+	}
+	defer func() {
+		sqlDB, _ := gormDB.DB()
+		sqlDB.Close()
+	}()
+
+	repo := NewRoleRepository(gormDB)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `roles` WHERE `roles`.`deleted_at` IS NULL ORDER BY id")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "code", "name", "status"}).
+			AddRow(1, domain.RoleCodeApplicant, "Applicant", 1).
+			AddRow(3, domain.RoleCodeAdmin, "Admin", 1))
+
+	roles, err := repo.List()
+	assert.NoError(t, err)
+	assert.Len(t, roles, 2)
+	assert.Equal(t, uint(3), roles[1].Id)
+	assert.Equal(t, domain.RoleCodeAdmin, roles[1].Code)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -70,13 +124,18 @@ func TestRoleRepository_GetByID(t *testing.T) {
 	}
 
 	rows := sqlmock.NewRows([]string{"id", "name", "status"}).AddRow(1, role.Name, role.Status)
-	mock.ExpectQuery("SELECT * FROM `roles` WHERE `roles`.`id` = ?").WithArgs(1).WillReturnRows(rows)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `roles` WHERE `roles`.`id` = ? AND `roles`.`deleted_at` IS NULL")).WithArgs(uint(1), 1).WillReturnRows(rows)
 
 	result, err := repo.GetByID(1)
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.Equal(t, role.Name, result.Name)
 	assert.Equal(t, role.Status, result.Status)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `roles`")).WithArgs(uint(2), 1).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	_, err = repo.GetByID(2)
+	assert.ErrorIs(t, err, domain.ErrRoleNotFound)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -93,12 +152,14 @@ func TestRoleRepository_Update(t *testing.T) {
 	repo := NewRoleRepository(gormDB)
 
 	role := &domain.Role{
+		Id:     1,
+		Code:   "admin",
 		Name:   "Admin",
 		Status: 789,
 	}
 
 	mock.ExpectBegin()
-	mock.ExpectExec("UPDATE `roles` SET `name`=?,`status`=? WHERE `id` = ?").WithArgs(role.Name, role.Status, role.Id).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `roles` SET `code`=?,`parent_id`=?,`name`=?,`status`=?,`created_at`=?,`updated_at`=?,`deleted_at`=? WHERE `roles`.`deleted_at` IS NULL AND `id` = ?")).WithArgs(role.Code, nil, role.Name, role.Status, sqlmock.AnyArg(), sqlmock.AnyArg(), nil, role.Id).WillReturnResult(sqlmock.NewResult(0, 1))
 	mock.ExpectCommit()
 
 	err = repo.Update(role)
@@ -118,7 +179,7 @@ func TestRoleRepository_Delete(t *testing.T) {
 	repo := NewRoleRepository(gormDB)
 
 	mock.ExpectBegin()
-	mock.ExpectExec("DELETE FROM `roles` WHERE `id` = ?").WithArgs(1).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `roles` SET `deleted_at`=? WHERE `roles`.`id` = ? AND `roles`.`deleted_at` IS NULL")).WithArgs(sqlmock.AnyArg(), 1).WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
 	err = repo.Delete(1)
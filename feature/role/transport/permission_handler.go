@@ -0,0 +1,145 @@
+package transport
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/role/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/role/usecase"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/validation"
+	"github.com/gin-gonic/gin"
+)
+
+// PermissionHandler handles the HTTP requests for the permissions of roles.
+type PermissionHandler struct {
+	usecase usecase.PermissionUsecaseInterface
+}
+
+// NewPermissionHandler creates a new instance of PermissionHandler.
+func NewPermissionHandler(usecase usecase.PermissionUsecaseInterface) *PermissionHandler {
+	return &PermissionHandler{usecase: usecase}
+}
+
+// ListPermissions godoc
+// @Summary List permissions
+// @Description List the permissions that roles can be granted
+// @Produce json
+// @Tags role
+// @Security bearerToken
+// @Success 200 {array} domain.Permission
+// @Router /api/v1/permissions [get]
+func (h *PermissionHandler) ListPermissions(c *gin.Context) {
+	permissions, err := h.usecase.ListPermissions()
+	if err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, permissions)
+}
+
+// GetRolePermissions godoc
+// @Summary Get role permissions
+// @Description Permissions granted to a role, and all those it holds including the ones inherited from its parents
+// @Produce json
+// @Tags role
+// @Security bearerToken
+// @Param id path int true "Role ID"
+// @Success 200 {object} dto.RolePermissionsDTO
+// @Failure 404 {object} apperror.Response "Role not found"
+// @Router /api/v1/role/{id}/permissions [get]
+func (h *PermissionHandler) GetRolePermissions(c *gin.Context) {
+	id, ok := roleID(c)
+	if !ok {
+		return
+	}
+	permissions, err := h.usecase.RolePermissions(id)
+	if err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, permissions)
+}
+
+// GrantPermission godoc
+// @Summary Grant permission
+// @Description Grant a permission to a role and to the roles inheriting from it. Granting a permission twice does nothing.
+// @Accept json
+// @Produce json
+// @Tags role
+// @Security bearerToken
+// @Param id path int true "Role ID"
+// @Param request body dto.PermissionGrantDTO true "Grant Permission Request"
+// @Success 200 {string} message "Permission granted successfully"
+// @Failure 404 {object} apperror.Response "Role or permission not found"
+// @Router /api/v1/role/{id}/permissions [post]
+func (h *PermissionHandler) GrantPermission(c *gin.Context) {
+	id, ok := roleID(c)
+	if !ok {
+		return
+	}
+	var input dto.PermissionGrantDTO
+	if err := c.ShouldBindJSON(&input); err != nil {
+		apperror.Abort(c, validation.Error(err))
+		return
+	}
+	if err := h.usecase.GrantPermission(id, input); err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "permission granted successfully"})
+}
+
+// RevokePermission godoc
+// @Summary Revoke permission
+// @Description Take back a permission granted to a role. Permissions inherited from its parents are not affected.
+// @Produce json
+// @Tags role
+// @Security bearerToken
+// @Param id path int true "Role ID"
+// @Param permission path string true "Permission code"
+// @Success 204
+// @Failure 404 {object} apperror.Response "Permission is not granted to the role"
+// @Router /api/v1/role/{id}/permissions/{permission} [delete]
+func (h *PermissionHandler) RevokePermission(c *gin.Context) {
+	id, ok := roleID(c)
+	if !ok {
+		return
+	}
+	if err := h.usecase.RevokePermission(id, c.Param("permission")); err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// MyPermissions godoc
+// @Summary My permissions
+// @Description Role and permissions of the current user, inherited ones included, to render menus
+// @Produce json
+// @Tags role
+// @Security bearerToken
+// @Success 200 {object} dto.MyPermissionsDTO
+// @Router /api/v1/me/permissions [get]
+func (h *PermissionHandler) MyPermissions(c *gin.Context) {
+	roleID, exists := c.Get("roleId")
+	if !exists {
+		apperror.Abort(c, apperror.ErrUnauthorized)
+		return
+	}
+	permissions, err := h.usecase.MyPermissions(roleID.(int))
+	if err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, permissions)
+}
+
+func roleID(c *gin.Context) (uint, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		apperror.Abort(c, apperror.New(apperror.Validation, "Invalid role ID"))
+		return 0, false
+	}
+	return uint(id), true
+}
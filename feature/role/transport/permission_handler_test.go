@@ -0,0 +1,107 @@
+package transport
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/role/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/role/dto"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockPermissionUsecase is a mock implementation of the PermissionUsecaseInterface.
+type MockPermissionUsecase struct {
+	mock.Mock
+}
+
+func (m *MockPermissionUsecase) ListPermissions() ([]domain.Permission, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.Permission), args.Error(1)
+}
+
+func (m *MockPermissionUsecase) RolePermissions(roleID uint) (*dto.RolePermissionsDTO, error) {
+	args := m.Called(roleID)
+	return args.Get(0).(*dto.RolePermissionsDTO), args.Error(1)
+}
+
+func (m *MockPermissionUsecase) GrantPermission(roleID uint, input dto.PermissionGrantDTO) error {
+	args := m.Called(roleID, input)
+	return args.Error(0)
+}
+
+func (m *MockPermissionUsecase) RevokePermission(roleID uint, permission string) error {
+	args := m.Called(roleID, permission)
+	return args.Error(0)
+}
+
+func (m *MockPermissionUsecase) MyPermissions(roleID int) (*dto.MyPermissionsDTO, error) {
+	args := m.Called(roleID)
+	return args.Get(0).(*dto.MyPermissionsDTO), args.Error(1)
+}
+
+func newPermissionRouter(usecase *MockPermissionUsecase) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	handler := NewPermissionHandler(usecase)
+	router := gin.New()
+	router.Use(apperror.Middleware())
+	router.GET("/api/v1/role/:id/permissions", handler.GetRolePermissions)
+	router.POST("/api/v1/role/:id/permissions", handler.GrantPermission)
+	router.DELETE("/api/v1/role/:id/permissions/:permission", handler.RevokePermission)
+	router.GET("/api/v1/me/permissions", func(c *gin.Context) { c.Set("roleId", 3) }, handler.MyPermissions)
+	return router
+}
+
+func TestPermissionHandler_GrantPermission(t *testing.T) {
+	usecase := new(MockPermissionUsecase)
+	router := newPermissionRouter(usecase)
+	usecase.On("GrantPermission", uint(2), dto.PermissionGrantDTO{Permission: domain.PermissionCountryWrite}).Return(nil)
+	usecase.On("GrantPermission", uint(2), dto.PermissionGrantDTO{Permission: "country.burn"}).Return(domain.ErrPermissionNotFound)
+
+	for permission, status := range map[string]int{
+		domain.PermissionCountryWrite: http.StatusOK,
+		"country.burn":                http.StatusNotFound,
+		"":                            http.StatusBadRequest,
+	} {
+		body, _ := json.Marshal(dto.PermissionGrantDTO{Permission: permission})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/role/2/permissions", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		assert.Equal(t, status, w.Code, permission)
+	}
+}
+
+func TestPermissionHandler_RevokePermission(t *testing.T) {
+	usecase := new(MockPermissionUsecase)
+	router := newPermissionRouter(usecase)
+	usecase.On("RevokePermission", uint(2), domain.PermissionCountryWrite).Return(nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodDelete, "/api/v1/role/2/permissions/country.write", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodDelete, "/api/v1/role/abc/permissions/country.write", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	usecase.AssertExpectations(t)
+}
+
+func TestPermissionHandler_MyPermissions(t *testing.T) {
+	usecase := new(MockPermissionUsecase)
+	router := newPermissionRouter(usecase)
+	usecase.On("MyPermissions", 3).Return(&dto.MyPermissionsDTO{Role: domain.RoleCodeCVL, Permissions: []string{domain.PermissionRequestReview}}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/me/permissions", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"role":"cvl","permissions":["request.review"]}`, w.Body.String())
+}
@@ -4,8 +4,10 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/role/dto"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/role/usecase"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/validation"
 	"github.com/gin-gonic/gin"
 )
 
@@ -25,23 +27,25 @@ func NewRoleHandler(usecase usecase.RoleUsecaseInterface) *RoleHandler {
 // @Description Create role
 // @Produce json
 // @Tags role
+// @Security bearerToken
 // @Param request body dto.RoleCreateDTO true "Create Role Request"
 // @Success 201 {object} domain.Role
+// @Failure 409 {object} apperror.Response "Role code is already used"
 // @Router /api/v1/role/ [post]
 func (h *RoleHandler) CreateRole(c *gin.Context) {
 	var input dto.RoleCreateDTO
 	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apperror.Abort(c, validation.Error(err))
 		return
 	}
 
 	err := h.usecase.CreateRole(input)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apperror.Abort(c, err)
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{"message": "department created successfully"})
+	c.JSON(http.StatusCreated, gin.H{"message": "role created successfully"})
 }
 
 // GetRoleByID handles the HTTP GET request to retrieve a role by its ID.
@@ -56,13 +60,13 @@ func (h *RoleHandler) CreateRole(c *gin.Context) {
 func (h *RoleHandler) GetRoleByID(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid role ID"})
+		apperror.Abort(c, apperror.New(apperror.Validation, "Invalid role ID"))
 		return
 	}
 
 	role, err := h.usecase.GetRoleByID(uint(id))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Role not found"})
+		apperror.Abort(c, err)
 		return
 	}
 
@@ -75,6 +79,7 @@ func (h *RoleHandler) GetRoleByID(c *gin.Context) {
 // @Description Update role
 // @Produce json
 // @Tags role
+// @Security bearerToken
 // @Param id path int true "Role ID"
 // @Param request body dto.RoleUpdateDTO true "Update Role Request"
 // @Success 200 {object} domain.Role
@@ -82,18 +87,18 @@ func (h *RoleHandler) GetRoleByID(c *gin.Context) {
 func (h *RoleHandler) UpdateRole(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid role ID"})
+		apperror.Abort(c, apperror.New(apperror.Validation, "Invalid role ID"))
 		return
 	}
 
 	var input dto.RoleUpdateDTO
 	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apperror.Abort(c, validation.Error(err))
 		return
 	}
 
 	if err := h.usecase.UpdateRole(uint(id), input); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apperror.Abort(c, err)
 		return
 	}
 
@@ -106,21 +111,49 @@ func (h *RoleHandler) UpdateRole(c *gin.Context) {
 // @Description Delete role
 // @Produce json
 // @Tags role
+// @Security bearerToken
 // @Param id path int true "Role ID"
 // @Success 204
 // @Router /api/v1/role/{id} [delete]
 func (h *RoleHandler) DeleteRole(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid role ID"})
+		apperror.Abort(c, apperror.New(apperror.Validation, "Invalid role ID"))
 		return
 	}
 
 	err = h.usecase.DeleteRole(uint(id))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apperror.Abort(c, err)
 		return
 	}
 
 	c.JSON(http.StatusNoContent, nil)
 }
+
+// RestoreRole handles the HTTP POST request to restore a deleted role.
+// RestoreRole godoc
+// @Summary Restore role
+// @Description Restore a deleted role
+// @Produce json
+// @Tags role
+// @Security bearerToken
+// @Param id path int true "Role ID"
+// @Success 200 {string} message "Role restored successfully"
+// @Failure 404 {object} apperror.Response "No deleted role with this ID"
+// @Router /api/v1/role/{id}/restore [post]
+func (h *RoleHandler) RestoreRole(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apperror.Abort(c, apperror.New(apperror.Validation, "Invalid role ID"))
+		return
+	}
+
+	err = h.usecase.RestoreRole(uint(id))
+	if err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "role restored successfully"})
+}
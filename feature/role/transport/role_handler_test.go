@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/role/domain"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/role/dto"
 	"github.com/gin-gonic/gin"
@@ -39,15 +40,22 @@ func (m *MockRoleUsecase) DeleteRole(id uint) error {
 	return args.Error(0)
 }
 
+func (m *MockRoleUsecase) RestoreRole(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
 func TestRoleHandler_CreateRole(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	mockUsecase := new(MockRoleUsecase)
 	handler := NewRoleHandler(mockUsecase)
 
 	router := gin.Default()
+	router.Use(apperror.Middleware())
 	router.POST("/api/v1/role", handler.CreateRole)
 
 	input := dto.RoleCreateDTO{
+		Code:   "admin",
 		Name:   "Admin",
 		Status: 123,
 	}
@@ -62,7 +70,7 @@ func TestRoleHandler_CreateRole(t *testing.T) {
 	router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusCreated, w.Code)
-	assert.Contains(t, w.Body.String(), "Role created successfully")
+	assert.Contains(t, w.Body.String(), "role created successfully")
 	mockUsecase.AssertCalled(t, "CreateRole", input)
 }
 
@@ -72,6 +80,7 @@ func TestRoleHandler_GetRoleByID(t *testing.T) {
 	handler := NewRoleHandler(mockUsecase)
 
 	router := gin.Default()
+	router.Use(apperror.Middleware())
 	router.GET("/api/v1/role/:id", handler.GetRoleByID)
 
 	role := &domain.Role{
@@ -97,6 +106,7 @@ func TestRoleHandler_UpdateRole(t *testing.T) {
 	handler := NewRoleHandler(mockUsecase)
 
 	router := gin.Default()
+	router.Use(apperror.Middleware())
 	router.PUT("/api/v1/role/:id", handler.UpdateRole)
 
 	input := dto.RoleUpdateDTO{
@@ -114,7 +124,7 @@ func TestRoleHandler_UpdateRole(t *testing.T) {
 	router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusOK, w.Code)
-	assert.Contains(t, w.Body.String(), "department updated successfully")
+	assert.Contains(t, w.Body.String(), "role updated successfully")
 	mockUsecase.AssertCalled(t, "UpdateRole", uint(1), input)
 }
 
@@ -124,6 +134,7 @@ func TestRoleHandler_DeleteRole(t *testing.T) {
 	handler := NewRoleHandler(mockUsecase)
 
 	router := gin.Default()
+	router.Use(apperror.Middleware())
 	router.DELETE("/api/v1/role/:id", handler.DeleteRole)
 
 	mockUsecase.On("DeleteRole", uint(1)).Return(nil)
@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/role/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/role/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/role/storage"
+)
+
+// PermissionUsecaseInterface defines the methods to manage the permissions of roles.
+type PermissionUsecaseInterface interface {
+	ListPermissions() ([]domain.Permission, error)
+	RolePermissions(roleID uint) (*dto.RolePermissionsDTO, error)
+	GrantPermission(roleID uint, input dto.PermissionGrantDTO) error
+	RevokePermission(roleID uint, permission string) error
+	MyPermissions(roleID int) (*dto.MyPermissionsDTO, error)
+}
+
+// PermissionRegistry resolves the permissions of roles, inherited ones included.
+type PermissionRegistry interface {
+	RoleRefresher
+	RoleCode(roleID int) (string, error)
+	Permissions(roleID int) ([]string, error)
+	GrantedPermissions(roleID int) ([]string, error)
+}
+
+// PermissionUsecase handles the business logic for permissions.
+type PermissionUsecase struct {
+	repo     storage.RolePermissionRepositoryInterface
+	registry PermissionRegistry
+}
+
+// NewPermissionUsecase creates a new instance of PermissionUsecase.
+func NewPermissionUsecase(repo storage.RolePermissionRepositoryInterface, registry PermissionRegistry) *PermissionUsecase {
+	return &PermissionUsecase{repo: repo, registry: registry}
+}
+
+// ListPermissions lists the permissions that roles can be granted.
+func (u *PermissionUsecase) ListPermissions() ([]domain.Permission, error) {
+	return u.repo.ListPermissions()
+}
+
+// RolePermissions returns the permissions granted to a role and those it inherits.
+func (u *PermissionUsecase) RolePermissions(roleID uint) (*dto.RolePermissionsDTO, error) {
+	granted, err := u.registry.GrantedPermissions(int(roleID))
+	if err != nil {
+		return nil, err
+	}
+	effective, err := u.registry.Permissions(int(roleID))
+	if err != nil {
+		return nil, err
+	}
+	return &dto.RolePermissionsDTO{RoleID: roleID, Granted: granted, Effective: effective}, nil
+}
+
+// GrantPermission grants a permission to a role, and so to the roles inheriting from it.
+func (u *PermissionUsecase) GrantPermission(roleID uint, input dto.PermissionGrantDTO) error {
+	return refreshed(u.registry, u.repo.Grant(roleID, input.Permission))
+}
+
+// RevokePermission takes back a permission granted to a role.
+func (u *PermissionUsecase) RevokePermission(roleID uint, permission string) error {
+	return refreshed(u.registry, u.repo.Revoke(roleID, permission))
+}
+
+// MyPermissions returns the role and the permissions of the user holding roleID.
+func (u *PermissionUsecase) MyPermissions(roleID int) (*dto.MyPermissionsDTO, error) {
+	code, err := u.registry.RoleCode(roleID)
+	if err != nil {
+		return nil, err
+	}
+	permissions, err := u.registry.Permissions(roleID)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.MyPermissionsDTO{Role: code, Permissions: permissions}, nil
+}
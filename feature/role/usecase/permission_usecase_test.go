@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/role/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/role/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockRolePermissionRepository is a mock implementation of the RolePermissionRepositoryInterface.
+type MockRolePermissionRepository struct {
+	mock.Mock
+}
+
+func (m *MockRolePermissionRepository) ListPermissions() ([]domain.Permission, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.Permission), args.Error(1)
+}
+
+func (m *MockRolePermissionRepository) ListGrants() ([]domain.RolePermission, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.RolePermission), args.Error(1)
+}
+
+func (m *MockRolePermissionRepository) Grant(roleID uint, permission string) error {
+	args := m.Called(roleID, permission)
+	return args.Error(0)
+}
+
+func (m *MockRolePermissionRepository) Revoke(roleID uint, permission string) error {
+	args := m.Called(roleID, permission)
+	return args.Error(0)
+}
+
+func newPermissionRegistry(t *testing.T, grants *MockRolePermissionRepository) *RoleRegistry {
+	roles := new(MockRoleRepository)
+	roles.On("List").Return([]domain.Role{
+		{Id: 2, Code: domain.RoleCodeMNVC},
+		{Id: 3, Code: domain.RoleCodeCVL, ParentId: parent(2)},
+	}, nil)
+	registry := NewRoleRegistry(roles, grants)
+	require.NoError(t, registry.Refresh())
+	return registry
+}
+
+func TestRolePermissions(t *testing.T) {
+	repo := new(MockRolePermissionRepository)
+	repo.On("ListGrants").Return([]domain.RolePermission{
+		{RoleId: 2, Permission: domain.PermissionRequestReview},
+		{RoleId: 3, Permission: domain.PermissionCountryWrite},
+	}, nil)
+	usecase := NewPermissionUsecase(repo, newPermissionRegistry(t, repo))
+
+	permissions, err := usecase.RolePermissions(3)
+	assert.NoError(t, err)
+	assert.Equal(t, &dto.RolePermissionsDTO{
+		RoleID:    3,
+		Granted:   []string{domain.PermissionCountryWrite},
+		Effective: []string{domain.PermissionCountryWrite, domain.PermissionRequestReview},
+	}, permissions)
+
+	mine, err := usecase.MyPermissions(3)
+	assert.NoError(t, err)
+	assert.Equal(t, &dto.MyPermissionsDTO{Role: domain.RoleCodeCVL, Permissions: permissions.Effective}, mine)
+
+	_, err = usecase.RolePermissions(7)
+	assert.ErrorIs(t, err, domain.ErrRoleNotFound)
+}
+
+func TestGrantPermission(t *testing.T) {
+	repo := new(MockRolePermissionRepository)
+	repo.On("ListGrants").Return([]domain.RolePermission{}, nil).Once()
+	usecase := NewPermissionUsecase(repo, newPermissionRegistry(t, repo))
+
+	repo.On("Grant", uint(2), domain.PermissionDepartmentWrite).Return(nil)
+	repo.On("ListGrants").Return([]domain.RolePermission{{RoleId: 2, Permission: domain.PermissionDepartmentWrite}}, nil)
+	repo.On("Grant", uint(2), "department.burn").Return(domain.ErrPermissionNotFound)
+
+	assert.NoError(t, usecase.GrantPermission(2, dto.PermissionGrantDTO{Permission: domain.PermissionDepartmentWrite}))
+	// the grant reaches the roles inheriting from the role at once
+	mine, err := usecase.MyPermissions(3)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{domain.PermissionDepartmentWrite}, mine.Permissions)
+
+	err = usecase.GrantPermission(2, dto.PermissionGrantDTO{Permission: "department.burn"})
+	assert.ErrorIs(t, err, domain.ErrPermissionNotFound)
+	repo.AssertNumberOfCalls(t, "ListGrants", 2)
+}
+
+func TestRevokePermission(t *testing.T) {
+	repo := new(MockRolePermissionRepository)
+	repo.On("ListGrants").Return([]domain.RolePermission{}, nil)
+	usecase := NewPermissionUsecase(repo, newPermissionRegistry(t, repo))
+
+	repo.On("Revoke", uint(2), domain.PermissionRequestReview).Return(domain.ErrPermissionNotGranted)
+
+	err := usecase.RevokePermission(2, domain.PermissionRequestReview)
+	assert.ErrorIs(t, err, domain.ErrPermissionNotGranted)
+	repo.AssertNumberOfCalls(t, "ListGrants", 1)
+}
@@ -0,0 +1,281 @@
+package usecase
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/role/domain"
+)
+
+const (
+	// RoleRegistryMaxAge is how long the registry trusts the roles it loaded.
+	// It bounds how long a change made by another instance goes unnoticed.
+	RoleRegistryMaxAge = time.Minute
+	// RoleRegistryMissInterval is how long a lookup that misses waits after
+	// the last reload before reloading again, so that tokens of deleted roles
+	// do not query the database on every request.
+	RoleRegistryMissInterval = 5 * time.Second
+)
+
+// RoleLister loads the roles that are not deleted.
+type RoleLister interface {
+	List() ([]domain.Role, error)
+}
+
+// GrantLister loads the permissions granted to roles.
+type GrantLister interface {
+	ListGrants() ([]domain.RolePermission, error)
+}
+
+// RoleRegistry caches the roles and their permissions so that roles can be
+// resolved by code, and permissions checked, on every request without
+// querying the database. It is loaded at startup and refreshed whenever
+// roles or grants change through this instance, or when older than
+// RoleRegistryMaxAge. A lookup that misses reloads the roles once, unless
+// they were loaded less than RoleRegistryMissInterval ago, which picks up
+// roles created by another instance or by the seed command.
+type RoleRegistry struct {
+	roles  RoleLister
+	grants GrantLister
+
+	// refreshing serializes reloads so that concurrent lookups reload once.
+	refreshing sync.Mutex
+	mu         sync.RWMutex
+	byID       map[int]domain.Role
+	byCode     map[string]domain.Role
+	granted    map[int][]string
+	allowed    map[int][]string
+	loadedAt   time.Time
+}
+
+// NewRoleRegistry creates an empty registry. Call Refresh to load it.
+func NewRoleRegistry(roles RoleLister, grants GrantLister) *RoleRegistry {
+	return &RoleRegistry{
+		roles:   roles,
+		grants:  grants,
+		byID:    map[int]domain.Role{},
+		byCode:  map[string]domain.Role{},
+		granted: map[int][]string{},
+		allowed: map[int][]string{},
+	}
+}
+
+// Refresh reloads the roles and their permissions from the database.
+func (r *RoleRegistry) Refresh() error {
+	r.refreshing.Lock()
+	defer r.refreshing.Unlock()
+	return r.load()
+}
+
+// refreshOlderThan reloads the registry unless it was loaded less than
+// maxAge ago, by this call or by a concurrent one it waited for.
+func (r *RoleRegistry) refreshOlderThan(maxAge time.Duration) error {
+	if r.loadedWithin(maxAge) {
+		return nil
+	}
+	r.refreshing.Lock()
+	defer r.refreshing.Unlock()
+	if r.loadedWithin(maxAge) {
+		return nil
+	}
+	return r.load()
+}
+
+func (r *RoleRegistry) loadedWithin(maxAge time.Duration) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return time.Since(r.loadedAt) <= maxAge
+}
+
+func (r *RoleRegistry) load() error {
+	roles, err := r.roles.List()
+	if err != nil {
+		return fmt.Errorf("load roles: %w", err)
+	}
+	grants, err := r.grants.ListGrants()
+	if err != nil {
+		return fmt.Errorf("load role permissions: %w", err)
+	}
+	byID := make(map[int]domain.Role, len(roles))
+	byCode := make(map[string]domain.Role, len(roles))
+	for _, role := range roles {
+		byID[int(role.Id)] = role
+		byCode[role.Code] = role
+	}
+	granted := map[int][]string{}
+	for _, grant := range grants {
+		if _, ok := byID[int(grant.RoleId)]; ok {
+			granted[int(grant.RoleId)] = append(granted[int(grant.RoleId)], grant.Permission)
+		}
+	}
+	allowed := make(map[int][]string, len(roles))
+	for id := range byID {
+		allowed[id] = inherited(id, byID, granted)
+	}
+
+	r.mu.Lock()
+	r.byID, r.byCode, r.granted, r.allowed = byID, byCode, granted, allowed
+	r.loadedAt = time.Now()
+	r.mu.Unlock()
+	return nil
+}
+
+// inherited returns the permissions granted to the role and to its
+// ancestors, sorted. Deleted ancestors grant nothing, and neither do the
+// roles beyond them.
+func inherited(roleID int, byID map[int]domain.Role, granted map[int][]string) []string {
+	seen := map[string]bool{}
+	visited := map[int]bool{}
+	permissions := []string{}
+	for id := roleID; !visited[id]; {
+		role, ok := byID[id]
+		if !ok {
+			break
+		}
+		visited[id] = true
+		for _, permission := range granted[id] {
+			if !seen[permission] {
+				seen[permission] = true
+				permissions = append(permissions, permission)
+			}
+		}
+		if role.ParentId == nil {
+			break
+		}
+		id = int(*role.ParentId)
+	}
+	sort.Strings(permissions)
+	return permissions
+}
+
+// RoleID returns the id of the role with the given code. It returns
+// domain.ErrRoleCodeUnknown when no such role exists.
+func (r *RoleRegistry) RoleID(code string) (int, error) {
+	var role domain.Role
+	found, err := r.read(func() (ok bool) {
+		role, ok = r.byCode[code]
+		return ok
+	})
+	if err != nil {
+		return 0, err
+	}
+	if !found {
+		return 0, fmt.Errorf("role %s: %w", code, domain.ErrRoleCodeUnknown)
+	}
+	return int(role.Id), nil
+}
+
+// RoleCode returns the code of the role with the given id. It returns
+// domain.ErrRoleNotFound when no such role exists.
+func (r *RoleRegistry) RoleCode(roleID int) (string, error) {
+	role, err := r.byRoleID(roleID)
+	if err != nil {
+		return "", err
+	}
+	return role.Code, nil
+}
+
+// RoleName returns the name of the role with the given id. It returns
+// domain.ErrRoleNotFound when no such role exists.
+func (r *RoleRegistry) RoleName(roleID int) (string, error) {
+	role, err := r.byRoleID(roleID)
+	if err != nil {
+		return "", err
+	}
+	return role.Name, nil
+}
+
+// Permissions returns the permissions of the role with the given id, those
+// it inherits included, sorted. It returns domain.ErrRoleNotFound when no
+// such role exists.
+func (r *RoleRegistry) Permissions(roleID int) ([]string, error) {
+	var permissions []string
+	found, err := r.read(func() bool {
+		if _, ok := r.byID[roleID]; !ok {
+			return false
+		}
+		permissions = r.allowed[roleID]
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, domain.ErrRoleNotFound
+	}
+	return permissions, nil
+}
+
+// GrantedPermissions returns the permissions granted to the role with the
+// given id itself, sorted. It returns domain.ErrRoleNotFound when no such
+// role exists.
+func (r *RoleRegistry) GrantedPermissions(roleID int) ([]string, error) {
+	var permissions []string
+	found, err := r.read(func() bool {
+		if _, ok := r.byID[roleID]; !ok {
+			return false
+		}
+		permissions = append([]string{}, r.granted[roleID]...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, domain.ErrRoleNotFound
+	}
+	sort.Strings(permissions)
+	return permissions, nil
+}
+
+// HasPermission reports whether the role with the given id holds the
+// permission, directly or through its parents. It returns
+// domain.ErrRoleNotFound when no such role exists.
+func (r *RoleRegistry) HasPermission(roleID int, permission string) (bool, error) {
+	permissions, err := r.Permissions(roleID)
+	if err != nil {
+		return false, err
+	}
+	i := sort.SearchStrings(permissions, permission)
+	return i < len(permissions) && permissions[i] == permission, nil
+}
+
+func (r *RoleRegistry) byRoleID(roleID int) (*domain.Role, error) {
+	var role domain.Role
+	found, err := r.read(func() (ok bool) {
+		role, ok = r.byID[roleID]
+		return ok
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, domain.ErrRoleNotFound
+	}
+	return &role, nil
+}
+
+// read runs find under the read lock and reports whether it found what it
+// looked for. The registry is reloaded first when it is older than
+// RoleRegistryMaxAge, and when find misses, once it is older than
+// RoleRegistryMissInterval, before running find again.
+func (r *RoleRegistry) read(find func() bool) (bool, error) {
+	if err := r.refreshOlderThan(RoleRegistryMaxAge); err != nil {
+		return false, err
+	}
+
+	r.mu.RLock()
+	found := find()
+	r.mu.RUnlock()
+	if found {
+		return true, nil
+	}
+	if err := r.refreshOlderThan(RoleRegistryMissInterval); err != nil {
+		return false, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return find(), nil
+}
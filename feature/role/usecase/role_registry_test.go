@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/role/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeGrants lists a fixed set of grants.
+type fakeGrants []domain.RolePermission
+
+func (f fakeGrants) ListGrants() ([]domain.RolePermission, error) {
+	return f, nil
+}
+
+func parent(id uint) *uint {
+	return &id
+}
+
+func TestRoleRegistry(t *testing.T) {
+	repo := new(MockRoleRepository)
+	repo.On("List").Return([]domain.Role{
+		{Id: 7, Code: domain.RoleCodeApplicant, Name: "Applicant"},
+		{Id: 9, Code: domain.RoleCodeVolunteer, Name: "Volunteer"},
+	}, nil)
+	registry := NewRoleRegistry(repo, fakeGrants{})
+	require.NoError(t, registry.Refresh())
+
+	id, err := registry.RoleID(domain.RoleCodeVolunteer)
+	assert.NoError(t, err)
+	assert.Equal(t, 9, id)
+
+	code, err := registry.RoleCode(7)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.RoleCodeApplicant, code)
+
+	name, err := registry.RoleName(9)
+	assert.NoError(t, err)
+	assert.Equal(t, "Volunteer", name)
+	repo.AssertNumberOfCalls(t, "List", 1)
+
+	// misses right after a reload do not reload again
+	_, err = registry.RoleID(domain.RoleCodeAdmin)
+	assert.ErrorIs(t, err, domain.ErrRoleCodeUnknown)
+	_, err = registry.RoleCode(3)
+	assert.ErrorIs(t, err, domain.ErrRoleNotFound)
+	repo.AssertNumberOfCalls(t, "List", 1)
+
+	// later, concurrent misses reload the roles once before giving up
+	registry.loadedAt = registry.loadedAt.Add(-RoleRegistryMissInterval - time.Second)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := registry.RoleCode(3)
+			assert.ErrorIs(t, err, domain.ErrRoleNotFound)
+		}()
+	}
+	wg.Wait()
+	repo.AssertNumberOfCalls(t, "List", 2)
+}
+
+func TestRoleRegistryPicksUpNewRoles(t *testing.T) {
+	repo := new(MockRoleRepository)
+	repo.On("List").Return([]domain.Role{}, nil).Once()
+	repo.On("List").Return([]domain.Role{{Id: 3, Code: domain.RoleCodeAdmin, Name: "Admin"}}, nil)
+	registry := NewRoleRegistry(repo, fakeGrants{})
+	require.NoError(t, registry.Refresh())
+	registry.loadedAt = registry.loadedAt.Add(-RoleRegistryMissInterval - time.Second)
+
+	code, err := registry.RoleCode(3)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.RoleCodeAdmin, code)
+}
+
+func TestRoleRegistryRefreshError(t *testing.T) {
+	repo := new(MockRoleRepository)
+	repo.On("List").Return([]domain.Role(nil), errors.New("connection refused"))
+	registry := NewRoleRegistry(repo, fakeGrants{})
+
+	assert.ErrorContains(t, registry.Refresh(), "connection refused")
+	_, err := registry.RoleID(domain.RoleCodeAdmin)
+	assert.ErrorContains(t, err, "connection refused")
+}
+
+func TestRoleRegistryPermissions(t *testing.T) {
+	repo := new(MockRoleRepository)
+	repo.On("List").Return([]domain.Role{
+		{Id: 1, Code: "coordinator"},
+		{Id: 2, Code: domain.RoleCodeMNVC, ParentId: parent(1)},
+		{Id: 3, Code: domain.RoleCodeCVL, ParentId: parent(2)},
+		// its parent was deleted
+		{Id: 4, Code: domain.RoleCodeCOM, ParentId: parent(9)},
+		// a loop left by concurrent updates must not hang the registry
+		{Id: 5, Code: "a", ParentId: parent(6)},
+		{Id: 6, Code: "b", ParentId: parent(5)},
+	}, nil)
+	registry := NewRoleRegistry(repo, fakeGrants{
+		{RoleId: 1, Permission: domain.PermissionRequestReview},
+		{RoleId: 2, Permission: domain.PermissionDepartmentWrite},
+		{RoleId: 2, Permission: domain.PermissionRequestReview},
+		{RoleId: 3, Permission: domain.PermissionCountryWrite},
+		{RoleId: 5, Permission: domain.PermissionCountryWrite},
+		{RoleId: 6, Permission: domain.PermissionRoleWrite},
+		{RoleId: 9, Permission: domain.PermissionRoleWrite},
+	})
+	require.NoError(t, registry.Refresh())
+
+	permissions, err := registry.Permissions(3)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{domain.PermissionCountryWrite, domain.PermissionDepartmentWrite, domain.PermissionRequestReview}, permissions)
+	granted, err := registry.GrantedPermissions(3)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{domain.PermissionCountryWrite}, granted)
+
+	permissions, err = registry.Permissions(4)
+	assert.NoError(t, err)
+	assert.Empty(t, permissions)
+
+	permissions, err = registry.Permissions(5)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{domain.PermissionCountryWrite, domain.PermissionRoleWrite}, permissions)
+
+	allowed, err := registry.HasPermission(3, domain.PermissionRequestReview)
+	assert.NoError(t, err)
+	assert.True(t, allowed)
+	allowed, err = registry.HasPermission(1, domain.PermissionCountryWrite)
+	assert.NoError(t, err)
+	assert.False(t, allowed)
+	_, err = registry.HasPermission(9, domain.PermissionRoleWrite)
+	assert.ErrorIs(t, err, domain.ErrRoleNotFound)
+}
+
+func TestRoleRegistryMaxAge(t *testing.T) {
+	repo := new(MockRoleRepository)
+	repo.On("List").Return([]domain.Role{{Id: 3, Code: domain.RoleCodeAdmin}}, nil)
+	registry := NewRoleRegistry(repo, fakeGrants{})
+	require.NoError(t, registry.Refresh())
+
+	_, err := registry.RoleCode(3)
+	assert.NoError(t, err)
+	repo.AssertNumberOfCalls(t, "List", 1)
+
+	registry.loadedAt = registry.loadedAt.Add(-RoleRegistryMaxAge - time.Second)
+	_, err = registry.RoleCode(3)
+	assert.NoError(t, err)
+	repo.AssertNumberOfCalls(t, "List", 2)
+}
@@ -1,6 +1,9 @@
 package usecase
 
 import (
+	"errors"
+	"log"
+
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/role/domain"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/role/dto"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/role/storage"
@@ -12,25 +15,37 @@ type RoleUsecaseInterface interface {
 	GetRoleByID(id uint) (*domain.Role, error)
 	UpdateRole(id uint, input dto.RoleUpdateDTO) error
 	DeleteRole(id uint) error
+	RestoreRole(id uint) error
+}
+
+// RoleRefresher reloads the roles cached by the RoleRegistry.
+type RoleRefresher interface {
+	Refresh() error
 }
 
 // RoleUsecase handles the business logic for roles.
 type RoleUsecase struct {
 	Rolerepo storage.RoleRepositoryInterface
+	Registry RoleRefresher
 }
 
 // NewRoleUsecase creates a new instance of RoleUsecase.
-func NewRoleUsecase(Rolerepo storage.RoleRepositoryInterface) *RoleUsecase {
-	return &RoleUsecase{Rolerepo: Rolerepo}
+func NewRoleUsecase(Rolerepo storage.RoleRepositoryInterface, registry RoleRefresher) *RoleUsecase {
+	return &RoleUsecase{Rolerepo: Rolerepo, Registry: registry}
 }
 
 // CreateRole creates a new role using the provided DTO.
 func (u *RoleUsecase) CreateRole(input dto.RoleCreateDTO) error {
+	if err := u.checkParent(0, input.ParentID); err != nil {
+		return err
+	}
 	role := &domain.Role{
-		Name:   input.Name,
-		Status: input.Status,
+		Code:     input.Code,
+		ParentId: input.ParentID,
+		Name:     input.Name,
+		Status:   input.Status,
 	}
-	return u.Rolerepo.Create(role)
+	return refreshed(u.Registry, u.Rolerepo.Create(role))
 }
 
 // GetRoleByID retrieves a role by its ID.
@@ -44,12 +59,62 @@ func (u *RoleUsecase) UpdateRole(id uint, input dto.RoleUpdateDTO) error {
 	if err != nil {
 		return err
 	}
+	if err := u.checkParent(id, input.ParentID); err != nil {
+		return err
+	}
 	role.Name = input.Name
 	role.Status = input.Status
-	return u.Rolerepo.Update(role)
+	role.ParentId = input.ParentID
+	return refreshed(u.Registry, u.Rolerepo.Update(role))
+}
+
+// checkParent makes sure that the parent exists and that the role with the
+// given id, 0 for a new role, is not among its ancestors.
+func (u *RoleUsecase) checkParent(id uint, parentID *uint) error {
+	visited := map[uint]bool{}
+	for next := parentID; next != nil; {
+		if *next == id {
+			return domain.ErrRoleCycle
+		}
+		if visited[*next] {
+			// an existing loop that the role is not part of
+			return nil
+		}
+		visited[*next] = true
+		parent, err := u.Rolerepo.GetByID(*next)
+		if errors.Is(err, domain.ErrRoleNotFound) {
+			if next == parentID {
+				return domain.ErrParentRoleNotFound
+			}
+			// a deleted ancestor ends the chain
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		next = parent.ParentId
+	}
+	return nil
 }
 
 // DeleteRole deletes a role by its ID.
 func (u *RoleUsecase) DeleteRole(id uint) error {
-	return u.Rolerepo.Delete(id)
+	return refreshed(u.Registry, u.Rolerepo.Delete(id))
+}
+
+// RestoreRole restores a deleted role by its ID.
+func (u *RoleUsecase) RestoreRole(id uint) error {
+	return refreshed(u.Registry, u.Rolerepo.Restore(id))
+}
+
+// refreshed refreshes the registry once a change succeeded. A failed refresh
+// is only logged: the change is done, and the next refresh picks it up.
+func refreshed(registry RoleRefresher, err error) error {
+	if err != nil {
+		return err
+	}
+	if err := registry.Refresh(); err != nil {
+		log.Printf("refresh role registry: %v", err)
+	}
+	return nil
 }
@@ -26,6 +26,12 @@ func (m *MockRoleRepository) GetByID(id uint) (*domain.Role, error) {
 	return args.Get(0).(*domain.Role), args.Error(1)
 }
 
+// List is a mock method for listing the roles.
+func (m *MockRoleRepository) List() ([]domain.Role, error) {
+	args := m.Called()
+	return args.Get(0).([]domain.Role), args.Error(1)
+}
+
 // Update is a mock method for updating a role
 func (m *MockRoleRepository) Update(role *domain.Role) error {
 	args := m.Called(role)
@@ -38,16 +44,34 @@ func (m *MockRoleRepository) Delete(id uint) error {
 	return args.Error(0)
 }
 
+func (m *MockRoleRepository) Restore(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// countingRefresher counts the refreshes of the registry.
+type countingRefresher struct {
+	refreshes int
+}
+
+func (r *countingRefresher) Refresh() error {
+	r.refreshes++
+	return nil
+}
+
 func TestCreateRole(t *testing.T) {
 	mockRepo := new(MockRoleRepository)
-	usecase := NewRoleUsecase(mockRepo)
+	registry := &countingRefresher{}
+	usecase := NewRoleUsecase(mockRepo, registry)
 
 	input := dto.RoleCreateDTO{
+		Code:   "admin",
 		Name:   "Admin",
 		Status: 123,
 	}
 
 	role := &domain.Role{
+		Code:   input.Code,
 		Name:   input.Name,
 		Status: input.Status,
 	}
@@ -57,11 +81,24 @@ func TestCreateRole(t *testing.T) {
 	err := usecase.CreateRole(input)
 	assert.NoError(t, err)
 	mockRepo.AssertCalled(t, "Create", role)
+	assert.Equal(t, 1, registry.refreshes)
+}
+
+func TestCreateRoleCodeTaken(t *testing.T) {
+	mockRepo := new(MockRoleRepository)
+	registry := &countingRefresher{}
+	usecase := NewRoleUsecase(mockRepo, registry)
+
+	mockRepo.On("Create", mock.Anything).Return(domain.ErrRoleCodeTaken)
+
+	err := usecase.CreateRole(dto.RoleCreateDTO{Code: "admin", Name: "Admin", Status: 1})
+	assert.ErrorIs(t, err, domain.ErrRoleCodeTaken)
+	assert.Zero(t, registry.refreshes)
 }
 
 func TestGetRoleByID(t *testing.T) {
 	mockRepo := new(MockRoleRepository)
-	usecase := NewRoleUsecase(mockRepo)
+	usecase := NewRoleUsecase(mockRepo, &countingRefresher{})
 
 	role := &domain.Role{
 
@@ -81,7 +118,7 @@ func TestGetRoleByID(t *testing.T) {
 
 func TestUpdateRole(t *testing.T) {
 	mockRepo := new(MockRoleRepository)
-	usecase := NewRoleUsecase(mockRepo)
+	usecase := NewRoleUsecase(mockRepo, &countingRefresher{})
 
 	role := &domain.Role{
 		Name:   "Admin",
@@ -106,11 +143,47 @@ func TestUpdateRole(t *testing.T) {
 
 func TestDeleteRole(t *testing.T) {
 	mockRepo := new(MockRoleRepository)
-	usecase := NewRoleUsecase(mockRepo)
+	usecase := NewRoleUsecase(mockRepo, &countingRefresher{})
 
 	mockRepo.On("Delete", uint(1)).Return(nil)
 
 	err := usecase.DeleteRole(1)
 	assert.NoError(t, err)
 	mockRepo.AssertCalled(t, "Delete", uint(1))
+	assert.Equal(t, 1, usecase.Registry.(*countingRefresher).refreshes)
+}
+
+func TestCreateRoleWithParent(t *testing.T) {
+	mockRepo := new(MockRoleRepository)
+	usecase := NewRoleUsecase(mockRepo, &countingRefresher{})
+
+	mockRepo.On("GetByID", uint(2)).Return(&domain.Role{Id: 2, Code: "mnvc"}, nil)
+	mockRepo.On("GetByID", uint(9)).Return((*domain.Role)(nil), domain.ErrRoleNotFound)
+	mockRepo.On("Create", mock.Anything).Return(nil)
+
+	parentID := uint(2)
+	assert.NoError(t, usecase.CreateRole(dto.RoleCreateDTO{Code: "cvl", Name: "CVL", Status: 1, ParentID: &parentID}))
+	mockRepo.AssertCalled(t, "Create", mock.MatchedBy(func(role *domain.Role) bool {
+		return role.ParentId != nil && *role.ParentId == 2
+	}))
+
+	unknownID := uint(9)
+	err := usecase.CreateRole(dto.RoleCreateDTO{Code: "com", Name: "COM", Status: 1, ParentID: &unknownID})
+	assert.ErrorIs(t, err, domain.ErrParentRoleNotFound)
+}
+
+func TestUpdateRoleParentCycle(t *testing.T) {
+	mockRepo := new(MockRoleRepository)
+	usecase := NewRoleUsecase(mockRepo, &countingRefresher{})
+
+	// 3 inherits from 2, which inherits from 1
+	mockRepo.On("GetByID", uint(1)).Return(&domain.Role{Id: 1, Name: "coordinator"}, nil)
+	mockRepo.On("GetByID", uint(2)).Return(&domain.Role{Id: 2, Name: "MNVC", ParentId: parent(1)}, nil)
+	mockRepo.On("GetByID", uint(3)).Return(&domain.Role{Id: 3, Name: "CVL", ParentId: parent(2)}, nil)
+
+	for _, parentID := range []uint{1, 3} {
+		err := usecase.UpdateRole(1, dto.RoleUpdateDTO{Name: "coordinator", Status: 1, ParentID: parent(parentID)})
+		assert.ErrorIs(t, err, domain.ErrRoleCycle, "parent %d", parentID)
+	}
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
 }
@@ -0,0 +1,259 @@
+package seed
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	departmentDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/department/domain"
+	requestDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/request/domain"
+	roleDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/role/domain"
+	userDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
+	identityDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/user_identity/domain"
+	volunteerDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+	"gorm.io/gorm"
+)
+
+// Fixtures describes the fake users to generate. Every fake user logs in
+// with Password.
+type Fixtures struct {
+	Applicants int
+	Volunteers int
+	Password   string
+	Rand       *rand.Rand
+}
+
+var (
+	givenNames = []string{"An", "Binh", "Chi", "Dung", "Giang", "Hoa", "Hung", "Lan", "Linh", "Minh", "Nam", "Ngoc", "Phuong", "Quang", "Thao", "Trang", "Tuan", "Vy",
+		"Alice", "Ben", "Chloe", "David", "Emma", "Felix", "Hannah", "Jonas", "Lucas", "Maria", "Noah", "Olivia", "Sofia", "Tom"}
+	surnames = []string{"Nguyen", "Tran", "Le", "Pham", "Hoang", "Huynh", "Phan", "Vu", "Vo", "Dang", "Bui", "Do", "Ho", "Ngo",
+		"Smith", "Johnson", "Brown", "Garcia", "Muller", "Martin", "Rossi", "Kim", "Tanaka", "Dubois"}
+	fixtureCountries = []string{"VN", "VN", "VN", "VN", "US", "GB", "FR", "DE", "JP", "KR", "AU", "SG"}
+)
+
+// fixtureBatch is the number of users created per transaction.
+const fixtureBatch = 100
+
+// SeedFixtures generates fake applicants and volunteers, along with their
+// requests and identity documents:
+//   - applicants hold a pending registration request, and half of them a
+//     pending identity document;
+//   - volunteers hold an approved registration request, an approved
+//     verification request, a verified identity document and a volunteer
+//     record, active for most of them.
+//
+// Roles, countries and departments must be seeded first. Identity numbers
+// are encrypted, so the keyring must be set up too.
+func (s *Seeder) SeedFixtures(fixtures Fixtures) ([]Result, error) {
+	users := Result{Table: "users"}
+	requests := Result{Table: "requests"}
+	identities := Result{Table: "user_identities"}
+	volunteers := Result{Table: "volunteer_details"}
+	results := func() []Result {
+		return []Result{users, requests, identities, volunteers}
+	}
+
+	generator, err := s.newGenerator(fixtures)
+	if err != nil {
+		return results(), err
+	}
+	for _, kind := range []struct {
+		roleID int
+		total  int
+	}{
+		{generator.applicantRoleID, fixtures.Applicants},
+		{generator.volunteerRoleID, fixtures.Volunteers},
+	} {
+		for done := 0; done < kind.total; done += fixtureBatch {
+			size := min(fixtureBatch, kind.total-done)
+			batch, err := generator.batch(kind.roleID, size)
+			if err != nil {
+				return results(), err
+			}
+			err = s.DB.Transaction(func(tx *gorm.DB) error {
+				return batch.create(tx)
+			})
+			if err != nil {
+				return results(), err
+			}
+			users.Created += len(batch.users)
+			requests.Created += len(batch.requests)
+			identities.Created += len(batch.identities)
+			volunteers.Created += len(batch.volunteers)
+		}
+	}
+	return results(), nil
+}
+
+// generator draws fake users from the reference data of the database.
+type generator struct {
+	rand            *rand.Rand
+	password        string
+	applicantRoleID int
+	volunteerRoleID int
+	departmentIDs   []int
+	countryIDs      []int
+}
+
+func (s *Seeder) newGenerator(fixtures Fixtures) (*generator, error) {
+	g := &generator{rand: fixtures.Rand}
+	if g.rand == nil {
+		g.rand = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	var err error
+	if g.password, err = s.Hasher.Hash(fixtures.Password); err != nil {
+		return nil, err
+	}
+	if g.applicantRoleID, err = roleID(s.DB, roleDomain.RoleCodeApplicant); err != nil {
+		return nil, err
+	}
+	if g.volunteerRoleID, err = roleID(s.DB, roleDomain.RoleCodeVolunteer); err != nil {
+		return nil, err
+	}
+	err = s.DB.Model(&departmentDomain.Department{}).Order("id").Pluck("id", &g.departmentIDs).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(g.departmentIDs) == 0 {
+		return nil, fmt.Errorf("seed: no department to give fake users")
+	}
+	for _, code := range fixtureCountries {
+		name, _ := countryByCode(code)
+		var ids []int
+		if err := s.DB.Table("countries").Where("name = ? AND deleted_at IS NULL", name).Pluck("id", &ids).Error; err != nil {
+			return nil, err
+		}
+		g.countryIDs = append(g.countryIDs, ids...)
+	}
+	if len(g.countryIDs) == 0 {
+		return nil, fmt.Errorf("seed: no country to give fake users, seed the countries first")
+	}
+	return g, nil
+}
+
+// batch holds the rows of a batch of fake users. The requests, identities
+// and volunteers point to their user through its index in users until the
+// users are created.
+type batch struct {
+	users           []*userDomain.User
+	requests        []*requestDomain.Request
+	requestOwners   []int
+	identities      []*identityDomain.UserIdentity
+	identityOwners  []int
+	volunteers      []*volunteerDomain.Volunteer
+	volunteerOwners []int
+}
+
+func (g *generator) batch(roleID int, size int) (*batch, error) {
+	b := &batch{}
+	volunteer := roleID == g.volunteerRoleID
+	for i := 0; i < size; i++ {
+		user := g.user(roleID)
+		b.users = append(b.users, user)
+
+		registration := &requestDomain.Request{Type: requestDomain.RequestTypeRegistration, Status: requestDomain.RequestStatusPending}
+		b.requests = append(b.requests, registration)
+		b.requestOwners = append(b.requestOwners, i)
+		if volunteer {
+			registration.Status = requestDomain.RequestStatusApproved
+			verification := &requestDomain.Request{Type: requestDomain.RequestTypeVerification, Status: requestDomain.RequestStatusApproved}
+			b.requests = append(b.requests, verification)
+			b.requestOwners = append(b.requestOwners, i)
+			b.volunteers = append(b.volunteers, &volunteerDomain.Volunteer{DepartmentID: *user.DepartmentID, Status: g.volunteerStatus()})
+			b.volunteerOwners = append(b.volunteerOwners, i)
+		}
+
+		if volunteer || g.rand.Intn(2) == 0 {
+			identity, err := g.identity(volunteer)
+			if err != nil {
+				return nil, err
+			}
+			b.identities = append(b.identities, identity)
+			b.identityOwners = append(b.identityOwners, i)
+		}
+	}
+	return b, nil
+}
+
+func (b *batch) create(tx *gorm.DB) error {
+	if err := tx.Create(b.users).Error; err != nil {
+		return err
+	}
+	for i, request := range b.requests {
+		request.UserID = b.users[b.requestOwners[i]].ID
+	}
+	if err := tx.Create(b.requests).Error; err != nil {
+		return err
+	}
+	if len(b.identities) > 0 {
+		for i, identity := range b.identities {
+			identity.UserID = b.users[b.identityOwners[i]].ID
+		}
+		if err := tx.Create(b.identities).Error; err != nil {
+			return err
+		}
+	}
+	if len(b.volunteers) > 0 {
+		for i, volunteer := range b.volunteers {
+			volunteer.UserID = b.users[b.volunteerOwners[i]].ID
+		}
+		if err := tx.Create(b.volunteers).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *generator) user(roleID int) *userDomain.User {
+	name := g.pick(givenNames)
+	surname := g.pick(surnames)
+	countryID := g.countryIDs[g.rand.Intn(len(g.countryIDs))]
+	user := &userDomain.User{
+		RoleID:            roleID,
+		Email:             fmt.Sprintf("%s.%s.%d@example.com", strings.ToLower(name), strings.ToLower(surname), g.rand.Intn(1_000_000_000)),
+		Password:          g.password,
+		Name:              name,
+		Surname:           surname,
+		Gender:            g.pick([]string{"male", "female"}),
+		Dob:               time.Date(1960+g.rand.Intn(45), time.Month(1+g.rand.Intn(12)), 1+g.rand.Intn(28), 0, 0, 0, 0, time.UTC),
+		Mobile:            fmt.Sprintf("+849%08d", g.rand.Intn(100_000_000)),
+		CountryID:         countryID,
+		ResidentCountryID: countryID,
+		Status:            1,
+	}
+	departmentID := g.departmentIDs[g.rand.Intn(len(g.departmentIDs))]
+	user.DepartmentID = &departmentID
+	if roleID == g.volunteerRoleID {
+		user.VerificationStatus = 1
+	}
+	return user
+}
+
+func (g *generator) identity(verified bool) (*identityDomain.UserIdentity, error) {
+	identity := &identityDomain.UserIdentity{
+		Status:      identityDomain.IdentityStatusPending,
+		ExpiryDate:  identityDomain.Today(time.Now()).AddDate(1+g.rand.Intn(9), 0, 0),
+		PlaceIssued: g.pick([]string{"Hanoi", "Ho Chi Minh City", "Da Nang"}),
+	}
+	if verified {
+		identity.Status = identityDomain.IdentityStatusVerified
+	}
+	number := fmt.Sprintf("%c%08d", 'A'+g.rand.Intn(26), g.rand.Intn(100_000_000))
+	if err := identity.SetNumber(identityDomain.DocumentTypePassport, number); err != nil {
+		return nil, err
+	}
+	return identity, nil
+}
+
+// volunteerStatus makes one volunteer in ten inactive.
+func (g *generator) volunteerStatus() int {
+	if g.rand.Intn(10) == 0 {
+		return volunteerDomain.VolunteerStatusInactive
+	}
+	return volunteerDomain.VolunteerStatusActive
+}
+
+func (g *generator) pick(values []string) string {
+	return values[g.rand.Intn(len(values))]
+}
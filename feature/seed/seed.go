@@ -0,0 +1,312 @@
+// Package seed fills a database with the reference data the application
+// expects, and with fake users for demos and load tests. Reference data is
+// only inserted when missing, so seeding can run again after every
+// migration.
+package seed
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	countryDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/country/domain"
+	departmentDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/department/domain"
+	roleDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/role/domain"
+	userDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
+	"gorm.io/gorm"
+)
+
+// Role is a role the application relies on.
+type Role struct {
+	Code string
+	Name string
+}
+
+// Roles lists the canonical roles in the order they are created.
+var Roles = []Role{
+	{Code: roleDomain.RoleCodeApplicant, Name: "applicant"},
+	{Code: roleDomain.RoleCodeVolunteer, Name: "volunteer"},
+	{Code: roleDomain.RoleCodeAdmin, Name: "admin"},
+	{Code: roleDomain.RoleCodeCOM, Name: "COM"},
+	{Code: roleDomain.RoleCodeCVL, Name: "CVL"},
+	{Code: roleDomain.RoleCodeMNVC, Name: "MNVC"},
+}
+
+// AdminPermissions are granted to the admin role.
+var AdminPermissions = []string{
+	roleDomain.PermissionRequestReview,
+	roleDomain.PermissionCountryWrite,
+	roleDomain.PermissionDepartmentWrite,
+	roleDomain.PermissionRoleWrite,
+}
+
+// Departments lists the departments created when missing, so that users
+// have departments to join.
+var Departments = []departmentDomain.Department{
+	{Name: "Hanoi", Address: "12 Trang Tien, Hoan Kiem, Hanoi", Status: 1},
+	{Name: "Ho Chi Minh City", Address: "45 Le Loi, District 1, Ho Chi Minh City", Status: 1},
+	{Name: "Da Nang", Address: "8 Bach Dang, Hai Chau, Da Nang", Status: 1},
+	{Name: "Hue", Address: "3 Le Loi, Vinh Ninh, Hue", Status: 1},
+	{Name: "Can Tho", Address: "20 Hoa Binh, Ninh Kieu, Can Tho", Status: 1},
+}
+
+var ErrRoleNotSeeded = errors.New("seed: role is missing, seed the roles first")
+
+// Result counts the rows of a table that were created, and those that were
+// left untouched because they already existed.
+type Result struct {
+	Table    string
+	Created  int
+	Existing int
+}
+
+func (r Result) String() string {
+	return fmt.Sprintf("%s: %d created, %d already present", r.Table, r.Created, r.Existing)
+}
+
+// PasswordHasher hashes the password of the seeded accounts.
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+}
+
+type Seeder struct {
+	DB     *gorm.DB
+	Hasher PasswordHasher
+}
+
+func NewSeeder(db *gorm.DB, hasher PasswordHasher) *Seeder {
+	return &Seeder{DB: db, Hasher: hasher}
+}
+
+// SeedRoles creates the missing canonical roles and permissions, and grants
+// AdminPermissions to the admin role. Roles are matched by code, deleted ones included, so a
+// role deleted by an admin stays deleted.
+func (s *Seeder) SeedRoles() ([]Result, error) {
+	roles := Result{Table: "roles"}
+	catalog := Result{Table: "permissions"}
+	permissions := Result{Table: "role_permissions"}
+	err := s.DB.Transaction(func(tx *gorm.DB) error {
+		for _, permission := range roleDomain.Permissions {
+			var count int64
+			err := tx.Model(&roleDomain.Permission{}).Where("code = ?", permission.Code).Count(&count).Error
+			if err != nil {
+				return err
+			}
+			if count > 0 {
+				catalog.Existing++
+				continue
+			}
+			if err := tx.Create(&roleDomain.Permission{Code: permission.Code, Description: permission.Description}).Error; err != nil {
+				return err
+			}
+			catalog.Created++
+		}
+
+		for _, role := range Roles {
+			_, err := roleID(tx, role.Code)
+			if err == nil {
+				roles.Existing++
+				continue
+			}
+			if !errors.Is(err, ErrRoleNotSeeded) {
+				return err
+			}
+			if err := tx.Create(&roleDomain.Role{Code: role.Code, Name: role.Name, Status: 1}).Error; err != nil {
+				return err
+			}
+			roles.Created++
+		}
+
+		adminID, err := roleID(tx, roleDomain.RoleCodeAdmin)
+		if err != nil {
+			return err
+		}
+		for _, permission := range AdminPermissions {
+			var count int64
+			err := tx.Model(&roleDomain.RolePermission{}).
+				Where("role_id = ? AND permission = ?", adminID, permission).
+				Count(&count).Error
+			if err != nil {
+				return err
+			}
+			if count > 0 {
+				permissions.Existing++
+				continue
+			}
+			grant := roleDomain.RolePermission{RoleId: uint(adminID), Permission: permission}
+			if err := tx.Create(&grant).Error; err != nil {
+				return err
+			}
+			permissions.Created++
+		}
+		return nil
+	})
+	return []Result{roles, catalog, permissions}, err
+}
+
+// roleID returns the id of the role with the given code, deleted or not.
+func roleID(db *gorm.DB, code string) (int, error) {
+	var ids []int
+	if err := db.Unscoped().Model(&roleDomain.Role{}).Where("code = ?", code).Pluck("id", &ids).Error; err != nil {
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, fmt.Errorf("%w: %s", ErrRoleNotSeeded, code)
+	}
+	return ids[0], nil
+}
+
+// SeedCountries creates the countries of Countries that are missing, matched
+// by name, deleted ones included. New countries are active.
+func (s *Seeder) SeedCountries() (Result, error) {
+	result := Result{Table: "countries"}
+	var names []string
+	if err := s.DB.Unscoped().Model(&countryDomain.Country{}).Pluck("name", &names).Error; err != nil {
+		return result, err
+	}
+	existing := make(map[string]bool, len(names))
+	for _, name := range names {
+		existing[name] = true
+	}
+
+	var missing []countryDomain.Country
+	for _, country := range Countries {
+		if existing[country.Name] {
+			result.Existing++
+			continue
+		}
+		missing = append(missing, countryDomain.Country{Name: country.Name, Status: 1})
+	}
+	if len(missing) == 0 {
+		return result, nil
+	}
+	if err := s.DB.CreateInBatches(missing, 100).Error; err != nil {
+		return result, err
+	}
+	result.Created = len(missing)
+	return result, nil
+}
+
+// SeedDepartments creates the departments of Departments that are missing,
+// matched by name, deleted ones included.
+func (s *Seeder) SeedDepartments() (Result, error) {
+	result := Result{Table: "departments"}
+	for _, department := range Departments {
+		var count int64
+		err := s.DB.Unscoped().Model(&departmentDomain.Department{}).Where("name = ?", department.Name).Count(&count).Error
+		if err != nil {
+			return result, err
+		}
+		if count > 0 {
+			result.Existing++
+			continue
+		}
+		if err := s.DB.Create(&department).Error; err != nil {
+			return result, err
+		}
+		result.Created++
+	}
+	return result, nil
+}
+
+// Admin describes the initial admin account.
+type Admin struct {
+	Email    string
+	Password string
+	Name     string
+	Surname  string
+	// Country is the ISO 3166-1 alpha-2 code of the country of the admin.
+	Country string
+}
+
+// AdminFromEnv reads SEED_ADMIN_EMAIL, SEED_ADMIN_PASSWORD, SEED_ADMIN_NAME,
+// SEED_ADMIN_SURNAME and SEED_ADMIN_COUNTRY. It reports false when
+// SEED_ADMIN_EMAIL is not set.
+func AdminFromEnv() (Admin, bool) {
+	admin := Admin{
+		Email:    os.Getenv("SEED_ADMIN_EMAIL"),
+		Password: os.Getenv("SEED_ADMIN_PASSWORD"),
+		Name:     os.Getenv("SEED_ADMIN_NAME"),
+		Surname:  os.Getenv("SEED_ADMIN_SURNAME"),
+		Country:  os.Getenv("SEED_ADMIN_COUNTRY"),
+	}
+	if admin.Name == "" {
+		admin.Name = "Admin"
+	}
+	if admin.Surname == "" {
+		admin.Surname = "Admin"
+	}
+	if admin.Country == "" {
+		admin.Country = "VN"
+	}
+	return admin, admin.Email != ""
+}
+
+// SeedAdmin creates the admin account unless a user with the same email
+// already exists, in which case the account is left as it is: its password
+// may have been changed since. The account is verified and active.
+func (s *Seeder) SeedAdmin(admin Admin) (Result, error) {
+	result := Result{Table: "users"}
+	if len(admin.Password) < 8 {
+		return result, errors.New("seed: the password of the admin must have at least 8 characters")
+	}
+	countryName, ok := countryByCode(admin.Country)
+	if !ok {
+		return result, fmt.Errorf("seed: unknown country code %q", admin.Country)
+	}
+
+	err := s.DB.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		err := tx.Unscoped().Model(&userDomain.User{}).Where("email = ?", admin.Email).Count(&count).Error
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			result.Existing++
+			return nil
+		}
+
+		roleID, err := roleID(tx, roleDomain.RoleCodeAdmin)
+		if err != nil {
+			return err
+		}
+		var country countryDomain.Country
+		if err := tx.Where("name = ?", countryName).First(&country).Error; err != nil {
+			return fmt.Errorf("seed: country %s of the admin: %w", admin.Country, err)
+		}
+		hashed, err := s.Hasher.Hash(admin.Password)
+		if err != nil {
+			return err
+		}
+		user := userDomain.User{
+			RoleID:             roleID,
+			Email:              admin.Email,
+			Password:           hashed,
+			Name:               admin.Name,
+			Surname:            admin.Surname,
+			Gender:             "other",
+			Dob:                time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
+			CountryID:          int(country.Id),
+			ResidentCountryID:  int(country.Id),
+			VerificationStatus: 1,
+			Status:             1,
+		}
+		if err := tx.Create(&user).Error; err != nil {
+			return err
+		}
+		result.Created++
+		return nil
+	})
+	return result, err
+}
+
+func countryByCode(code string) (string, bool) {
+	for _, country := range Countries {
+		if strings.EqualFold(country.Code, code) {
+			return country.Name, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,186 @@
+package seed
+
+import (
+	"bytes"
+	"math/rand"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/database/databasetest"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/encryption"
+	roleDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/role/domain"
+	identityDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/user_identity/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type plainHasher struct{}
+
+func (plainHasher) Hash(password string) (string, error) { return "hashed:" + password, nil }
+
+func useTestKeyring(t *testing.T) {
+	keyring, err := encryption.NewKeyring("test", map[string][]byte{"test": bytes.Repeat([]byte("k"), 32)}, bytes.Repeat([]byte("i"), 32))
+	assert.NoError(t, err)
+	encryption.Use(keyring)
+	t.Cleanup(func() { encryption.Use(nil) })
+}
+
+func count(t *testing.T, db *gorm.DB, query string, args ...interface{}) int {
+	var n int
+	require.NoError(t, db.Raw(query, args...).Scan(&n).Error)
+	return n
+}
+
+func TestCountries(t *testing.T) {
+	codes := map[string]bool{}
+	names := map[string]bool{}
+	for _, country := range Countries {
+		assert.Len(t, country.Code, 2)
+		assert.Equal(t, strings.ToUpper(country.Code), country.Code)
+		assert.LessOrEqual(t, utf8.RuneCountInString(country.Name), 45, "%s does not fit countries.name", country.Name)
+		assert.False(t, codes[country.Code], "duplicate %s", country.Code)
+		assert.False(t, names[country.Name], "duplicate %s", country.Name)
+		codes[country.Code] = true
+		names[country.Name] = true
+	}
+	assert.Len(t, Countries, 249)
+}
+
+func TestSeedRoles(t *testing.T) {
+	db := databasetest.Open(t)
+	seeder := NewSeeder(db, plainHasher{})
+
+	results, err := seeder.SeedRoles()
+	require.NoError(t, err)
+	assert.Equal(t, []Result{{Table: "roles", Created: 6}, {Table: "permissions", Existing: 4}, {Table: "role_permissions", Created: 4}}, results)
+	assert.Equal(t, 1, count(t, db, "SELECT COUNT(*) FROM roles WHERE code = ? AND name = 'CVL' AND status = 1", roleDomain.RoleCodeCVL))
+
+	// deleted roles are not created again
+	require.NoError(t, db.Exec("UPDATE roles SET deleted_at = CURRENT_TIMESTAMP WHERE code = ?", roleDomain.RoleCodeMNVC).Error)
+	results, err = seeder.SeedRoles()
+	require.NoError(t, err)
+	assert.Equal(t, []Result{{Table: "roles", Existing: 6}, {Table: "permissions", Existing: 4}, {Table: "role_permissions", Existing: 4}}, results)
+	assert.Equal(t, 6, count(t, db, "SELECT COUNT(*) FROM roles"))
+}
+
+func TestSeedCountries(t *testing.T) {
+	db := databasetest.Open(t)
+	seeder := NewSeeder(db, plainHasher{})
+	require.NoError(t, db.Exec("INSERT INTO countries (name, status) VALUES ('Viet Nam', 0)").Error)
+
+	result, err := seeder.SeedCountries()
+	require.NoError(t, err)
+	assert.Equal(t, Result{Table: "countries", Created: 248, Existing: 1}, result)
+	// existing countries are left as they are
+	assert.Equal(t, 0, count(t, db, "SELECT status FROM countries WHERE name = 'Viet Nam'"))
+
+	result, err = seeder.SeedCountries()
+	require.NoError(t, err)
+	assert.Equal(t, Result{Table: "countries", Existing: 249}, result)
+}
+
+func TestSeedDepartments(t *testing.T) {
+	db := databasetest.Open(t)
+	seeder := NewSeeder(db, plainHasher{})
+	require.NoError(t, db.Exec("INSERT INTO departments (name, address, status, deleted_at) VALUES ('Hue', 'Elsewhere', 0, CURRENT_TIMESTAMP)").Error)
+
+	result, err := seeder.SeedDepartments()
+	require.NoError(t, err)
+	assert.Equal(t, Result{Table: "departments", Created: len(Departments) - 1, Existing: 1}, result)
+	// deleted departments are not created again
+	assert.Equal(t, 1, count(t, db, "SELECT COUNT(*) FROM departments WHERE name = 'Hue'"))
+
+	result, err = seeder.SeedDepartments()
+	require.NoError(t, err)
+	assert.Equal(t, Result{Table: "departments", Existing: len(Departments)}, result)
+}
+
+func TestSeedAdmin(t *testing.T) {
+	db := databasetest.Open(t)
+	seeder := NewSeeder(db, plainHasher{})
+	_, err := seeder.SeedRoles()
+	require.NoError(t, err)
+	_, err = seeder.SeedCountries()
+	require.NoError(t, err)
+	admin := Admin{Email: "admin@example.com", Password: "correct horse", Name: "Ada", Surname: "Lovelace", Country: "gb"}
+
+	result, err := seeder.SeedAdmin(admin)
+	require.NoError(t, err)
+	assert.Equal(t, Result{Table: "users", Created: 1}, result)
+	var user struct {
+		RoleCode string
+		Password string
+		Country  string
+		Status   int
+	}
+	require.NoError(t, db.Raw(`SELECT roles.code AS role_code, users.password, countries.name AS country, users.status
+		FROM users JOIN roles ON roles.id = users.role_id JOIN countries ON countries.id = users.country_id`).Scan(&user).Error)
+	assert.Equal(t, roleDomain.RoleCodeAdmin, user.RoleCode)
+	assert.Equal(t, "hashed:correct horse", user.Password)
+	assert.Equal(t, "United Kingdom", user.Country)
+	assert.Equal(t, 1, user.Status)
+
+	admin.Password = "another password"
+	result, err = seeder.SeedAdmin(admin)
+	require.NoError(t, err)
+	assert.Equal(t, Result{Table: "users", Existing: 1}, result)
+	var password string
+	require.NoError(t, db.Raw("SELECT password FROM users WHERE email = ?", admin.Email).Scan(&password).Error)
+	assert.Equal(t, "hashed:correct horse", password)
+
+	_, err = seeder.SeedAdmin(Admin{Email: "x@example.com", Password: "short", Country: "VN"})
+	assert.Error(t, err)
+	_, err = seeder.SeedAdmin(Admin{Email: "x@example.com", Password: "long enough", Country: "XX"})
+	assert.ErrorContains(t, err, "unknown country")
+}
+
+func TestAdminFromEnv(t *testing.T) {
+	t.Setenv("SEED_ADMIN_EMAIL", "")
+	_, ok := AdminFromEnv()
+	assert.False(t, ok)
+
+	t.Setenv("SEED_ADMIN_EMAIL", "admin@example.com")
+	t.Setenv("SEED_ADMIN_PASSWORD", "secret123")
+	t.Setenv("SEED_ADMIN_NAME", "")
+	t.Setenv("SEED_ADMIN_SURNAME", "")
+	t.Setenv("SEED_ADMIN_COUNTRY", "")
+	admin, ok := AdminFromEnv()
+	assert.True(t, ok)
+	assert.Equal(t, Admin{Email: "admin@example.com", Password: "secret123", Name: "Admin", Surname: "Admin", Country: "VN"}, admin)
+}
+
+func TestSeedFixtures(t *testing.T) {
+	useTestKeyring(t)
+	db := databasetest.Open(t)
+	seeder := NewSeeder(db, plainHasher{})
+
+	_, err := seeder.SeedFixtures(Fixtures{Applicants: 1, Password: "password"})
+	assert.ErrorIs(t, err, ErrRoleNotSeeded)
+
+	_, err = seeder.SeedRoles()
+	require.NoError(t, err)
+	_, err = seeder.SeedCountries()
+	require.NoError(t, err)
+	_, err = seeder.SeedFixtures(Fixtures{Applicants: 1, Password: "password", Rand: rand.New(rand.NewSource(1))})
+	assert.ErrorContains(t, err, "no department")
+
+	_, err = seeder.SeedDepartments()
+	require.NoError(t, err)
+	results, err := seeder.SeedFixtures(Fixtures{Applicants: 130, Volunteers: 20, Password: "password", Rand: rand.New(rand.NewSource(1))})
+	require.NoError(t, err)
+	assert.Equal(t, Result{Table: "users", Created: 150}, results[0])
+	assert.Equal(t, Result{Table: "requests", Created: 170}, results[1])
+	assert.Equal(t, Result{Table: "volunteer_details", Created: 20}, results[3])
+
+	assert.Equal(t, 130, count(t, db, "SELECT COUNT(*) FROM requests WHERE type = 'registration' AND status = 0"))
+	assert.Equal(t, 20, count(t, db, "SELECT COUNT(*) FROM users JOIN volunteer_details ON volunteer_details.user_id = users.id JOIN roles ON roles.id = users.role_id WHERE roles.code = ?", roleDomain.RoleCodeVolunteer))
+	assert.Equal(t, 20, count(t, db, "SELECT COUNT(*) FROM user_identities WHERE status = ?", identityDomain.IdentityStatusVerified))
+	assert.Equal(t, results[2].Created, count(t, db, "SELECT COUNT(*) FROM user_identities"))
+	assert.Zero(t, count(t, db, "SELECT COUNT(*) FROM user_identities WHERE number NOT LIKE 'enc:%' OR number_index IS NULL"))
+
+	var identity identityDomain.UserIdentity
+	require.NoError(t, db.First(&identity).Error)
+	assert.Len(t, identity.Number, 9)
+}
@@ -0,0 +1,109 @@
+package upload
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+)
+
+var errMalformed = errors.New("upload: malformed image")
+
+// JPEG markers that carry metadata: APP1 holds EXIF and XMP, APP13 holds
+// IPTC. The JFIF header, ICC profiles and Adobe markers are kept.
+const (
+	jpegAPP1  = 0xE1
+	jpegAPP13 = 0xED
+	jpegSOS   = 0xDA
+	jpegEOI   = 0xD9
+)
+
+// stripJPEG removes the EXIF, XMP and IPTC segments of a JPEG without
+// decoding it, so that the image itself is unchanged. The EXIF orientation
+// goes with them: phone pictures taken sideways are shown as stored.
+func stripJPEG(data []byte) ([]byte, error) {
+	if len(data) < 2 || data[0] != 0xFF || data[1] != 0xD8 {
+		return nil, errMalformed
+	}
+	out := bytes.NewBuffer(make([]byte, 0, len(data)))
+	out.Write(data[:2])
+	for i := 2; ; {
+		// Markers may be preceded by any number of 0xFF fill bytes.
+		for i < len(data) && data[i] == 0xFF && i+1 < len(data) && data[i+1] == 0xFF {
+			i++
+		}
+		if i+1 >= len(data) || data[i] != 0xFF {
+			return nil, errMalformed
+		}
+		marker := data[i+1]
+		switch {
+		case marker == jpegEOI:
+			out.Write(data[i : i+2])
+			return out.Bytes(), nil
+		case marker == 0x01 || marker >= 0xD0 && marker <= 0xD7:
+			out.Write(data[i : i+2])
+			i += 2
+			continue
+		}
+		if i+4 > len(data) {
+			return nil, errMalformed
+		}
+		end := i + 2 + int(binary.BigEndian.Uint16(data[i+2:i+4]))
+		if end > len(data) || end < i+4 {
+			return nil, errMalformed
+		}
+		if marker == jpegSOS {
+			// The entropy-coded data that follows runs to the end of the
+			// image and is copied as is.
+			out.Write(data[i:])
+			return out.Bytes(), nil
+		}
+		if marker != jpegAPP1 && marker != jpegAPP13 {
+			out.Write(data[i:end])
+		}
+		i = end
+	}
+}
+
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// pngMetadataChunks are the ancillary chunks that may hold EXIF data, text
+// such as the author or the software used, or the time of the last edit.
+var pngMetadataChunks = map[string]bool{
+	"eXIf": true,
+	"tEXt": true,
+	"zTXt": true,
+	"iTXt": true,
+	"tIME": true,
+}
+
+// stripPNG removes the metadata chunks of a PNG and checks the CRC of the
+// others.
+func stripPNG(data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, pngSignature) {
+		return nil, errMalformed
+	}
+	out := bytes.NewBuffer(make([]byte, 0, len(data)))
+	out.Write(pngSignature)
+	for i := len(pngSignature); ; {
+		if i+8 > len(data) {
+			return nil, errMalformed
+		}
+		length := int(binary.BigEndian.Uint32(data[i : i+4]))
+		chunkType := string(data[i+4 : i+8])
+		end := i + 12 + length
+		if length < 0 || end > len(data) || end < i {
+			return nil, errMalformed
+		}
+		if crc32.ChecksumIEEE(data[i+4:end-4]) != binary.BigEndian.Uint32(data[end-4:end]) {
+			return nil, errMalformed
+		}
+		if !pngMetadataChunks[chunkType] {
+			out.Write(data[i:end])
+		}
+		if chunkType == "IEND" {
+			return out.Bytes(), nil
+		}
+		i = end
+	}
+}
@@ -0,0 +1,123 @@
+// Package upload reads files sent as multipart form fields. The type of a
+// file is decided by its content, never by its name or the Content-Type the
+// client sent, and images are stripped of their metadata before they are
+// stored.
+package upload
+
+import (
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/validation"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	TypeJPEG = "image/jpeg"
+	TypePNG  = "image/png"
+	TypePDF  = "application/pdf"
+)
+
+var extensions = map[string]string{
+	TypeJPEG: ".jpg",
+	TypePNG:  ".png",
+	TypePDF:  ".pdf",
+}
+
+// multipartOverhead is allowed on top of the file size for the boundaries
+// and headers of the form.
+const multipartOverhead = 64 << 10
+
+var (
+	ErrFileTooLarge    = apperror.New(apperror.TooLarge, "file is too large")
+	ErrUnsupportedFile = apperror.New(apperror.Unsupported, "file type is not supported")
+)
+
+// Policy limits what a form field accepts.
+type Policy struct {
+	MaxSize int64
+	Types   []string
+}
+
+var (
+	AvatarPolicy   = Policy{MaxSize: 2 << 20, Types: []string{TypeJPEG, TypePNG}}
+	DocumentPolicy = Policy{MaxSize: 10 << 20, Types: []string{TypeJPEG, TypePNG, TypePDF}}
+)
+
+// File is an accepted upload, ready to be stored.
+type File struct {
+	Data        []byte
+	ContentType string
+	Ext         string
+}
+
+// FormFile reads the file sent in field and checks it against policy. The
+// request body is capped so that an oversized upload is cut short instead of
+// being spooled to disk.
+func FormFile(c *gin.Context, field string, policy Policy) (*File, error) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, policy.MaxSize+multipartOverhead)
+	header, err := c.FormFile(field)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return nil, tooLarge(policy)
+		}
+		return nil, validation.ErrInvalidInput.WithDetails(validation.Details{Fields: []validation.FieldError{
+			{Field: field, Rule: "required", Message: "is required"},
+		}})
+	}
+	return Read(header, policy)
+}
+
+// Read reads an uploaded file and checks it against policy.
+func Read(header *multipart.FileHeader, policy Policy) (*File, error) {
+	if header.Size > policy.MaxSize {
+		return nil, tooLarge(policy)
+	}
+	f, err := header.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	data, err := io.ReadAll(io.LimitReader(f, policy.MaxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > policy.MaxSize {
+		return nil, tooLarge(policy)
+	}
+	return Sanitize(data, policy)
+}
+
+// Sanitize checks the type of data against policy and removes the metadata
+// of images.
+func Sanitize(data []byte, policy Policy) (*File, error) {
+	contentType := strings.SplitN(http.DetectContentType(data), ";", 2)[0]
+	allowed := false
+	for _, t := range policy.Types {
+		allowed = allowed || t == contentType
+	}
+	if !allowed {
+		return nil, ErrUnsupportedFile.WithDetails(gin.H{"allowed_types": policy.Types})
+	}
+
+	var err error
+	switch contentType {
+	case TypeJPEG:
+		data, err = stripJPEG(data)
+	case TypePNG:
+		data, err = stripPNG(data)
+	}
+	if err != nil {
+		return nil, ErrUnsupportedFile.WithDetails(gin.H{"allowed_types": policy.Types})
+	}
+	return &File{Data: data, ContentType: contentType, Ext: extensions[contentType]}, nil
+}
+
+func tooLarge(policy Policy) error {
+	return ErrFileTooLarge.WithDetails(gin.H{"max_size": policy.MaxSize})
+}
@@ -0,0 +1,140 @@
+package upload
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func testImage() image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, 8, 8))
+	for x := 0; x < 8; x++ {
+		img.Set(x, x, color.RGBA{R: 255, A: 255})
+	}
+	return img
+}
+
+func jpegWithExif(t *testing.T) []byte {
+	var buf bytes.Buffer
+	assert.NoError(t, jpeg.Encode(&buf, testImage(), nil))
+	exif := append([]byte("Exif\x00\x00"), []byte("GPS 48.8584 N 2.2945 E")...)
+	segment := append([]byte{0xFF, 0xE1, 0, 0}, exif...)
+	binary.BigEndian.PutUint16(segment[2:4], uint16(len(exif)+2))
+	data := buf.Bytes()
+	return append(append(append([]byte{}, data[:2]...), segment...), data[2:]...)
+}
+
+func pngWithText(t *testing.T) []byte {
+	var buf bytes.Buffer
+	assert.NoError(t, png.Encode(&buf, testImage()))
+	data := buf.Bytes()
+	text := []byte("tEXtAuthor\x00Jane Doe")
+	chunk := make([]byte, 4, 12+len(text))
+	binary.BigEndian.PutUint32(chunk, uint32(len(text)-4))
+	chunk = append(chunk, text...)
+	chunk = binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE(text))
+	iend := len(data) - 12
+	return append(append(append([]byte{}, data[:iend]...), chunk...), data[iend:]...)
+}
+
+func TestSanitize(t *testing.T) {
+	t.Run("jpeg", func(t *testing.T) {
+		file, err := Sanitize(jpegWithExif(t), AvatarPolicy)
+		assert.NoError(t, err)
+		assert.Equal(t, TypeJPEG, file.ContentType)
+		assert.Equal(t, ".jpg", file.Ext)
+		assert.NotContains(t, string(file.Data), "Exif")
+		assert.NotContains(t, string(file.Data), "GPS")
+		_, err = jpeg.Decode(bytes.NewReader(file.Data))
+		assert.NoError(t, err)
+	})
+
+	t.Run("png", func(t *testing.T) {
+		file, err := Sanitize(pngWithText(t), AvatarPolicy)
+		assert.NoError(t, err)
+		assert.Equal(t, TypePNG, file.ContentType)
+		assert.NotContains(t, string(file.Data), "Jane Doe")
+		_, err = png.Decode(bytes.NewReader(file.Data))
+		assert.NoError(t, err)
+	})
+
+	t.Run("pdf", func(t *testing.T) {
+		data := []byte("%PDF-1.4\n%%EOF\n")
+		file, err := Sanitize(data, DocumentPolicy)
+		assert.NoError(t, err)
+		assert.Equal(t, TypePDF, file.ContentType)
+		assert.Equal(t, data, file.Data)
+
+		_, err = Sanitize(data, AvatarPolicy)
+		assert.ErrorIs(t, err, ErrUnsupportedFile)
+	})
+
+	t.Run("gif", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NoError(t, gif.Encode(&buf, testImage(), nil))
+		_, err := Sanitize(buf.Bytes(), DocumentPolicy)
+		assert.ErrorIs(t, err, ErrUnsupportedFile)
+	})
+
+	t.Run("truncated jpeg", func(t *testing.T) {
+		_, err := Sanitize(jpegWithExif(t)[:40], AvatarPolicy)
+		assert.ErrorIs(t, err, ErrUnsupportedFile)
+	})
+
+	t.Run("png with bad checksum", func(t *testing.T) {
+		data := pngWithText(t)
+		data[20] ^= 0xFF
+		_, err := Sanitize(data, AvatarPolicy)
+		assert.ErrorIs(t, err, ErrUnsupportedFile)
+	})
+}
+
+func TestFormFile(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(apperror.Middleware())
+	router.POST("/upload", func(c *gin.Context) {
+		file, err := FormFile(c, "file", Policy{MaxSize: 1 << 10, Types: []string{TypePNG}})
+		if err != nil {
+			apperror.Abort(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"content_type": file.ContentType})
+	})
+	post := func(field string, data []byte) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		form := multipart.NewWriter(&body)
+		part, _ := form.CreateFormFile(field, "picture.png")
+		part.Write(data)
+		form.Close()
+		req, _ := http.NewRequest(http.MethodPost, "/upload", &body)
+		req.Header.Set("Content-Type", form.FormDataContentType())
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	assert.Equal(t, http.StatusOK, post("file", pngWithText(t)).Code)
+	assert.Equal(t, http.StatusBadRequest, post("picture", pngWithText(t)).Code)
+	assert.Equal(t, http.StatusUnsupportedMediaType, post("file", []byte("<html><script>alert(1)</script></html>")).Code)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, post("file", append(pngWithText(t), make([]byte, 2<<10)...)).Code)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, post("file", make([]byte, 128<<10)).Code)
+}
+
+func TestReadTooLarge(t *testing.T) {
+	_, err := Read(&multipart.FileHeader{Size: 11 << 20}, DocumentPolicy)
+	assert.ErrorIs(t, err, ErrFileTooLarge)
+}
@@ -1,6 +1,10 @@
 package domain
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type User struct {
 	ID                 int       `gorm:"primaryKey"`
@@ -16,10 +20,11 @@ type User struct {
 	CountryID          int       `gorm:"index"`
 	ResidentCountryID  int       `gorm:"index"`
 	Avatar             *string
-	VerificationStatus int       `gorm:"default:0"`
-	Status             int       `gorm:"not null"`
-	CreatedAt          time.Time `gorm:"autoCreateTime"`
-	UpdatedAt          time.Time `gorm:"autoUpdateTime"`
+	VerificationStatus int            `gorm:"default:0"`
+	Status             int            `gorm:"not null"`
+	CreatedAt          time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt          time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt          gorm.DeletedAt `gorm:"index"`
 }
 
 type Request struct {
@@ -28,16 +33,18 @@ type Request struct {
 	Type        string `gorm:"not null"`
 	Status      int    `gorm:"not null"`
 	RejectNotes string
-	VerifierID  int       `gorm:"index"`
-	CreatedAt   time.Time `gorm:"autoCreateTime"`
-	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+	VerifierID  int            `gorm:"index"`
+	CreatedAt   time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
 }
 
 type VolunteerDetail struct {
-	ID           int       `gorm:"primaryKey"`
-	UserID       uint      `gorm:"index"`
-	DepartmentID int       `gorm:"index"`
-	Status       int       `gorm:"not null"`
-	CreatedAt    time.Time `gorm:"autoCreateTime"`
-	UpdatedAt    time.Time `gorm:"autoUpdateTime"`
+	ID           int            `gorm:"primaryKey"`
+	UserID       uint           `gorm:"index"`
+	DepartmentID int            `gorm:"index"`
+	Status       int            `gorm:"not null"`
+	CreatedAt    time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt    time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt    gorm.DeletedAt `gorm:"index"`
 }
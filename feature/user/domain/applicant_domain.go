@@ -1,6 +1,16 @@
 package domain
 
-import "time"
+import (
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrApplicantNotFound = apperror.New(apperror.NotFound, "applicant not found")
+	ErrNoAvatar          = apperror.New(apperror.NotFound, "user has no avatar")
+)
 
 type ApplicantDomain struct {
 	ID                 int `gorm:"primaryKey"`
@@ -16,8 +26,13 @@ type ApplicantDomain struct {
 	CountryID          int       `gorm:"not null"`
 	ResidentCountryID  int       `gorm:"not null"`
 	Avatar             string
-	VerificationStatus int       `gorm:"default:0"`
-	Status             int       `gorm:"not null"`
-	CreatedAt          time.Time `gorm:"autoCreateTime"`
-	UpdatedAt          time.Time `gorm:"autoUpdateTime"`
+	VerificationStatus int            `gorm:"default:0"`
+	Status             int            `gorm:"not null"`
+	CreatedAt          time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt          time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt          gorm.DeletedAt `gorm:"index"`
+}
+
+func (ApplicantDomain) TableName() string {
+	return "users"
 }
@@ -1,12 +0,0 @@
-package domain
-
-import "time"
-
-type ApplicantRequestDomain struct {
-	ID        int       `gorm:"primaryKey"`
-	UserID    int       `gorm:"not null"`
-	Type      string    `gorm:"not null"`
-	Status    int       `gorm:"not null"`
-	CreatedAt time.Time `gorm:"autoCreateTime"`
-	UpdatedAt time.Time `gorm:"autoUpdateTime"`
-}
@@ -3,7 +3,7 @@ package dto
 import (
 	"time"
 
-	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
+	requestDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/request/domain"
 )
 
 type PendingRequest struct {
@@ -15,20 +15,62 @@ type PendingRequest struct {
 }
 
 type RequestResponse struct {
-	ID          int       `json:"id"`
-	UserID      uint      `json:"user_id"`
-	Type        string    `json:"type"`
-	Status      int       `json:"status"`
-	RejectNotes string    `json:"reject_notes"`
-	VerifierID  int       `json:"verifier_id"`
-	CreateAt    time.Time `json:"create_at"`
-	UpdateAt    time.Time `json:"update_at"`
+	ID         int       `json:"id"`
+	UserID     int       `json:"user_id"`
+	Type       string    `json:"type"`
+	Status     int       `json:"status"`
+	VerifierID *int      `json:"verifier_id"`
+	ParentID   *int      `json:"parent_id"`
+	CreateAt   time.Time `json:"create_at"`
+	UpdateAt   time.Time `json:"update_at"`
+	// History holds the earlier revisions of a resubmitted request, newest first.
+	History []RequestRevision `json:"history"`
+}
+
+type RequestRevision struct {
+	ID         int       `json:"id"`
+	Status     int       `json:"status"`
+	VerifierID *int      `json:"verifier_id"`
+	CreateAt   time.Time `json:"create_at"`
+	UpdateAt   time.Time `json:"update_at"`
 }
 
 type ListRequest struct {
-	Requests []*domain.Request `json:"requests"`
+	Requests []*requestDomain.Request `json:"requests"`
+}
+
+// RequestListQuery holds the query parameters of the admin request listing.
+// Sort is "created_at" or "-created_at" (default, newest first).
+// IncludeDeleted also lists soft-deleted requests.
+type RequestListQuery struct {
+	Status         *int       `form:"status"`
+	Type           string     `form:"type"`
+	Email          string     `form:"email"`
+	CreatedFrom    *time.Time `form:"created_from" time_format:"2006-01-02T15:04:05Z07:00"`
+	CreatedTo      *time.Time `form:"created_to" time_format:"2006-01-02T15:04:05Z07:00"`
+	VerifierID     *int       `form:"verifier_id"`
+	IncludeDeleted bool       `form:"include_deleted"`
+	Sort           string     `form:"sort"`
+	Limit          int        `form:"limit"`
+	Cursor         string     `form:"cursor"`
+}
+
+type RequestListItem struct {
+	ID         int        `json:"id"`
+	UserID     int        `json:"user_id"`
+	Type       string     `json:"type"`
+	Status     int        `json:"status"`
+	VerifierID *int       `json:"verifier_id"`
+	ParentID   *int       `json:"parent_id"`
+	CreateAt   time.Time  `json:"create_at"`
+	UpdateAt   time.Time  `json:"update_at"`
+	DeletedAt  *time.Time `json:"deleted_at,omitempty"`
 }
 
-type AddRejectNoteRequest struct {
-	Notes string `json:"notes"`
+// RequestPage is one page of the admin request listing. NextCursor is null on
+// the last page.
+type RequestPage struct {
+	Items      []RequestListItem `json:"items"`
+	NextCursor *string           `json:"next_cursor"`
+	Total      int64             `json:"total"`
 }
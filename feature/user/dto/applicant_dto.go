@@ -1,22 +1,23 @@
 package dto
 
+import "time"
+
 type ApplicantCreateDTO struct {
-	Email   string `json:"email" binding:"required"`
+	Email   string `json:"email" binding:"required,email"`
 	Name    string `json:"name" binding:"required"`
 	Surname string `json:"surname" binding:"required"`
 }
 
 type ApplicantUpdateDTO struct {
-	Email             string `json:"email"`
-	Name              string `json:"name"`
-	Surname           string `json:"surname"`
-	Gender            string `json:"gender"`
-	DOB               string `json:"dob"`
-	Mobile            string `json:"mobile"`
-	RoleID            int    `json:"role_id"`
-	CountryID         int    `json:"country_id"`
-	ResidentCountryID int    `json:"resident_country_id"`
-	DepartmentID      int    `json:"department_id"`
+	Email             string `json:"email" binding:"required,email"`
+	Name              string `json:"name" binding:"required"`
+	Surname           string `json:"surname" binding:"required"`
+	Gender            string `json:"gender" binding:"omitempty,oneof=male female other"`
+	DOB               string `json:"dob" binding:"required,pastdate" example:"1990-04-23"`
+	Mobile            string `json:"mobile" binding:"omitempty,e164" example:"+14155552671"`
+	CountryID         int    `json:"country_id" binding:"omitempty,exists=countries"`
+	ResidentCountryID int    `json:"resident_country_id" binding:"omitempty,exists=countries"`
+	DepartmentID      int    `json:"department_id" binding:"omitempty,exists=departments"`
 }
 
 type ApplicantResponseDTO struct {
@@ -31,4 +32,11 @@ type ApplicantResponseDTO struct {
 	CountryID         int    `json:"country_id"`
 	ResidentCountryID int    `json:"resident_country_id"`
 	DepartmentID      int    `json:"department_id"`
+	HasAvatar         bool   `json:"has_avatar"`
+}
+
+// FileURLResponse is a signed link to a private file.
+type FileURLResponse struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
 }
@@ -1,20 +1,34 @@
 package storage
 
 import (
+	"errors"
+	"time"
+
+	requestDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/request/domain"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
+	identityDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/user_identity/domain"
 	"gorm.io/gorm"
-	"strings"
+	"gorm.io/gorm/clause"
 )
 
 type AdminRepositoryInterface interface {
-	GetListPendingRequest() ([]*domain.Request, string)
-	GetPendingRequestByID(id int) (*domain.Request, string)
-	GetListAllRequest() ([]*domain.Request, string)
-	GetRequestByID(id int) (*domain.Request, string)
-	ApproveRequest(id int, verifier_id int) string
-	RejectRequest(id int, verifier_id int) string
-	AddRejectNotes(id int, notes string) string
-	DeleteRequest(id int) string
+	GetListPendingRequest() ([]*requestDomain.Request, error)
+	GetPendingRequestByID(id int) (*requestDomain.Request, error)
+	GetListAllRequest() ([]*requestDomain.Request, error)
+	GetRequestByID(id int) (*requestDomain.Request, error)
+	ListRequests(filter RequestFilter) ([]*requestDomain.Request, int64, error)
+	GetRequestHistory(request *requestDomain.Request) ([]*requestDomain.Request, error)
+	ApproveRequest(id int, verifierID int, roles ApprovalRoles) error
+	RejectRequest(id int, verifierID int) error
+	DeleteRequest(id int) error
+	RestoreRequest(id int) error
+}
+
+// ApprovalRoles holds the ids of the roles that approving a request assigns,
+// which differ between databases.
+type ApprovalRoles struct {
+	ApplicantRoleID int
+	VolunteerRoleID int
 }
 
 type AdminRepository struct {
@@ -24,137 +38,207 @@ type AdminRepository struct {
 func NewAdminRepository(db *gorm.DB) *AdminRepository {
 	return &AdminRepository{db: db}
 }
-func (r *AdminRepository) GetListPendingRequest() ([]*domain.Request, string) {
-	var listRequest []*domain.Request
-	result := r.db.Where("status = ?", 0).Find(&listRequest)
+func (r *AdminRepository) GetListPendingRequest() ([]*requestDomain.Request, error) {
+	var listRequest []*requestDomain.Request
+	result := r.db.Where("status = ?", requestDomain.RequestStatusPending).Find(&listRequest)
 	if result.Error != nil {
-		return nil, result.Error.Error()
-	}
-	if len(listRequest) == 0 {
-		return nil, "No request pending"
+		return nil, result.Error
 	}
-	return listRequest, ""
+	return listRequest, nil
 }
 
-func (r *AdminRepository) GetPendingRequestByID(id int) (*domain.Request, string) {
-	var request domain.Request
-	result := r.db.Where("id = ? and status = 0", id).First(&request)
+func (r *AdminRepository) GetPendingRequestByID(id int) (*requestDomain.Request, error) {
+	var request requestDomain.Request
+	result := r.db.Where("id = ? and status = ?", id, requestDomain.RequestStatusPending).First(&request)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, requestDomain.ErrRequestNotFound
+	}
 	if result.Error != nil {
-		return nil, result.Error.Error()
+		return nil, result.Error
 	}
-	return &request, ""
+	return &request, nil
 }
 
-func (r *AdminRepository) GetListAllRequest() ([]*domain.Request, string) {
-	var listRequest []*domain.Request
+func (r *AdminRepository) GetListAllRequest() ([]*requestDomain.Request, error) {
+	var listRequest []*requestDomain.Request
 	result := r.db.Find(&listRequest)
 	if result.Error != nil {
-		return nil, result.Error.Error()
-	}
-	if len(listRequest) == 0 {
-		return nil, "No request found"
+		return nil, result.Error
 	}
-	return listRequest, ""
+	return listRequest, nil
 }
 
-func (r *AdminRepository) GetRequestByID(id int) (*domain.Request, string) {
-	var request domain.Request
+func (r *AdminRepository) GetRequestByID(id int) (*requestDomain.Request, error) {
+	var request requestDomain.Request
 	result := r.db.Where("id = ?", id).First(&request)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, requestDomain.ErrRequestNotFound
+	}
 	if result.Error != nil {
-		return nil, result.Error.Error()
+		return nil, result.Error
 	}
-	return &request, ""
+	return &request, nil
 }
 
-// ApproveRequest change status of request to 1 (approved)
-// change verifier_id to admin id
-// if requestType is registration, change user role to 1 (applicant)
-// else if requestType is verification, change user role to 2 (volunteer) and change verification status to 1 (active)
-// and insert this user to volunteer_details table
-func (r *AdminRepository) ApproveRequest(id int, verifier_id int) string {
-	// get request type
-	request := r.getRequestByRequestID(id)
-	if request == nil {
-		return "Request not found"
-	}
-	if request.Status != 0 {
-		return "Request already processed"
+// GetRequestHistory returns the earlier revisions of request, newest first,
+// by following parent_id back to the original submission.
+func (r *AdminRepository) GetRequestHistory(request *requestDomain.Request) ([]*requestDomain.Request, error) {
+	history := []*requestDomain.Request{}
+	for parentID := request.ParentID; parentID != nil; {
+		var parent requestDomain.Request
+		// Deleted revisions are still part of the history.
+		if err := r.db.Unscoped().First(&parent, *parentID).Error; err != nil {
+			return nil, err
+		}
+		history = append(history, &parent)
+		parentID = parent.ParentID
 	}
-	userID := request.UserID
-	if strings.TrimSpace(request.Type) == "registration" {
-		result := r.db.Model(&domain.Request{}).Where("id = ?", id).Update("status", 1).Update("verifier_id", verifier_id)
-		if result.Error != nil {
-			return result.Error.Error()
+	return history, nil
+}
+
+// ApproveRequest approves a pending request inside a single transaction.
+// The request row is locked with SELECT ... FOR UPDATE so that concurrent
+// reviews of the same request are serialized.
+// A registration request moves the user to the applicant role.
+// A verification request moves the user to the volunteer role and inserts
+// the user into volunteer_details, which requires the user to have a department
+// that is not deleted, no volunteer record yet, deleted or not, and a verified
+// identity document that has not expired.
+func (r *AdminRepository) ApproveRequest(id int, verifierID int, roles ApprovalRoles) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		request, err := lockRequest(tx, id)
+		if err != nil {
+			return err
+		}
+		if err := request.TransitionTo(requestDomain.RequestStatusApproved); err != nil {
+			return err
 		}
-		// change user role to 1 (applicant)
-		s, done := updateRoleId(result, r, userID, 1)
-		if done {
-			return s
+
+		var user domain.User
+		err = tx.Select("id", "department_id").First(&user, request.UserID).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return requestDomain.ErrRequesterNotFound
 		}
-		return "Approve request success"
-	} else if strings.TrimSpace(request.Type) == "verification" {
-		result := r.db.Model(&domain.Request{}).Where("id = ?", id).Update("status", 1).Update("verifier_id", verifier_id)
-		if result.Error != nil {
-			return result.Error.Error()
+		if err != nil {
+			return err
 		}
-		// change user role to 2 (volunteer)
-		s, done := updateRoleId(result, r, userID, 2)
-		if done {
-			return s
+
+		var roleID int
+		switch request.Type {
+		case requestDomain.RequestTypeRegistration:
+			roleID = roles.ApplicantRoleID
+		case requestDomain.RequestTypeVerification:
+			if user.DepartmentID == nil {
+				return requestDomain.ErrRequesterHasNoDepartment
+			}
+			var departments int64
+			err := tx.Table("departments").Where("id = ? AND deleted_at IS NULL", *user.DepartmentID).Count(&departments).Error
+			if err != nil {
+				return err
+			}
+			if departments == 0 {
+				return requestDomain.ErrRequesterDepartmentDeleted
+			}
+			var volunteers int64
+			err = tx.Unscoped().Model(&domain.VolunteerDetail{}).Where("user_id = ?", user.ID).Count(&volunteers).Error
+			if err != nil {
+				return err
+			}
+			if volunteers > 0 {
+				return requestDomain.ErrRequesterAlreadyVolunteer
+			}
+			var validIdentities int64
+			err = tx.Model(&identityDomain.UserIdentity{}).
+				Where("user_id = ? AND status = ? AND expiry_date >= ?", user.ID, identityDomain.IdentityStatusVerified, identityDomain.Today(time.Now())).
+				Count(&validIdentities).Error
+			if err != nil {
+				return err
+			}
+			if validIdentities == 0 {
+				return requestDomain.ErrRequesterHasNoValidIdentity
+			}
+			roleID = roles.VolunteerRoleID
+		default:
+			return requestDomain.ErrInvalidRequestType
 		}
-		// insert to volunteer_details
-		departmentID := r.getDeptIdFromUser(userID)
-		volunteerDetail := domain.VolunteerDetail{
-			UserID:       userID,
-			DepartmentID: *departmentID,
-			Status:       1,
+
+		if err := reviewRequest(tx, request, verifierID); err != nil {
+			return err
 		}
-		result = r.db.Create(&volunteerDetail)
-		if result.Error != nil {
-			return result.Error.Error()
+		if err := tx.Model(&domain.User{}).Where("id = ?", user.ID).Update("role_id", roleID).Error; err != nil {
+			return err
 		}
-		return "Approve request success"
-	}
-	return "Invalid request type"
+		if request.Type == requestDomain.RequestTypeVerification {
+			volunteerDetail := domain.VolunteerDetail{
+				UserID:       uint(user.ID),
+				DepartmentID: *user.DepartmentID,
+				Status:       1,
+			}
+			if err := tx.Create(&volunteerDetail).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
-func (r *AdminRepository) RejectRequest(id int, verifier_id int) string {
-	result := r.db.Model(&domain.Request{}).Where("id = ?", id).Update("status", 2).Update("verifier_id", verifier_id)
-	if result.Error != nil {
-		return result.Error.Error()
-	}
-	return "Reject request success"
+
+// RejectRequest rejects a pending request inside a single transaction.
+func (r *AdminRepository) RejectRequest(id int, verifierID int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		request, err := lockRequest(tx, id)
+		if err != nil {
+			return err
+		}
+		if err := request.TransitionTo(requestDomain.RequestStatusRejected); err != nil {
+			return err
+		}
+		return reviewRequest(tx, request, verifierID)
+	})
 }
-func (r *AdminRepository) AddRejectNotes(id int, notes string) string {
-	result := r.db.Model(&domain.Request{}).Where("id = ?", id).Update("reject_notes", notes)
+
+// DeleteRequest soft deletes a request. Deleted requests are not found again.
+func (r *AdminRepository) DeleteRequest(id int) error {
+	result := r.db.Where("id = ?", id).Delete(&requestDomain.Request{})
 	if result.Error != nil {
-		return result.Error.Error()
+		return result.Error
 	}
-	return "Add reject notes success"
-}
-func (r *AdminRepository) DeleteRequest(id int) string {
-	result := r.db.Where("id = ?", id).Delete(&domain.Request{})
-	if result.Error != nil {
-		return result.Error.Error()
+	if result.RowsAffected == 0 {
+		return requestDomain.ErrRequestNotFound
 	}
-	return "Delete request success"
+	return nil
 }
 
-func (r *AdminRepository) getRequestByRequestID(requestID int) *domain.Request {
-	var request *domain.Request
-	r.db.First(&request, requestID)
-	return request
+// RestoreRequest clears deleted_at of a deleted request.
+func (r *AdminRepository) RestoreRequest(id int) error {
+	result := r.db.Unscoped().Model(&requestDomain.Request{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return requestDomain.ErrRequestNotFound
+	}
+	return nil
 }
 
-func (r *AdminRepository) getDeptIdFromUser(id uint) *int {
-	var user domain.User
-	r.db.First(&user, id)
-	return user.DepartmentID
+// lockRequest loads the request and holds a row lock on it until tx ends.
+func lockRequest(tx *gorm.DB, id int) (*requestDomain.Request, error) {
+	var request requestDomain.Request
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&request, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, requestDomain.ErrRequestNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &request, nil
 }
 
-func updateRoleId(result *gorm.DB, r *AdminRepository, userID uint, roleId int) (string, bool) {
-	result = r.db.Model(&domain.User{}).Where("id = ?", userID).Update("role_id", roleId)
-	if result.Error != nil {
-		return result.Error.Error(), true
-	}
-	return "", false
+// reviewRequest persists the new status of request together with its verifier.
+func reviewRequest(tx *gorm.DB, request *requestDomain.Request, verifierID int) error {
+	return tx.Model(&requestDomain.Request{}).Where("id = ?", request.ID).Updates(map[string]interface{}{
+		"status":      request.Status,
+		"verifier_id": verifierID,
+	}).Error
 }
@@ -0,0 +1,74 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/database/databasetest"
+	requestDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/request/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApproveRequestSQLite(t *testing.T) {
+	db := databasetest.Open(t)
+	databasetest.Exec(t, db,
+		"INSERT INTO roles (id, code, name) VALUES (5, 'admin', 'Admin'), (6, 'applicant', 'Applicant'), (7, 'volunteer', 'Volunteer')",
+		"INSERT INTO departments (id, name, address, status) VALUES (1, 'Hanoi', 'Hoan Kiem', 1)",
+		"INSERT INTO departments (id, name, address, status, deleted_at) VALUES (2, 'Hue', 'Phu Hoi', 1, CURRENT_TIMESTAMP)",
+		"INSERT INTO countries (id, name, status) VALUES (1, 'Vietnam', 1)",
+		`INSERT INTO users (id, role_id, department_id, email, password, name, surname, gender, dob, mobile, country_id, resident_country_id, status) VALUES
+			(1, 5, NULL, 'admin@example.com', 'x', 'Ada', 'Admin', 'female', '1980-01-01', '1', 1, 1, 1),
+			(2, 6, 1, 'jane@example.com', 'x', 'Jane', 'Doe', 'female', '1990-01-01', '2', 1, 1, 1),
+			(3, 6, 1, 'john@example.com', 'x', 'John', 'Doe', 'male', '1990-01-01', '3', 1, 1, 1),
+			(4, 6, 2, 'mai@example.com', 'x', 'Mai', 'Tran', 'female', '1990-01-01', '4', 1, 1, 1),
+			(5, 6, 1, 'minh@example.com', 'x', 'Minh', 'Le', 'male', '1990-01-01', '5', 1, 1, 1)`,
+		`INSERT INTO user_identities (user_id, number, type, status, expiry_date, place_issued) VALUES
+			(2, 'enc:v1:test', 'passport', 1, '2099-01-01', 'Hanoi'),
+			(3, 'enc:v1:test', 'passport', 1, '2000-01-01', 'Hanoi'),
+			(4, 'enc:v1:test', 'passport', 1, '2099-01-01', 'Hanoi'),
+			(5, 'enc:v1:test', 'passport', 1, '2099-01-01', 'Hanoi')`,
+		"INSERT INTO volunteer_details (user_id, department_id, status, deleted_at) VALUES (5, 1, 1, CURRENT_TIMESTAMP)",
+		`INSERT INTO requests (id, user_id, type, status) VALUES
+			(1, 2, 'verification', 0), (2, 3, 'verification', 0), (3, 4, 'verification', 0), (4, 5, 'verification', 0)`,
+	)
+	repo := NewAdminRepository(db)
+	roles := ApprovalRoles{ApplicantRoleID: 6, VolunteerRoleID: 7}
+
+	t.Run("verification", func(t *testing.T) {
+		require.NoError(t, repo.ApproveRequest(1, 1, roles))
+
+		request, err := repo.GetRequestByID(1)
+		require.NoError(t, err)
+		assert.Equal(t, requestDomain.RequestStatusApproved, request.Status)
+		require.NotNil(t, request.VerifierID)
+		assert.Equal(t, 1, *request.VerifierID)
+		var roleID, volunteers int
+		require.NoError(t, db.Raw("SELECT role_id FROM users WHERE id = 2").Scan(&roleID).Error)
+		assert.Equal(t, 7, roleID)
+		require.NoError(t, db.Raw("SELECT COUNT(*) FROM volunteer_details WHERE user_id = 2 AND department_id = 1").Scan(&volunteers).Error)
+		assert.Equal(t, 1, volunteers)
+
+		var transitionErr *requestDomain.TransitionError
+		assert.ErrorAs(t, repo.ApproveRequest(1, 1, roles), &transitionErr)
+	})
+
+	t.Run("expired identity", func(t *testing.T) {
+		assert.ErrorIs(t, repo.ApproveRequest(2, 1, roles), requestDomain.ErrRequesterHasNoValidIdentity)
+
+		request, err := repo.GetRequestByID(2)
+		require.NoError(t, err)
+		assert.Equal(t, requestDomain.RequestStatusPending, request.Status)
+	})
+
+	t.Run("deleted department", func(t *testing.T) {
+		assert.ErrorIs(t, repo.ApproveRequest(3, 1, roles), requestDomain.ErrRequesterDepartmentDeleted)
+	})
+
+	t.Run("deleted volunteer record", func(t *testing.T) {
+		assert.ErrorIs(t, repo.ApproveRequest(4, 1, roles), requestDomain.ErrRequesterAlreadyVolunteer)
+
+		var roleID int
+		require.NoError(t, db.Raw("SELECT role_id FROM users WHERE id = 5").Scan(&roleID).Error)
+		assert.Equal(t, 6, roleID)
+	})
+}
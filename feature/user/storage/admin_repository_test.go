@@ -5,9 +5,9 @@ import (
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
-	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
+	requestDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/request/domain"
+	identityDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/user_identity/domain"
 	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/mock"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
@@ -20,7 +20,8 @@ func setupMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock, func()) {
 	}
 
 	gdb, err := gorm.Open(mysql.New(mysql.Config{
-		Conn: db,
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
 	}), &gorm.Config{
 		Logger: logger.Default.LogMode(logger.Silent),
 	})
@@ -41,13 +42,13 @@ func TestGetListPendingRequest(t *testing.T) {
 
 	repo := NewAdminRepository(db)
 
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "requests" WHERE status = $1`)).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `requests` WHERE status = ?")).
 		WithArgs(0).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "type", "status"}).
 			AddRow(1, 1, "registration", 0))
 
 	requests, err := repo.GetListPendingRequest()
-	if err != "" {
+	if err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
 
@@ -62,13 +63,13 @@ func TestGetPendingRequestByID(t *testing.T) {
 
 	repo := NewAdminRepository(db)
 
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "requests" WHERE id = $1 AND status = $2 ORDER BY "requests"."id" LIMIT 1`)).
-		WithArgs(1, 0).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `requests` WHERE (id = ? and status = ?) AND `requests`.`deleted_at` IS NULL ORDER BY `requests`.`id` LIMIT ?")).
+		WithArgs(1, 0, 1).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "type", "status"}).
 			AddRow(1, 1, "registration", 0))
 
 	request, err := repo.GetPendingRequestByID(1)
-	if err != "" {
+	if err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
 
@@ -78,113 +79,299 @@ func TestGetPendingRequestByID(t *testing.T) {
 }
 
 func TestGetListAllRequest(t *testing.T) {
-	mockDB := new(MockDB)
-	repo := NewAdminRepository(&mockDB.DB)
-
-	expectedRequests := []*domain.Request{
-		{ID: 1, UserID: 1, Type: "type1", Status: 0},
-		{ID: 2, UserID: 2, Type: "type2", Status: 1},
-	}
-	mockDB.On("Find", &[]*domain.Request{}).Run(func(args mock.Arguments) {
-		arg := args.Get(0).(*[]*domain.Request)
-		*arg = expectedRequests
-	}).Return(mockDB)
-
-	result, msg := repo.GetListAllRequest()
-
-	assert.Equal(t, expectedRequests, result)
-	assert.Empty(t, msg)
-	mockDB.AssertExpectations(t)
-}
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
 
-func TestGetRequestByID(t *testing.T) {
-	mockDB := new(MockDB)
-	repo := NewAdminRepository(&mockDB.DB)
+	repo := NewAdminRepository(db)
 
-	expectedRequest := &domain.Request{ID: 1, UserID: 1, Type: "type1", Status: 0}
-	mockDB.On("Where", "id = ?", 1).Return(mockDB)
-	mockDB.On("First", &domain.Request{}).Run(func(args mock.Arguments) {
-		arg := args.Get(0).(*domain.Request)
-		*arg = *expectedRequest
-	}).Return(mockDB)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `requests` WHERE `requests`.`deleted_at` IS NULL")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "type", "status"}).
+			AddRow(1, 1, "registration", 0).
+			AddRow(2, 2, "verification", 1))
 
-	result, msg := repo.GetRequestByID(1)
+	result, err := repo.GetListAllRequest()
 
-	assert.Equal(t, expectedRequest, result)
-	assert.Empty(t, msg)
-	mockDB.AssertExpectations(t)
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.Equal(t, requestDomain.RequestTypeRegistration, result[0].Type)
+	assert.Equal(t, requestDomain.RequestStatusApproved, result[1].Status)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
-func TestApproveRequest(t *testing.T) {
+func TestGetRequestByID(t *testing.T) {
 	db, mock, cleanup := setupMockDB(t)
 	defer cleanup()
 
 	repo := NewAdminRepository(db)
 
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "requests" WHERE id = $1 ORDER BY "requests"."id" LIMIT 1`)).
-		WithArgs(1).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `requests` WHERE id = ? AND `requests`.`deleted_at` IS NULL ORDER BY `requests`.`id` LIMIT ?")).
+		WithArgs(1, 1).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "type", "status"}).
 			AddRow(1, 1, "registration", 0))
 
-	mock.ExpectBegin()
-	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "requests" SET "status"=$1,"verifier_id"=$2 WHERE "id" = $3`)).
-		WithArgs(1, 1, 1).
-		WillReturnResult(sqlmock.NewResult(1, 1))
-	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "users" SET "role_id"=$1 WHERE "id" = $2`)).
-		WithArgs(1, 1).
-		WillReturnResult(sqlmock.NewResult(1, 1))
-	mock.ExpectCommit()
+	result, err := repo.GetRequestByID(1)
 
-	err := repo.ApproveRequest(1, 1)
-	if err != "" {
-		t.Errorf("unexpected error: %v", err)
-	}
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.ID)
+	assert.Equal(t, requestDomain.RequestTypeRegistration, result.Type)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
-func TestRejectRequest(t *testing.T) {
+func TestGetRequestHistory(t *testing.T) {
 	db, mock, cleanup := setupMockDB(t)
 	defer cleanup()
 
 	repo := NewAdminRepository(db)
 
-	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "requests" SET "status"=$1,"verifier_id"=$2 WHERE "id" = $3`)).
-		WithArgs(2, 1, 1).
-		WillReturnResult(sqlmock.NewResult(1, 1))
+	// deleted revisions are still part of the history
+	query := regexp.QuoteMeta("SELECT * FROM `requests` WHERE `requests`.`id` = ? ORDER BY `requests`.`id` LIMIT ?")
+	mock.ExpectQuery(query).WithArgs(2, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "type", "status", "parent_id"}).
+			AddRow(2, 7, "verification", 2, 1))
+	mock.ExpectQuery(query).WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "type", "status", "parent_id"}).
+			AddRow(1, 7, "verification", 2, nil))
+
+	parentID := 2
+	history, err := repo.GetRequestHistory(&requestDomain.Request{ID: 3, ParentID: &parentID})
+	assert.NoError(t, err)
+	assert.Len(t, history, 2)
+	assert.Equal(t, 2, history[0].ID)
+	assert.Equal(t, 1, history[1].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
 
-	err := repo.RejectRequest(1, 1)
-	if err != "" {
-		t.Errorf("unexpected error: %v", err)
+func TestApproveRequest(t *testing.T) {
+	lock := regexp.QuoteMeta("SELECT * FROM `requests` WHERE `requests`.`id` = ? AND `requests`.`deleted_at` IS NULL ORDER BY `requests`.`id` LIMIT ? FOR UPDATE")
+	selectUser := regexp.QuoteMeta("SELECT `id`,`department_id` FROM `users` WHERE `users`.`id` = ? AND `users`.`deleted_at` IS NULL ORDER BY `users`.`id` LIMIT ?")
+	updateRequest := regexp.QuoteMeta("UPDATE `requests` SET `status`=?,`verifier_id`=?,`updated_at`=? WHERE id = ?")
+	updateRole := regexp.QuoteMeta("UPDATE `users` SET `role_id`=?,`updated_at`=? WHERE id = ?")
+	countDepartments := regexp.QuoteMeta("SELECT count(*) FROM `departments` WHERE id = ? AND deleted_at IS NULL")
+	countVolunteers := regexp.QuoteMeta("SELECT count(*) FROM `volunteer_details` WHERE user_id = ?")
+	countIdentities := regexp.QuoteMeta("SELECT count(*) FROM `user_identities` WHERE user_id = ? AND status = ? AND expiry_date >= ?")
+	roles := ApprovalRoles{ApplicantRoleID: 11, VolunteerRoleID: 12}
+	requestRow := func(requestType string, status int) *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "user_id", "type", "status"}).AddRow(1, 7, requestType, status)
 	}
+
+	t.Run("registration", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+		repo := NewAdminRepository(db)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lock).WithArgs(1, 1).WillReturnRows(requestRow("registration", 0))
+		mock.ExpectQuery(selectUser).WithArgs(7, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "department_id"}).AddRow(7, nil))
+		mock.ExpectExec(updateRequest).WithArgs(requestDomain.RequestStatusApproved, 3, sqlmock.AnyArg(), 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(updateRole).WithArgs(roles.ApplicantRoleID, sqlmock.AnyArg(), 7).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		assert.NoError(t, repo.ApproveRequest(1, 3, roles))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("verification", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+		repo := NewAdminRepository(db)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lock).WithArgs(1, 1).WillReturnRows(requestRow("verification", 0))
+		mock.ExpectQuery(selectUser).WithArgs(7, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "department_id"}).AddRow(7, 4))
+		mock.ExpectQuery(countDepartments).WithArgs(4).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectQuery(countVolunteers).WithArgs(7).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectQuery(countIdentities).WithArgs(7, identityDomain.IdentityStatusVerified, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectExec(updateRequest).WithArgs(requestDomain.RequestStatusApproved, 3, sqlmock.AnyArg(), 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(updateRole).WithArgs(roles.VolunteerRoleID, sqlmock.AnyArg(), 7).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `volunteer_details`")).
+			WithArgs(7, 4, 1, sqlmock.AnyArg(), sqlmock.AnyArg(), nil).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		assert.NoError(t, repo.ApproveRequest(1, 3, roles))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("user without department", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+		repo := NewAdminRepository(db)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lock).WithArgs(1, 1).WillReturnRows(requestRow("verification", 0))
+		mock.ExpectQuery(selectUser).WithArgs(7, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "department_id"}).AddRow(7, nil))
+		mock.ExpectRollback()
+
+		err := repo.ApproveRequest(1, 3, roles)
+		assert.ErrorIs(t, err, requestDomain.ErrRequesterHasNoDepartment)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("deleted department", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+		repo := NewAdminRepository(db)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lock).WithArgs(1, 1).WillReturnRows(requestRow("verification", 0))
+		mock.ExpectQuery(selectUser).WithArgs(7, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "department_id"}).AddRow(7, 4))
+		mock.ExpectQuery(countDepartments).WithArgs(4).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectRollback()
+
+		err := repo.ApproveRequest(1, 3, roles)
+		assert.ErrorIs(t, err, requestDomain.ErrRequesterDepartmentDeleted)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("user already volunteer", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+		repo := NewAdminRepository(db)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lock).WithArgs(1, 1).WillReturnRows(requestRow("verification", 0))
+		mock.ExpectQuery(selectUser).WithArgs(7, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "department_id"}).AddRow(7, 4))
+		mock.ExpectQuery(countDepartments).WithArgs(4).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectQuery(countVolunteers).WithArgs(7).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectRollback()
+
+		err := repo.ApproveRequest(1, 3, roles)
+		assert.ErrorIs(t, err, requestDomain.ErrRequesterAlreadyVolunteer)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("user without valid identity", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+		repo := NewAdminRepository(db)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lock).WithArgs(1, 1).WillReturnRows(requestRow("verification", 0))
+		mock.ExpectQuery(selectUser).WithArgs(7, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "department_id"}).AddRow(7, 4))
+		mock.ExpectQuery(countDepartments).WithArgs(4).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectQuery(countVolunteers).WithArgs(7).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectQuery(countIdentities).WithArgs(7, identityDomain.IdentityStatusVerified, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectRollback()
+
+		err := repo.ApproveRequest(1, 3, roles)
+		assert.ErrorIs(t, err, requestDomain.ErrRequesterHasNoValidIdentity)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rejected request", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+		repo := NewAdminRepository(db)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lock).WithArgs(1, 1).WillReturnRows(requestRow("registration", 2))
+		mock.ExpectRollback()
+
+		err := repo.ApproveRequest(1, 3, roles)
+		assert.ErrorIs(t, err, requestDomain.ErrInvalidTransition)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("request not found", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+		repo := NewAdminRepository(db)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lock).WithArgs(9, 1).WillReturnError(gorm.ErrRecordNotFound)
+		mock.ExpectRollback()
+
+		err := repo.ApproveRequest(9, 3, roles)
+		assert.ErrorIs(t, err, requestDomain.ErrRequestNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRejectRequest(t *testing.T) {
+	lock := regexp.QuoteMeta("SELECT * FROM `requests` WHERE `requests`.`id` = ? AND `requests`.`deleted_at` IS NULL ORDER BY `requests`.`id` LIMIT ? FOR UPDATE")
+
+	t.Run("pending request", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+		repo := NewAdminRepository(db)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lock).WithArgs(1, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "type", "status"}).AddRow(1, 7, "registration", 0))
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `requests` SET `status`=?,`verifier_id`=?,`updated_at`=? WHERE id = ?")).
+			WithArgs(requestDomain.RequestStatusRejected, 3, sqlmock.AnyArg(), 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		assert.NoError(t, repo.RejectRequest(1, 3))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("approved request", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+		repo := NewAdminRepository(db)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lock).WithArgs(1, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "type", "status"}).AddRow(1, 7, "registration", 1))
+		mock.ExpectRollback()
+
+		err := repo.RejectRequest(1, 3)
+		assert.ErrorIs(t, err, requestDomain.ErrInvalidTransition)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
 }
 
-func TestAddRejectNotes(t *testing.T) {
+func TestDeleteRequest(t *testing.T) {
 	db, mock, cleanup := setupMockDB(t)
 	defer cleanup()
 
 	repo := NewAdminRepository(db)
 
-	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "requests" SET "reject_notes"=$1 WHERE "id" = $2`)).
-		WithArgs("some notes", 1).
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `requests` SET `deleted_at`=? WHERE id = ? AND `requests`.`deleted_at` IS NULL")).
+		WithArgs(sqlmock.AnyArg(), 1).
 		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `requests` SET `deleted_at`=? WHERE id = ? AND `requests`.`deleted_at` IS NULL")).
+		WithArgs(sqlmock.AnyArg(), 2).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
 
-	err := repo.AddRejectNotes(1, "some notes")
-	if err != "" {
-		t.Errorf("unexpected error: %v", err)
-	}
+	assert.NoError(t, repo.DeleteRequest(1))
+	assert.ErrorIs(t, repo.DeleteRequest(2), requestDomain.ErrRequestNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
-func TestDeleteRequest(t *testing.T) {
+func TestRestoreRequest(t *testing.T) {
 	db, mock, cleanup := setupMockDB(t)
 	defer cleanup()
 
 	repo := NewAdminRepository(db)
 
-	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "requests" WHERE "id" = $1`)).
-		WithArgs(1).
-		WillReturnResult(sqlmock.NewResult(1, 1))
+	restore := regexp.QuoteMeta("UPDATE `requests` SET `deleted_at`=?,`updated_at`=? WHERE id = ? AND deleted_at IS NOT NULL")
+	mock.ExpectBegin()
+	mock.ExpectExec(restore).WithArgs(nil, sqlmock.AnyArg(), 1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec(restore).WithArgs(nil, sqlmock.AnyArg(), 2).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
 
-	err := repo.DeleteRequest(1)
-	if err != "" {
-		t.Errorf("unexpected error: %v", err)
-	}
+	assert.NoError(t, repo.RestoreRequest(1))
+	assert.ErrorIs(t, repo.RestoreRequest(2), requestDomain.ErrRequestNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -0,0 +1,89 @@
+package storage
+
+import (
+	"time"
+
+	requestDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/request/domain"
+	"gorm.io/gorm"
+)
+
+// RequestCursor is the position of the last request of a page.
+type RequestCursor struct {
+	CreatedAt time.Time
+	ID        int
+}
+
+// RequestFilter narrows down and orders the admin request listing.
+// Zero values mean "no filter"; soft-deleted requests are left out unless
+// IncludeDeleted is set.
+type RequestFilter struct {
+	Status         *requestDomain.RequestStatus
+	Type           requestDomain.RequestType
+	Email          string
+	CreatedFrom    *time.Time
+	CreatedTo      *time.Time
+	VerifierID     *int
+	IncludeDeleted bool
+	Ascending      bool
+	After          *RequestCursor
+	Limit          int
+}
+
+// ListRequests returns one page of requests matching filter, ordered by
+// created_at then id, together with the number of matching requests across
+// all pages. The page holds up to Limit+1 rows so that callers can tell
+// whether another page follows.
+func (r *AdminRepository) ListRequests(filter RequestFilter) ([]*requestDomain.Request, int64, error) {
+	var total int64
+	if err := r.filterRequests(filter).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	direction, compare := "DESC", "<"
+	if filter.Ascending {
+		direction, compare = "ASC", ">"
+	}
+	page := r.filterRequests(filter)
+	if filter.After != nil {
+		page = page.Where(
+			"requests.created_at "+compare+" ? OR (requests.created_at = ? AND requests.id "+compare+" ?)",
+			filter.After.CreatedAt, filter.After.CreatedAt, filter.After.ID,
+		)
+	}
+	requests := []*requestDomain.Request{}
+	err := page.Select("requests.*").
+		Order("requests.created_at " + direction).
+		Order("requests.id " + direction).
+		Limit(filter.Limit + 1).
+		Find(&requests).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return requests, total, nil
+}
+
+func (r *AdminRepository) filterRequests(filter RequestFilter) *gorm.DB {
+	query := r.db.Model(&requestDomain.Request{})
+	if filter.IncludeDeleted {
+		query = query.Unscoped()
+	}
+	if filter.Status != nil {
+		query = query.Where("requests.status = ?", *filter.Status)
+	}
+	if filter.Type != "" {
+		query = query.Where("requests.type = ?", filter.Type)
+	}
+	if filter.Email != "" {
+		query = query.Joins("JOIN users ON users.id = requests.user_id").Where("users.email = ?", filter.Email)
+	}
+	if filter.CreatedFrom != nil {
+		query = query.Where("requests.created_at >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		query = query.Where("requests.created_at < ?", *filter.CreatedTo)
+	}
+	if filter.VerifierID != nil {
+		query = query.Where("requests.verifier_id = ?", *filter.VerifierID)
+	}
+	return query
+}
@@ -0,0 +1,76 @@
+package storage
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	requestDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/request/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListRequests(t *testing.T) {
+	columns := []string{"id", "user_id", "type", "status", "created_at"}
+	createdAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("filters and first page", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+		repo := NewAdminRepository(db)
+
+		status := requestDomain.RequestStatusPending
+		filter := RequestFilter{
+			Status: &status,
+			Type:   requestDomain.RequestTypeVerification,
+			Email:  "jane@example.com",
+			Limit:  2,
+		}
+		where := "JOIN users ON users.id = requests.user_id WHERE requests.status = ? AND requests.type = ? AND users.email = ? " +
+			"AND `requests`.`deleted_at` IS NULL"
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `requests` "+where)).
+			WithArgs(status, filter.Type, filter.Email).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT requests.* FROM `requests` "+where+
+			" ORDER BY requests.created_at DESC,requests.id DESC LIMIT ?")).
+			WithArgs(status, filter.Type, filter.Email, 3).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(3, 7, "verification", 0, createdAt).
+				AddRow(2, 7, "verification", 0, createdAt).
+				AddRow(1, 7, "verification", 0, createdAt))
+
+		requests, total, err := repo.ListRequests(filter)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), total)
+		assert.Len(t, requests, 3)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ascending after cursor", func(t *testing.T) {
+		db, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+		repo := NewAdminRepository(db)
+
+		verifierID := 4
+		filter := RequestFilter{
+			VerifierID: &verifierID,
+			Ascending:  true,
+			After:      &RequestCursor{CreatedAt: createdAt, ID: 2},
+			Limit:      20,
+		}
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `requests` WHERE requests.verifier_id = ? AND `requests`.`deleted_at` IS NULL")).
+			WithArgs(verifierID).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT requests.* FROM `requests` WHERE requests.verifier_id = ? AND "+
+			"(requests.created_at > ? OR (requests.created_at = ? AND requests.id > ?)) AND `requests`.`deleted_at` IS NULL "+
+			"ORDER BY requests.created_at ASC,requests.id ASC LIMIT ?")).
+			WithArgs(verifierID, createdAt, createdAt, 2, 21).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		requests, total, err := repo.ListRequests(filter)
+		assert.NoError(t, err)
+		assert.Zero(t, total)
+		assert.Empty(t, requests)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
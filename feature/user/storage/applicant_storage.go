@@ -1,6 +1,8 @@
 package storage
 
 import (
+	"errors"
+
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
 
 	"gorm.io/gorm"
@@ -10,6 +12,7 @@ type ApplicantRepositoryInterface interface {
 	CreateApplicant(user *domain.ApplicantDomain) error
 	UpdateApplicant(user *domain.ApplicantDomain) error
 	DeleteApplicant(id int) error
+	RestoreApplicant(id int) error
 	FindApplicantByID(id int) (*domain.ApplicantDomain, error)
 }
 
@@ -33,10 +36,39 @@ func (r *ApplicantRepository) DeleteApplicant(id int) error {
 	return r.DB.Delete(&domain.ApplicantDomain{}, id).Error
 }
 
+// RestoreApplicant clears deleted_at of a deleted user.
+func (r *ApplicantRepository) RestoreApplicant(id int) error {
+	result := r.DB.Unscoped().Model(&domain.ApplicantDomain{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrApplicantNotFound
+	}
+	return nil
+}
+
 func (r *ApplicantRepository) FindApplicantByID(id int) (*domain.ApplicantDomain, error) {
 	var user domain.ApplicantDomain
 	if err := r.DB.First(&user, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrApplicantNotFound
+		}
 		return nil, err
 	}
 	return &user, nil
 }
+
+// UpdateAvatar sets the key of the avatar of a user.
+func (r *ApplicantRepository) UpdateAvatar(id int, key string) error {
+	result := r.DB.Model(&domain.ApplicantDomain{}).Where("id = ?", id).Update("avatar", key)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrApplicantNotFound
+	}
+	return nil
+}
@@ -1,20 +1,17 @@
 package storage
 
 import (
+	"regexp"
 	"testing"
 	"time"
 
+	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
 	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/mock"
-	"gorm.io/gorm"
 )
 
-func TestCreateApplicant(t *testing.T) {
-	mockDB := new(MockDB)
-	repo := NewApplicantRepository(&mockDB.DB)
-
-	applicant := &domain.ApplicantDomain{
+func newApplicant() *domain.ApplicantDomain {
+	return &domain.ApplicantDomain{
 		ID:                 1,
 		RoleID:             1,
 		DepartmentID:       2,
@@ -31,97 +28,87 @@ func TestCreateApplicant(t *testing.T) {
 		VerificationStatus: 0,
 		Status:             0,
 	}
-	mockDB.On("Create", applicant).Return(&gorm.DB{Error: nil})
+}
+
+func TestCreateApplicant(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+	repo := NewApplicantRepository(db)
 
-	err := repo.CreateApplicant(applicant)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `users`")).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err := repo.CreateApplicant(newApplicant())
 
 	assert.NoError(t, err)
-	mockDB.AssertExpectations(t)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestUpdateApplicant(t *testing.T) {
-	mockDB := new(MockDB)
-	repo := NewApplicantRepository(&mockDB.DB)
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+	repo := NewApplicantRepository(db)
 
-	applicant := &domain.ApplicantDomain{
-		ID:                 1,
-		RoleID:             1,
-		DepartmentID:       2,
-		Email:              "test@example.com",
-		Password:           "123456",
-		Name:               "Johnny",
-		Surname:            "Hoang",
-		Gender:             "Male",
-		DOB:                time.Date(2002, 9, 20, 0, 0, 0, 0, time.UTC),
-		Mobile:             "0912345987",
-		CountryID:          3,
-		ResidentCountryID:  5,
-		Avatar:             "http://linktoavatar.com",
-		VerificationStatus: 0,
-		Status:             1,
-	}
-	mockDB.On("Save", applicant).Return(&gorm.DB{Error: nil})
+	applicant := newApplicant()
+	applicant.Status = 1
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `users` SET")).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
 
 	err := repo.UpdateApplicant(applicant)
 
 	assert.NoError(t, err)
-	mockDB.AssertExpectations(t)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestDeleteApplicant(t *testing.T) {
-	mockDB := new(MockDB)
-	repo := NewApplicantRepository(&mockDB.DB)
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+	repo := NewApplicantRepository(db)
 
-	mockDB.On("Delete", &domain.ApplicantDomain{}, 1).Return(&gorm.DB{Error: nil})
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `users` SET `deleted_at`=? WHERE `users`.`id` = ? AND `users`.`deleted_at` IS NULL")).
+		WithArgs(sqlmock.AnyArg(), 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
 
 	err := repo.DeleteApplicant(1)
 
 	assert.NoError(t, err)
-	mockDB.AssertExpectations(t)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestFindApplicantByID(t *testing.T) {
-	mockDB := new(MockDB)
-	repo := NewApplicantRepository(&mockDB.DB)
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+	repo := NewApplicantRepository(db)
 
-	applicant := &domain.ApplicantDomain{
-		ID:                 1,
-		RoleID:             1,
-		DepartmentID:       2,
-		Email:              "test@example.com",
-		Password:           "123456",
-		Name:               "Johnny",
-		Surname:            "Hoang",
-		Gender:             "Male",
-		DOB:                time.Date(2002, 9, 20, 0, 0, 0, 0, time.UTC),
-		Mobile:             "0912345987",
-		CountryID:          3,
-		ResidentCountryID:  5,
-		Avatar:             "http://linktoavatar.com",
-		VerificationStatus: 0,
-		Status:             0,
-	}
-	mockDB.On("First", &applicant, 1).Return(&gorm.DB{Error: nil}).Run(func(args mock.Arguments) {
-		arg := args.Get(0).(**domain.ApplicantDomain)
-		*arg = applicant
-	})
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE `users`.`id` = ? AND `users`.`deleted_at` IS NULL ORDER BY `users`.`id` LIMIT ?")).
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email", "name", "surname"}).
+			AddRow(1, "test@example.com", "Johnny", "Hoang"))
 
 	result, err := repo.FindApplicantByID(1)
 
 	assert.NoError(t, err)
-	assert.Equal(t, applicant, result)
-	mockDB.AssertExpectations(t)
+	assert.Equal(t, 1, result.ID)
+	assert.Equal(t, "Johnny", result.Name)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestFindApplicantByID_NotFound(t *testing.T) {
-	mockDB := new(MockDB)
-	repo := NewApplicantRepository(&mockDB.DB)
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+	repo := NewApplicantRepository(db)
 
-	mockDB.On("First", mock.Anything, 1).Return(&gorm.DB{Error: gorm.ErrRecordNotFound})
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE `users`.`id` = ?")).
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
 
 	result, err := repo.FindApplicantByID(1)
 
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, domain.ErrApplicantNotFound)
 	assert.Nil(t, result)
-	mockDB.AssertExpectations(t)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -13,7 +13,7 @@ func NewMySQLDB(dsn string) (*gorm.DB, error) {
 		return nil, err
 	}
 
-	err = db.AutoMigrate(&domain.ApplicantDomain{}, &domain.Request{})
+	err = db.AutoMigrate(&domain.ApplicantDomain{})
 	if err != nil {
 		return nil, err
 	}
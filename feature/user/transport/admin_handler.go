@@ -1,8 +1,10 @@
 package transport
 
 import (
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/usecase"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/validation"
 	"github.com/gin-gonic/gin"
 	"net/http"
 	"strconv"
@@ -18,16 +20,17 @@ func NewAuthenticationHandler(usecase usecase.AdminUsecaseInterface) *AdminHandl
 
 // GetListPendingRequest godoc
 // @Summary Get list pending request
-// @Description Get list pending request
+// @Description Get list pending request. Use GET /admin/requests?status=0 instead.
+// @Deprecated
 // @Produce json
 // @Tags admin
 // @Security bearerToken
 // @Success 200 {object} dto.ListRequest{}
 // @Router /api/v1/admin/list-pending-request [get]
 func (h *AdminHandler) GetListPendingRequest(c *gin.Context) {
-	resp, msg := h.usecase.GetListPendingRequest()
-	if msg != "" {
-		c.JSON(http.StatusNotFound, gin.H{"error": msg})
+	resp, err := h.usecase.GetListPendingRequest()
+	if err != nil {
+		apperror.Abort(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, resp)
@@ -45,12 +48,12 @@ func (h *AdminHandler) GetListPendingRequest(c *gin.Context) {
 func (h *AdminHandler) GetPendingRequestById(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request ID"})
+		apperror.Abort(c, apperror.New(apperror.Validation, "Invalid request ID"))
 		return
 	}
-	resp, msg := h.usecase.GetPendingRequestById(id)
-	if msg != "" {
-		c.JSON(http.StatusNotFound, gin.H{"error": msg})
+	resp, err := h.usecase.GetPendingRequestById(id)
+	if err != nil {
+		apperror.Abort(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, resp)
@@ -58,21 +61,55 @@ func (h *AdminHandler) GetPendingRequestById(c *gin.Context) {
 
 // GetListRequest godoc
 // @Summary Get list request
-// @Description Get list request
+// @Description Get list request. Use GET /admin/requests instead.
+// @Deprecated
 // @Produce json
 // @Tags admin
 // @Security bearerToken
 // @Success 200 {object} dto.ListRequest{}
 // @Router /api/v1/admin/list-request [get]
 func (h *AdminHandler) GetListRequest(c *gin.Context) {
-	resp, msg := h.usecase.GetListRequest()
-	if msg != "" {
-		c.JSON(http.StatusNotFound, gin.H{"error": msg})
+	resp, err := h.usecase.GetListRequest()
+	if err != nil {
+		apperror.Abort(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, resp)
 }
 
+// ListRequests godoc
+// @Summary List requests
+// @Description List requests with filters and cursor pagination, newest first by default
+// @Produce json
+// @Tags admin
+// @Param status query int false "Request status (0 pending, 1 approved, 2 rejected, 3 cancelled)"
+// @Param type query string false "Request type (registration, verification)"
+// @Param email query string false "Requester email"
+// @Param created_from query string false "Created at or after (RFC 3339)"
+// @Param created_to query string false "Created before (RFC 3339)"
+// @Param verifier_id query int false "Verifier user ID"
+// @Param include_deleted query bool false "Also list deleted requests"
+// @Param sort query string false "created_at or -created_at"
+// @Param limit query int false "Page size, 1 to 100 (default 20)"
+// @Param cursor query string false "next_cursor of the previous page"
+// @Success 200 {object} dto.RequestPage
+// @Failure 400 {object} apperror.Response "Invalid query"
+// @Security bearerToken
+// @Router /api/v1/admin/requests [get]
+func (h *AdminHandler) ListRequests(c *gin.Context) {
+	var query dto.RequestListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		apperror.Abort(c, validation.Error(err))
+		return
+	}
+	page, err := h.usecase.ListRequests(query)
+	if err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, page)
+}
+
 // GetRequestById godoc
 // @Summary Get request by ID
 // @Description Get request by ID
@@ -85,12 +122,12 @@ func (h *AdminHandler) GetListRequest(c *gin.Context) {
 func (h *AdminHandler) GetRequestById(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request ID"})
+		apperror.Abort(c, apperror.New(apperror.Validation, "Invalid request ID"))
 		return
 	}
-	resp, msg := h.usecase.GetRequestById(id)
-	if msg != "" {
-		c.JSON(http.StatusNotFound, gin.H{"error": msg})
+	resp, err := h.usecase.GetRequestById(id)
+	if err != nil {
+		apperror.Abort(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, resp)
@@ -103,21 +140,27 @@ func (h *AdminHandler) GetRequestById(c *gin.Context) {
 // @Tags admin
 // @Param id path int true "Request ID"
 // @Success 200 string message
+// @Failure 404 {object} apperror.Response "Request not found"
+// @Failure 409 {object} apperror.Response "Request cannot move to the target status, or the user already has a volunteer record"
+// @Failure 422 {object} apperror.Response "Requesting user cannot be approved"
 // @Security bearerToken
 // @Router /api/v1/admin/approve-request/{id} [post]
 func (h *AdminHandler) ApproveRequest(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request ID"})
+		apperror.Abort(c, apperror.New(apperror.Validation, "Invalid request ID"))
 		return
 	}
 	userId, exists := c.Get("userId")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		apperror.Abort(c, apperror.ErrUnauthorized)
 		return
 	}
-	msg := h.usecase.ApproveRequest(id, userId.(int))
-	c.JSON(http.StatusOK, gin.H{"message": msg})
+	if err := h.usecase.ApproveRequest(id, userId.(int)); err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Approve request success"})
 }
 
 // RejectRequest godoc
@@ -127,63 +170,79 @@ func (h *AdminHandler) ApproveRequest(c *gin.Context) {
 // @Tags admin
 // @Param id path int true "Request ID"
 // @Success 200 string message
+// @Failure 404 {object} apperror.Response "Request not found"
+// @Failure 409 {object} apperror.Response "Request cannot move to the target status"
 // @Security bearerToken
 // @Router /api/v1/admin/reject-request/{id} [post]
 func (h *AdminHandler) RejectRequest(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request ID"})
+		apperror.Abort(c, apperror.New(apperror.Validation, "Invalid request ID"))
 		return
 	}
 	userId, exists := c.Get("userId")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		apperror.Abort(c, apperror.ErrUnauthorized)
+		return
+	}
+	if err := h.usecase.RejectRequest(id, userId.(int)); err != nil {
+		apperror.Abort(c, err)
 		return
 	}
-	msg := h.usecase.RejectRequest(id, userId.(int))
-	c.JSON(http.StatusOK, gin.H{"message": msg})
+	c.JSON(http.StatusOK, gin.H{"message": "Reject request success"})
 }
 
-// AddRejectNotes godoc
-// @Summary Add reject notes
-// @Description Add reject notes
+// DeleteRequest godoc
+// @Summary Delete request
+// @Description Delete request
 // @Produce json
 // @Tags admin
 // @Param id path int true "Request ID"
-// @Param notes body dto.AddRejectNoteRequest true "Add Reject Note Request"
 // @Success 200 string message
 // @Security bearerToken
-// @Router /api/v1/admin/add-reject-notes/{id} [post]
-func (h *AdminHandler) AddRejectNotes(c *gin.Context) {
+// @Router /api/v1/admin/delete-request/{id} [delete]
+func (h *AdminHandler) DeleteRequest(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request ID"})
+		apperror.Abort(c, apperror.New(apperror.Validation, "Invalid request ID"))
+		return
+	}
+	userId, exists := c.Get("userId")
+	if !exists {
+		apperror.Abort(c, apperror.ErrUnauthorized)
 		return
 	}
-	var req dto.AddRejectNoteRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := h.usecase.DeleteRequest(id, userId.(int)); err != nil {
+		apperror.Abort(c, err)
 		return
 	}
-	msg := h.usecase.AddRejectNotes(id, req.Notes)
-	c.JSON(http.StatusOK, gin.H{"message": msg})
+	c.JSON(http.StatusOK, gin.H{"message": "Delete request success"})
 }
 
-// DeleteRequest godoc
-// @Summary Delete request
-// @Description Delete request
+// RestoreRequest godoc
+// @Summary Restore request
+// @Description Restore a deleted request
 // @Produce json
 // @Tags admin
 // @Param id path int true "Request ID"
 // @Success 200 string message
+// @Failure 404 {object} apperror.Response "No deleted request with this ID"
 // @Security bearerToken
-// @Router /api/v1/admin/delete-request/{id} [delete]
-func (h *AdminHandler) DeleteRequest(c *gin.Context) {
+// @Router /api/v1/admin/requests/{id}/restore [post]
+func (h *AdminHandler) RestoreRequest(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request ID"})
+		apperror.Abort(c, apperror.New(apperror.Validation, "Invalid request ID"))
+		return
+	}
+	userId, exists := c.Get("userId")
+	if !exists {
+		apperror.Abort(c, apperror.ErrUnauthorized)
+		return
+	}
+	if err := h.usecase.RestoreRequest(id, userId.(int)); err != nil {
+		apperror.Abort(c, err)
 		return
 	}
-	msg := h.usecase.DeleteRequest(id)
-	c.JSON(http.StatusOK, gin.H{"message": msg})
+	c.JSON(http.StatusOK, gin.H{"message": "Restore request success"})
 }
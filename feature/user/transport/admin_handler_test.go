@@ -1,11 +1,15 @@
 package transport
 
 import (
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+	requestDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/request/domain"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/usecase"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -16,49 +20,71 @@ type MockAdminUsecase struct {
 	mock.Mock
 }
 
-func (m *MockAdminUsecase) GetListPendingRequest() (*dto.ListRequest, string) {
+func (m *MockAdminUsecase) GetListPendingRequest() (*dto.ListRequest, error) {
 	args := m.Called()
-	return args.Get(0).(*dto.ListRequest), args.String(1)
+	if args.Get(0) != nil {
+		return args.Get(0).(*dto.ListRequest), args.Error(1)
+	}
+	return nil, args.Error(1)
 }
 
-func (m *MockAdminUsecase) GetPendingRequestById(id int) (*dto.RequestResponse, string) {
+func (m *MockAdminUsecase) GetPendingRequestById(id int) (*dto.RequestResponse, error) {
 	args := m.Called(id)
-	return args.Get(0).(*dto.RequestResponse), args.String(1)
+	if args.Get(0) != nil {
+		return args.Get(0).(*dto.RequestResponse), args.Error(1)
+	}
+	return nil, args.Error(1)
 }
 
-func (m *MockAdminUsecase) GetListRequest() (*dto.ListRequest, string) {
+func (m *MockAdminUsecase) GetListRequest() (*dto.ListRequest, error) {
 	args := m.Called()
-	return args.Get(0).(*dto.ListRequest), args.String(1)
+	if args.Get(0) != nil {
+		return args.Get(0).(*dto.ListRequest), args.Error(1)
+	}
+	return nil, args.Error(1)
 }
 
-func (m *MockAdminUsecase) GetRequestById(id int) (*dto.RequestResponse, string) {
+func (m *MockAdminUsecase) GetRequestById(id int) (*dto.RequestResponse, error) {
 	args := m.Called(id)
-	return args.Get(0).(*dto.RequestResponse), args.String(1)
+	if args.Get(0) != nil {
+		return args.Get(0).(*dto.RequestResponse), args.Error(1)
+	}
+	return nil, args.Error(1)
 }
 
-func (m *MockAdminUsecase) ApproveRequest(id, userId int) string {
+func (m *MockAdminUsecase) ListRequests(query dto.RequestListQuery) (*dto.RequestPage, error) {
+	args := m.Called(query)
+	if args.Get(0) != nil {
+		return args.Get(0).(*dto.RequestPage), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAdminUsecase) ApproveRequest(id, userId int) error {
 	args := m.Called(id, userId)
-	return args.String(0)
+	return args.Error(0)
 }
 
-func (m *MockAdminUsecase) RejectRequest(id, userId int) string {
+func (m *MockAdminUsecase) RejectRequest(id, userId int) error {
 	args := m.Called(id, userId)
-	return args.String(0)
+	return args.Error(0)
 }
 
-func (m *MockAdminUsecase) AddRejectNotes(id int, notes string) string {
-	args := m.Called(id, notes)
-	return args.String(0)
+func (m *MockAdminUsecase) DeleteRequest(id, userId int) error {
+	args := m.Called(id, userId)
+	return args.Error(0)
 }
 
-func (m *MockAdminUsecase) DeleteRequest(id int) string {
-	args := m.Called(id)
-	return args.String(0)
+func (m *MockAdminUsecase) RestoreRequest(id, userId int) error {
+	args := m.Called(id, userId)
+	return args.Error(0)
 }
 
 func setupRouter() *gin.Engine {
 	gin.SetMode(gin.TestMode)
-	return gin.Default()
+	router := gin.Default()
+	router.Use(apperror.Middleware())
+	return router
 }
 
 func TestGetListPendingRequest(t *testing.T) {
@@ -68,14 +94,14 @@ func TestGetListPendingRequest(t *testing.T) {
 	router := setupRouter()
 	router.GET("/api/v1/admin/list-pending-request", handler.GetListPendingRequest)
 
-	mockUsecase.On("GetListPendingRequest").Return([]dto.RequestResponse{}, "")
+	mockUsecase.On("GetListPendingRequest").Return(&dto.ListRequest{Requests: []*requestDomain.Request{}}, nil)
 
 	req, _ := http.NewRequest(http.MethodGet, "/api/v1/admin/list-pending-request", nil)
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusOK, w.Code)
-	assert.Contains(t, w.Body.String(), "[]")
+	assert.JSONEq(t, `{"requests":[]}`, w.Body.String())
 	mockUsecase.AssertExpectations(t)
 }
 
@@ -86,37 +112,95 @@ func TestGetPendingRequestById(t *testing.T) {
 	router := setupRouter()
 	router.GET("/api/v1/admin/pending-request/:id", handler.GetPendingRequestById)
 
-	mockUsecase.On("GetPendingRequestById", 1).Return(dto.RequestResponse{}, "")
+	mockUsecase.On("GetPendingRequestById", 1).Return(&dto.RequestResponse{ID: 1, UserID: 2, Type: "verification"}, nil)
 
 	req, _ := http.NewRequest(http.MethodGet, "/api/v1/admin/pending-request/1", nil)
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusOK, w.Code)
-	assert.Contains(t, w.Body.String(), "{}")
+	assert.Contains(t, w.Body.String(), `"id":1`)
+	assert.Contains(t, w.Body.String(), `"user_id":2`)
+	assert.Contains(t, w.Body.String(), `"type":"verification"`)
 	mockUsecase.AssertExpectations(t)
 }
 
 // More tests for other handlers can be added similarly...
 
-func TestApproveRequest(t *testing.T) {
+func TestListRequests(t *testing.T) {
 	mockUsecase := new(MockAdminUsecase)
 	handler := NewAuthenticationHandler(mockUsecase)
 
 	router := setupRouter()
-	router.POST("/api/v1/admin/approve-request/:id", func(c *gin.Context) {
-		c.Set("userId", 1)
-		handler.ApproveRequest(c)
-	})
+	router.GET("/api/v1/admin/requests", handler.ListRequests)
 
-	mockUsecase.On("ApproveRequest", 1, 1).Return("Request approved")
+	status := 0
+	mockUsecase.On("ListRequests", dto.RequestListQuery{Status: &status, Type: "verification", Limit: 10}).
+		Return(&dto.RequestPage{Items: []dto.RequestListItem{}, Total: 0}, nil)
+	mockUsecase.On("ListRequests", dto.RequestListQuery{Sort: "email"}).
+		Return(nil, fmt.Errorf("%w: bad sort", usecase.ErrInvalidRequestQuery))
 
-	req, _ := http.NewRequest(http.MethodPost, "/api/v1/admin/approve-request/1", nil)
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/admin/requests?status=0&type=verification&limit=10", nil)
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"items":[],"next_cursor":null,"total":0}`, w.Body.String())
 
+	mockUsecase.On("ListRequests", dto.RequestListQuery{IncludeDeleted: true}).
+		Return(&dto.RequestPage{Items: []dto.RequestListItem{}, Total: 0}, nil)
+	req, _ = http.NewRequest(http.MethodGet, "/api/v1/admin/requests?include_deleted=true", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
 	assert.Equal(t, http.StatusOK, w.Code)
-	assert.Contains(t, w.Body.String(), "Request approved")
+
+	req, _ = http.NewRequest(http.MethodGet, "/api/v1/admin/requests?sort=email", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	req, _ = http.NewRequest(http.MethodGet, "/api/v1/admin/requests?created_from=yesterday", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestApproveRequest(t *testing.T) {
+	mockUsecase := new(MockAdminUsecase)
+	handler := NewAuthenticationHandler(mockUsecase)
+
+	router := setupRouter()
+	router.POST("/api/v1/admin/approve-request/:id", func(c *gin.Context) {
+		c.Set("userId", 1)
+		handler.ApproveRequest(c)
+	})
+
+	mockUsecase.On("ApproveRequest", 1, 1).Return(nil)
+	mockUsecase.On("ApproveRequest", 2, 1).Return(&requestDomain.TransitionError{
+		From: requestDomain.RequestStatusRejected,
+		To:   requestDomain.RequestStatusApproved,
+	})
+	mockUsecase.On("ApproveRequest", 3, 1).Return(requestDomain.ErrRequesterHasNoDepartment)
+	mockUsecase.On("ApproveRequest", 4, 1).Return(requestDomain.ErrRequestNotFound)
+
+	cases := []struct {
+		id   string
+		code int
+		body string
+	}{
+		{"1", http.StatusOK, "Approve request success"},
+		{"2", http.StatusConflict, "cannot move request from rejected to approved"},
+		{"3", http.StatusUnprocessableEntity, "requesting user has no department"},
+		{"4", http.StatusNotFound, "request not found"},
+	}
+	for _, tc := range cases {
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/admin/approve-request/"+tc.id, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, tc.code, w.Code)
+		assert.Contains(t, w.Body.String(), tc.body)
+	}
 	mockUsecase.AssertExpectations(t)
 }
 
@@ -130,13 +214,43 @@ func TestRejectRequest(t *testing.T) {
 		handler.RejectRequest(c)
 	})
 
-	mockUsecase.On("RejectRequest", 1, 1).Return("Request rejected")
+	mockUsecase.On("RejectRequest", 1, 1).Return(nil)
 
 	req, _ := http.NewRequest(http.MethodPost, "/api/v1/admin/reject-request/1", nil)
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusOK, w.Code)
-	assert.Contains(t, w.Body.String(), "Request rejected")
+	assert.Contains(t, w.Body.String(), "Reject request success")
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestRestoreRequest(t *testing.T) {
+	mockUsecase := new(MockAdminUsecase)
+	handler := NewAuthenticationHandler(mockUsecase)
+
+	router := setupRouter()
+	router.POST("/api/v1/admin/requests/:id/restore", func(c *gin.Context) {
+		c.Set("userId", 1)
+		handler.RestoreRequest(c)
+	})
+
+	mockUsecase.On("RestoreRequest", 1, 1).Return(nil)
+	mockUsecase.On("RestoreRequest", 2, 1).Return(requestDomain.ErrRequestNotFound)
+
+	cases := []struct {
+		id   string
+		code int
+	}{
+		{"1", http.StatusOK},
+		{"2", http.StatusNotFound},
+		{"abc", http.StatusBadRequest},
+	}
+	for _, tc := range cases {
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/admin/requests/"+tc.id+"/restore", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, tc.code, w.Code)
+	}
 	mockUsecase.AssertExpectations(t)
 }
@@ -4,8 +4,10 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/usecase"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/validation"
 
 	"github.com/gin-gonic/gin"
 )
@@ -20,21 +22,23 @@ func NewApplicantHandler(userUsecase usecase.ApplicantUsecaseInterface) *Applica
 
 // CreateApplicant godoc
 // @Summary Create applicant
-// @Description Create applicant
+// @Description Create applicant. Admins only; applicants sign up through /auth/register.
 // @Produce json
 // @Tags applicant
 // @Param request body dto.ApplicantCreateDTO true "Create Applicant Request"
 // @Success 201 {string} message "Applicant created successfully"
+// @Failure 403 {object} apperror.Response "Not an admin"
+// @Security bearerToken
 // @Router /api/v1/applicant/ [post]
 func (h *ApplicantHandler) CreateApplicant(c *gin.Context) {
 	var request dto.ApplicantCreateDTO
 	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apperror.Abort(c, validation.Error(err))
 		return
 	}
 
 	if err := h.ApplicantUseCaseH.CreateApplicant(request); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apperror.Abort(c, err)
 		return
 	}
 
@@ -43,28 +47,31 @@ func (h *ApplicantHandler) CreateApplicant(c *gin.Context) {
 
 // UpdateApplicant godoc
 // @Summary Update applicant
-// @Description Update applicant
+// @Description Update applicant. Users can update their own profile, admins anyone's. The role changes through request approval only.
 // @Produce json
 // @Tags applicant
 // @Param id path int true "Applicant ID"
-// @Param request body dto.AppplicantUpdateDTO true "Update Applicant Request"
+// @Param request body dto.ApplicantUpdateDTO true "Update Applicant Request"
 // @Success 200 {string} message "Applicant updated successfully"
+// @Failure 400 {object} apperror.Response "Invalid input, with the rejected fields in details"
+// @Failure 403 {object} apperror.Response "Not the user nor an admin"
+// @Security bearerToken
 // @Router /api/v1/applicant/{id} [put]
 func (h *ApplicantHandler) UpdateApplicant(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		apperror.Abort(c, apperror.New(apperror.Validation, "Invalid user ID"))
 		return
 	}
 
 	var request dto.ApplicantUpdateDTO
 	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apperror.Abort(c, validation.Error(err))
 		return
 	}
 
 	if err := h.ApplicantUseCaseH.UpdateApplicant(id, request); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apperror.Abort(c, err)
 		return
 	}
 
@@ -72,46 +79,75 @@ func (h *ApplicantHandler) UpdateApplicant(c *gin.Context) {
 }
 
 // DeleteApplicant godoc
-// @Summary Delete applicant
-// @Description Delete applicant
+// @Summary Delete user
+// @Description Delete a user, whose tokens stop working. The user can be restored until purged.
 // @Produce json
-// @Tags applicant
-// @Param id path int true "Applicant ID"
+// @Tags admin
+// @Param id path int true "User ID"
 // @Success 200 string message
-// @Router /api/v1/applicant/{id} [delete]
+// @Security bearerToken
+// @Router /api/v1/admin/users/{id} [delete]
 func (h *ApplicantHandler) DeleteApplicant(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		apperror.Abort(c, apperror.New(apperror.Validation, "Invalid user ID"))
 		return
 	}
 
 	if err := h.ApplicantUseCaseH.DeleteApplicant(id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apperror.Abort(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "User deleted successfully"})
 }
 
+// RestoreApplicant godoc
+// @Summary Restore user
+// @Description Restore a deleted user
+// @Produce json
+// @Tags admin
+// @Param id path int true "User ID"
+// @Success 200 string message
+// @Failure 404 {object} apperror.Response "No deleted user with this ID"
+// @Security bearerToken
+// @Router /api/v1/admin/users/{id}/restore [post]
+func (h *ApplicantHandler) RestoreApplicant(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apperror.Abort(c, apperror.New(apperror.Validation, "Invalid user ID"))
+		return
+	}
+
+	err = h.ApplicantUseCaseH.RestoreApplicant(id)
+	if err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "User restored successfully"})
+}
+
 // FindApplicantByID godoc
 // @Summary Find applicant by ID
-// @Description Find applicant by ID
+// @Description Find applicant by ID. Users can read their own profile, admins anyone's.
 // @Produce json
 // @Tags applicant
 // @Param id path int true "Applicant ID"
 // @Success 200 {object} dto.ApplicantResponseDTO
+// @Failure 403 {object} apperror.Response "Not the user nor an admin"
+// @Security bearerToken
 // @Router /api/v1/applicant/{id} [get]
 func (h *ApplicantHandler) FindApplicantByID(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		apperror.Abort(c, apperror.New(apperror.Validation, "Invalid user ID"))
 		return
 	}
 
 	user, err := h.ApplicantUseCaseH.FindApplicantByID(id)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apperror.Abort(c, err)
 		return
 	}
 
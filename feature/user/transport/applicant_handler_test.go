@@ -1,16 +1,17 @@
 package transport
 
 import (
-	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
 
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
 )
 
@@ -33,9 +34,17 @@ func (m *MockApplicantUsecase) DeleteApplicant(id int) error {
 	return args.Error(0)
 }
 
+func (m *MockApplicantUsecase) RestoreApplicant(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
 func (m *MockApplicantUsecase) FindApplicantByID(id int) (*dto.ApplicantResponseDTO, error) {
 	args := m.Called(id)
-	return args.Get(0).(*dto.ApplicantResponseDTO), args.Error(1)
+	if args.Get(0) != nil {
+		return args.Get(0).(*dto.ApplicantResponseDTO), args.Error(1)
+	}
+	return nil, args.Error(1)
 }
 
 func TestCreateApplicant(t *testing.T) {
@@ -44,6 +53,7 @@ func TestCreateApplicant(t *testing.T) {
 
 	gin.SetMode(gin.TestMode)
 	r := gin.Default()
+	r.Use(apperror.Middleware())
 	r.POST("/api/v1/applicant", handler.CreateApplicant)
 
 	t.Run("success", func(t *testing.T) {
@@ -85,34 +95,33 @@ func TestUpdateApplicant(t *testing.T) {
 
 	gin.SetMode(gin.TestMode)
 	r := gin.Default()
+	r.Use(apperror.Middleware())
 	r.PUT("/api/v1/applicant/:id", handler.UpdateApplicant)
 
 	t.Run("success", func(t *testing.T) {
 		mockInput := dto.ApplicantUpdateDTO{
-			RoleID:            1,
 			DepartmentID:      2,
 			Email:             "test@example.com",
 			Name:              "Tony",
 			Surname:           "Quang",
-			Gender:            "Male",
-			DOB:               "time.Date(2002, 9, 20, 0, 0, 0, 0, time.UTC)",
-			Mobile:            "0913895987",
+			Gender:            "male",
+			DOB:               "2002-09-20",
+			Mobile:            "+84913895987",
 			CountryID:         2,
 			ResidentCountryID: 7,
 		}
 		mockUsecase.On("UpdateApplicant", 1, mockInput).Return(nil)
 
 		body := `{
-			"RoleID": 1, 
-			"DepartmentID": 2, 
-			"Email": "test@example.com", 
-			"Name": "Tony", 
-			"Surname": "Quang", 
-			"Gender": "Male", 
-			"DOB": "2002-09-20 00:00:00 +0000 UTC",
-			"Mobile": "0913895987",
-			"CountryID": 2,
-			"ResidentCountryID": 7,
+			"department_id": 2,
+			"email": "test@example.com",
+			"name": "Tony",
+			"surname": "Quang",
+			"gender": "male",
+			"dob": "2002-09-20",
+			"mobile": "+84913895987",
+			"country_id": 2,
+			"resident_country_id": 7
 		}`
 		req, err := http.NewRequest(http.MethodPut, "/api/v1/applicant/1", strings.NewReader(body))
 		assert.NoError(t, err)
@@ -155,6 +164,7 @@ func TestDeleteApplicant(t *testing.T) {
 
 	gin.SetMode(gin.TestMode)
 	r := gin.Default()
+	r.Use(apperror.Middleware())
 	r.DELETE("/api/v1/applicant/:id", handler.DeleteApplicant)
 
 	t.Run("success", func(t *testing.T) {
@@ -187,6 +197,7 @@ func TestFindApplicantByID(t *testing.T) {
 
 	gin.SetMode(gin.TestMode)
 	r := gin.Default()
+	r.Use(apperror.Middleware())
 	r.GET("/api/v1/applicant/:id", handler.FindApplicantByID)
 
 	t.Run("success", func(t *testing.T) {
@@ -216,9 +227,9 @@ func TestFindApplicantByID(t *testing.T) {
 	})
 
 	t.Run("not found", func(t *testing.T) {
-		mockUsecase.On("FindApplicantByID", 1).Return(nil, errors.New("Applicant not found"))
+		mockUsecase.On("FindApplicantByID", 2).Return(nil, domain.ErrApplicantNotFound)
 
-		req, err := http.NewRequest(http.MethodGet, "/api/v1/applicant/1", nil)
+		req, err := http.NewRequest(http.MethodGet, "/api/v1/applicant/2", nil)
 		assert.NoError(t, err)
 
 		rr := httptest.NewRecorder()
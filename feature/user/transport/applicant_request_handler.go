@@ -1,41 +1,38 @@
 package transport
 
 import (
-	"net/http"
-
-	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
-	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/usecase"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+	requestDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/request/domain"
+	requestDto "github.com/cesc1802/onboarding-and-volunteer-service/feature/request/dto"
+	requestTransport "github.com/cesc1802/onboarding-and-volunteer-service/feature/request/transport"
+	requestUsecase "github.com/cesc1802/onboarding-and-volunteer-service/feature/request/usecase"
 
 	"github.com/gin-gonic/gin"
 )
 
 type RequestHandler struct {
-	RequestUsecase usecase.ApplicantRequestUsecaseInterface
+	RequestUsecase requestUsecase.RequestUsecaseInterface
 }
 
-func NewApplicantRequestHandler(requestUsecase usecase.ApplicantRequestUsecaseInterface) *RequestHandler {
+func NewApplicantRequestHandler(requestUsecase requestUsecase.RequestUsecaseInterface) *RequestHandler {
 	return &RequestHandler{RequestUsecase: requestUsecase}
 }
 
 // CreateRequest godoc
 // @Summary Create request
-// @Description Create request
+// @Description Create a registration request for the current user
 // @Produce json
 // @Tags request
-// @Param request body dto.ApplicantRequestCreatingDTO true "Create Request Request"
-// @Success 201 {string} message "Request created successfully"
+// @Security bearerToken
+// @Success 201 {object} requestDto.RequestResponseDTO
 // @Router /api/v1/applicant-request/ [post]
 func (h *RequestHandler) CreateApplicantRequest(c *gin.Context) {
-	var request dto.ApplicantRequestCreatingDTO
-	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	userId, exists := c.Get("userId")
+	if !exists {
+		apperror.Abort(c, apperror.ErrUnauthorized)
 		return
 	}
-
-	if err := h.RequestUsecase.CreateApplicantRequest(request); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
-	}
-
-	c.JSON(http.StatusCreated, gin.H{"message": "Request created successfully"})
+	requestTransport.CreateFor(c, h.RequestUsecase, userId.(int), requestDto.RequestCreateDTO{
+		Type: string(requestDomain.RequestTypeRegistration),
+	})
 }
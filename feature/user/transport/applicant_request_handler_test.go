@@ -3,61 +3,63 @@ package transport
 import (
 	"net/http"
 	"net/http/httptest"
-	"strings"
 	"testing"
 
-	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+	requestDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/request/domain"
+	requestDto "github.com/cesc1802/onboarding-and-volunteer-service/feature/request/dto"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/mock"
 )
 
-type MockApplicantRequestUsecase struct {
-	mock.Mock
-}
-
-func (m *MockApplicantRequestUsecase) CreateApplicantRequest(input dto.ApplicantRequestCreatingDTO) error {
-	args := m.Called(input)
-	return args.Error(0)
-}
-
 func TestCreateApplicantRequest(t *testing.T) {
-	mockUsecase := new(MockApplicantRequestUsecase)
-	handler := NewApplicantRequestHandler(mockUsecase)
-
 	gin.SetMode(gin.TestMode)
-	r := gin.Default()
-	r.POST("/api/v1/applicant-request", handler.CreateApplicantRequest)
+
+	newRouter := func(handler *RequestHandler, userID int) *gin.Engine {
+		r := gin.Default()
+		r.Use(apperror.Middleware())
+		r.POST("/api/v1/applicant-request", func(c *gin.Context) {
+			if userID != 0 {
+				c.Set("userId", userID)
+			}
+		}, handler.CreateApplicantRequest)
+		return r
+	}
 
 	t.Run("success", func(t *testing.T) {
-		mockInput := dto.ApplicantRequestCreatingDTO{
-			UserID: 1,
-			Type:   "Application",
-			Status: 0,
-		}
-		mockUsecase.On("CreateApplicantRequest", mockInput).Return(nil)
-
-		body := `{"user_id":1,"type":"Application","status":"Pending"}`
-		req, err := http.NewRequest(http.MethodPost, "/api/v1/applicant-request", strings.NewReader(body))
-		assert.NoError(t, err)
-		req.Header.Set("Content-Type", "application/json")
+		mockUsecase := new(MockRequestUsecase)
+		input := requestDto.RequestCreateDTO{Type: string(requestDomain.RequestTypeRegistration)}
+		mockUsecase.On("CreateRequest", 1, input).
+			Return(&requestDto.RequestResponseDTO{ID: 1, UserID: 1, Type: input.Type, Status: "pending"}, nil)
 
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/applicant-request", nil)
+		assert.NoError(t, err)
 		rr := httptest.NewRecorder()
-		r.ServeHTTP(rr, req)
+		newRouter(NewApplicantRequestHandler(mockUsecase), 1).ServeHTTP(rr, req)
 
 		assert.Equal(t, http.StatusCreated, rr.Code)
 		mockUsecase.AssertExpectations(t)
 	})
 
-	t.Run("bad request", func(t *testing.T) {
-		body := `{"user_id":abc,"type":"Application","status":"Pending"}`
-		req, err := http.NewRequest(http.MethodPost, "/api/v1/applicant-request", strings.NewReader(body))
+	t.Run("pending request exists", func(t *testing.T) {
+		mockUsecase := new(MockRequestUsecase)
+		mockUsecase.On("CreateRequest", 2, requestDto.RequestCreateDTO{Type: string(requestDomain.RequestTypeRegistration)}).
+			Return(nil, requestDomain.ErrPendingRequestExists)
+
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/applicant-request", nil)
 		assert.NoError(t, err)
-		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		newRouter(NewApplicantRequestHandler(mockUsecase), 2).ServeHTTP(rr, req)
 
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/applicant-request", nil)
+		assert.NoError(t, err)
 		rr := httptest.NewRecorder()
-		r.ServeHTTP(rr, req)
+		newRouter(NewApplicantRequestHandler(new(MockRequestUsecase)), 0).ServeHTTP(rr, req)
 
-		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
 	})
 }
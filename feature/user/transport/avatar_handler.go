@@ -0,0 +1,81 @@
+package transport
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/upload"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type AvatarHandler struct {
+	AvatarUsecase usecase.AvatarUsecaseInterface
+}
+
+func NewAvatarHandler(avatarUsecase usecase.AvatarUsecaseInterface) *AvatarHandler {
+	return &AvatarHandler{AvatarUsecase: avatarUsecase}
+}
+
+// UploadAvatar godoc
+// @Summary Upload avatar
+// @Description Replace the avatar of a user with a JPEG or PNG image of at most 2 MiB. The image is stripped of its metadata. Users can change their own avatar, admins any user's.
+// @Accept multipart/form-data
+// @Produce json
+// @Tags applicant
+// @Param id path int true "User ID"
+// @Param file formData file true "JPEG or PNG image"
+// @Success 200 {object} dto.FileURLResponse
+// @Failure 400 {object} apperror.Response "No file in the form"
+// @Failure 403 {object} apperror.Response "Not the user nor an admin"
+// @Failure 413 {object} apperror.Response "File is too large"
+// @Failure 415 {object} apperror.Response "File is not a JPEG or PNG image"
+// @Security bearerToken
+// @Router /api/v1/users/{id}/avatar [put]
+func (h *AvatarHandler) UploadAvatar(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apperror.Abort(c, apperror.New(apperror.Validation, "Invalid user ID"))
+		return
+	}
+	file, err := upload.FormFile(c, "file", upload.AvatarPolicy)
+	if err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+
+	response, err := h.AvatarUsecase.UploadAvatar(id, file)
+	if err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetAvatar godoc
+// @Summary Get avatar
+// @Description Short-lived link to the avatar of a user
+// @Produce json
+// @Tags applicant
+// @Param id path int true "User ID"
+// @Success 200 {object} dto.FileURLResponse
+// @Failure 404 {object} apperror.Response "User not found or without avatar"
+// @Security bearerToken
+// @Router /api/v1/users/{id}/avatar [get]
+func (h *AvatarHandler) GetAvatar(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apperror.Abort(c, apperror.New(apperror.Validation, "Invalid user ID"))
+		return
+	}
+
+	response, err := h.AvatarUsecase.AvatarURL(id)
+	if err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,101 @@
+package transport
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/upload"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAvatarUsecase struct {
+	mock.Mock
+}
+
+func (m *MockAvatarUsecase) UploadAvatar(userID int, file *upload.File) (*dto.FileURLResponse, error) {
+	args := m.Called(userID, file)
+	response, _ := args.Get(0).(*dto.FileURLResponse)
+	return response, args.Error(1)
+}
+
+func (m *MockAvatarUsecase) AvatarURL(userID int) (*dto.FileURLResponse, error) {
+	args := m.Called(userID)
+	response, _ := args.Get(0).(*dto.FileURLResponse)
+	return response, args.Error(1)
+}
+
+func multipartFile(t *testing.T, data []byte) (*bytes.Buffer, string) {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("file", "avatar.png")
+	assert.NoError(t, err)
+	part.Write(data)
+	assert.NoError(t, form.Close())
+	return &body, form.FormDataContentType()
+}
+
+func TestUploadAvatar(t *testing.T) {
+	mockUsecase := new(MockAvatarUsecase)
+	handler := NewAvatarHandler(mockUsecase)
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(apperror.Middleware())
+	r.PUT("/api/v1/users/:id/avatar", handler.UploadAvatar)
+	r.GET("/api/v1/users/:id/avatar", handler.GetAvatar)
+
+	var picture bytes.Buffer
+	assert.NoError(t, png.Encode(&picture, imageOf(4)))
+	response := &dto.FileURLResponse{URL: "https://files.example.com/avatars/7/abc.png", ExpiresAt: time.Now()}
+
+	t.Run("success", func(t *testing.T) {
+		mockUsecase.On("UploadAvatar", 7, mock.MatchedBy(func(file *upload.File) bool {
+			return file.ContentType == upload.TypePNG
+		})).Return(response, nil).Once()
+
+		body, contentType := multipartFile(t, picture.Bytes())
+		req, _ := http.NewRequest(http.MethodPut, "/api/v1/users/7/avatar", body)
+		req.Header.Set("Content-Type", contentType)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), response.URL)
+	})
+
+	t.Run("not an image", func(t *testing.T) {
+		body, contentType := multipartFile(t, []byte("%PDF-1.4\n%%EOF\n"))
+		req, _ := http.NewRequest(http.MethodPut, "/api/v1/users/7/avatar", body)
+		req.Header.Set("Content-Type", contentType)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+	})
+
+	t.Run("no avatar", func(t *testing.T) {
+		mockUsecase.On("AvatarURL", 8).Return(nil, domain.ErrNoAvatar).Once()
+
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/users/8/avatar", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	mockUsecase.AssertExpectations(t)
+}
+
+func imageOf(size int) image.Image {
+	return image.NewGray(image.Rect(0, 0, size, size))
+}
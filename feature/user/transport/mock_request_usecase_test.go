@@ -0,0 +1,44 @@
+package transport
+
+import (
+	requestDto "github.com/cesc1802/onboarding-and-volunteer-service/feature/request/dto"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRequestUsecase struct {
+	mock.Mock
+}
+
+func (m *MockRequestUsecase) CreateRequest(userID int, input requestDto.RequestCreateDTO) (*requestDto.RequestResponseDTO, error) {
+	args := m.Called(userID, input)
+	if args.Get(0) != nil {
+		return args.Get(0).(*requestDto.RequestResponseDTO), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockRequestUsecase) ListRequests(userID int) ([]requestDto.RequestResponseDTO, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]requestDto.RequestResponseDTO), args.Error(1)
+}
+
+func (m *MockRequestUsecase) GetRequest(userID int, id int) (*requestDto.RequestResponseDTO, error) {
+	args := m.Called(userID, id)
+	if args.Get(0) != nil {
+		return args.Get(0).(*requestDto.RequestResponseDTO), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockRequestUsecase) CancelRequest(userID int, id int) error {
+	args := m.Called(userID, id)
+	return args.Error(0)
+}
+
+func (m *MockRequestUsecase) ResubmitRequest(userID int, id int) (*requestDto.RequestResponseDTO, error) {
+	args := m.Called(userID, id)
+	if args.Get(0) != nil {
+		return args.Get(0).(*requestDto.RequestResponseDTO), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
@@ -1,41 +1,37 @@
 package transport
 
 import (
-	"net/http"
-
-	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
-	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/usecase"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+	requestDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/request/domain"
+	requestDto "github.com/cesc1802/onboarding-and-volunteer-service/feature/request/dto"
+	requestTransport "github.com/cesc1802/onboarding-and-volunteer-service/feature/request/transport"
+	requestUsecase "github.com/cesc1802/onboarding-and-volunteer-service/feature/request/usecase"
 	"github.com/gin-gonic/gin"
 )
 
 type VolunteerRequestHandler struct {
-	VolRequestUsecase usecase.VolunteerRequestUsecaseInterface
+	VolRequestUsecase requestUsecase.RequestUsecaseInterface
 }
 
-func NewVolunteerRequestHandler(volRequestUsecase usecase.VolunteerRequestUsecaseInterface) *VolunteerRequestHandler {
+func NewVolunteerRequestHandler(volRequestUsecase requestUsecase.RequestUsecaseInterface) *VolunteerRequestHandler {
 	return &VolunteerRequestHandler{VolRequestUsecase: volRequestUsecase}
 }
 
 // CreateRequest godoc
 // @Summary Create a new volunteer request
-// @Description Create a new volunteer request
+// @Description Create a verification request for the current user
 // @Produce json
 // @Tags volunteer
-// @Accept json
-// @Param request body dto.VoluteerRequestCreatingDTO true "Request body"
-// @Success 201 {object} string
-// @Router /api/v1/volunteer/request [post]
+// @Security bearerToken
+// @Success 201 {object} requestDto.RequestResponseDTO
+// @Router /api/v1/volunteer-request/ [post]
 func (h *VolunteerRequestHandler) CreateVolunteerRequest(c *gin.Context) {
-	var request dto.VoluteerRequestCreatingDTO
-	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	userId, exists := c.Get("userId")
+	if !exists {
+		apperror.Abort(c, apperror.ErrUnauthorized)
 		return
 	}
-
-	if err := h.VolRequestUsecase.CreateVolunteerRequest(request); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
-	}
-
-	c.JSON(http.StatusCreated, gin.H{"message": "Request created successfully"})
+	requestTransport.CreateFor(c, h.VolRequestUsecase, userId.(int), requestDto.RequestCreateDTO{
+		Type: string(requestDomain.RequestTypeVerification),
+	})
 }
@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+	requestDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/request/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/storage"
+)
+
+const (
+	defaultRequestPageSize = 20
+	maxRequestPageSize     = 100
+)
+
+// ErrInvalidRequestQuery is returned when the listing query parameters are invalid.
+var ErrInvalidRequestQuery = apperror.New(apperror.Validation, "invalid request query")
+
+// requestCursor is the JSON payload of an opaque next_cursor value.
+type requestCursor struct {
+	CreatedAt time.Time `json:"c"`
+	ID        int       `json:"i"`
+}
+
+// ListRequests returns one page of requests for the admin listing.
+func (u *AdminUsecase) ListRequests(query dto.RequestListQuery) (*dto.RequestPage, error) {
+	filter, err := toRequestFilter(query)
+	if err != nil {
+		return nil, err
+	}
+	requests, total, err := u.repo.ListRequests(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &dto.RequestPage{Items: make([]dto.RequestListItem, 0, len(requests)), Total: total}
+	if len(requests) > filter.Limit {
+		requests = requests[:filter.Limit]
+		last := requests[len(requests)-1]
+		cursor := encodeRequestCursor(requestCursor{CreatedAt: last.CreatedAt, ID: last.ID})
+		page.NextCursor = &cursor
+	}
+	for _, request := range requests {
+		var deletedAt *time.Time
+		if request.DeletedAt.Valid {
+			deletedAt = &request.DeletedAt.Time
+		}
+		page.Items = append(page.Items, dto.RequestListItem{
+			ID:         request.ID,
+			UserID:     request.UserID,
+			Type:       string(request.Type),
+			Status:     int(request.Status),
+			VerifierID: request.VerifierID,
+			ParentID:   request.ParentID,
+			CreateAt:   request.CreatedAt,
+			UpdateAt:   request.UpdatedAt,
+			DeletedAt:  deletedAt,
+		})
+	}
+	return page, nil
+}
+
+func toRequestFilter(query dto.RequestListQuery) (storage.RequestFilter, error) {
+	filter := storage.RequestFilter{
+		Email:          query.Email,
+		CreatedFrom:    query.CreatedFrom,
+		CreatedTo:      query.CreatedTo,
+		VerifierID:     query.VerifierID,
+		IncludeDeleted: query.IncludeDeleted,
+		Limit:          query.Limit,
+	}
+	if query.Status != nil {
+		status := requestDomain.RequestStatus(*query.Status)
+		if status < requestDomain.RequestStatusPending || status > requestDomain.RequestStatusCancelled {
+			return filter, fmt.Errorf("%w: unknown status %d", ErrInvalidRequestQuery, *query.Status)
+		}
+		filter.Status = &status
+	}
+	if query.Type != "" {
+		filter.Type = requestDomain.RequestType(query.Type)
+		if !filter.Type.IsValid() {
+			return filter, fmt.Errorf("%w: unknown type %q", ErrInvalidRequestQuery, query.Type)
+		}
+	}
+	switch query.Sort {
+	case "", "-created_at":
+	case "created_at":
+		filter.Ascending = true
+	default:
+		return filter, fmt.Errorf("%w: sort must be created_at or -created_at", ErrInvalidRequestQuery)
+	}
+	switch {
+	case filter.Limit == 0:
+		filter.Limit = defaultRequestPageSize
+	case filter.Limit < 0 || filter.Limit > maxRequestPageSize:
+		return filter, fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidRequestQuery, maxRequestPageSize)
+	}
+	if query.Cursor != "" {
+		cursor, err := decodeRequestCursor(query.Cursor)
+		if err != nil {
+			return filter, fmt.Errorf("%w: malformed cursor", ErrInvalidRequestQuery)
+		}
+		filter.After = &storage.RequestCursor{CreatedAt: cursor.CreatedAt, ID: cursor.ID}
+	}
+	return filter, nil
+}
+
+func encodeRequestCursor(cursor requestCursor) string {
+	payload, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+func decodeRequestCursor(value string) (requestCursor, error) {
+	var cursor requestCursor
+	payload, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return cursor, err
+	}
+	err = json.Unmarshal(payload, &cursor)
+	return cursor, err
+}
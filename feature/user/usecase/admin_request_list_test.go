@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	requestDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/request/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestListRequests(t *testing.T) {
+	createdAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("next cursor round trip", func(t *testing.T) {
+		mockRepo := new(MockAdminRepository)
+		usecase := NewAdminUsecase(mockRepo, new(MockVerificationSender), new(MockAuditRecorder), testRoles)
+
+		mockRepo.On("ListRequests", mock.MatchedBy(func(f storage.RequestFilter) bool {
+			return f.After == nil && f.Limit == 2
+		})).Return([]*requestDomain.Request{
+			{ID: 3, CreatedAt: createdAt},
+			{ID: 2, CreatedAt: createdAt},
+			{ID: 1, CreatedAt: createdAt},
+		}, int64(3), nil)
+
+		page, err := usecase.ListRequests(dto.RequestListQuery{Limit: 2})
+		assert.NoError(t, err)
+		assert.Len(t, page.Items, 2)
+		assert.Equal(t, int64(3), page.Total)
+		assert.NotNil(t, page.NextCursor)
+
+		mockRepo.On("ListRequests", mock.MatchedBy(func(f storage.RequestFilter) bool {
+			return f.After != nil && f.After.ID == 2 && f.After.CreatedAt.Equal(createdAt)
+		})).Return([]*requestDomain.Request{{ID: 1, CreatedAt: createdAt}}, int64(3), nil)
+
+		page, err = usecase.ListRequests(dto.RequestListQuery{Limit: 2, Cursor: *page.NextCursor})
+		assert.NoError(t, err)
+		assert.Len(t, page.Items, 1)
+		assert.Nil(t, page.NextCursor)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("empty result", func(t *testing.T) {
+		mockRepo := new(MockAdminRepository)
+		usecase := NewAdminUsecase(mockRepo, new(MockVerificationSender), new(MockAuditRecorder), testRoles)
+		mockRepo.On("ListRequests", mock.MatchedBy(func(f storage.RequestFilter) bool {
+			return f.Limit == defaultRequestPageSize && !f.Ascending
+		})).Return([]*requestDomain.Request{}, int64(0), nil)
+
+		page, err := usecase.ListRequests(dto.RequestListQuery{})
+		assert.NoError(t, err)
+		assert.NotNil(t, page.Items)
+		assert.Empty(t, page.Items)
+		assert.Nil(t, page.NextCursor)
+	})
+
+	t.Run("invalid queries", func(t *testing.T) {
+		usecase := NewAdminUsecase(new(MockAdminRepository), new(MockVerificationSender), new(MockAuditRecorder), testRoles)
+		status := 9
+		for _, query := range []dto.RequestListQuery{
+			{Status: &status},
+			{Type: "promotion"},
+			{Sort: "email"},
+			{Limit: 500},
+			{Cursor: "not a cursor"},
+		} {
+			_, err := usecase.ListRequests(query)
+			assert.ErrorIs(t, err, ErrInvalidRequestQuery)
+		}
+	})
+}
@@ -1,97 +1,199 @@
 package usecase
 
 import (
+	"log"
+	"strconv"
+
+	auditUsecase "github.com/cesc1802/onboarding-and-volunteer-service/feature/audit/usecase"
+	requestDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/request/domain"
+	roleDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/role/domain"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/storage"
 )
 
 type AdminUsecaseInterface interface {
-	GetListPendingRequest() (*dto.ListRequest, string)
-	GetPendingRequestById(id int) (*dto.RequestResponse, string)
-	GetListRequest() (*dto.ListRequest, string)
-	GetRequestById(id int) (*dto.RequestResponse, string)
-	ApproveRequest(id int, verifier_id int) string
-	RejectRequest(id int, verifier_id int) string
-	AddRejectNotes(id int, notes string) string
-	DeleteRequest(id int) string
+	GetListPendingRequest() (*dto.ListRequest, error)
+	GetPendingRequestById(id int) (*dto.RequestResponse, error)
+	GetListRequest() (*dto.ListRequest, error)
+	GetRequestById(id int) (*dto.RequestResponse, error)
+	ListRequests(query dto.RequestListQuery) (*dto.RequestPage, error)
+	ApproveRequest(id int, verifierID int) error
+	RejectRequest(id int, verifierID int) error
+	DeleteRequest(id int, adminID int) error
+	RestoreRequest(id int, adminID int) error
+}
+
+// EmailVerificationSender emails a verification link to a user whose
+// request was approved.
+type EmailVerificationSender interface {
+	SendVerificationEmail(userID int) error
+}
+
+// AuditRecorder appends the changes made by admins to the audit log.
+type AuditRecorder interface {
+	Record(entry auditUsecase.Entry) error
+}
+
+// RoleResolver resolves the id of a role from its code.
+type RoleResolver interface {
+	RoleID(code string) (int, error)
 }
 
 type AdminUsecase struct {
-	repo storage.AdminRepositoryInterface
+	repo         storage.AdminRepositoryInterface
+	verification EmailVerificationSender
+	audit        AuditRecorder
+	roles        RoleResolver
 }
 
-func NewAdminUsecase(repo storage.AdminRepositoryInterface) *AdminUsecase {
-	return &AdminUsecase{repo: repo}
-}
-func (u *AdminUsecase) GetListPendingRequest() (*dto.ListRequest, string) {
-	requests, msg := u.repo.GetListPendingRequest()
-	if requests != nil {
-		return &dto.ListRequest{
-			Requests: requests,
-		}, msg
-	} else {
-		msg = "No request found"
-	}
-	return nil, msg
-}
-func (u *AdminUsecase) GetPendingRequestById(id int) (*dto.RequestResponse, string) {
-	request, msg := u.repo.GetPendingRequestByID(id)
-	if request != nil {
-		return &dto.RequestResponse{
-			ID:          request.ID,
-			UserID:      request.UserID,
-			Type:        request.Type,
-			Status:      request.Status,
-			RejectNotes: request.RejectNotes,
-			VerifierID:  request.VerifierID,
-			CreateAt:    request.CreatedAt,
-			UpdateAt:    request.UpdatedAt,
-		}, msg
-	} else {
-		msg = "Request not found"
-	}
-	return nil, msg
+func NewAdminUsecase(repo storage.AdminRepositoryInterface, verification EmailVerificationSender, audit AuditRecorder, roles RoleResolver) *AdminUsecase {
+	return &AdminUsecase{repo: repo, verification: verification, audit: audit, roles: roles}
+}
+func (u *AdminUsecase) GetListPendingRequest() (*dto.ListRequest, error) {
+	requests, err := u.repo.GetListPendingRequest()
+	if err != nil {
+		return nil, err
+	}
+	if requests == nil {
+		requests = []*requestDomain.Request{}
+	}
+	return &dto.ListRequest{
+		Requests: requests,
+	}, nil
+}
+func (u *AdminUsecase) GetPendingRequestById(id int) (*dto.RequestResponse, error) {
+	request, err := u.repo.GetPendingRequestByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return u.toRequestResponse(request)
 }
 
-func (u *AdminUsecase) GetListRequest() (*dto.ListRequest, string) {
-	requests, msg := u.repo.GetListAllRequest()
-	if requests != nil {
-		return &dto.ListRequest{
-			Requests: requests,
-		}, msg
-	} else {
-		msg = "No request found"
-	}
-	return nil, msg
-}
-func (u *AdminUsecase) GetRequestById(id int) (*dto.RequestResponse, string) {
-	request, msg := u.repo.GetRequestByID(id)
-	if request != nil {
-		return &dto.RequestResponse{
-			ID:          request.ID,
-			UserID:      request.UserID,
-			Type:        request.Type,
-			Status:      request.Status,
-			RejectNotes: request.RejectNotes,
-			VerifierID:  request.VerifierID,
-			CreateAt:    request.CreatedAt,
-			UpdateAt:    request.UpdatedAt,
-		}, msg
-	} else {
-		msg = "Request not found"
-	}
-	return nil, msg
+func (u *AdminUsecase) GetListRequest() (*dto.ListRequest, error) {
+	requests, err := u.repo.GetListAllRequest()
+	if err != nil {
+		return nil, err
+	}
+	if requests == nil {
+		requests = []*requestDomain.Request{}
+	}
+	return &dto.ListRequest{
+		Requests: requests,
+	}, nil
+}
+func (u *AdminUsecase) GetRequestById(id int) (*dto.RequestResponse, error) {
+	request, err := u.repo.GetRequestByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return u.toRequestResponse(request)
 }
 
-func (u *AdminUsecase) ApproveRequest(id int, verifier_id int) string {
-	return u.repo.ApproveRequest(id, verifier_id)
+// ApproveRequest approves the request and sends the requester a verification
+// email. A failed email does not undo the approval; the requester can ask for
+// a new link.
+func (u *AdminUsecase) ApproveRequest(id int, verifierID int) error {
+	roles, err := u.approvalRoles()
+	if err != nil {
+		return err
+	}
+	before, _ := u.repo.GetRequestByID(id)
+	if err := u.repo.ApproveRequest(id, verifierID, roles); err != nil {
+		return err
+	}
+	request, err := u.repo.GetRequestByID(id)
+	u.recordChange("request.approve", verifierID, id, before, request)
+	if err != nil {
+		log.Printf("send verification email for request %d: %v", id, err)
+		return nil
+	}
+	if err := u.verification.SendVerificationEmail(request.UserID); err != nil {
+		log.Printf("send verification email to user %d: %v", request.UserID, err)
+	}
+	return nil
+}
+
+func (u *AdminUsecase) approvalRoles() (storage.ApprovalRoles, error) {
+	var roles storage.ApprovalRoles
+	var err error
+	if roles.ApplicantRoleID, err = u.roles.RoleID(roleDomain.RoleCodeApplicant); err != nil {
+		return roles, err
+	}
+	if roles.VolunteerRoleID, err = u.roles.RoleID(roleDomain.RoleCodeVolunteer); err != nil {
+		return roles, err
+	}
+	return roles, nil
+}
+
+func (u *AdminUsecase) RejectRequest(id int, verifierID int) error {
+	before, _ := u.repo.GetRequestByID(id)
+	if err := u.repo.RejectRequest(id, verifierID); err != nil {
+		return err
+	}
+	after, _ := u.repo.GetRequestByID(id)
+	u.recordChange("request.reject", verifierID, id, before, after)
+	return nil
+}
+func (u *AdminUsecase) DeleteRequest(id int, adminID int) error {
+	before, _ := u.repo.GetRequestByID(id)
+	if err := u.repo.DeleteRequest(id); err != nil {
+		return err
+	}
+	u.recordChange("request.delete", adminID, id, before, nil)
+	return nil
 }
-func (u *AdminUsecase) RejectRequest(id int, verifier_id int) string {
-	return u.repo.RejectRequest(id, verifier_id)
+
+// RestoreRequest brings back a deleted request.
+func (u *AdminUsecase) RestoreRequest(id int, adminID int) error {
+	if err := u.repo.RestoreRequest(id); err != nil {
+		return err
+	}
+	after, _ := u.repo.GetRequestByID(id)
+	u.recordChange("request.restore", adminID, id, nil, after)
+	return nil
 }
-func (u *AdminUsecase) AddRejectNotes(id int, notes string) string {
-	return u.repo.AddRejectNotes(id, notes)
+
+// recordChange audits a change of request id by an admin. The change is
+// already committed, so a failure to record it is only logged.
+func (u *AdminUsecase) recordChange(action string, adminID int, id int, before, after *requestDomain.Request) {
+	err := u.audit.Record(auditUsecase.Entry{
+		ActorID:    &adminID,
+		Action:     action,
+		EntityType: "request",
+		EntityID:   strconv.Itoa(id),
+		Before:     before,
+		After:      after,
+	})
+	if err != nil {
+		log.Printf("record audit event %s for request %d: %v", action, id, err)
+	}
 }
-func (u *AdminUsecase) DeleteRequest(id int) string {
-	return u.repo.DeleteRequest(id)
+
+// toRequestResponse maps request together with its revision history.
+func (u *AdminUsecase) toRequestResponse(request *requestDomain.Request) (*dto.RequestResponse, error) {
+	history, err := u.repo.GetRequestHistory(request)
+	if err != nil {
+		return nil, err
+	}
+	response := &dto.RequestResponse{
+		ID:         request.ID,
+		UserID:     request.UserID,
+		Type:       string(request.Type),
+		Status:     int(request.Status),
+		VerifierID: request.VerifierID,
+		ParentID:   request.ParentID,
+		CreateAt:   request.CreatedAt,
+		UpdateAt:   request.UpdatedAt,
+		History:    make([]dto.RequestRevision, 0, len(history)),
+	}
+	for _, revision := range history {
+		response.History = append(response.History, dto.RequestRevision{
+			ID:         revision.ID,
+			Status:     int(revision.Status),
+			VerifierID: revision.VerifierID,
+			CreateAt:   revision.CreatedAt,
+			UpdateAt:   revision.UpdatedAt,
+		})
+	}
+	return response, nil
 }
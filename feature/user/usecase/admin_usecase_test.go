@@ -1,140 +1,287 @@
 package usecase
 
 import (
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
-	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
+	auditUsecase "github.com/cesc1802/onboarding-and-volunteer-service/feature/audit/usecase"
+	requestDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/request/domain"
+	roleDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/role/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/storage"
 )
 
+// fakeRoles resolves role codes from a fixed map.
+type fakeRoles map[string]int
+
+func (f fakeRoles) RoleID(code string) (int, error) {
+	id, ok := f[code]
+	if !ok {
+		return 0, roleDomain.ErrRoleCodeUnknown
+	}
+	return id, nil
+}
+
+// testRoles gives the roles ids that differ from their creation order.
+var testRoles = fakeRoles{roleDomain.RoleCodeApplicant: 6, roleDomain.RoleCodeVolunteer: 7}
+
 // Mocking the AdminRepositoryInterface
 type MockAdminRepository struct {
 	mock.Mock
 }
 
-func (m *MockAdminRepository) GetListPendingRequest() ([]*domain.Request, string) {
+func (m *MockAdminRepository) GetListPendingRequest() ([]*requestDomain.Request, error) {
 	args := m.Called()
-	return args.Get(0).([]*domain.Request), args.String(1)
+	return args.Get(0).([]*requestDomain.Request), args.Error(1)
 }
 
-func (m *MockAdminRepository) GetPendingRequestByID(id int) (*domain.Request, string) {
+func (m *MockAdminRepository) GetPendingRequestByID(id int) (*requestDomain.Request, error) {
 	args := m.Called(id)
-	return args.Get(0).(*domain.Request), args.String(1)
+	return args.Get(0).(*requestDomain.Request), args.Error(1)
 }
 
-func (m *MockAdminRepository) GetListAllRequest() ([]*domain.Request, string) {
+func (m *MockAdminRepository) GetListAllRequest() ([]*requestDomain.Request, error) {
 	args := m.Called()
-	return args.Get(0).([]*domain.Request), args.String(1)
+	return args.Get(0).([]*requestDomain.Request), args.Error(1)
+}
+
+func (m *MockAdminRepository) GetRequestHistory(request *requestDomain.Request) ([]*requestDomain.Request, error) {
+	args := m.Called(request)
+	if args.Get(0) != nil {
+		return args.Get(0).([]*requestDomain.Request), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAdminRepository) ListRequests(filter storage.RequestFilter) ([]*requestDomain.Request, int64, error) {
+	args := m.Called(filter)
+	if args.Get(0) != nil {
+		return args.Get(0).([]*requestDomain.Request), args.Get(1).(int64), args.Error(2)
+	}
+	return nil, 0, args.Error(2)
 }
 
-func (m *MockAdminRepository) GetRequestByID(id int) (*domain.Request, string) {
+func (m *MockAdminRepository) GetRequestByID(id int) (*requestDomain.Request, error) {
 	args := m.Called(id)
-	return args.Get(0).(*domain.Request), args.String(1)
+	return args.Get(0).(*requestDomain.Request), args.Error(1)
 }
 
-func (m *MockAdminRepository) ApproveRequest(id int, verifier_id int) string {
-	args := m.Called(id, verifier_id)
-	return args.String(0)
+func (m *MockAdminRepository) ApproveRequest(id int, verifierID int, roles storage.ApprovalRoles) error {
+	args := m.Called(id, verifierID, roles)
+	return args.Error(0)
 }
 
-func (m *MockAdminRepository) RejectRequest(id int, verifier_id int) string {
-	args := m.Called(id, verifier_id)
-	return args.String(0)
+func (m *MockAdminRepository) RejectRequest(id int, verifierID int) error {
+	args := m.Called(id, verifierID)
+	return args.Error(0)
 }
 
-func (m *MockAdminRepository) AddRejectNotes(id int, notes string) string {
-	args := m.Called(id, notes)
-	return args.String(0)
+func (m *MockAdminRepository) DeleteRequest(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
 }
 
-func (m *MockAdminRepository) DeleteRequest(id int) string {
+func (m *MockAdminRepository) RestoreRequest(id int) error {
 	args := m.Called(id)
-	return args.String(0)
+	return args.Error(0)
+}
+
+type MockVerificationSender struct {
+	mock.Mock
+}
+
+func (m *MockVerificationSender) SendVerificationEmail(userID int) error {
+	args := m.Called(userID)
+	return args.Error(0)
+}
+
+type MockAuditRecorder struct {
+	mock.Mock
+}
+
+func (m *MockAuditRecorder) Record(entry auditUsecase.Entry) error {
+	args := m.Called(entry)
+	return args.Error(0)
+}
+
+// auditAction matches an audit entry by its action and entity id.
+func auditAction(action string, entityID string) interface{} {
+	return mock.MatchedBy(func(entry auditUsecase.Entry) bool {
+		return entry.Action == action && entry.EntityID == entityID
+	})
 }
 
 func TestGetListPendingRequest(t *testing.T) {
 	mockRepo := new(MockAdminRepository)
-	usecase := NewAdminUsecase(mockRepo)
-	mockRepo.On("GetListPendingRequest").Return(nil, "No request found")
+	usecase := NewAdminUsecase(mockRepo, new(MockVerificationSender), new(MockAuditRecorder), testRoles)
+	mockRepo.On("GetListPendingRequest").Return([]*requestDomain.Request{}, nil)
 
-	result, msg := usecase.GetListPendingRequest()
-	assert.Nil(t, result)
-	assert.Equal(t, "No request found", msg)
+	result, err := usecase.GetListPendingRequest()
+	assert.NoError(t, err)
+	assert.Equal(t, &dto.ListRequest{Requests: []*requestDomain.Request{}}, result)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetListRequestEmpty(t *testing.T) {
+	mockRepo := new(MockAdminRepository)
+	usecase := NewAdminUsecase(mockRepo, new(MockVerificationSender), new(MockAuditRecorder), testRoles)
+	mockRepo.On("GetListAllRequest").Return([]*requestDomain.Request(nil), nil)
+
+	result, err := usecase.GetListRequest()
+	assert.NoError(t, err)
+	assert.Equal(t, &dto.ListRequest{Requests: []*requestDomain.Request{}}, result)
 	mockRepo.AssertExpectations(t)
 }
 
 func TestGetPendingRequestById(t *testing.T) {
 	mockRepo := new(MockAdminRepository)
-	usecase := NewAdminUsecase(mockRepo)
-
-	mockRequest := &domain.Request{
-		ID:          1,
-		UserID:      23,
-		Type:        "abc",
-		Status:      123,
-		RejectNotes: "abc",
-		VerifierID:  124,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+	usecase := NewAdminUsecase(mockRepo, new(MockVerificationSender), new(MockAuditRecorder), testRoles)
+
+	verifierID := 124
+	mockRequest := &requestDomain.Request{
+		ID:         1,
+		UserID:     23,
+		Type:       requestDomain.RequestTypeVerification,
+		Status:     requestDomain.RequestStatusPending,
+		VerifierID: &verifierID,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
 	}
 
-	mockRepo.On("GetPendingRequestByID", 1).Return(mockRequest, "Request found")
+	mockRepo.On("GetPendingRequestByID", 1).Return(mockRequest, nil)
+	mockRepo.On("GetRequestHistory", mockRequest).Return([]*requestDomain.Request{}, nil)
 
-	result, msg := usecase.GetPendingRequestById(1)
+	result, err := usecase.GetPendingRequestById(1)
+	assert.NoError(t, err)
 	assert.NotNil(t, result)
-	assert.Equal(t, "Request found", msg)
 	assert.Equal(t, mockRequest.ID, result.ID)
 	assert.Equal(t, mockRequest.UserID, result.UserID)
-	assert.Equal(t, mockRequest.Type, result.Type)
-	assert.Equal(t, mockRequest.Status, result.Status)
-	assert.Equal(t, mockRequest.RejectNotes, result.RejectNotes)
+	assert.Equal(t, string(mockRequest.Type), result.Type)
+	assert.Equal(t, int(mockRequest.Status), result.Status)
 	assert.Equal(t, mockRequest.VerifierID, result.VerifierID)
 	mockRepo.AssertExpectations(t)
 }
 
+func TestGetRequestByIdWithHistory(t *testing.T) {
+	mockRepo := new(MockAdminRepository)
+	usecase := NewAdminUsecase(mockRepo, new(MockVerificationSender), new(MockAuditRecorder), testRoles)
+
+	parentID := 1
+	verifierID := 9
+	rejected := &requestDomain.Request{
+		ID:         1,
+		UserID:     23,
+		Type:       requestDomain.RequestTypeVerification,
+		Status:     requestDomain.RequestStatusRejected,
+		VerifierID: &verifierID,
+	}
+	revision := &requestDomain.Request{
+		ID:       2,
+		UserID:   23,
+		Type:     requestDomain.RequestTypeVerification,
+		Status:   requestDomain.RequestStatusPending,
+		ParentID: &parentID,
+	}
+	mockRepo.On("GetRequestByID", 2).Return(revision, nil)
+	mockRepo.On("GetRequestHistory", revision).Return([]*requestDomain.Request{rejected}, nil)
+
+	result, err := usecase.GetRequestById(2)
+	assert.NoError(t, err)
+	assert.Equal(t, &parentID, result.ParentID)
+	assert.Len(t, result.History, 1)
+	assert.Equal(t, 1, result.History[0].ID)
+	assert.Equal(t, int(requestDomain.RequestStatusRejected), result.History[0].Status)
+	mockRepo.AssertExpectations(t)
+}
+
 func TestApproveRequest(t *testing.T) {
 	mockRepo := new(MockAdminRepository)
-	usecase := NewAdminUsecase(mockRepo)
+	mockSender := new(MockVerificationSender)
+	mockAudit := new(MockAuditRecorder)
+	usecase := NewAdminUsecase(mockRepo, mockSender, mockAudit, testRoles)
 
-	mockRepo.On("ApproveRequest", 1, 456).Return("Request approved")
+	roles := storage.ApprovalRoles{ApplicantRoleID: 6, VolunteerRoleID: 7}
+	mockRepo.On("ApproveRequest", 1, 456, roles).Return(nil)
+	mockRepo.On("GetRequestByID", 1).Return(&requestDomain.Request{ID: 1, UserID: 7}, nil)
+	mockRepo.On("GetRequestByID", 2).Return((*requestDomain.Request)(nil), requestDomain.ErrRequestNotFound)
+	mockRepo.On("ApproveRequest", 2, 456, roles).Return(requestDomain.ErrInvalidTransition)
+	mockRepo.On("ApproveRequest", 3, 456, roles).Return(nil)
+	mockRepo.On("GetRequestByID", 3).Return(&requestDomain.Request{ID: 3, UserID: 8}, nil)
+	mockSender.On("SendVerificationEmail", 7).Return(nil)
+	mockSender.On("SendVerificationEmail", 8).Return(errors.New("smtp down"))
+	mockAudit.On("Record", auditAction("request.approve", "1")).Return(nil)
+	mockAudit.On("Record", auditAction("request.approve", "3")).Return(errors.New("db down"))
 
-	msg := usecase.ApproveRequest(1, 456)
-	assert.Equal(t, "Request approved", msg)
+	assert.NoError(t, usecase.ApproveRequest(1, 456))
+	assert.ErrorIs(t, usecase.ApproveRequest(2, 456), requestDomain.ErrInvalidTransition)
+	assert.NoError(t, usecase.ApproveRequest(3, 456), "a failed email must not fail the approval")
 	mockRepo.AssertExpectations(t)
+	mockSender.AssertExpectations(t)
+	mockAudit.AssertExpectations(t)
+}
+
+func TestApproveRequestWithoutVolunteerRole(t *testing.T) {
+	mockRepo := new(MockAdminRepository)
+	usecase := NewAdminUsecase(mockRepo, new(MockVerificationSender), new(MockAuditRecorder), fakeRoles{roleDomain.RoleCodeApplicant: 6})
+
+	assert.ErrorIs(t, usecase.ApproveRequest(1, 456), roleDomain.ErrRoleCodeUnknown)
+	mockRepo.AssertNotCalled(t, "ApproveRequest", mock.Anything, mock.Anything, mock.Anything)
 }
 
 func TestRejectRequest(t *testing.T) {
 	mockRepo := new(MockAdminRepository)
-	usecase := NewAdminUsecase(mockRepo)
+	mockAudit := new(MockAuditRecorder)
+	usecase := NewAdminUsecase(mockRepo, new(MockVerificationSender), mockAudit, testRoles)
 
-	mockRepo.On("RejectRequest", 1, 456).Return("Request rejected")
+	pending := &requestDomain.Request{ID: 1, Status: requestDomain.RequestStatusPending}
+	verifierID := 456
+	rejected := &requestDomain.Request{ID: 1, Status: requestDomain.RequestStatusRejected, VerifierID: &verifierID}
+	mockRepo.On("GetRequestByID", 1).Return(pending, nil).Once()
+	mockRepo.On("RejectRequest", 1, 456).Return(nil)
+	mockRepo.On("GetRequestByID", 1).Return(rejected, nil).Once()
+	mockAudit.On("Record", mock.MatchedBy(func(entry auditUsecase.Entry) bool {
+		return *entry.ActorID == 456 && entry.EntityType == "request" &&
+			entry.Before == pending && entry.After == rejected
+	})).Return(nil)
 
-	msg := usecase.RejectRequest(1, 456)
-	assert.Equal(t, "Request rejected", msg)
+	assert.NoError(t, usecase.RejectRequest(1, 456))
 	mockRepo.AssertExpectations(t)
+	mockAudit.AssertExpectations(t)
 }
 
-func TestAddRejectNotes(t *testing.T) {
+func TestDeleteRequest(t *testing.T) {
 	mockRepo := new(MockAdminRepository)
-	usecase := NewAdminUsecase(mockRepo)
+	mockAudit := new(MockAuditRecorder)
+	usecase := NewAdminUsecase(mockRepo, new(MockVerificationSender), mockAudit, testRoles)
 
-	mockRepo.On("AddRejectNotes", 1, "Some notes").Return("Reject notes added")
+	mockRepo.On("GetRequestByID", 1).Return(&requestDomain.Request{ID: 1}, nil)
+	mockRepo.On("DeleteRequest", 1).Return(nil)
+	mockRepo.On("GetRequestByID", 2).Return((*requestDomain.Request)(nil), requestDomain.ErrRequestNotFound)
+	mockRepo.On("DeleteRequest", 2).Return(requestDomain.ErrRequestNotFound)
+	mockAudit.On("Record", auditAction("request.delete", "1")).Return(nil)
 
-	msg := usecase.AddRejectNotes(1, "Some notes")
-	assert.Equal(t, "Reject notes added", msg)
+	assert.NoError(t, usecase.DeleteRequest(1, 9))
+	assert.ErrorIs(t, usecase.DeleteRequest(2, 9), requestDomain.ErrRequestNotFound)
 	mockRepo.AssertExpectations(t)
+	mockAudit.AssertExpectations(t)
 }
 
-func TestDeleteRequest(t *testing.T) {
+func TestRestoreRequest(t *testing.T) {
 	mockRepo := new(MockAdminRepository)
-	usecase := NewAdminUsecase(mockRepo)
+	mockAudit := new(MockAuditRecorder)
+	usecase := NewAdminUsecase(mockRepo, new(MockVerificationSender), mockAudit, testRoles)
 
-	mockRepo.On("DeleteRequest", 1).Return("Request deleted")
+	mockRepo.On("RestoreRequest", 1).Return(nil)
+	mockRepo.On("GetRequestByID", 1).Return(&requestDomain.Request{ID: 1}, nil)
+	mockRepo.On("RestoreRequest", 2).Return(requestDomain.ErrRequestNotFound)
+	mockAudit.On("Record", auditAction("request.restore", "1")).Return(nil).Once()
 
-	msg := usecase.DeleteRequest(1)
-	assert.Equal(t, "Request deleted", msg)
+	assert.NoError(t, usecase.RestoreRequest(1, 9))
+	assert.ErrorIs(t, usecase.RestoreRequest(2, 9), requestDomain.ErrRequestNotFound)
 	mockRepo.AssertExpectations(t)
+	mockAudit.AssertExpectations(t)
 }
@@ -3,6 +3,7 @@ package usecase
 import (
 	"time"
 
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/storage"
@@ -12,6 +13,7 @@ type ApplicantUsecaseInterface interface {
 	CreateApplicant(request dto.ApplicantCreateDTO) error
 	UpdateApplicant(id int, request dto.ApplicantUpdateDTO) error
 	DeleteApplicant(id int) error
+	RestoreApplicant(id int) error
 	FindApplicantByID(id int) (*dto.ApplicantResponseDTO, error)
 }
 
@@ -40,7 +42,7 @@ func (u *ApplicantUsecase) UpdateApplicant(id int, request dto.ApplicantUpdateDT
 	//Thay doi request DOB ve dang time.Time
 	dob, err := time.Parse("2006-01-02", request.DOB)
 	if err != nil {
-		return err
+		return apperror.Wrap(apperror.Validation, err)
 	}
 
 	user.Email = request.Email
@@ -49,7 +51,6 @@ func (u *ApplicantUsecase) UpdateApplicant(id int, request dto.ApplicantUpdateDT
 	user.Gender = request.Gender
 	user.DOB = dob
 	user.Mobile = request.Mobile
-	user.RoleID = request.RoleID
 	user.CountryID = request.CountryID
 	user.ResidentCountryID = request.ResidentCountryID
 	user.DepartmentID = request.DepartmentID
@@ -61,6 +62,10 @@ func (u *ApplicantUsecase) DeleteApplicant(id int) error {
 	return u.ApplicantRepo.DeleteApplicant(id)
 }
 
+func (u *ApplicantUsecase) RestoreApplicant(id int) error {
+	return u.ApplicantRepo.RestoreApplicant(id)
+}
+
 func (u *ApplicantUsecase) FindApplicantByID(id int) (*dto.ApplicantResponseDTO, error) {
 	user, err := u.ApplicantRepo.FindApplicantByID(id)
 	if err != nil {
@@ -79,6 +84,7 @@ func (u *ApplicantUsecase) FindApplicantByID(id int) (*dto.ApplicantResponseDTO,
 		CountryID:         user.CountryID,
 		ResidentCountryID: user.ResidentCountryID,
 		DepartmentID:      user.DepartmentID,
+		HasAvatar:         user.Avatar != "",
 	}
 	return response, nil
 }
@@ -30,9 +30,17 @@ func (m *MockApplicantRepository) DeleteApplicant(id int) error {
 	return args.Error(0)
 }
 
+func (m *MockApplicantRepository) RestoreApplicant(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
 func (m *MockApplicantRepository) FindApplicantByID(id int) (*domain.ApplicantDomain, error) {
 	args := m.Called(id)
-	return args.Get(0).(*domain.ApplicantDomain), args.Error(1)
+	if args.Get(0) != nil {
+		return args.Get(0).(*domain.ApplicantDomain), args.Error(1)
+	}
+	return nil, args.Error(1)
 }
 
 func TestCreateApplicant(t *testing.T) {
@@ -58,13 +66,12 @@ func TestUpdateApplicant(t *testing.T) {
 	usecase := NewApplicantUsecase(mockRepo)
 
 	input := dto.ApplicantUpdateDTO{
-		RoleID:            1,
 		DepartmentID:      2,
 		Email:             "test@example.com",
 		Name:              "Tony",
 		Surname:           "Quang",
 		Gender:            "Male",
-		DOB:               "time.Date(2002, 9, 20, 0, 0, 0, 0, time.UTC)",
+		DOB:               "2002-09-20",
 		Mobile:            "0913895987",
 		CountryID:         2,
 		ResidentCountryID: 7,
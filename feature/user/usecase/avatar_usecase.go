@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/blob"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/upload"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/dto"
+)
+
+// avatarURLTTL is how long a link to an avatar stays valid.
+const avatarURLTTL = 15 * time.Minute
+
+type AvatarUsecaseInterface interface {
+	UploadAvatar(userID int, file *upload.File) (*dto.FileURLResponse, error)
+	AvatarURL(userID int) (*dto.FileURLResponse, error)
+}
+
+// AvatarRepository reads and sets the avatar key of a user.
+type AvatarRepository interface {
+	FindApplicantByID(id int) (*domain.ApplicantDomain, error)
+	UpdateAvatar(id int, key string) error
+}
+
+type AvatarUsecase struct {
+	repo  AvatarRepository
+	files blob.BlobStore
+	now   func() time.Time
+}
+
+func NewAvatarUsecase(repo AvatarRepository, files blob.BlobStore) *AvatarUsecase {
+	return &AvatarUsecase{repo: repo, files: files, now: time.Now}
+}
+
+// UploadAvatar stores a new avatar for a user and removes the previous one.
+func (u *AvatarUsecase) UploadAvatar(userID int, file *upload.File) (*dto.FileURLResponse, error) {
+	user, err := u.repo.FindApplicantByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	key, err := blob.NewKey(fmt.Sprintf("avatars/%d", userID), file.Ext)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.files.Put(key, file.Data, file.ContentType); err != nil {
+		return nil, err
+	}
+	if err := u.repo.UpdateAvatar(userID, key); err != nil {
+		if deleteErr := u.files.Delete(key); deleteErr != nil {
+			log.Printf("delete orphaned avatar %s: %v", key, deleteErr)
+		}
+		return nil, err
+	}
+	// The new avatar is in place, so a leftover file is only logged.
+	if user.Avatar != "" {
+		if err := u.files.Delete(user.Avatar); err != nil {
+			log.Printf("delete previous avatar %s: %v", user.Avatar, err)
+		}
+	}
+	return u.signedURL(key)
+}
+
+// AvatarURL returns a link to the avatar of a user.
+func (u *AvatarUsecase) AvatarURL(userID int) (*dto.FileURLResponse, error) {
+	user, err := u.repo.FindApplicantByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Avatar == "" {
+		return nil, domain.ErrNoAvatar
+	}
+	return u.signedURL(user.Avatar)
+}
+
+func (u *AvatarUsecase) signedURL(key string) (*dto.FileURLResponse, error) {
+	url, err := u.files.SignedURL(key, avatarURLTTL)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.FileURLResponse{URL: url, ExpiresAt: u.now().Add(avatarURLTTL).UTC()}, nil
+}
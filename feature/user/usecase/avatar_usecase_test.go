@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/upload"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func (m *MockApplicantRepository) UpdateAvatar(id int, key string) error {
+	args := m.Called(id, key)
+	return args.Error(0)
+}
+
+type MockBlobStore struct {
+	mock.Mock
+}
+
+func (m *MockBlobStore) Put(key string, data []byte, contentType string) error {
+	args := m.Called(key, data, contentType)
+	return args.Error(0)
+}
+
+func (m *MockBlobStore) Delete(key string) error {
+	args := m.Called(key)
+	return args.Error(0)
+}
+
+func (m *MockBlobStore) SignedURL(key string, ttl time.Duration) (string, error) {
+	args := m.Called(key, ttl)
+	return args.String(0), args.Error(1)
+}
+
+func isAvatarKey(key string) bool {
+	return strings.HasPrefix(key, "avatars/7/") && strings.HasSuffix(key, ".png")
+}
+
+func TestUploadAvatar(t *testing.T) {
+	file := &upload.File{Data: []byte("png data"), ContentType: upload.TypePNG, Ext: ".png"}
+
+	t.Run("replaces previous avatar", func(t *testing.T) {
+		repo := new(MockApplicantRepository)
+		files := new(MockBlobStore)
+		usecase := NewAvatarUsecase(repo, files)
+
+		repo.On("FindApplicantByID", 7).Return(&domain.ApplicantDomain{ID: 7, Avatar: "avatars/7/old.jpg"}, nil)
+		files.On("Put", mock.MatchedBy(isAvatarKey), file.Data, upload.TypePNG).Return(nil)
+		repo.On("UpdateAvatar", 7, mock.MatchedBy(isAvatarKey)).Return(nil)
+		files.On("Delete", "avatars/7/old.jpg").Return(errors.New("disk full"))
+		files.On("SignedURL", mock.MatchedBy(isAvatarKey), avatarURLTTL).Return("https://files.example.com/avatars/7/new.png?signature=abc", nil)
+
+		response, err := usecase.UploadAvatar(7, file)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "https://files.example.com/avatars/7/new.png?signature=abc", response.URL)
+		repo.AssertExpectations(t)
+		files.AssertExpectations(t)
+	})
+
+	t.Run("removes the file when the user cannot be updated", func(t *testing.T) {
+		repo := new(MockApplicantRepository)
+		files := new(MockBlobStore)
+		usecase := NewAvatarUsecase(repo, files)
+
+		repo.On("FindApplicantByID", 7).Return(&domain.ApplicantDomain{ID: 7}, nil)
+		files.On("Put", mock.MatchedBy(isAvatarKey), file.Data, upload.TypePNG).Return(nil)
+		repo.On("UpdateAvatar", 7, mock.MatchedBy(isAvatarKey)).Return(domain.ErrApplicantNotFound)
+		files.On("Delete", mock.MatchedBy(isAvatarKey)).Return(nil)
+
+		_, err := usecase.UploadAvatar(7, file)
+
+		assert.ErrorIs(t, err, domain.ErrApplicantNotFound)
+		files.AssertExpectations(t)
+	})
+}
+
+func TestAvatarURL(t *testing.T) {
+	repo := new(MockApplicantRepository)
+	files := new(MockBlobStore)
+	usecase := NewAvatarUsecase(repo, files)
+
+	repo.On("FindApplicantByID", 7).Return(&domain.ApplicantDomain{ID: 7, Avatar: "avatars/7/abc.png"}, nil)
+	repo.On("FindApplicantByID", 8).Return(&domain.ApplicantDomain{ID: 8}, nil)
+	files.On("SignedURL", "avatars/7/abc.png", avatarURLTTL).Return("https://files.example.com/avatars/7/abc.png?signature=abc", nil)
+
+	response, err := usecase.AvatarURL(7)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://files.example.com/avatars/7/abc.png?signature=abc", response.URL)
+
+	_, err = usecase.AvatarURL(8)
+	assert.ErrorIs(t, err, domain.ErrNoAvatar)
+}
@@ -1,15 +1,172 @@
 package domain
 
-import "time"
+import (
+	"strings"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/encryption"
+)
+
+// numberIndexDomain separates the blind index of document numbers from the
+// indexes of other columns.
+const numberIndexDomain = "user_identities.number"
+
+// Kinds of identity documents.
+const (
+	DocumentTypePassport      = "passport"
+	DocumentTypeNationalID    = "national_id"
+	DocumentTypeDriverLicence = "driver_licence"
+)
+
+// Sides of a document that can be scanned.
+const (
+	ScanSideFront = "front"
+	ScanSideBack  = "back"
+)
+
+// A document is pending until an admin verifies or rejects it. Pending and
+// verified documents become expired once their expiry date has passed.
+const (
+	IdentityStatusPending  = 0
+	IdentityStatusVerified = 1
+	IdentityStatusRejected = 2
+	IdentityStatusExpired  = 3
+)
+
+var (
+	ErrUserIdentityNotFound = apperror.New(apperror.NotFound, "user identity not found")
+	ErrIdentityNotPending   = apperror.New(apperror.Conflict, "identity document was already reviewed")
+	ErrIdentityExpired      = apperror.New(apperror.Unprocessable, "identity document has expired")
+	ErrInvalidScanSide      = apperror.New(apperror.Validation, "scan side must be front or back")
+	ErrScanNotFound         = apperror.New(apperror.NotFound, "identity document has no scan of this side")
+	ErrDuplicateIdentity    = apperror.New(apperror.Conflict, "identity document is already registered")
+)
 
 type UserIdentity struct {
-	ID          int       `gorm:"primaryKey"`
-	UserID      int       `gorm:"not null"`
-	Number      string    `gorm:"not null"`
-	Type        string    `gorm:"not null"`
-	Status      int       `gorm:"not null"`
-	ExpiryDate  time.Time `gorm:"not null"`
-	PlaceIssued string    `gorm:"not null"`
-	CreatedAt   time.Time `gorm:"autoCreateTime"`
-	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+	ID              int    `gorm:"primaryKey"`
+	UserID          int    `gorm:"not null"`
+	Number          string `gorm:"not null;serializer:encrypted"`
+	NumberIndex     string
+	Type            string    `gorm:"not null"`
+	Status          int       `gorm:"not null"`
+	ExpiryDate      time.Time `gorm:"not null"`
+	PlaceIssued     string    `gorm:"not null"`
+	VerifiedBy      *int
+	VerifiedAt      *time.Time
+	RejectionReason *string
+	ExpiryAlertedAt *time.Time
+	FrontScan       *string
+	BackScan        *string
+	CreatedAt       time.Time `gorm:"autoCreateTime"`
+	UpdatedAt       time.Time `gorm:"autoUpdateTime"`
+}
+
+// SetNumber sets the type and number of the document along with the blind
+// index that finds documents with the same type and number.
+func (i *UserIdentity) SetNumber(documentType string, number string) error {
+	index, err := NumberIndex(documentType, number)
+	if err != nil {
+		return err
+	}
+	i.Type = documentType
+	i.Number = number
+	i.NumberIndex = index
+	return nil
+}
+
+// NumberIndex returns the blind index of a document number. Numbers are
+// compared regardless of case, spaces, dashes and dots.
+func NumberIndex(documentType string, number string) (string, error) {
+	normalized := strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' || r == '.' {
+			return -1
+		}
+		return r
+	}, strings.ToUpper(number))
+	return encryption.BlindIndex(numberIndexDomain, documentType+":"+normalized)
+}
+
+// MaskNumber hides all but the last four characters of a document number.
+func MaskNumber(number string) string {
+	runes := []rune(number)
+	if len(runes) <= 4 {
+		return "****"
+	}
+	return "****" + string(runes[len(runes)-4:])
+}
+
+// Today returns the current date at midnight, in the form expiry dates are
+// compared with.
+func Today(now time.Time) time.Time {
+	year, month, day := now.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// IsExpired reports whether the document expired before today. A document is
+// still valid on its expiry date.
+func (i *UserIdentity) IsExpired(today time.Time) bool {
+	return i.ExpiryDate.Before(today)
+}
+
+// Review records the decision of an admin on a pending document. Expired
+// documents can be rejected but not verified.
+func (i *UserIdentity) Review(status int, reviewerID int, reason *string, now time.Time) error {
+	if i.Status != IdentityStatusPending {
+		return ErrIdentityNotPending
+	}
+	if status == IdentityStatusVerified && i.IsExpired(Today(now)) {
+		return ErrIdentityExpired
+	}
+	i.Status = status
+	i.VerifiedBy = &reviewerID
+	i.VerifiedAt = &now
+	i.RejectionReason = reason
+	return nil
+}
+
+// Resubmit puts a changed document back in review.
+func (i *UserIdentity) Resubmit() {
+	i.Status = IdentityStatusPending
+	i.VerifiedBy = nil
+	i.VerifiedAt = nil
+	i.RejectionReason = nil
+	i.ExpiryAlertedAt = nil
+}
+
+// Scan returns the key of the scan of side, or nil when it was not uploaded.
+func (i *UserIdentity) Scan(side string) (*string, error) {
+	switch side {
+	case ScanSideFront:
+		return i.FrontScan, nil
+	case ScanSideBack:
+		return i.BackScan, nil
+	}
+	return nil, ErrInvalidScanSide
+}
+
+// SetScan attaches the scan stored under key to side and puts the document
+// back in review, since the admin has not seen the new scan.
+func (i *UserIdentity) SetScan(side string, key string) error {
+	switch side {
+	case ScanSideFront:
+		i.FrontScan = &key
+	case ScanSideBack:
+		i.BackScan = &key
+	default:
+		return ErrInvalidScanSide
+	}
+	i.Resubmit()
+	return nil
+}
+
+// ExpiringIdentity is a verified document that expires soon, with the user
+// to alert.
+type ExpiringIdentity struct {
+	ID         int
+	UserID     int
+	Type       string
+	ExpiryDate time.Time
+	Email      string
+	Name       string
 }
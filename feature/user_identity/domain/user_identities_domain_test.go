@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/encryption"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReview(t *testing.T) {
+	now := time.Date(2024, 5, 1, 15, 0, 0, 0, time.UTC)
+	reason := "blurry scan"
+
+	tests := []struct {
+		name       string
+		status     int
+		expiryDate time.Time
+		review     int
+		err        error
+	}{
+		{"verify", IdentityStatusPending, time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC), IdentityStatusVerified, nil},
+		{"verify on expiry date", IdentityStatusPending, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), IdentityStatusVerified, nil},
+		{"verify expired", IdentityStatusPending, time.Date(2024, 4, 30, 0, 0, 0, 0, time.UTC), IdentityStatusVerified, ErrIdentityExpired},
+		{"reject expired", IdentityStatusPending, time.Date(2024, 4, 30, 0, 0, 0, 0, time.UTC), IdentityStatusRejected, nil},
+		{"already verified", IdentityStatusVerified, time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC), IdentityStatusRejected, ErrIdentityNotPending},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identity := &UserIdentity{Status: tt.status, ExpiryDate: tt.expiryDate}
+			err := identity.Review(tt.review, 3, &reason, now)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				assert.Equal(t, tt.status, identity.Status)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.review, identity.Status)
+			assert.Equal(t, 3, *identity.VerifiedBy)
+			assert.Equal(t, now, *identity.VerifiedAt)
+
+			identity.Resubmit()
+			assert.Equal(t, IdentityStatusPending, identity.Status)
+			assert.Nil(t, identity.VerifiedBy)
+		})
+	}
+}
+
+func TestSetNumber(t *testing.T) {
+	var identity UserIdentity
+	assert.ErrorIs(t, identity.SetNumber(DocumentTypePassport, "B1234567"), encryption.ErrNoKeyring)
+
+	keyring, err := encryption.NewKeyring("test", map[string][]byte{"test": bytes.Repeat([]byte("k"), 32)}, bytes.Repeat([]byte("i"), 32))
+	assert.NoError(t, err)
+	encryption.Use(keyring)
+	defer encryption.Use(nil)
+
+	assert.NoError(t, identity.SetNumber(DocumentTypePassport, "B1234567"))
+	assert.Equal(t, "B1234567", identity.Number)
+	assert.Len(t, identity.NumberIndex, 64)
+
+	same, err := NumberIndex(DocumentTypePassport, "b-123 45.67")
+	assert.NoError(t, err)
+	assert.Equal(t, identity.NumberIndex, same)
+
+	otherType, err := NumberIndex(DocumentTypeNationalID, "B1234567")
+	assert.NoError(t, err)
+	assert.NotEqual(t, identity.NumberIndex, otherType)
+}
+
+func TestMaskNumber(t *testing.T) {
+	assert.Equal(t, "****4567", MaskNumber("B1234567"))
+	assert.Equal(t, "****", MaskNumber("123"))
+}
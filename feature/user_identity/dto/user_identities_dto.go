@@ -1,29 +1,52 @@
 package dto
 
+import "time"
+
 type CreateUserIdentityRequest struct {
-	UserID      int    `json:"user_id" binding:"required"`
-	Number      string `json:"number" binding:"required"`
-	Type        string `json:"type" binding:"required"`
-	Status      int    `json:"status" binding:"required"`
-	ExpiryDate  string `json:"expiry_date" binding:"required"`
-	PlaceIssued string `json:"place_issued" binding:"required"`
+	UserID      int    `json:"user_id" binding:"required,exists=users"`
+	Number      string `json:"number" binding:"required,max=30"`
+	Type        string `json:"type" binding:"required,oneof=passport national_id driver_licence" enums:"passport,national_id,driver_licence"`
+	ExpiryDate  string `json:"expiry_date" binding:"required,futuredate" example:"2030-01-31"`
+	PlaceIssued string `json:"place_issued" binding:"required,max=100"`
 }
 
+// UpdateUserIdentityRequest replaces the details of a document, which then
+// goes back to review. The owner of a document cannot be changed.
 type UpdateUserIdentityRequest struct {
-	UserID      int    `json:"user_id"`
-	Number      string `json:"number"`
-	Type        string `json:"type"`
-	Status      int    `json:"status"`
-	ExpiryDate  string `json:"expiry_date"`
-	PlaceIssued string `json:"place_issued"`
+	Number      string `json:"number" binding:"required,max=30"`
+	Type        string `json:"type" binding:"required,oneof=passport national_id driver_licence" enums:"passport,national_id,driver_licence"`
+	ExpiryDate  string `json:"expiry_date" binding:"required,futuredate" example:"2030-01-31"`
+	PlaceIssued string `json:"place_issued" binding:"required,max=100"`
+}
+
+type RejectUserIdentityRequest struct {
+	Reason string `json:"reason" binding:"required,max=255"`
 }
 
+// UserIdentityResponse shows the last four characters of the number only.
 type UserIdentityResponse struct {
-	ID          int    `json:"id"`
-	UserID      int    `json:"user_id"`
-	Number      string `json:"number"`
-	Type        string `json:"type"`
-	Status      int    `json:"status"`
-	ExpiryDate  string `json:"expiry_date"`
-	PlaceIssued string `json:"place_issued"`
+	ID              int        `json:"id"`
+	UserID          int        `json:"user_id"`
+	Number          string     `json:"number" example:"****1234"`
+	Type            string     `json:"type"`
+	Status          int        `json:"status"`
+	ExpiryDate      string     `json:"expiry_date"`
+	PlaceIssued     string     `json:"place_issued"`
+	VerifiedBy      *int       `json:"verified_by"`
+	VerifiedAt      *time.Time `json:"verified_at"`
+	RejectionReason *string    `json:"rejection_reason"`
+	HasFrontScan    bool       `json:"has_front_scan"`
+	HasBackScan     bool       `json:"has_back_scan"`
+}
+
+// IdentityNumberResponse is the full number of a document, for admins.
+type IdentityNumberResponse struct {
+	ID     int    `json:"id"`
+	Number string `json:"number"`
+}
+
+// FileURLResponse is a signed link to a private file.
+type FileURLResponse struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
 }
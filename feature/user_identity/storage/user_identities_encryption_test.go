@@ -0,0 +1,71 @@
+package storage
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/encryption"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user_identity/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func useTestKeyring(t *testing.T) {
+	keyring, err := encryption.NewKeyring("test", map[string][]byte{"test": bytes.Repeat([]byte("k"), 32)}, bytes.Repeat([]byte("i"), 32))
+	assert.NoError(t, err)
+	encryption.Use(keyring)
+	t.Cleanup(func() { encryption.Use(nil) })
+}
+
+// sealed matches a number sealed by the encrypted serializer.
+type sealed struct{}
+
+func (sealed) Match(v driver.Value) bool {
+	s, ok := v.(string)
+	return ok && encryption.IsSealed(s)
+}
+
+func TestNumberIndexExists(t *testing.T) {
+	db, mock := setupSQLMock(t)
+	repo := NewUserIdentityRepository(db)
+
+	query := regexp.QuoteMeta("SELECT count(*) FROM `user_identities` WHERE number_index = ? AND id <> ?")
+	mock.ExpectQuery(query).WithArgs("abc", 1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(query).WithArgs("def", 0).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+	exists, err := repo.NumberIndexExists("abc", 1)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = repo.NumberIndexExists("def", 0)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReencryptIdentities(t *testing.T) {
+	useTestKeyring(t)
+	db, mock := setupSQLMock(t)
+	repo := NewUserIdentityRepository(db)
+
+	index, err := domain.NumberIndex(domain.DocumentTypePassport, "B1234567")
+	assert.NoError(t, err)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `user_identities` ORDER BY `user_identities`.`id` LIMIT ?")).
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "number", "type"}).
+			AddRow(1, "B1234567", domain.DocumentTypePassport))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `user_identities` SET `number`=?,`number_index`=?")).
+		WithArgs(sealed{}, index, sqlmock.AnyArg(), 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	saved, err := repo.ReencryptIdentities(2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, saved)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
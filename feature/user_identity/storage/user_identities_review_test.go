@@ -0,0 +1,118 @@
+package storage
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user_identity/domain"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func setupSQLMock(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	assert.NoError(t, err)
+	return gormDB, mock
+}
+
+func TestReviewUserIdentity(t *testing.T) {
+	lock := regexp.QuoteMeta("SELECT * FROM `user_identities` WHERE `user_identities`.`id` = ? ORDER BY `user_identities`.`id` LIMIT ? FOR UPDATE")
+	columns := []string{"id", "user_id", "number", "type", "status", "expiry_date", "place_issued"}
+	now := time.Date(2024, 5, 1, 15, 0, 0, 0, time.UTC)
+
+	t.Run("verify", func(t *testing.T) {
+		useTestKeyring(t)
+		db, mock := setupSQLMock(t)
+		repo := NewUserIdentityRepository(db)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lock).WithArgs(1, 1).WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(1, 7, "B1234567", domain.DocumentTypePassport, domain.IdentityStatusPending, time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC), "Hanoi"))
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `user_identities` SET")).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		before, after, err := repo.ReviewUserIdentity(1, domain.IdentityStatusVerified, 3, nil, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.IdentityStatusPending, before.Status)
+		assert.Equal(t, domain.IdentityStatusVerified, after.Status)
+		assert.Equal(t, 3, *after.VerifiedBy)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		db, mock := setupSQLMock(t)
+		repo := NewUserIdentityRepository(db)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lock).WithArgs(1, 1).WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(1, 7, "B1234567", domain.DocumentTypePassport, domain.IdentityStatusPending, time.Date(2024, 4, 30, 0, 0, 0, 0, time.UTC), "Hanoi"))
+		mock.ExpectRollback()
+
+		_, _, err := repo.ReviewUserIdentity(1, domain.IdentityStatusVerified, 3, nil, now)
+
+		assert.ErrorIs(t, err, domain.ErrIdentityExpired)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		db, mock := setupSQLMock(t)
+		repo := NewUserIdentityRepository(db)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lock).WithArgs(9, 1).WillReturnRows(sqlmock.NewRows(columns))
+		mock.ExpectRollback()
+
+		_, _, err := repo.ReviewUserIdentity(9, domain.IdentityStatusRejected, 3, nil, now)
+
+		assert.ErrorIs(t, err, domain.ErrUserIdentityNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestMarkExpiredIdentities(t *testing.T) {
+	db, mock := setupSQLMock(t)
+	repo := NewUserIdentityRepository(db)
+	today := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `user_identities` SET `status`=?,`updated_at`=? WHERE status IN (?,?) AND expiry_date < ?")).
+		WithArgs(domain.IdentityStatusExpired, sqlmock.AnyArg(), domain.IdentityStatusPending, domain.IdentityStatusVerified, today).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	expired, err := repo.MarkExpiredIdentities(today)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), expired)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListExpiringIdentities(t *testing.T) {
+	db, mock := setupSQLMock(t)
+	repo := NewUserIdentityRepository(db)
+	today := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	until := today.AddDate(0, 0, 30)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT user_identities.id, user_identities.user_id, user_identities.type, user_identities.expiry_date, users.email, users.name FROM `user_identities` JOIN users ON users.id = user_identities.user_id AND users.deleted_at IS NULL WHERE user_identities.status = ? AND user_identities.expiry_date BETWEEN ? AND ? AND user_identities.expiry_alerted_at IS NULL ORDER BY user_identities.id")).
+		WithArgs(domain.IdentityStatusVerified, today, until).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "type", "expiry_date", "email", "name"}).
+			AddRow(1, 7, domain.DocumentTypeNationalID, time.Date(2024, 5, 20, 0, 0, 0, 0, time.UTC), "jane@example.com", "Jane"))
+
+	identities, err := repo.ListExpiringIdentities(today, until)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*domain.ExpiringIdentity{
+		{ID: 1, UserID: 7, Type: domain.DocumentTypeNationalID, ExpiryDate: time.Date(2024, 5, 20, 0, 0, 0, 0, time.UTC), Email: "jane@example.com", Name: "Jane"},
+	}, identities)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
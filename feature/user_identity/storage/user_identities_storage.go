@@ -1,14 +1,22 @@
 package storage
 
 import (
+	"errors"
+	"fmt"
+	"time"
+
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user_identity/domain"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type UserIndentityRepositoryInterface interface {
 	CreateUserIdentity(identity *domain.UserIdentity) error
 	UpdateUserIdentity(identity *domain.UserIdentity) error
 	FindUserIdentityByID(id int) (*domain.UserIdentity, error)
+	ListUserIdentities(userID int) ([]*domain.UserIdentity, error)
+	ReviewUserIdentity(id int, status int, reviewerID int, reason *string, now time.Time) (before, after *domain.UserIdentity, err error)
+	NumberIndexExists(index string, exceptID int) (bool, error)
 }
 
 type UserIdentityRepository struct {
@@ -30,7 +38,103 @@ func (r *UserIdentityRepository) UpdateUserIdentity(identity *domain.UserIdentit
 func (r *UserIdentityRepository) FindUserIdentityByID(id int) (*domain.UserIdentity, error) {
 	var identity domain.UserIdentity
 	if err := r.DB.First(&identity, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrUserIdentityNotFound
+		}
 		return nil, err
 	}
 	return &identity, nil
 }
+
+// ListUserIdentities returns the documents of a user, oldest first.
+func (r *UserIdentityRepository) ListUserIdentities(userID int) ([]*domain.UserIdentity, error) {
+	identities := []*domain.UserIdentity{}
+	err := r.DB.Where("user_id = ?", userID).Order("id").Find(&identities).Error
+	return identities, err
+}
+
+// NumberIndexExists reports whether a document other than exceptID has the
+// blind index of a number.
+func (r *UserIdentityRepository) NumberIndexExists(index string, exceptID int) (bool, error) {
+	var count int64
+	err := r.DB.Model(&domain.UserIdentity{}).Where("number_index = ? AND id <> ?", index, exceptID).Count(&count).Error
+	return count > 0, err
+}
+
+// ReviewUserIdentity locks a document and records the review of an admin, so
+// that two admins cannot review the same document at once. It returns the
+// document before and after the review.
+func (r *UserIdentityRepository) ReviewUserIdentity(id int, status int, reviewerID int, reason *string, now time.Time) (before, after *domain.UserIdentity, err error) {
+	err = r.DB.Transaction(func(tx *gorm.DB) error {
+		var identity domain.UserIdentity
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&identity, id).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.ErrUserIdentityNotFound
+		}
+		if err != nil {
+			return err
+		}
+		unchanged := identity
+		if err := identity.Review(status, reviewerID, reason, now); err != nil {
+			return err
+		}
+		if err := tx.Save(&identity).Error; err != nil {
+			return err
+		}
+		before, after = &unchanged, &identity
+		return nil
+	})
+	return before, after, err
+}
+
+// MarkExpiredIdentities moves the pending and verified documents that expired
+// before today to IdentityStatusExpired, and returns how many it moved.
+func (r *UserIdentityRepository) MarkExpiredIdentities(today time.Time) (int64, error) {
+	result := r.DB.Model(&domain.UserIdentity{}).
+		Where("status IN ? AND expiry_date < ?", []int{domain.IdentityStatusPending, domain.IdentityStatusVerified}, today).
+		Update("status", domain.IdentityStatusExpired)
+	return result.RowsAffected, result.Error
+}
+
+// ListExpiringIdentities returns the verified documents of active users that
+// expire between today and until, both included, and were not alerted yet.
+func (r *UserIdentityRepository) ListExpiringIdentities(today, until time.Time) ([]*domain.ExpiringIdentity, error) {
+	identities := []*domain.ExpiringIdentity{}
+	err := r.DB.Table("user_identities").
+		Select("user_identities.id, user_identities.user_id, user_identities.type, user_identities.expiry_date, users.email, users.name").
+		Joins("JOIN users ON users.id = user_identities.user_id AND users.deleted_at IS NULL").
+		Where("user_identities.status = ? AND user_identities.expiry_date BETWEEN ? AND ? AND user_identities.expiry_alerted_at IS NULL",
+			domain.IdentityStatusVerified, today, until).
+		Order("user_identities.id").
+		Scan(&identities).Error
+	return identities, err
+}
+
+// MarkIdentityAlerted records that the owner of a document was told it
+// expires soon.
+func (r *UserIdentityRepository) MarkIdentityAlerted(id int, at time.Time) error {
+	return r.DB.Model(&domain.UserIdentity{}).Where("id = ?", id).Update("expiry_alerted_at", at).Error
+}
+
+// ReencryptIdentities saves the number of every document again, which seals
+// numbers stored in plaintext and numbers sealed with an older key with the
+// current key, and fills in missing blind indexes. It returns how many
+// documents it saved.
+func (r *UserIdentityRepository) ReencryptIdentities(batchSize int) (int, error) {
+	saved := 0
+	var identities []*domain.UserIdentity
+	err := r.DB.FindInBatches(&identities, batchSize, func(tx *gorm.DB, batch int) error {
+		for _, identity := range identities {
+			if err := identity.SetNumber(identity.Type, identity.Number); err != nil {
+				return err
+			}
+			err := r.DB.Model(identity).Select("number", "number_index").Updates(identity).Error
+			if err != nil {
+				return fmt.Errorf("identity %d: %w", identity.ID, err)
+			}
+			saved++
+		}
+		return nil
+	}).Error
+	return saved, err
+}
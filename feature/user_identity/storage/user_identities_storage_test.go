@@ -1,38 +1,19 @@
 package storage
 
 import (
+	"regexp"
 	"testing"
 	"time"
 
+	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user_identity/domain"
 	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/mock"
-	"gorm.io/gorm"
 )
 
-type MockDB struct {
-	mock.Mock
-	gorm.DB
-}
-
-func (m *MockDB) Create(value interface{}) *gorm.DB {
-	args := m.Called(value)
-	return args.Get(0).(*gorm.DB)
-}
-
-func (m *MockDB) Save(value interface{}) *gorm.DB {
-	args := m.Called(value)
-	return args.Get(0).(*gorm.DB)
-}
-
-func (m *MockDB) First(dest interface{}, conds ...interface{}) *gorm.DB {
-	args := m.Called(dest, conds)
-	return args.Get(0).(*gorm.DB)
-}
-
 func TestCreateUserIdentity(t *testing.T) {
-	mockDB := new(MockDB)
-	repo := NewUserIdentityRepository(&mockDB.DB)
+	useTestKeyring(t)
+	db, mock := setupSQLMock(t)
+	repo := NewUserIdentityRepository(db)
 
 	userIdentity := &domain.UserIdentity{
 		ID:          1,
@@ -43,17 +24,20 @@ func TestCreateUserIdentity(t *testing.T) {
 		ExpiryDate:  time.Date(2025, 12, 12, 0, 0, 0, 0, time.UTC),
 		PlaceIssued: "Some city",
 	}
-	mockDB.On("Create", userIdentity).Return(&gorm.DB{Error: nil})
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `user_identities`")).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
 
 	err := repo.CreateUserIdentity(userIdentity)
 
 	assert.NoError(t, err)
-	mockDB.AssertExpectations(t)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestUpdateUserIdentity(t *testing.T) {
-	mockDB := new(MockDB)
-	repo := NewUserIdentityRepository(&mockDB.DB)
+	useTestKeyring(t)
+	db, mock := setupSQLMock(t)
+	repo := NewUserIdentityRepository(db)
 
 	userIdentity := &domain.UserIdentity{
 		ID:          1,
@@ -64,48 +48,44 @@ func TestUpdateUserIdentity(t *testing.T) {
 		ExpiryDate:  time.Date(2025, 12, 12, 0, 0, 0, 0, time.UTC),
 		PlaceIssued: "Some city",
 	}
-	mockDB.On("Save", userIdentity).Return(&gorm.DB{Error: nil})
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `user_identities` SET")).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
 
 	err := repo.UpdateUserIdentity(userIdentity)
 
 	assert.NoError(t, err)
-	mockDB.AssertExpectations(t)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestFindUserIdentityByID(t *testing.T) {
-	mockDB := new(MockDB)
-	repo := NewUserIdentityRepository(&mockDB.DB)
+	db, mock := setupSQLMock(t)
+	repo := NewUserIdentityRepository(db)
 
-	userIdentity := &domain.UserIdentity{
-		ID:          1,
-		UserID:      2,
-		Number:      "123456987",
-		Type:        "Citizen ID",
-		Status:      0,
-		ExpiryDate:  time.Date(2025, 12, 12, 0, 0, 0, 0, time.UTC),
-		PlaceIssued: "Some city",
-	}
-	mockDB.On("First", &userIdentity, 1).Return(&gorm.DB{Error: nil}).Run(func(args mock.Arguments) {
-		arg := args.Get(0).(**domain.UserIdentity)
-		*arg = userIdentity
-	})
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `user_identities` WHERE `user_identities`.`id` = ?")).
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "type", "place_issued"}).
+			AddRow(1, 2, "Citizen ID", "Some city"))
 
 	result, err := repo.FindUserIdentityByID(1)
 
 	assert.NoError(t, err)
-	assert.Equal(t, userIdentity, result)
-	mockDB.AssertExpectations(t)
+	assert.Equal(t, 1, result.ID)
+	assert.Equal(t, "Some city", result.PlaceIssued)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestFindUserIdentityByID_NotFound(t *testing.T) {
-	mockDB := new(MockDB)
-	repo := NewUserIdentityRepository(&mockDB.DB)
+	db, mock := setupSQLMock(t)
+	repo := NewUserIdentityRepository(db)
 
-	mockDB.On("First", mock.Anything, 1).Return(&gorm.DB{Error: gorm.ErrRecordNotFound})
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `user_identities` WHERE `user_identities`.`id` = ?")).
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
 
 	result, err := repo.FindUserIdentityByID(1)
 
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, domain.ErrUserIdentityNotFound)
 	assert.Nil(t, result)
-	mockDB.AssertExpectations(t)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
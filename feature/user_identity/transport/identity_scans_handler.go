@@ -0,0 +1,99 @@
+package transport
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/upload"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user_identity/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type ScanHandler struct {
+	ScanUsecase usecase.ScanUsecaseInterface
+}
+
+func NewScanHandler(scanUsecase usecase.ScanUsecaseInterface) *ScanHandler {
+	return &ScanHandler{ScanUsecase: scanUsecase}
+}
+
+// UploadScan godoc
+// @Summary Upload identity document scan
+// @Description Replace the scan of one side of an identity document with a JPEG, PNG or PDF file of at most 10 MiB. Images are stripped of their metadata. The document goes back to review. Users can upload scans of their own documents, admins of any user's.
+// @Accept multipart/form-data
+// @Produce json
+// @Tags user_identity
+// @Param id path int true "User ID"
+// @Param identityId path int true "Identity ID"
+// @Param side path string true "Side of the document" Enums(front, back)
+// @Param file formData file true "JPEG, PNG or PDF file"
+// @Success 200 {object} dto.FileURLResponse
+// @Failure 400 {object} apperror.Response "Invalid side or no file in the form"
+// @Failure 403 {object} apperror.Response "Not the user nor an admin"
+// @Failure 404 {object} apperror.Response "User identity not found"
+// @Failure 413 {object} apperror.Response "File is too large"
+// @Failure 415 {object} apperror.Response "File is not a JPEG, PNG or PDF file"
+// @Security bearerToken
+// @Router /api/v1/users/{id}/identities/{identityId}/scans/{side} [put]
+func (h *ScanHandler) UploadScan(c *gin.Context) {
+	userID, identityID, ok := scanParams(c)
+	if !ok {
+		return
+	}
+	file, err := upload.FormFile(c, "file", upload.DocumentPolicy)
+	if err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+
+	response, err := h.ScanUsecase.UploadScan(userID, identityID, c.Param("side"), file)
+	if err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetScan godoc
+// @Summary Get identity document scan
+// @Description Short-lived link to the scan of one side of an identity document. Users can see the scans of their own documents, admins of any user's.
+// @Produce json
+// @Tags user_identity
+// @Param id path int true "User ID"
+// @Param identityId path int true "Identity ID"
+// @Param side path string true "Side of the document" Enums(front, back)
+// @Success 200 {object} dto.FileURLResponse
+// @Failure 403 {object} apperror.Response "Not the user nor an admin"
+// @Failure 404 {object} apperror.Response "User identity or scan not found"
+// @Security bearerToken
+// @Router /api/v1/users/{id}/identities/{identityId}/scans/{side} [get]
+func (h *ScanHandler) GetScan(c *gin.Context) {
+	userID, identityID, ok := scanParams(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.ScanUsecase.ScanURL(userID, identityID, c.Param("side"))
+	if err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func scanParams(c *gin.Context) (userID int, identityID int, ok bool) {
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apperror.Abort(c, apperror.New(apperror.Validation, "Invalid user ID"))
+		return 0, 0, false
+	}
+	identityID, err = strconv.Atoi(c.Param("identityId"))
+	if err != nil {
+		apperror.Abort(c, apperror.New(apperror.Validation, "Invalid identity ID"))
+		return 0, 0, false
+	}
+	return userID, identityID, true
+}
@@ -0,0 +1,83 @@
+package transport
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/upload"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user_identity/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user_identity/dto"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockScanUsecase struct {
+	mock.Mock
+}
+
+func (m *MockScanUsecase) UploadScan(userID int, identityID int, side string, file *upload.File) (*dto.FileURLResponse, error) {
+	args := m.Called(userID, identityID, side, file)
+	response, _ := args.Get(0).(*dto.FileURLResponse)
+	return response, args.Error(1)
+}
+
+func (m *MockScanUsecase) ScanURL(userID int, identityID int, side string) (*dto.FileURLResponse, error) {
+	args := m.Called(userID, identityID, side)
+	response, _ := args.Get(0).(*dto.FileURLResponse)
+	return response, args.Error(1)
+}
+
+func TestScanHandler(t *testing.T) {
+	mockUsecase := new(MockScanUsecase)
+	handler := NewScanHandler(mockUsecase)
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(apperror.Middleware())
+	r.PUT("/api/v1/users/:id/identities/:identityId/scans/:side", handler.UploadScan)
+	r.GET("/api/v1/users/:id/identities/:identityId/scans/:side", handler.GetScan)
+
+	response := &dto.FileURLResponse{URL: "https://files.example.com/scan?signature=abc", ExpiresAt: time.Now()}
+	serve := func(req *http.Request) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("upload", func(t *testing.T) {
+		mockUsecase.On("UploadScan", 7, 1, "front", mock.MatchedBy(func(file *upload.File) bool {
+			return file.ContentType == upload.TypePDF
+		})).Return(response, nil).Once()
+
+		var body bytes.Buffer
+		form := multipart.NewWriter(&body)
+		part, _ := form.CreateFormFile("file", "passport.pdf")
+		part.Write([]byte("%PDF-1.4\n%%EOF\n"))
+		form.Close()
+		req, _ := http.NewRequest(http.MethodPut, "/api/v1/users/7/identities/1/scans/front", &body)
+		req.Header.Set("Content-Type", form.FormDataContentType())
+
+		w := serve(req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), response.URL)
+	})
+
+	t.Run("get", func(t *testing.T) {
+		mockUsecase.On("ScanURL", 7, 1, "back").Return(nil, domain.ErrScanNotFound).Once()
+
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/users/7/identities/1/scans/back", nil)
+		assert.Equal(t, http.StatusNotFound, serve(req).Code)
+	})
+
+	t.Run("invalid identity ID", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/users/7/identities/abc/scans/front", nil)
+		assert.Equal(t, http.StatusBadRequest, serve(req).Code)
+	})
+
+	mockUsecase.AssertExpectations(t)
+}
@@ -4,9 +4,12 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user_identity/dto"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user_identity/usecase"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/validation"
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 )
 
 type UserIdentityHandler struct {
@@ -19,21 +22,26 @@ func NewUserIdentityHandler(userIdentityUsecase usecase.UserIdentityUsecaseInter
 
 // CreateUserIdentity godoc
 // @Summary Create user identity
-// @Description Create user identity
+// @Description Create user identity. Users can add documents for themselves, admins for anyone.
 // @Produce json
 // @Tags user_identity
 // @Param request body dto.CreateUserIdentityRequest true "Create User Identity Request"
 // @Success 201 {string} message "User identity created successfully"
+// @Failure 400 {object} apperror.Response "Invalid input, with the rejected fields in details"
+// @Failure 403 {object} apperror.Response "Not the user nor an admin"
+// @Failure 409 {object} apperror.Response "Identity document is already registered"
+// @Security bearerToken
 // @Router /api/v1/applicant-identity/ [post]
 func (h *UserIdentityHandler) CreateUserIdentity(c *gin.Context) {
 	var request dto.CreateUserIdentityRequest
-	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	// the body may have been read by RequestedOwner already
+	if err := c.ShouldBindBodyWith(&request, binding.JSON); err != nil {
+		apperror.Abort(c, validation.Error(err))
 		return
 	}
 
 	if err := h.UserIdentityUsecase.CreateUserIdentity(request); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apperror.Abort(c, err)
 		return
 	}
 
@@ -42,28 +50,32 @@ func (h *UserIdentityHandler) CreateUserIdentity(c *gin.Context) {
 
 // UpdateUserIdentity godoc
 // @Summary Update user identity
-// @Description Update user identity
+// @Description Update user identity. Users can update their own documents, admins anyone's.
 // @Produce json
 // @Tags user_identity
 // @Param id path int true "Identity ID"
 // @Param request body dto.UpdateUserIdentityRequest true "Update User Identity Request"
 // @Success 200 {string} message "User identity updated successfully"
+// @Failure 400 {object} apperror.Response "Invalid input, with the rejected fields in details"
+// @Failure 403 {object} apperror.Response "Not the owner nor an admin"
+// @Failure 409 {object} apperror.Response "Identity document is already registered"
+// @Security bearerToken
 // @Router /api/v1/applicant-identity/{id} [put]
 func (h *UserIdentityHandler) UpdateUserIdentity(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid identity ID"})
+		apperror.Abort(c, apperror.New(apperror.Validation, "Invalid identity ID"))
 		return
 	}
 
 	var request dto.UpdateUserIdentityRequest
 	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apperror.Abort(c, validation.Error(err))
 		return
 	}
 
 	if err := h.UserIdentityUsecase.UpdateUserIdentity(id, request); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apperror.Abort(c, err)
 		return
 	}
 
@@ -72,24 +84,181 @@ func (h *UserIdentityHandler) UpdateUserIdentity(c *gin.Context) {
 
 // FindUserIdentity godoc
 // @Summary Find user identity
-// @Description Find user identity
+// @Description Find user identity. Users can read their own documents, admins anyone's.
 // @Produce json
 // @Tags user_identity
 // @Param id path int true "Identity ID"
 // @Success 200 {object} dto.UserIdentityResponse
+// @Failure 403 {object} apperror.Response "Not the owner nor an admin"
+// @Security bearerToken
 // @Router /api/v1/applicant-identity/{id} [get]
 func (h *UserIdentityHandler) FindUserIdentity(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid identity ID"})
+		apperror.Abort(c, apperror.New(apperror.Validation, "Invalid identity ID"))
 		return
 	}
 
 	identity, err := h.UserIdentityUsecase.FindUserIdentityByID(id)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apperror.Abort(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, identity)
 }
+
+// ListUserIdentities godoc
+// @Summary List user identities
+// @Description Identity documents of a user, oldest first. Users can list their own documents, admins any user's.
+// @Produce json
+// @Tags user_identity
+// @Param id path int true "User ID"
+// @Success 200 {array} dto.UserIdentityResponse
+// @Failure 403 {object} apperror.Response "Not the user nor an admin"
+// @Security bearerToken
+// @Router /api/v1/users/{id}/identities [get]
+func (h *UserIdentityHandler) ListUserIdentities(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apperror.Abort(c, apperror.New(apperror.Validation, "Invalid user ID"))
+		return
+	}
+
+	identities, err := h.UserIdentityUsecase.ListUserIdentities(userID)
+	if err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, identities)
+}
+
+// VerifyUserIdentity godoc
+// @Summary Verify user identity
+// @Description Accept a pending identity document that has not expired
+// @Produce json
+// @Tags admin
+// @Param id path int true "Identity ID"
+// @Success 200 {string} message "User identity verified successfully"
+// @Failure 404 {object} apperror.Response "User identity not found"
+// @Failure 409 {object} apperror.Response "Identity document was already reviewed"
+// @Failure 422 {object} apperror.Response "Identity document has expired"
+// @Security bearerToken
+// @Router /api/v1/admin/identities/{id}/verify [post]
+func (h *UserIdentityHandler) VerifyUserIdentity(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apperror.Abort(c, apperror.New(apperror.Validation, "Invalid identity ID"))
+		return
+	}
+	userId, exists := c.Get("userId")
+	if !exists {
+		apperror.Abort(c, apperror.ErrUnauthorized)
+		return
+	}
+
+	if err := h.UserIdentityUsecase.VerifyUserIdentity(id, userId.(int)); err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "User identity verified successfully"})
+}
+
+// RejectUserIdentity godoc
+// @Summary Reject user identity
+// @Description Turn down a pending identity document
+// @Accept json
+// @Produce json
+// @Tags admin
+// @Param id path int true "Identity ID"
+// @Param request body dto.RejectUserIdentityRequest true "Why the document is rejected"
+// @Success 200 {string} message "User identity rejected successfully"
+// @Failure 404 {object} apperror.Response "User identity not found"
+// @Failure 409 {object} apperror.Response "Identity document was already reviewed"
+// @Security bearerToken
+// @Router /api/v1/admin/identities/{id}/reject [post]
+func (h *UserIdentityHandler) RejectUserIdentity(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apperror.Abort(c, apperror.New(apperror.Validation, "Invalid identity ID"))
+		return
+	}
+	userId, exists := c.Get("userId")
+	if !exists {
+		apperror.Abort(c, apperror.ErrUnauthorized)
+		return
+	}
+	var request dto.RejectUserIdentityRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		apperror.Abort(c, validation.Error(err))
+		return
+	}
+
+	if err := h.UserIdentityUsecase.RejectUserIdentity(id, userId.(int), request); err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "User identity rejected successfully"})
+}
+
+// RevealIdentityNumber godoc
+// @Summary Reveal identity number
+// @Description Full number of an identity document. Every reveal is recorded in the audit log, and the number is not revealed when it cannot be recorded.
+// @Produce json
+// @Tags admin
+// @Param id path int true "Identity ID"
+// @Success 200 {object} dto.IdentityNumberResponse
+// @Failure 404 {object} apperror.Response "User identity not found"
+// @Security bearerToken
+// @Router /api/v1/admin/identities/{id}/reveal-number [post]
+func (h *UserIdentityHandler) RevealIdentityNumber(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apperror.Abort(c, apperror.New(apperror.Validation, "Invalid identity ID"))
+		return
+	}
+	userId, exists := c.Get("userId")
+	if !exists {
+		apperror.Abort(c, apperror.ErrUnauthorized)
+		return
+	}
+
+	response, err := h.UserIdentityUsecase.RevealNumber(id, userId.(int))
+	if err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, response)
+}
+
+// Owner finds the user of the document in the id path parameter, for
+// middleware.RequireOwnerOrRole.
+func (h *UserIdentityHandler) Owner(c *gin.Context) (int, error) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return 0, apperror.New(apperror.Validation, "Invalid identity ID")
+	}
+	identity, err := h.UserIdentityUsecase.FindUserIdentityByID(id)
+	if err != nil {
+		return 0, err
+	}
+	return identity.UserID, nil
+}
+
+// RequestedOwner reads the user a document is created for from the request
+// body, for middleware.RequireOwnerOrRole. The body is kept for
+// CreateUserIdentity.
+func (h *UserIdentityHandler) RequestedOwner(c *gin.Context) (int, error) {
+	var request struct {
+		UserID int `json:"user_id"`
+	}
+	if err := c.ShouldBindBodyWith(&request, binding.JSON); err != nil {
+		return 0, apperror.Wrap(apperror.Validation, err)
+	}
+	return request.UserID, nil
+}
@@ -1,12 +1,14 @@
 package transport
 
 import (
-	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
 
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/middleware"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user_identity/domain"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user_identity/dto"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -29,7 +31,29 @@ func (m *MockUserIdentityUsecase) UpdateUserIdentity(id int, input dto.UpdateUse
 
 func (m *MockUserIdentityUsecase) FindUserIdentityByID(id int) (*dto.UserIdentityResponse, error) {
 	args := m.Called(id)
-	return args.Get(0).(*dto.UserIdentityResponse), args.Error(1)
+	response, _ := args.Get(0).(*dto.UserIdentityResponse)
+	return response, args.Error(1)
+}
+
+func (m *MockUserIdentityUsecase) ListUserIdentities(userID int) ([]dto.UserIdentityResponse, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]dto.UserIdentityResponse), args.Error(1)
+}
+
+func (m *MockUserIdentityUsecase) VerifyUserIdentity(id int, adminID int) error {
+	args := m.Called(id, adminID)
+	return args.Error(0)
+}
+
+func (m *MockUserIdentityUsecase) RejectUserIdentity(id int, adminID int, request dto.RejectUserIdentityRequest) error {
+	args := m.Called(id, adminID, request)
+	return args.Error(0)
+}
+
+func (m *MockUserIdentityUsecase) RevealNumber(id int, adminID int) (*dto.IdentityNumberResponse, error) {
+	args := m.Called(id, adminID)
+	response, _ := args.Get(0).(*dto.IdentityNumberResponse)
+	return response, args.Error(1)
 }
 
 func TestCreateUserIdentity(t *testing.T) {
@@ -37,20 +61,20 @@ func TestCreateUserIdentity(t *testing.T) {
 	handler := NewUserIdentityHandler(mockUsecase)
 	gin.SetMode(gin.TestMode)
 	r := gin.Default()
+	r.Use(apperror.Middleware())
 	r.POST("/api/v1/user-identity", handler.CreateUserIdentity)
 
 	t.Run("success", func(t *testing.T) {
 		mockInput := dto.CreateUserIdentityRequest{
 			UserID:      2,
 			Number:      "123456789",
-			Type:        "Citizen ID",
-			Status:      0,
-			ExpiryDate:  "12-12-2025",
+			Type:        domain.DocumentTypePassport,
+			ExpiryDate:  "2099-12-12",
 			PlaceIssued: "Some city",
 		}
 		mockUsecase.On("CreateUserIdentity", mockInput).Return(nil)
 
-		body := `{"UserID":2,"Number":"123456789","Type":"Citizen ID","Status":"Approved","Expiry Date":"12-12-2025","PlaceIssued":"Some city"}`
+		body := `{"user_id":2,"number":"123456789","type":"passport","expiry_date":"2099-12-12","place_issued":"Some city"}`
 		req, err := http.NewRequest(http.MethodPost, "/api/v1/user-identity", strings.NewReader(body))
 		assert.NoError(t, err)
 		req.Header.Set("Content-Type", "application/json")
@@ -63,7 +87,7 @@ func TestCreateUserIdentity(t *testing.T) {
 	})
 
 	t.Run("bad request", func(t *testing.T) {
-		body := `{"UserID":abc,"Number":"123456789","Type":"Citizen ID","Status":"Approved","Expiry Date":"12-12-2025","PlaceIssued":"Some city"}`
+		body := `{"user_id":abc,"number":"123456789","type":"passport","expiry_date":"2099-12-12","place_issued":"Some city"}`
 		req, err := http.NewRequest(http.MethodPost, "/api/v1/user-identity", strings.NewReader(body))
 		assert.NoError(t, err)
 		req.Header.Set("Content-Type", "application/json")
@@ -75,25 +99,80 @@ func TestCreateUserIdentity(t *testing.T) {
 	})
 }
 
+type fakeRoles map[int]string
+
+func (f fakeRoles) RoleCode(roleID int) (string, error) {
+	code, ok := f[roleID]
+	if !ok {
+		return "", apperror.New(apperror.NotFound, "role not found")
+	}
+	return code, nil
+}
+
+func TestUserIdentityOwnership(t *testing.T) {
+	mockUsecase := new(MockUserIdentityUsecase)
+	handler := NewUserIdentityHandler(mockUsecase)
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(apperror.Middleware())
+	// user 2 is an applicant, user 3 an admin
+	login := func(c *gin.Context) {
+		userID := 2
+		if c.GetHeader("X-Admin") != "" {
+			userID = 3
+		}
+		c.Set("userId", userID)
+		c.Set("roleId", userID)
+	}
+	roles := fakeRoles{2: "applicant", 3: "admin"}
+	r.POST("/api/v1/applicant-identity/", login, middleware.RequireOwnerOrRole(roles, handler.RequestedOwner, "admin"), handler.CreateUserIdentity)
+	r.GET("/api/v1/applicant-identity/:id", login, middleware.RequireOwnerOrRole(roles, handler.Owner, "admin"), handler.FindUserIdentity)
+
+	mockUsecase.On("CreateUserIdentity", mock.Anything).Return(nil)
+	mockUsecase.On("FindUserIdentityByID", 1).Return(&dto.UserIdentityResponse{ID: 1, UserID: 2}, nil)
+	mockUsecase.On("FindUserIdentityByID", 2).Return(&dto.UserIdentityResponse{ID: 2, UserID: 5}, nil)
+
+	serve := func(method string, path string, body string, admin bool) int {
+		req, _ := http.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if admin {
+			req.Header.Set("X-Admin", "1")
+		}
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr.Code
+	}
+	document := func(userID string) string {
+		return `{"user_id":` + userID + `,"number":"123456789","type":"passport","expiry_date":"2099-12-12","place_issued":"Some city"}`
+	}
+
+	assert.Equal(t, http.StatusCreated, serve(http.MethodPost, "/api/v1/applicant-identity/", document("2"), false))
+	assert.Equal(t, http.StatusForbidden, serve(http.MethodPost, "/api/v1/applicant-identity/", document("5"), false))
+	assert.Equal(t, http.StatusCreated, serve(http.MethodPost, "/api/v1/applicant-identity/", document("5"), true))
+	assert.Equal(t, http.StatusOK, serve(http.MethodGet, "/api/v1/applicant-identity/1", "", false))
+	assert.Equal(t, http.StatusForbidden, serve(http.MethodGet, "/api/v1/applicant-identity/2", "", false))
+	assert.Equal(t, http.StatusOK, serve(http.MethodGet, "/api/v1/applicant-identity/2", "", true))
+	mockUsecase.AssertNumberOfCalls(t, "CreateUserIdentity", 2)
+}
+
 func TestUpdateUserIdentity(t *testing.T) {
 	mockUsecase := new(MockUserIdentityUsecase)
 	handler := NewUserIdentityHandler(mockUsecase)
 	gin.SetMode(gin.TestMode)
 	r := gin.Default()
+	r.Use(apperror.Middleware())
 	r.PUT("/api/v1/user-identity/:id", handler.UpdateUserIdentity)
 
 	t.Run("success", func(t *testing.T) {
 		mockInput := dto.UpdateUserIdentityRequest{
-			UserID:      2,
 			Number:      "123888789",
-			Type:        "Passport",
-			Status:      0,
-			ExpiryDate:  "12-12-2025",
+			Type:        domain.DocumentTypePassport,
+			ExpiryDate:  "2099-12-12",
 			PlaceIssued: "Another city",
 		}
 		mockUsecase.On("UpdateUserIdentity", 1, mockInput).Return(nil)
 
-		body := `{"UserID":2,"Number":"123888789","Type":"Passport","Status":"Approved","Expiry Date":"12-12-2025","PlaceIssued":"Another city"}`
+		body := `{"number":"123888789","type":"passport","expiry_date":"2099-12-12","place_issued":"Another city"}`
 		req, err := http.NewRequest(http.MethodPut, "/api/v1/user-identity/1", strings.NewReader(body))
 		assert.NoError(t, err)
 		req.Header.Set("Content-Type", "application/json")
@@ -106,7 +185,7 @@ func TestUpdateUserIdentity(t *testing.T) {
 	})
 
 	t.Run("bad request", func(t *testing.T) {
-		body := `{"UserID":abc,"Number":"123888789","Type":"Passport","Status":"Approved","Expiry Date":"12-12-2025","PlaceIssued":"Another city"}`
+		body := `{"number":123888789,"type":"passport","expiry_date":"2099-12-12","place_issued":"Another city"}`
 		req, err := http.NewRequest(http.MethodPut, "/api/v1/user-identity/1", strings.NewReader(body))
 		assert.NoError(t, err)
 		req.Header.Set("Content-Type", "application/json")
@@ -123,19 +202,20 @@ func TestFindUserIdentity(t *testing.T) {
 	handler := NewUserIdentityHandler(mockUsecase)
 	gin.SetMode(gin.TestMode)
 	r := gin.Default()
+	r.Use(apperror.Middleware())
 	r.GET("/api/v1/user-identity/:id", handler.FindUserIdentity)
 
 	t.Run("success", func(t *testing.T) {
 		mockUserIdentity := &dto.UserIdentityResponse{
 			ID:          1,
 			UserID:      2,
-			Number:      "123456789",
-			Type:        "Citizen ID",
+			Number:      "*****6789",
+			Type:        domain.DocumentTypePassport,
 			Status:      0,
-			ExpiryDate:  "12-12-2025",
+			ExpiryDate:  "2099-12-12",
 			PlaceIssued: "Some city",
 		}
-		mockUsecase.On("FindUserIdentity", 1).Return(mockUserIdentity, nil)
+		mockUsecase.On("FindUserIdentityByID", 1).Return(mockUserIdentity, nil)
 
 		req, err := http.NewRequest(http.MethodGet, "/api/v1/user-identity/1", nil)
 		assert.NoError(t, err)
@@ -148,9 +228,9 @@ func TestFindUserIdentity(t *testing.T) {
 	})
 
 	t.Run("not found", func(t *testing.T) {
-		mockUsecase.On("FindUserIdentity", 1).Return(nil, errors.New("user identity not found"))
+		mockUsecase.On("FindUserIdentityByID", 2).Return(nil, apperror.New(apperror.NotFound, "user identity not found"))
 
-		req, err := http.NewRequest(http.MethodGet, "/api/v1/user-identity/1", nil)
+		req, err := http.NewRequest(http.MethodGet, "/api/v1/user-identity/2", nil)
 		assert.NoError(t, err)
 
 		rr := httptest.NewRecorder()
@@ -159,3 +239,104 @@ func TestFindUserIdentity(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, rr.Code)
 	})
 }
+
+func TestListUserIdentities(t *testing.T) {
+	mockUsecase := new(MockUserIdentityUsecase)
+	handler := NewUserIdentityHandler(mockUsecase)
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.Use(apperror.Middleware())
+	r.GET("/api/v1/users/:id/identities", handler.ListUserIdentities)
+
+	mockUsecase.On("ListUserIdentities", 2).Return([]dto.UserIdentityResponse{
+		{ID: 1, UserID: 2, Number: "123456789", Type: domain.DocumentTypePassport, ExpiryDate: "2030-12-12", PlaceIssued: "Some city"},
+	}, nil)
+
+	req, err := http.NewRequest(http.MethodGet, "/api/v1/users/2/identities", nil)
+	assert.NoError(t, err)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"type":"passport"`)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestReviewUserIdentity(t *testing.T) {
+	mockUsecase := new(MockUserIdentityUsecase)
+	handler := NewUserIdentityHandler(mockUsecase)
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.Use(apperror.Middleware())
+	setAdmin := func(c *gin.Context) { c.Set("userId", 3) }
+	r.POST("/api/v1/admin/identities/:id/verify", setAdmin, handler.VerifyUserIdentity)
+	r.POST("/api/v1/admin/identities/:id/reject", setAdmin, handler.RejectUserIdentity)
+
+	serve := func(path string, body string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		assert.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("verify", func(t *testing.T) {
+		mockUsecase.On("VerifyUserIdentity", 1, 3).Return(nil).Once()
+		assert.Equal(t, http.StatusOK, serve("/api/v1/admin/identities/1/verify", "").Code)
+	})
+
+	t.Run("verify expired", func(t *testing.T) {
+		mockUsecase.On("VerifyUserIdentity", 2, 3).Return(domain.ErrIdentityExpired).Once()
+		assert.Equal(t, http.StatusUnprocessableEntity, serve("/api/v1/admin/identities/2/verify", "").Code)
+	})
+
+	t.Run("reject", func(t *testing.T) {
+		request := dto.RejectUserIdentityRequest{Reason: "blurry scan"}
+		mockUsecase.On("RejectUserIdentity", 1, 3, request).Return(nil).Once()
+		assert.Equal(t, http.StatusOK, serve("/api/v1/admin/identities/1/reject", `{"reason":"blurry scan"}`).Code)
+	})
+
+	t.Run("reject without reason", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, serve("/api/v1/admin/identities/1/reject", `{}`).Code)
+	})
+
+	t.Run("already reviewed", func(t *testing.T) {
+		mockUsecase.On("VerifyUserIdentity", 4, 3).Return(domain.ErrIdentityNotPending).Once()
+		assert.Equal(t, http.StatusConflict, serve("/api/v1/admin/identities/4/verify", "").Code)
+	})
+
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestRevealIdentityNumber(t *testing.T) {
+	mockUsecase := new(MockUserIdentityUsecase)
+	handler := NewUserIdentityHandler(mockUsecase)
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.Use(apperror.Middleware())
+	r.POST("/api/v1/admin/identities/:id/reveal-number", func(c *gin.Context) { c.Set("userId", 3) }, handler.RevealIdentityNumber)
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest(http.MethodPost, path, nil)
+		assert.NoError(t, err)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("revealed", func(t *testing.T) {
+		mockUsecase.On("RevealNumber", 1, 3).Return(&dto.IdentityNumberResponse{ID: 1, Number: "B1234567"}, nil).Once()
+		rr := serve("/api/v1/admin/identities/1/reveal-number")
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"id":1,"number":"B1234567"}`, rr.Body.String())
+		assert.Equal(t, "no-store", rr.Header().Get("Cache-Control"))
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockUsecase.On("RevealNumber", 9, 3).Return(nil, domain.ErrUserIdentityNotFound).Once()
+		assert.Equal(t, http.StatusNotFound, serve("/api/v1/admin/identities/9/reveal-number").Code)
+	})
+
+	mockUsecase.AssertExpectations(t)
+}
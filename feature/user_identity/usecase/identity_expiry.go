@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"log"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user_identity/domain"
+)
+
+const identityExpiringTemplate = "identity_expiring"
+
+var documentTypeLabels = map[string]string{
+	domain.DocumentTypePassport:      "passport",
+	domain.DocumentTypeNationalID:    "national ID",
+	domain.DocumentTypeDriverLicence: "driver licence",
+}
+
+// ExpiryRepository finds the documents that expired or are about to.
+type ExpiryRepository interface {
+	MarkExpiredIdentities(today time.Time) (int64, error)
+	ListExpiringIdentities(today, until time.Time) ([]*domain.ExpiringIdentity, error)
+	MarkIdentityAlerted(id int, at time.Time) error
+}
+
+// TemplateSender renders a named mail template and sends it.
+type TemplateSender interface {
+	SendTemplate(to string, name string, data interface{}) error
+}
+
+// ExpiryReport counts what a run of ExpiryChecker did. Failed counts the
+// alerts that could not be sent; they are retried on the next run.
+type ExpiryReport struct {
+	Expired int64
+	Alerted int
+	Failed  int
+}
+
+// ExpiryChecker invalidates expired documents and alerts the owners of
+// documents that expire soon. It is meant to run once a day.
+type ExpiryChecker struct {
+	repo   ExpiryRepository
+	mailer TemplateSender
+}
+
+func NewExpiryChecker(repo ExpiryRepository, mailer TemplateSender) *ExpiryChecker {
+	return &ExpiryChecker{repo: repo, mailer: mailer}
+}
+
+// Check marks the documents that expired before now as expired, then alerts,
+// once per document, the owners of verified documents that expire within
+// days.
+func (c *ExpiryChecker) Check(now time.Time, days int) (*ExpiryReport, error) {
+	today := domain.Today(now)
+	expired, err := c.repo.MarkExpiredIdentities(today)
+	if err != nil {
+		return nil, err
+	}
+	report := &ExpiryReport{Expired: expired}
+
+	expiring, err := c.repo.ListExpiringIdentities(today, today.AddDate(0, 0, days))
+	if err != nil {
+		return report, err
+	}
+	for _, identity := range expiring {
+		err := c.mailer.SendTemplate(identity.Email, identityExpiringTemplate, map[string]interface{}{
+			"Name":         identity.Name,
+			"DocumentType": documentTypeLabel(identity.Type),
+			"ExpiryDate":   identity.ExpiryDate.Format("2006-01-02"),
+		})
+		if err == nil {
+			err = c.repo.MarkIdentityAlerted(identity.ID, now)
+		}
+		if err != nil {
+			log.Printf("alert expiring identity %d of user %d: %v", identity.ID, identity.UserID, err)
+			report.Failed++
+			continue
+		}
+		report.Alerted++
+	}
+	return report, nil
+}
+
+// documentTypeLabel names a document type in emails. Documents added before
+// the types were fixed keep their free-form type.
+func documentTypeLabel(documentType string) string {
+	if label, ok := documentTypeLabels[documentType]; ok {
+		return label
+	}
+	return documentType
+}
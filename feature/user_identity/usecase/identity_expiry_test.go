@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user_identity/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockExpiryRepository struct {
+	mock.Mock
+}
+
+func (m *MockExpiryRepository) MarkExpiredIdentities(today time.Time) (int64, error) {
+	args := m.Called(today)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockExpiryRepository) ListExpiringIdentities(today, until time.Time) ([]*domain.ExpiringIdentity, error) {
+	args := m.Called(today, until)
+	return args.Get(0).([]*domain.ExpiringIdentity), args.Error(1)
+}
+
+func (m *MockExpiryRepository) MarkIdentityAlerted(id int, at time.Time) error {
+	args := m.Called(id, at)
+	return args.Error(0)
+}
+
+type MockTemplateSender struct {
+	mock.Mock
+}
+
+func (m *MockTemplateSender) SendTemplate(to string, name string, data interface{}) error {
+	args := m.Called(to, name, data)
+	return args.Error(0)
+}
+
+func TestExpiryCheckerCheck(t *testing.T) {
+	now := time.Date(2024, 5, 1, 15, 30, 0, 0, time.UTC)
+	today := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC)
+
+	repo := new(MockExpiryRepository)
+	sender := new(MockTemplateSender)
+	checker := NewExpiryChecker(repo, sender)
+
+	repo.On("MarkExpiredIdentities", today).Return(int64(2), nil)
+	repo.On("ListExpiringIdentities", today, until).Return([]*domain.ExpiringIdentity{
+		{ID: 1, UserID: 7, Type: domain.DocumentTypeNationalID, ExpiryDate: time.Date(2024, 5, 20, 0, 0, 0, 0, time.UTC), Email: "jane@example.com", Name: "Jane"},
+		{ID: 2, UserID: 8, Type: domain.DocumentTypePassport, ExpiryDate: time.Date(2024, 5, 25, 0, 0, 0, 0, time.UTC), Email: "john@example.com", Name: "John"},
+	}, nil)
+	sender.On("SendTemplate", "jane@example.com", "identity_expiring", map[string]interface{}{
+		"Name":         "Jane",
+		"DocumentType": "national ID",
+		"ExpiryDate":   "2024-05-20",
+	}).Return(nil)
+	sender.On("SendTemplate", "john@example.com", "identity_expiring", mock.Anything).Return(errors.New("smtp unavailable"))
+	repo.On("MarkIdentityAlerted", 1, now).Return(nil)
+
+	report, err := checker.Check(now, 30)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &ExpiryReport{Expired: 2, Alerted: 1, Failed: 1}, report)
+	repo.AssertExpectations(t)
+	repo.AssertNotCalled(t, "MarkIdentityAlerted", 2, now)
+	sender.AssertExpectations(t)
+}
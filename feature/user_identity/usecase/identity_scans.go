@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/blob"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/upload"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user_identity/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user_identity/dto"
+)
+
+// scanURLTTL is how long a link to a document scan stays valid. Scans are
+// sensitive, so links are meant to be opened right away.
+const scanURLTTL = 5 * time.Minute
+
+type ScanUsecaseInterface interface {
+	UploadScan(userID int, identityID int, side string, file *upload.File) (*dto.FileURLResponse, error)
+	ScanURL(userID int, identityID int, side string) (*dto.FileURLResponse, error)
+}
+
+// ScanRepository loads and saves the document a scan belongs to.
+type ScanRepository interface {
+	FindUserIdentityByID(id int) (*domain.UserIdentity, error)
+	UpdateUserIdentity(identity *domain.UserIdentity) error
+}
+
+type ScanUsecase struct {
+	repo  ScanRepository
+	files blob.BlobStore
+	now   func() time.Time
+}
+
+func NewScanUsecase(repo ScanRepository, files blob.BlobStore) *ScanUsecase {
+	return &ScanUsecase{repo: repo, files: files, now: time.Now}
+}
+
+// UploadScan stores the scan of one side of a document of userID, replacing
+// the previous scan of that side. The document goes back to review.
+func (u *ScanUsecase) UploadScan(userID int, identityID int, side string, file *upload.File) (*dto.FileURLResponse, error) {
+	identity, err := u.findOwned(userID, identityID)
+	if err != nil {
+		return nil, err
+	}
+	previous, err := identity.Scan(side)
+	if err != nil {
+		return nil, err
+	}
+	key, err := blob.NewKey(fmt.Sprintf("identities/%d/%d", userID, identityID), file.Ext)
+	if err != nil {
+		return nil, err
+	}
+	if err := identity.SetScan(side, key); err != nil {
+		return nil, err
+	}
+	if err := u.files.Put(key, file.Data, file.ContentType); err != nil {
+		return nil, err
+	}
+	if err := u.repo.UpdateUserIdentity(identity); err != nil {
+		if deleteErr := u.files.Delete(key); deleteErr != nil {
+			log.Printf("delete orphaned scan %s: %v", key, deleteErr)
+		}
+		return nil, err
+	}
+	// The new scan is in place, so a leftover file is only logged.
+	if previous != nil {
+		if err := u.files.Delete(*previous); err != nil {
+			log.Printf("delete previous scan %s: %v", *previous, err)
+		}
+	}
+	return u.signedURL(key)
+}
+
+// ScanURL returns a link to the scan of one side of a document of userID.
+func (u *ScanUsecase) ScanURL(userID int, identityID int, side string) (*dto.FileURLResponse, error) {
+	identity, err := u.findOwned(userID, identityID)
+	if err != nil {
+		return nil, err
+	}
+	key, err := identity.Scan(side)
+	if err != nil {
+		return nil, err
+	}
+	if key == nil {
+		return nil, domain.ErrScanNotFound
+	}
+	return u.signedURL(*key)
+}
+
+// findOwned loads a document of userID. The documents of other users are
+// reported as not found.
+func (u *ScanUsecase) findOwned(userID int, identityID int) (*domain.UserIdentity, error) {
+	identity, err := u.repo.FindUserIdentityByID(identityID)
+	if err != nil {
+		return nil, err
+	}
+	if identity.UserID != userID {
+		return nil, domain.ErrUserIdentityNotFound
+	}
+	return identity, nil
+}
+
+func (u *ScanUsecase) signedURL(key string) (*dto.FileURLResponse, error) {
+	url, err := u.files.SignedURL(key, scanURLTTL)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.FileURLResponse{URL: url, ExpiresAt: u.now().Add(scanURLTTL).UTC()}, nil
+}
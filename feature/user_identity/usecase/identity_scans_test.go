@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/upload"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user_identity/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockBlobStore struct {
+	mock.Mock
+}
+
+func (m *MockBlobStore) Put(key string, data []byte, contentType string) error {
+	args := m.Called(key, data, contentType)
+	return args.Error(0)
+}
+
+func (m *MockBlobStore) Delete(key string) error {
+	args := m.Called(key)
+	return args.Error(0)
+}
+
+func (m *MockBlobStore) SignedURL(key string, ttl time.Duration) (string, error) {
+	args := m.Called(key, ttl)
+	return args.String(0), args.Error(1)
+}
+
+func isScanKey(key string) bool {
+	return strings.HasPrefix(key, "identities/7/1/") && strings.HasSuffix(key, ".pdf")
+}
+
+func TestUploadScan(t *testing.T) {
+	file := &upload.File{Data: []byte("%PDF-1.4"), ContentType: upload.TypePDF, Ext: ".pdf"}
+
+	t.Run("replaces the scan and resubmits the document", func(t *testing.T) {
+		repo := new(MockUserIdentityRepository)
+		files := new(MockBlobStore)
+		usecase := NewScanUsecase(repo, files)
+
+		previous, reviewer := "identities/7/1/old.jpg", 3
+		identity := &domain.UserIdentity{ID: 1, UserID: 7, Status: domain.IdentityStatusVerified, VerifiedBy: &reviewer, BackScan: &previous}
+		repo.On("FindUserIdentityByID", 1).Return(identity, nil)
+		files.On("Put", mock.MatchedBy(isScanKey), file.Data, upload.TypePDF).Return(nil)
+		repo.On("UpdateUserIdentity", mock.MatchedBy(func(identity *domain.UserIdentity) bool {
+			return identity.Status == domain.IdentityStatusPending && identity.VerifiedBy == nil && isScanKey(*identity.BackScan)
+		})).Return(nil)
+		files.On("Delete", previous).Return(nil)
+		files.On("SignedURL", mock.MatchedBy(isScanKey), scanURLTTL).Return("https://files.example.com/scan?signature=abc", nil)
+
+		response, err := usecase.UploadScan(7, 1, domain.ScanSideBack, file)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "https://files.example.com/scan?signature=abc", response.URL)
+		repo.AssertExpectations(t)
+		files.AssertExpectations(t)
+	})
+
+	t.Run("document of another user", func(t *testing.T) {
+		repo := new(MockUserIdentityRepository)
+		files := new(MockBlobStore)
+		usecase := NewScanUsecase(repo, files)
+
+		repo.On("FindUserIdentityByID", 1).Return(&domain.UserIdentity{ID: 1, UserID: 8}, nil)
+
+		_, err := usecase.UploadScan(7, 1, domain.ScanSideFront, file)
+
+		assert.ErrorIs(t, err, domain.ErrUserIdentityNotFound)
+		files.AssertNotCalled(t, "Put", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("invalid side", func(t *testing.T) {
+		repo := new(MockUserIdentityRepository)
+		usecase := NewScanUsecase(repo, new(MockBlobStore))
+
+		repo.On("FindUserIdentityByID", 1).Return(&domain.UserIdentity{ID: 1, UserID: 7}, nil)
+
+		_, err := usecase.UploadScan(7, 1, "middle", file)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidScanSide)
+	})
+}
+
+func TestScanURL(t *testing.T) {
+	repo := new(MockUserIdentityRepository)
+	files := new(MockBlobStore)
+	usecase := NewScanUsecase(repo, files)
+
+	front := "identities/7/1/abc.pdf"
+	repo.On("FindUserIdentityByID", 1).Return(&domain.UserIdentity{ID: 1, UserID: 7, FrontScan: &front}, nil)
+	files.On("SignedURL", front, scanURLTTL).Return("https://files.example.com/scan?signature=abc", nil)
+
+	response, err := usecase.ScanURL(7, 1, domain.ScanSideFront)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://files.example.com/scan?signature=abc", response.URL)
+
+	_, err = usecase.ScanURL(7, 1, domain.ScanSideBack)
+	assert.ErrorIs(t, err, domain.ErrScanNotFound)
+}
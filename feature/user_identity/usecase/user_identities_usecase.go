@@ -1,8 +1,14 @@
 package usecase
 
 import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+	auditUsecase "github.com/cesc1802/onboarding-and-volunteer-service/feature/audit/usecase"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user_identity/domain"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user_identity/dto"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user_identity/storage"
@@ -12,50 +18,81 @@ type UserIdentityUsecaseInterface interface {
 	CreateUserIdentity(request dto.CreateUserIdentityRequest) error
 	UpdateUserIdentity(id int, request dto.UpdateUserIdentityRequest) error
 	FindUserIdentityByID(id int) (*dto.UserIdentityResponse, error)
+	ListUserIdentities(userID int) ([]dto.UserIdentityResponse, error)
+	VerifyUserIdentity(id int, adminID int) error
+	RejectUserIdentity(id int, adminID int, request dto.RejectUserIdentityRequest) error
+	RevealNumber(id int, adminID int) (*dto.IdentityNumberResponse, error)
+}
+
+// AuditRecorder appends the changes made by admins to the audit log.
+type AuditRecorder interface {
+	Record(entry auditUsecase.Entry) error
 }
 
 type UserIdentityUsecase struct {
 	UserIdentityRepo storage.UserIndentityRepositoryInterface
+	audit            AuditRecorder
 }
 
-func NewUserIdentityUsecase(userIdentityRepo storage.UserIndentityRepositoryInterface) *UserIdentityUsecase {
-	return &UserIdentityUsecase{UserIdentityRepo: userIdentityRepo}
+func NewUserIdentityUsecase(userIdentityRepo storage.UserIndentityRepositoryInterface, audit AuditRecorder) *UserIdentityUsecase {
+	return &UserIdentityUsecase{UserIdentityRepo: userIdentityRepo, audit: audit}
 }
 
+// CreateUserIdentity adds a document, pending review.
 func (u *UserIdentityUsecase) CreateUserIdentity(request dto.CreateUserIdentityRequest) error {
 	expiryDate, err := time.Parse("2006-01-02", request.ExpiryDate)
 	if err != nil {
-		return err
+		return apperror.Wrap(apperror.Validation, err)
 	}
 
 	identity := &domain.UserIdentity{
 		UserID:      request.UserID,
-		Number:      request.Number,
-		Type:        request.Type,
-		Status:      request.Status,
+		Status:      domain.IdentityStatusPending,
 		ExpiryDate:  expiryDate,
 		PlaceIssued: request.PlaceIssued,
 	}
+	if err := u.setNumber(identity, request.Type, request.Number); err != nil {
+		return err
+	}
 	return u.UserIdentityRepo.CreateUserIdentity(identity)
 }
 
+// UpdateUserIdentity replaces the details of a document and puts it back in
+// review. The document keeps its owner.
 func (u *UserIdentityUsecase) UpdateUserIdentity(id int, request dto.UpdateUserIdentityRequest) error {
 	expiryDate, err := time.Parse("2006-01-02", request.ExpiryDate)
+	if err != nil {
+		return apperror.Wrap(apperror.Validation, err)
+	}
+	identity, err := u.UserIdentityRepo.FindUserIdentityByID(id)
 	if err != nil {
 		return err
 	}
-	identity := &domain.UserIdentity{
-		ID:          id,
-		UserID:      request.UserID,
-		Number:      request.Number,
-		Type:        request.Type,
-		Status:      request.Status,
-		ExpiryDate:  expiryDate,
-		PlaceIssued: request.PlaceIssued,
+	if err := u.setNumber(identity, request.Type, request.Number); err != nil {
+		return err
 	}
+	identity.ExpiryDate = expiryDate
+	identity.PlaceIssued = request.PlaceIssued
+	identity.Resubmit()
 	return u.UserIdentityRepo.UpdateUserIdentity(identity)
 }
 
+// setNumber sets the type and number of a document unless another document
+// has them already.
+func (u *UserIdentityUsecase) setNumber(identity *domain.UserIdentity, documentType string, number string) error {
+	if err := identity.SetNumber(documentType, number); err != nil {
+		return err
+	}
+	exists, err := u.UserIdentityRepo.NumberIndexExists(identity.NumberIndex, identity.ID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return domain.ErrDuplicateIdentity
+	}
+	return nil
+}
+
 func (u *UserIdentityUsecase) FindUserIdentityByID(id int) (*dto.UserIdentityResponse, error) {
 
 	identity, err := u.UserIdentityRepo.FindUserIdentityByID(id)
@@ -63,15 +100,88 @@ func (u *UserIdentityUsecase) FindUserIdentityByID(id int) (*dto.UserIdentityRes
 		return nil, err
 	}
 
-	response := &dto.UserIdentityResponse{
-		ID:          identity.ID,
-		UserID:      identity.UserID,
-		Number:      identity.Number,
-		Type:        identity.Type,
-		Status:      identity.Status,
-		ExpiryDate:  identity.ExpiryDate.Format("2006-01-02"),
-		PlaceIssued: identity.PlaceIssued,
-	}
+	response := toUserIdentityResponse(identity)
+	return &response, nil
+}
 
+// ListUserIdentities returns the documents of a user.
+func (u *UserIdentityUsecase) ListUserIdentities(userID int) ([]dto.UserIdentityResponse, error) {
+	identities, err := u.UserIdentityRepo.ListUserIdentities(userID)
+	if err != nil {
+		return nil, err
+	}
+	response := make([]dto.UserIdentityResponse, 0, len(identities))
+	for _, identity := range identities {
+		response = append(response, toUserIdentityResponse(identity))
+	}
 	return response, nil
 }
+
+// VerifyUserIdentity accepts a pending document that has not expired.
+func (u *UserIdentityUsecase) VerifyUserIdentity(id int, adminID int) error {
+	return u.review("identity.verify", id, domain.IdentityStatusVerified, adminID, nil)
+}
+
+// RejectUserIdentity turns down a pending document.
+func (u *UserIdentityUsecase) RejectUserIdentity(id int, adminID int, request dto.RejectUserIdentityRequest) error {
+	reason := strings.TrimSpace(request.Reason)
+	return u.review("identity.reject", id, domain.IdentityStatusRejected, adminID, &reason)
+}
+
+// RevealNumber returns the full number of a document to an admin. Every
+// reveal is recorded in the audit log, and the number is not revealed when
+// it cannot be recorded.
+func (u *UserIdentityUsecase) RevealNumber(id int, adminID int) (*dto.IdentityNumberResponse, error) {
+	identity, err := u.UserIdentityRepo.FindUserIdentityByID(id)
+	if err != nil {
+		return nil, err
+	}
+	entry := auditUsecase.Entry{
+		ActorID:    &adminID,
+		Action:     "identity.reveal_number",
+		EntityType: "user_identity",
+		EntityID:   strconv.Itoa(id),
+		After:      map[string]interface{}{"user_id": identity.UserID},
+	}
+	if err := u.audit.Record(entry); err != nil {
+		return nil, fmt.Errorf("record audit event %s for %s %s: %w", entry.Action, entry.EntityType, entry.EntityID, err)
+	}
+	return &dto.IdentityNumberResponse{ID: identity.ID, Number: identity.Number}, nil
+}
+
+func (u *UserIdentityUsecase) review(action string, id int, status int, adminID int, reason *string) error {
+	before, after, err := u.UserIdentityRepo.ReviewUserIdentity(id, status, adminID, reason, time.Now())
+	if err != nil {
+		return err
+	}
+	// The review is already committed, so a failure to record it is only logged.
+	entry := auditUsecase.Entry{
+		ActorID:    &adminID,
+		Action:     action,
+		EntityType: "user_identity",
+		EntityID:   strconv.Itoa(id),
+		Before:     map[string]interface{}{"status": before.Status},
+		After:      map[string]interface{}{"status": after.Status, "reason": after.RejectionReason},
+	}
+	if err := u.audit.Record(entry); err != nil {
+		log.Printf("record audit event %s for %s %s: %v", entry.Action, entry.EntityType, entry.EntityID, err)
+	}
+	return nil
+}
+
+func toUserIdentityResponse(identity *domain.UserIdentity) dto.UserIdentityResponse {
+	return dto.UserIdentityResponse{
+		ID:              identity.ID,
+		UserID:          identity.UserID,
+		Number:          domain.MaskNumber(identity.Number),
+		Type:            identity.Type,
+		Status:          identity.Status,
+		ExpiryDate:      identity.ExpiryDate.Format("2006-01-02"),
+		PlaceIssued:     identity.PlaceIssued,
+		VerifiedBy:      identity.VerifiedBy,
+		VerifiedAt:      identity.VerifiedAt,
+		RejectionReason: identity.RejectionReason,
+		HasFrontScan:    identity.FrontScan != nil,
+		HasBackScan:     identity.BackScan != nil,
+	}
+}
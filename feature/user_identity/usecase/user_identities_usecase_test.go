@@ -1,10 +1,14 @@
 package usecase
 
 import (
+	"bytes"
 	"errors"
 	"testing"
 	"time"
 
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+	auditUsecase "github.com/cesc1802/onboarding-and-volunteer-service/feature/audit/usecase"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/encryption"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user_identity/domain"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/user_identity/dto"
 	"github.com/stretchr/testify/assert"
@@ -27,71 +31,140 @@ func (m *MockUserIdentityRepository) UpdateUserIdentity(UserIdentity *domain.Use
 
 func (m *MockUserIdentityRepository) FindUserIdentityByID(id int) (*domain.UserIdentity, error) {
 	args := m.Called(id)
-	return args.Get(0).(*domain.UserIdentity), args.Error(1)
+	identity, _ := args.Get(0).(*domain.UserIdentity)
+	return identity, args.Error(1)
+}
+
+func (m *MockUserIdentityRepository) ListUserIdentities(userID int) ([]*domain.UserIdentity, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]*domain.UserIdentity), args.Error(1)
+}
+
+func (m *MockUserIdentityRepository) ReviewUserIdentity(id int, status int, reviewerID int, reason *string, now time.Time) (*domain.UserIdentity, *domain.UserIdentity, error) {
+	args := m.Called(id, status, reviewerID, reason, now)
+	before, _ := args.Get(0).(*domain.UserIdentity)
+	after, _ := args.Get(1).(*domain.UserIdentity)
+	return before, after, args.Error(2)
+}
+
+func (m *MockUserIdentityRepository) NumberIndexExists(index string, exceptID int) (bool, error) {
+	args := m.Called(index, exceptID)
+	return args.Bool(0), args.Error(1)
+}
+
+type MockAuditRecorder struct {
+	mock.Mock
+}
+
+func (m *MockAuditRecorder) Record(entry auditUsecase.Entry) error {
+	args := m.Called(entry)
+	return args.Error(0)
+}
+
+func useTestKeyring(t *testing.T) {
+	keyring, err := encryption.NewKeyring("test", map[string][]byte{"test": bytes.Repeat([]byte("k"), 32)}, bytes.Repeat([]byte("i"), 32))
+	assert.NoError(t, err)
+	encryption.Use(keyring)
+	t.Cleanup(func() { encryption.Use(nil) })
 }
 
 func TestCreateUserIdentity(t *testing.T) {
+	useTestKeyring(t)
 	mockRepo := new(MockUserIdentityRepository)
-	usecase := NewUserIdentityUsecase(mockRepo)
+	usecase := NewUserIdentityUsecase(mockRepo, new(MockAuditRecorder))
 
 	input := dto.CreateUserIdentityRequest{
 		UserID:      2,
-		Number:      "123456789",
-		Type:        "Citizen ID",
-		Status:      0,
-		ExpiryDate:  "12-12-2025",
+		Number:      "123 456 789",
+		Type:        domain.DocumentTypeNationalID,
+		ExpiryDate:  "2030-12-12",
 		PlaceIssued: "Some city",
 	}
+	index, err := domain.NumberIndex(domain.DocumentTypeNationalID, "123456789")
+	assert.NoError(t, err)
 
-	mockRepo.On("CreateUserIdentity", mock.Anything).Return(nil)
+	mockRepo.On("NumberIndexExists", index, 0).Return(false, nil)
+	mockRepo.On("CreateUserIdentity", mock.MatchedBy(func(identity *domain.UserIdentity) bool {
+		return identity.UserID == 2 && identity.Type == domain.DocumentTypeNationalID &&
+			identity.NumberIndex == index && identity.Status == domain.IdentityStatusPending &&
+			identity.ExpiryDate.Equal(time.Date(2030, 12, 12, 0, 0, 0, 0, time.UTC))
+	})).Return(nil)
 
-	err := usecase.CreateUserIdentity(input)
+	err = usecase.CreateUserIdentity(input)
 
 	assert.NoError(t, err)
 	mockRepo.AssertExpectations(t)
 }
 
 func TestUpdateUserIdentity(t *testing.T) {
+	useTestKeyring(t)
 	mockRepo := new(MockUserIdentityRepository)
-	usecase := NewUserIdentityUsecase(mockRepo)
+	usecase := NewUserIdentityUsecase(mockRepo, new(MockAuditRecorder))
 
 	input := dto.UpdateUserIdentityRequest{
-		UserID:      2,
-		Number:      "123555789",
-		Type:        "Passport",
-		Status:      0,
-		ExpiryDate:  "12-12-2028",
-		PlaceIssued: "Some city",
+		Number:      "C5557890",
+		Type:        domain.DocumentTypePassport,
+		ExpiryDate:  "2030-12-12",
+		PlaceIssued: "Other city",
 	}
 	userIdentity := &domain.UserIdentity{
 		ID:          1,
 		UserID:      2,
 		Number:      "123456987",
-		Type:        "Citizen ID",
-		Status:      0,
+		Type:        domain.DocumentTypeNationalID,
+		Status:      domain.IdentityStatusPending,
 		ExpiryDate:  time.Date(2025, 12, 12, 0, 0, 0, 0, time.UTC),
 		PlaceIssued: "Some city",
 	}
+	index, err := domain.NumberIndex(domain.DocumentTypePassport, "C5557890")
+	assert.NoError(t, err)
 
 	mockRepo.On("FindUserIdentityByID", 1).Return(userIdentity, nil)
+	mockRepo.On("NumberIndexExists", index, 1).Return(false, nil)
 	mockRepo.On("UpdateUserIdentity", userIdentity).Return(nil)
 
-	err := usecase.UpdateUserIdentity(1, input)
+	err = usecase.UpdateUserIdentity(1, input)
 
 	assert.NoError(t, err)
+	assert.Equal(t, "C5557890", userIdentity.Number)
+	assert.Equal(t, index, userIdentity.NumberIndex)
+	assert.Equal(t, "Other city", userIdentity.PlaceIssued)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUpdateUserIdentity_Duplicate(t *testing.T) {
+	useTestKeyring(t)
+	mockRepo := new(MockUserIdentityRepository)
+	usecase := NewUserIdentityUsecase(mockRepo, new(MockAuditRecorder))
+
+	userIdentity := &domain.UserIdentity{ID: 1, UserID: 2, Number: "123456987", Type: domain.DocumentTypeNationalID}
+	mockRepo.On("FindUserIdentityByID", 1).Return(userIdentity, nil)
+	mockRepo.On("NumberIndexExists", mock.Anything, 1).Return(true, nil)
+
+	err := usecase.UpdateUserIdentity(1, dto.UpdateUserIdentityRequest{
+		Number:      "B1234567",
+		Type:        domain.DocumentTypePassport,
+		ExpiryDate:  "2030-12-12",
+		PlaceIssued: "Some city",
+	})
+
+	assert.ErrorIs(t, err, domain.ErrDuplicateIdentity)
+	assert.Equal(t, apperror.Conflict, apperror.CodeOf(err))
+	mockRepo.AssertNotCalled(t, "UpdateUserIdentity", mock.Anything)
 	mockRepo.AssertExpectations(t)
 }
 
 func TestFindUserIdentityByID(t *testing.T) {
 	mockRepo := new(MockUserIdentityRepository)
-	usecase := NewUserIdentityUsecase(mockRepo)
+	usecase := NewUserIdentityUsecase(mockRepo, new(MockAuditRecorder))
 
 	userIdentity := &domain.UserIdentity{
 		ID:          1,
 		UserID:      2,
 		Number:      "123456987",
-		Type:        "Citizen ID",
-		Status:      0,
+		NumberIndex: "index",
+		Type:        domain.DocumentTypeNationalID,
+		Status:      domain.IdentityStatusPending,
 		ExpiryDate:  time.Date(2025, 12, 12, 0, 0, 0, 0, time.UTC),
 		PlaceIssued: "Some city",
 	}
@@ -104,18 +177,36 @@ func TestFindUserIdentityByID(t *testing.T) {
 	assert.Equal(t, &dto.UserIdentityResponse{
 		ID:          1,
 		UserID:      2,
-		Number:      "123456987",
-		Type:        "Citizen ID",
-		Status:      0,
-		ExpiryDate:  "2025-12-12 00:00:00 +0000 UTC",
+		Number:      "****6987",
+		Type:        domain.DocumentTypeNationalID,
+		Status:      domain.IdentityStatusPending,
+		ExpiryDate:  "2025-12-12",
 		PlaceIssued: "Some city",
 	}, result)
 	mockRepo.AssertExpectations(t)
 }
 
+func TestListUserIdentities_MasksNumbers(t *testing.T) {
+	mockRepo := new(MockUserIdentityRepository)
+	usecase := NewUserIdentityUsecase(mockRepo, new(MockAuditRecorder))
+
+	mockRepo.On("ListUserIdentities", 2).Return([]*domain.UserIdentity{
+		{ID: 1, UserID: 2, Number: "B1234567", Type: domain.DocumentTypePassport},
+		{ID: 2, UserID: 2, Number: "123", Type: domain.DocumentTypeDriverLicence},
+	}, nil)
+
+	result, err := usecase.ListUserIdentities(2)
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.Equal(t, "****4567", result[0].Number)
+	assert.Equal(t, "****", result[1].Number)
+	mockRepo.AssertExpectations(t)
+}
+
 func TestFindUserIdentityByID_NotFound(t *testing.T) {
 	mockRepo := new(MockUserIdentityRepository)
-	usecase := NewUserIdentityUsecase(mockRepo)
+	usecase := NewUserIdentityUsecase(mockRepo, new(MockAuditRecorder))
 
 	mockRepo.On("FindUserIdentityByID", 1).Return(nil, errors.New("record not found"))
 
@@ -125,3 +216,146 @@ func TestFindUserIdentityByID_NotFound(t *testing.T) {
 	assert.Nil(t, result)
 	mockRepo.AssertExpectations(t)
 }
+
+func TestUpdateUserIdentity_KeepsOwnerAndResubmits(t *testing.T) {
+	mockRepo := new(MockUserIdentityRepository)
+	usecase := NewUserIdentityUsecase(mockRepo, new(MockAuditRecorder))
+
+	reviewer, reason := 3, "blurry scan"
+	userIdentity := &domain.UserIdentity{
+		ID:              1,
+		UserID:          2,
+		Number:          "123456987",
+		Type:            domain.DocumentTypePassport,
+		Status:          domain.IdentityStatusRejected,
+		ExpiryDate:      time.Date(2025, 12, 12, 0, 0, 0, 0, time.UTC),
+		PlaceIssued:     "Some city",
+		VerifiedBy:      &reviewer,
+		RejectionReason: &reason,
+	}
+
+	useTestKeyring(t)
+	mockRepo.On("FindUserIdentityByID", 1).Return(userIdentity, nil)
+	mockRepo.On("NumberIndexExists", mock.Anything, 1).Return(false, nil)
+	mockRepo.On("UpdateUserIdentity", mock.MatchedBy(func(identity *domain.UserIdentity) bool {
+		return identity.UserID == 2 && identity.Number == "123555789" && identity.NumberIndex != "" &&
+			identity.Status == domain.IdentityStatusPending && identity.VerifiedBy == nil && identity.RejectionReason == nil
+	})).Return(nil)
+
+	err := usecase.UpdateUserIdentity(1, dto.UpdateUserIdentityRequest{
+		Number:      "123555789",
+		Type:        domain.DocumentTypePassport,
+		ExpiryDate:  "2030-12-12",
+		PlaceIssued: "Some city",
+	})
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestReviewUserIdentity(t *testing.T) {
+	t.Run("verify", func(t *testing.T) {
+		mockRepo := new(MockUserIdentityRepository)
+		audit := new(MockAuditRecorder)
+		usecase := NewUserIdentityUsecase(mockRepo, audit)
+
+		before := &domain.UserIdentity{ID: 1, Status: domain.IdentityStatusPending}
+		after := &domain.UserIdentity{ID: 1, Status: domain.IdentityStatusVerified}
+		mockRepo.On("ReviewUserIdentity", 1, domain.IdentityStatusVerified, 3, (*string)(nil), mock.Anything).Return(before, after, nil)
+		audit.On("Record", mock.MatchedBy(func(entry auditUsecase.Entry) bool {
+			return entry.Action == "identity.verify" && entry.EntityType == "user_identity" && entry.EntityID == "1" && *entry.ActorID == 3
+		})).Return(errors.New("audit log unavailable"))
+
+		assert.NoError(t, usecase.VerifyUserIdentity(1, 3))
+		mockRepo.AssertExpectations(t)
+		audit.AssertExpectations(t)
+	})
+
+	t.Run("reject", func(t *testing.T) {
+		mockRepo := new(MockUserIdentityRepository)
+		audit := new(MockAuditRecorder)
+		usecase := NewUserIdentityUsecase(mockRepo, audit)
+
+		reason := "blurry scan"
+		before := &domain.UserIdentity{ID: 1, Status: domain.IdentityStatusPending}
+		after := &domain.UserIdentity{ID: 1, Status: domain.IdentityStatusRejected, RejectionReason: &reason}
+		mockRepo.On("ReviewUserIdentity", 1, domain.IdentityStatusRejected, 3, &reason, mock.Anything).Return(before, after, nil)
+		audit.On("Record", mock.MatchedBy(func(entry auditUsecase.Entry) bool {
+			return entry.Action == "identity.reject"
+		})).Return(nil)
+
+		assert.NoError(t, usecase.RejectUserIdentity(1, 3, dto.RejectUserIdentityRequest{Reason: " blurry scan "}))
+		mockRepo.AssertExpectations(t)
+		audit.AssertExpectations(t)
+	})
+
+	t.Run("already reviewed", func(t *testing.T) {
+		mockRepo := new(MockUserIdentityRepository)
+		audit := new(MockAuditRecorder)
+		usecase := NewUserIdentityUsecase(mockRepo, audit)
+
+		mockRepo.On("ReviewUserIdentity", 1, domain.IdentityStatusVerified, 3, (*string)(nil), mock.Anything).
+			Return(nil, nil, domain.ErrIdentityNotPending)
+
+		assert.ErrorIs(t, usecase.VerifyUserIdentity(1, 3), domain.ErrIdentityNotPending)
+		audit.AssertNotCalled(t, "Record", mock.Anything)
+	})
+}
+
+func TestCreateUserIdentity_Duplicate(t *testing.T) {
+	useTestKeyring(t)
+	mockRepo := new(MockUserIdentityRepository)
+	usecase := NewUserIdentityUsecase(mockRepo, new(MockAuditRecorder))
+
+	index, err := domain.NumberIndex(domain.DocumentTypePassport, "B1234567")
+	assert.NoError(t, err)
+	mockRepo.On("NumberIndexExists", index, 0).Return(true, nil)
+
+	err = usecase.CreateUserIdentity(dto.CreateUserIdentityRequest{
+		UserID:      2,
+		Number:      "b-123 4567",
+		Type:        domain.DocumentTypePassport,
+		ExpiryDate:  "2030-12-12",
+		PlaceIssued: "Some city",
+	})
+
+	assert.ErrorIs(t, err, domain.ErrDuplicateIdentity)
+	assert.Equal(t, apperror.Conflict, apperror.CodeOf(err))
+	mockRepo.AssertNotCalled(t, "CreateUserIdentity", mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRevealNumber(t *testing.T) {
+	identity := &domain.UserIdentity{ID: 1, UserID: 2, Number: "B1234567", Type: domain.DocumentTypePassport}
+
+	t.Run("recorded", func(t *testing.T) {
+		mockRepo := new(MockUserIdentityRepository)
+		audit := new(MockAuditRecorder)
+		usecase := NewUserIdentityUsecase(mockRepo, audit)
+
+		mockRepo.On("FindUserIdentityByID", 1).Return(identity, nil)
+		audit.On("Record", mock.MatchedBy(func(entry auditUsecase.Entry) bool {
+			return entry.Action == "identity.reveal_number" && entry.EntityID == "1" && *entry.ActorID == 3
+		})).Return(nil)
+
+		result, err := usecase.RevealNumber(1, 3)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &dto.IdentityNumberResponse{ID: 1, Number: "B1234567"}, result)
+		audit.AssertExpectations(t)
+	})
+
+	t.Run("not recorded", func(t *testing.T) {
+		mockRepo := new(MockUserIdentityRepository)
+		audit := new(MockAuditRecorder)
+		usecase := NewUserIdentityUsecase(mockRepo, audit)
+
+		mockRepo.On("FindUserIdentityByID", 1).Return(identity, nil)
+		audit.On("Record", mock.Anything).Return(errors.New("audit log unavailable"))
+
+		result, err := usecase.RevealNumber(1, 3)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
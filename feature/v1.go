@@ -1,16 +1,27 @@
 package feature
 
 import (
+	"log"
 	"net/http"
+	"os"
 
 	_ "github.com/cesc1802/onboarding-and-volunteer-service/docs"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+	auditStorage "github.com/cesc1802/onboarding-and-volunteer-service/feature/audit/storage"
+	auditTransport "github.com/cesc1802/onboarding-and-volunteer-service/feature/audit/transport"
+	auditUsecase "github.com/cesc1802/onboarding-and-volunteer-service/feature/audit/usecase"
+	authHasher "github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/hasher"
 	authStorage "github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/storage"
 	authTransport "github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/transport"
 	authUsecase "github.com/cesc1802/onboarding-and-volunteer-service/feature/authentication/usecase"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/blob"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/encryption"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/mailer"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/middleware"
 	userStorage "github.com/cesc1802/onboarding-and-volunteer-service/feature/user/storage"
 	userTransport "github.com/cesc1802/onboarding-and-volunteer-service/feature/user/transport"
 	userUsecase "github.com/cesc1802/onboarding-and-volunteer-service/feature/user/usecase"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/validation"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 
@@ -30,6 +41,11 @@ import (
 	departmentTransport "github.com/cesc1802/onboarding-and-volunteer-service/feature/department/transport"
 	departmentUsecase "github.com/cesc1802/onboarding-and-volunteer-service/feature/department/usecase"
 
+	requestStorage "github.com/cesc1802/onboarding-and-volunteer-service/feature/request/storage"
+	requestTransport "github.com/cesc1802/onboarding-and-volunteer-service/feature/request/transport"
+	requestUsecase "github.com/cesc1802/onboarding-and-volunteer-service/feature/request/usecase"
+
+	roleDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/role/domain"
 	roleStorage "github.com/cesc1802/onboarding-and-volunteer-service/feature/role/storage"
 	roleTransport "github.com/cesc1802/onboarding-and-volunteer-service/feature/role/transport"
 	roleUsecase "github.com/cesc1802/onboarding-and-volunteer-service/feature/role/usecase"
@@ -37,11 +53,12 @@ import (
 	"github.com/cesc1802/share-module/system"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 // @host localhost:8080
 // @BasePath /api/v1
-func RegisterHandlerV1(mono system.Service) {
+func RegisterHandlerV1(mono system.Service, db *gorm.DB) {
 	router := mono.Router()
 	secretKey := authStorage.GetSecretKey()
 	router.Use(cors.Default())
@@ -54,115 +71,232 @@ func RegisterHandlerV1(mono system.Service) {
 	})
 	v1 := router.Group("/api/v1")
 	// Initialize repository
-	authRepo := authStorage.NewAuthenticationRepository(mono.DB())
-	userRepo := userStorage.NewAdminRepository(mono.DB())
-	applicantRepo := userStorage.NewApplicantRepository(mono.DB())
-	applicantRequestRepo := userStorage.NewApplicantRequestRepository(mono.DB())
-	applicantIdentityRepo := appliIdentityStorage.NewUserIdentityRepository(mono.DB())
-	volunteerRepo := volunteerStorage.NewVolunteerRepository(mono.DB())
-	volunteerRequestRepo := userStorage.NewVolunteerRequestRepository(mono.DB())
-	countryRepo := countryStorage.NewCountryRepository(mono.DB())
-	departmentRepo := departmentStorage.NewDepartmentRepository(mono.DB())
-	roleRepo := roleStorage.NewRoleRepository(mono.DB())
+	authRepo := authStorage.NewAuthenticationRepository(db)
+	tokenRepo := authStorage.NewTokenRepository(db)
+	verificationRepo := authStorage.NewVerificationRepository(db)
+	passwordResetRepo := authStorage.NewPasswordResetRepository(db)
+	userRepo := userStorage.NewAdminRepository(db)
+	applicantRepo := userStorage.NewApplicantRepository(db)
+	requestRepo := requestStorage.NewRequestRepository(db)
+	applicantIdentityRepo := appliIdentityStorage.NewUserIdentityRepository(db)
+	volunteerRepo := volunteerStorage.NewVolunteerRepository(db)
+	countryRepo := countryStorage.NewCountryRepository(db)
+	departmentRepo := departmentStorage.NewDepartmentRepository(db)
+	roleRepo := roleStorage.NewRoleRepository(db)
+	rolePermissionRepo := roleStorage.NewRolePermissionRepository(db)
+	auditRepo := auditStorage.NewAuditRepository(db)
+	validation.UseDB(db)
+	keyring, err := encryption.NewKeyringFromEnv()
+	if err != nil {
+		log.Fatalln(err)
+	}
+	encryption.Use(keyring)
 
 	// Initialize usecase
-	authUseCase := authUsecase.NewUserUsecase(authRepo, secretKey)
-	userUseCase := userUsecase.NewAdminUsecase(userRepo)
+	roleRegistry := roleUsecase.NewRoleRegistry(roleRepo, rolePermissionRepo)
+	if err := roleRegistry.Refresh(); err != nil {
+		log.Fatalln(err)
+	}
+	auditUseCase := auditUsecase.NewAuditUsecase(auditRepo)
+	appMailer := mailer.NewFromEnv()
+	passwordHasher := authHasher.NewFromEnv()
+	blobStore := blob.NewFromEnv(secretKey)
+	verificationUseCase := authUsecase.NewVerificationUsecase(authRepo, verificationRepo, appMailer, secretKey, os.Getenv("APP_BASE_URL"))
+	passwordResetUseCase := authUsecase.NewPasswordResetUsecase(authRepo, passwordResetRepo, passwordHasher, appMailer, os.Getenv("PASSWORD_RESET_URL"))
+	authUseCase := authUsecase.NewUserUsecase(authRepo, tokenRepo, passwordHasher, secretKey, verificationUseCase, roleRegistry)
+	userUseCase := userUsecase.NewAdminUsecase(userRepo, verificationUseCase, auditUseCase, roleRegistry)
 	applicantUseCase := userUsecase.NewApplicantUsecase(applicantRepo)
-	applicantRequestUseCase := userUsecase.NewApplicantRequestUsecase(applicantRequestRepo)
-	applicantIdenityUseCase := appliIdentityUsecase.NewUserIdentityUsecase(applicantIdentityRepo)
-	volunteerUseCase := volunteerUsecase.NewVolunteerUsecase(volunteerRepo)
-	volunteerRequestUseCase := userUsecase.NewVolunteerRequestUsecase(volunteerRequestRepo)
+	avatarUseCase := userUsecase.NewAvatarUsecase(applicantRepo, blobStore)
+	requestUseCase := requestUsecase.NewRequestUsecase(requestRepo)
+	messageUseCase := requestUsecase.NewMessageUsecase(requestRepo, requestRepo, mailer.NewTemplateMailer(appMailer))
+	applicantIdenityUseCase := appliIdentityUsecase.NewUserIdentityUsecase(applicantIdentityRepo, auditUseCase)
+	scanUseCase := appliIdentityUsecase.NewScanUsecase(applicantIdentityRepo, blobStore)
+	volunteerUseCase := volunteerUsecase.NewVolunteerUsecase(volunteerRepo, auditUseCase)
+	onboardingUseCase := volunteerUsecase.NewOnboardingUsecase(volunteerRepo, passwordHasher, passwordResetUseCase, auditUseCase, roleRegistry)
 	countryUsecase := countryUsecase.NewCountryUsecase(countryRepo)
 	departmentUsecase := departmentUsecase.NewDepartmentUsecase(departmentRepo)
-	roleUsecase := roleUsecase.NewRoleUsecase(roleRepo)
+	permissionUsecase := roleUsecase.NewPermissionUsecase(rolePermissionRepo, roleRegistry)
+	roleUsecase := roleUsecase.NewRoleUsecase(roleRepo, roleRegistry)
 
 	// Initialize handler
 	authHandler := authTransport.NewAuthenticationHandler(authUseCase)
+	verificationHandler := authTransport.NewVerificationHandler(verificationUseCase)
+	passwordResetHandler := authTransport.NewPasswordResetHandler(passwordResetUseCase)
 	userHandler := userTransport.NewAuthenticationHandler(userUseCase)
 	applicantHandler := userTransport.NewApplicantHandler(applicantUseCase)
-	applicantRequestHandler := userTransport.NewApplicantRequestHandler(applicantRequestUseCase)
+	avatarHandler := userTransport.NewAvatarHandler(avatarUseCase)
+	requestHandler := requestTransport.NewRequestHandler(requestUseCase)
+	messageHandler := requestTransport.NewMessageHandler(messageUseCase)
+	applicantRequestHandler := userTransport.NewApplicantRequestHandler(requestUseCase)
 	applicantIdentityHandler := appliIdentityTransport.NewUserIdentityHandler(applicantIdenityUseCase)
+	scanHandler := appliIdentityTransport.NewScanHandler(scanUseCase)
 	volunteerHandler := volunteerTransport.NewVolunteerHandler(volunteerUseCase)
-	volunteerRequestHandler := userTransport.NewVolunteerRequestHandler(volunteerRequestUseCase)
+	onboardingHandler := volunteerTransport.NewOnboardingHandler(onboardingUseCase)
+	volunteerRequestHandler := userTransport.NewVolunteerRequestHandler(requestUseCase)
 	countryHandler := countryTransport.NewCountryHandler(countryUsecase)
 	departmentHandler := departmentTransport.NewDepartmentHandler(departmentUsecase)
 	roleHandler := roleTransport.NewRoleHandler(roleUsecase)
+	permissionHandler := roleTransport.NewPermissionHandler(permissionUsecase)
+	auditHandler := auditTransport.NewAuditHandler(auditUseCase)
+
+	requireAuth := middleware.AuthMiddleware(secretKey, tokenRepo)
+	v1.Use(auditTransport.AuditMiddleware(auditUseCase, roleRegistry))
+	v1.Use(apperror.Middleware())
 
 	auth := v1.Group("/auth")
 	{
 		auth.POST("/login", authHandler.Login)
 
 		auth.POST("/register", authHandler.Register)
+
+		auth.POST("/refresh", authHandler.Refresh)
+
+		auth.POST("/logout", requireAuth, authHandler.Logout)
+
+		auth.GET("/verify-email", verificationHandler.VerifyEmail)
+
+		auth.POST("/resend-verification", verificationHandler.ResendVerification)
+
+		auth.POST("/forgot-password", passwordResetHandler.ForgotPassword)
+
+		auth.POST("/reset-password", passwordResetHandler.ResetPassword)
+	}
+
+	review := v1.Group("/admin")
+	review.Use(requireAuth, middleware.RequirePermission(roleRegistry, roleDomain.PermissionRequestReview))
+	{
+		review.GET("/requests", userHandler.ListRequests)
+		review.GET("/requests/:id/messages", messageHandler.ListMessages)
+		review.POST("/requests/:id/messages", messageHandler.PostMessage)
+		review.POST("/approve-request/:id", userHandler.ApproveRequest)
+		review.POST("/reject-request/:id", userHandler.RejectRequest)
 	}
 
 	admin := v1.Group("/admin")
-	admin.Use(middleware.AuthMiddleware(secretKey))
+	admin.Use(requireAuth, middleware.RequireRole(roleRegistry, roleDomain.RoleCodeAdmin))
 	{
+		admin.POST("/requests/:id/restore", userHandler.RestoreRequest)
+		admin.DELETE("/users/:id", applicantHandler.DeleteApplicant)
+		admin.POST("/users/:id/restore", applicantHandler.RestoreApplicant)
+		admin.GET("/volunteers", volunteerHandler.ListVolunteers)
+		admin.POST("/volunteers/onboard", onboardingHandler.OnboardVolunteer)
+		admin.POST("/volunteers/:id/deactivate", volunteerHandler.DeactivateVolunteer)
+		admin.POST("/volunteers/:id/reactivate", volunteerHandler.ReactivateVolunteer)
+		admin.GET("/volunteers/:id/status-history", volunteerHandler.ListStatusChanges)
+		admin.DELETE("/volunteers/:id", volunteerHandler.DeleteVolunteer)
+		admin.POST("/volunteers/:id/restore", volunteerHandler.RestoreVolunteer)
 		admin.GET("/list-request", userHandler.GetListRequest)
 		admin.GET("/request/:id", userHandler.GetRequestById)
 		admin.GET("/list-pending-request", userHandler.GetListPendingRequest)
 		admin.GET("/pending-request/:id", userHandler.GetPendingRequestById)
-		admin.POST("/approve-request/:id", userHandler.ApproveRequest)
-		admin.POST("/reject-request/:id", userHandler.RejectRequest)
-		admin.POST("/add-reject-notes/:id", userHandler.AddRejectNotes)
 		admin.DELETE("/delete-request/:id", userHandler.DeleteRequest)
+		admin.GET("/audit", auditHandler.ListEvents)
+		admin.GET("/audit/export", auditHandler.ExportEvents)
+		admin.POST("/identities/:id/verify", applicantIdentityHandler.VerifyUserIdentity)
+		admin.POST("/identities/:id/reject", applicantIdentityHandler.RejectUserIdentity)
+		admin.POST("/identities/:id/reveal-number", applicantIdentityHandler.RevealIdentityNumber)
+	}
+
+	me := v1.Group("/me")
+	me.Use(requireAuth)
+	{
+		me.POST("/requests", requestHandler.CreateRequest)
+		me.GET("/requests", requestHandler.ListRequests)
+		me.GET("/requests/:id", requestHandler.GetRequest)
+		me.POST("/requests/:id/cancel", requestHandler.CancelRequest)
+		me.POST("/requests/:id/resubmit", requestHandler.ResubmitRequest)
+		me.GET("/requests/:id/messages", messageHandler.ListOwnMessages)
+		me.POST("/requests/:id/messages", messageHandler.PostOwnMessage)
+		me.GET("/permissions", permissionHandler.MyPermissions)
 	}
 
+	// Profiles are read and updated by their users and by admins, who alone
+	// create applicants; roles change through request approval.
+	selfOrAdmin := middleware.RequireSelfOrRole(roleRegistry, "id", roleDomain.RoleCodeAdmin)
 	applicant := v1.Group("/applicant")
+	applicant.Use(requireAuth, selfOrAdmin)
 	{
 		applicant.POST("/", applicantHandler.CreateApplicant)
 		applicant.PUT("/:id", applicantHandler.UpdateApplicant)
-		applicant.DELETE("/:id", applicantHandler.DeleteApplicant)
 		applicant.GET("/:id", applicantHandler.FindApplicantByID)
 	}
 
 	appliRequest := v1.Group("/applicant-request")
 	{
-		appliRequest.POST("/", applicantRequestHandler.CreateApplicantRequest)
+		appliRequest.POST("/", requireAuth, applicantRequestHandler.CreateApplicantRequest)
 	}
 
+	users := v1.Group("/users/:id")
+	users.Use(requireAuth)
+	{
+		users.GET("/avatar", avatarHandler.GetAvatar)
+		users.PUT("/avatar", selfOrAdmin, avatarHandler.UploadAvatar)
+		users.GET("/identities", selfOrAdmin, applicantIdentityHandler.ListUserIdentities)
+		users.GET("/identities/:identityId/scans/:side", selfOrAdmin, scanHandler.GetScan)
+		users.PUT("/identities/:identityId/scans/:side", selfOrAdmin, scanHandler.UploadScan)
+	}
+
+	// Files of the local blob store are downloaded through the signed links
+	// handed out above; S3 serves its own.
+	if localStore, ok := blobStore.(*blob.LocalStore); ok {
+		v1.GET("/files/*key", localStore.Handler)
+	}
+
+	identityOwnerOrAdmin := middleware.RequireOwnerOrRole(roleRegistry, applicantIdentityHandler.Owner, roleDomain.RoleCodeAdmin)
 	appliIdentity := v1.Group("applicant-identity")
+	appliIdentity.Use(requireAuth)
 	{
-		appliIdentity.POST("/", applicantIdentityHandler.CreateUserIdentity)
-		appliIdentity.GET("/:id", applicantIdentityHandler.FindUserIdentity)
-		appliIdentity.PUT("/:id", applicantIdentityHandler.UpdateUserIdentity)
+		appliIdentity.POST("/", middleware.RequireOwnerOrRole(roleRegistry, applicantIdentityHandler.RequestedOwner, roleDomain.RoleCodeAdmin), applicantIdentityHandler.CreateUserIdentity)
+		appliIdentity.GET("/:id", identityOwnerOrAdmin, applicantIdentityHandler.FindUserIdentity)
+		appliIdentity.PUT("/:id", identityOwnerOrAdmin, applicantIdentityHandler.UpdateUserIdentity)
 	}
 
+	volunteerOrAdmin := middleware.RequireOwnerOrRole(roleRegistry, volunteerHandler.Owner, roleDomain.RoleCodeAdmin)
 	volunteer := v1.Group("/volunteer")
+	volunteer.Use(requireAuth)
 	{
-		volunteer.POST("/", volunteerHandler.CreateVolunteer)
-		volunteer.PUT("/:id", volunteerHandler.UpdateVolunteer)
-		volunteer.DELETE("/:id", volunteerHandler.DeleteVolunteer)
-		volunteer.GET("/:id", volunteerHandler.FindVolunteerByID)
+		volunteer.POST("/", middleware.RequireRole(roleRegistry, roleDomain.RoleCodeAdmin), volunteerHandler.CreateVolunteer)
+		volunteer.PUT("/:id", volunteerOrAdmin, volunteerHandler.UpdateVolunteer)
+		volunteer.GET("/:id", volunteerOrAdmin, volunteerHandler.FindVolunteerByID)
 	}
 
 	volRequest := v1.Group("/volunteer-request")
 	{
-		volRequest.POST("/", volunteerRequestHandler.CreateVolunteerRequest)
+		volRequest.POST("/", requireAuth, volunteerRequestHandler.CreateVolunteerRequest)
 	}
 
 	country := v1.Group("/country")
 	{
-		country.POST("/", countryHandler.CreateCountry)
-		country.PUT("/:id", countryHandler.UpdateCountry)
-		country.DELETE("/:id", countryHandler.DeleteCountry)
+		canWriteCountry := middleware.RequirePermission(roleRegistry, roleDomain.PermissionCountryWrite)
+		country.POST("/", requireAuth, canWriteCountry, countryHandler.CreateCountry)
+		country.PUT("/:id", requireAuth, canWriteCountry, countryHandler.UpdateCountry)
+		country.DELETE("/:id", requireAuth, canWriteCountry, countryHandler.DeleteCountry)
+		country.POST("/:id/restore", requireAuth, canWriteCountry, countryHandler.RestoreCountry)
 		country.GET("/:id", countryHandler.GetCountryByID)
 	}
 
 	department := v1.Group("/department")
 	{
-		department.POST("/", departmentHandler.CreateDepartment)
-		department.PUT("/:id", departmentHandler.UpdateDepartment)
-		department.DELETE("/:id", departmentHandler.DeleteDepartment)
+		canWriteDepartment := middleware.RequirePermission(roleRegistry, roleDomain.PermissionDepartmentWrite)
+		department.POST("/", requireAuth, canWriteDepartment, departmentHandler.CreateDepartment)
+		department.PUT("/:id", requireAuth, canWriteDepartment, departmentHandler.UpdateDepartment)
+		department.DELETE("/:id", requireAuth, canWriteDepartment, departmentHandler.DeleteDepartment)
+		department.POST("/:id/restore", requireAuth, canWriteDepartment, departmentHandler.RestoreDepartment)
 		department.GET("/:id", departmentHandler.GetDepartmentByID)
 	}
 
+	canWriteRole := middleware.RequirePermission(roleRegistry, roleDomain.PermissionRoleWrite)
+	v1.GET("/permissions", requireAuth, canWriteRole, permissionHandler.ListPermissions)
+
 	role := v1.Group("/role")
 	{
-		role.POST("/", roleHandler.CreateRole)
-		role.PUT("/:id", roleHandler.UpdateRole)
-		role.DELETE("/:id", roleHandler.DeleteRole)
+		role.POST("/", requireAuth, canWriteRole, roleHandler.CreateRole)
+		role.PUT("/:id", requireAuth, canWriteRole, roleHandler.UpdateRole)
+		role.DELETE("/:id", requireAuth, canWriteRole, roleHandler.DeleteRole)
+		role.POST("/:id/restore", requireAuth, canWriteRole, roleHandler.RestoreRole)
 		role.GET("/:id", roleHandler.GetRoleByID)
+		role.GET("/:id/permissions", requireAuth, canWriteRole, permissionHandler.GetRolePermissions)
+		role.POST("/:id/permissions", requireAuth, canWriteRole, permissionHandler.GrantPermission)
+		role.DELETE("/:id/permissions/:permission", requireAuth, canWriteRole, permissionHandler.RevokePermission)
 	}
 }
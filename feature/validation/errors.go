@@ -0,0 +1,105 @@
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+	"github.com/go-playground/validator/v10"
+)
+
+// ErrInvalidInput is returned when a request body or query fails
+// validation. Its details list the fields in error.
+var ErrInvalidInput = apperror.New(apperror.Validation, "invalid input")
+
+// FieldError tells why the value of a field was rejected. Rule is the tag
+// that failed, so that clients can pick their own wording.
+type FieldError struct {
+	Field   string `json:"field" example:"re_password"`
+	Rule    string `json:"rule" example:"eqfield"`
+	Message string `json:"message" example:"must match password"`
+}
+
+// Details is the details of ErrInvalidInput.
+type Details struct {
+	Fields []FieldError `json:"fields"`
+}
+
+// Error turns a binding error into ErrInvalidInput with one FieldError per
+// rejected field. Errors that do not concern a field, such as malformed JSON,
+// are reported as validation errors with their own message.
+func Error(err error) error {
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		fields := make([]FieldError, 0, len(validationErrors))
+		for _, fieldErr := range validationErrors {
+			fields = append(fields, FieldError{
+				Field:   fieldErr.Field(),
+				Rule:    fieldErr.Tag(),
+				Message: message(fieldErr),
+			})
+		}
+		return ErrInvalidInput.WithDetails(Details{Fields: fields})
+	}
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return ErrInvalidInput.WithDetails(Details{Fields: []FieldError{{
+			Field:   typeErr.Field,
+			Rule:    "type",
+			Message: "must be a " + typeErr.Type.String(),
+		}}})
+	}
+	return apperror.Wrap(apperror.Validation, err)
+}
+
+func message(fieldErr validator.FieldError) string {
+	param := fieldErr.Param()
+	switch fieldErr.Tag() {
+	case "required":
+		return "is required"
+	case "email":
+		return "must be a valid email address"
+	case "e164":
+		return "must be a phone number in E.164 format, e.g. +14155552671"
+	case "isodate":
+		return "must be a date in YYYY-MM-DD format"
+	case "pastdate":
+		return "must be a date in YYYY-MM-DD format before today"
+	case "futuredate":
+		return "must be a date in YYYY-MM-DD format after today"
+	case "password":
+		return fmt.Sprintf("must be at least %d characters long and contain a letter and a digit", minPasswordLength)
+	case "eqfield":
+		return "must match " + snakeCase(param)
+	case "oneof":
+		return "must be one of " + strings.Join(strings.Fields(param), ", ")
+	case "exists":
+		return "does not exist"
+	case "min", "max":
+		bound := map[string]string{"min": "at least", "max": "at most"}[fieldErr.Tag()]
+		if fieldErr.Kind() == reflect.String {
+			return fmt.Sprintf("must be %s %s characters long", bound, param)
+		}
+		return fmt.Sprintf("must be %s %s", bound, param)
+	}
+	return "is invalid"
+}
+
+// snakeCase turns the Go name of a field, as found in eqfield, into the
+// name clients know it by.
+func snakeCase(name string) string {
+	var b strings.Builder
+	previous := rune(0)
+	for _, r := range name {
+		if unicode.IsUpper(r) && unicode.IsLower(previous) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToLower(r))
+		previous = r
+	}
+	return b.String()
+}
@@ -0,0 +1,136 @@
+// Package validation registers the custom binding tags of the API with the
+// validator used by gin, and turns binding failures into field errors.
+//
+// Tags:
+//
+//	isodate     a date in YYYY-MM-DD format
+//	pastdate    an isodate before today
+//	futuredate  an isodate after today
+//	password    at least 8 characters with a letter and a digit
+//	exists      the id of a row that is not deleted, e.g. exists=countries
+//
+// The validator's own tags such as e164, eqfield and oneof are used as is.
+package validation
+
+import (
+	"log"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+// DateLayout is the layout of the dates exchanged as strings with clients.
+const DateLayout = "2006-01-02"
+
+const minPasswordLength = 8
+
+// existsTables lists the tables the exists tag may look up, so that the tag
+// parameter never ends up in a query unchecked.
+var existsTables = map[string]bool{
+	"countries":   true,
+	"departments": true,
+	"roles":       true,
+	"users":       true,
+}
+
+var (
+	db  *gorm.DB
+	now = time.Now
+)
+
+func init() {
+	engine, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+	engine.RegisterTagNameFunc(fieldName)
+	for tag, fn := range map[string]validator.Func{
+		"isodate":    isoDate,
+		"pastdate":   pastDate,
+		"futuredate": futureDate,
+		"password":   password,
+		"exists":     exists,
+	} {
+		if err := engine.RegisterValidation(tag, fn); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// UseDB sets the database the exists tag looks up. Until it is set, the tag
+// accepts any id and foreign keys are only checked by the database.
+func UseDB(database *gorm.DB) {
+	db = database
+}
+
+// fieldName reports fields under the name clients send them with.
+func fieldName(field reflect.StructField) string {
+	for _, key := range []string{"json", "form"} {
+		name := strings.Split(field.Tag.Get(key), ",")[0]
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+func parseDate(fl validator.FieldLevel) (time.Time, bool) {
+	date, err := time.Parse(DateLayout, fl.Field().String())
+	return date, err == nil
+}
+
+func today() time.Time {
+	return now().UTC().Truncate(24 * time.Hour)
+}
+
+func isoDate(fl validator.FieldLevel) bool {
+	_, ok := parseDate(fl)
+	return ok
+}
+
+func pastDate(fl validator.FieldLevel) bool {
+	date, ok := parseDate(fl)
+	return ok && date.Before(today())
+}
+
+func futureDate(fl validator.FieldLevel) bool {
+	date, ok := parseDate(fl)
+	return ok && date.After(today())
+}
+
+func password(fl validator.FieldLevel) bool {
+	value := fl.Field().String()
+	if len([]rune(value)) < minPasswordLength {
+		return false
+	}
+	hasLetter := strings.IndexFunc(value, unicode.IsLetter) >= 0
+	hasDigit := strings.IndexFunc(value, unicode.IsDigit) >= 0
+	return hasLetter && hasDigit
+}
+
+func exists(fl validator.FieldLevel) bool {
+	table := fl.Param()
+	if !existsTables[table] {
+		panic("validation: exists does not support table " + table)
+	}
+	if db == nil {
+		return true
+	}
+	var count int64
+	err := db.Table(table).Where("id = ? AND deleted_at IS NULL", fl.Field().Interface()).Count(&count).Error
+	if err != nil {
+		// The database rejects a dangling key anyway, so an outage is not
+		// reported as invalid input.
+		log.Printf("validation: look up %s %v: %v", table, fl.Field().Interface(), err)
+		return true
+	}
+	return count > 0
+}
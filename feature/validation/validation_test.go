@@ -0,0 +1,89 @@
+package validation
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type profile struct {
+	Password   string `json:"password" binding:"required,password"`
+	RePassword string `json:"re_password" binding:"required,eqfield=Password"`
+	Gender     string `json:"gender" binding:"omitempty,oneof=male female other"`
+	Mobile     string `json:"mobile" binding:"omitempty,e164"`
+	DOB        string `json:"dob" binding:"required,pastdate"`
+	ExpiryDate string `json:"expiry_date" binding:"omitempty,futuredate"`
+	CountryID  *int   `json:"country_id" binding:"omitempty,exists=countries"`
+}
+
+func setupSQLMock(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	assert.NoError(t, err)
+	return gormDB, mock
+}
+
+func fieldErrors(t *testing.T, err error) []FieldError {
+	var appErr *apperror.Error
+	if !assert.True(t, errors.As(Error(err), &appErr)) {
+		return nil
+	}
+	assert.ErrorIs(t, appErr, ErrInvalidInput)
+	return appErr.Details.(Details).Fields
+}
+
+func TestValidate(t *testing.T) {
+	now = func() time.Time { return time.Date(2024, 5, 1, 15, 0, 0, 0, time.UTC) }
+	defer func() { now = time.Now }()
+
+	valid := profile{Password: "secret123", RePassword: "secret123", Gender: "female", Mobile: "+84913895987", DOB: "2002-09-20", ExpiryDate: "2024-05-02"}
+	assert.NoError(t, binding.Validator.ValidateStruct(&valid))
+
+	invalid := profile{Password: "secret", RePassword: "secret1", Gender: "Male", Mobile: "0913895987", DOB: "2024-05-01", ExpiryDate: "01-05-2024"}
+	assert.Equal(t, []FieldError{
+		{Field: "password", Rule: "password", Message: "must be at least 8 characters long and contain a letter and a digit"},
+		{Field: "re_password", Rule: "eqfield", Message: "must match password"},
+		{Field: "gender", Rule: "oneof", Message: "must be one of male, female, other"},
+		{Field: "mobile", Rule: "e164", Message: "must be a phone number in E.164 format, e.g. +14155552671"},
+		{Field: "dob", Rule: "pastdate", Message: "must be a date in YYYY-MM-DD format before today"},
+		{Field: "expiry_date", Rule: "futuredate", Message: "must be a date in YYYY-MM-DD format after today"},
+	}, fieldErrors(t, binding.Validator.ValidateStruct(&invalid)))
+}
+
+func TestExists(t *testing.T) {
+	db, mock := setupSQLMock(t)
+	UseDB(db)
+	defer UseDB(nil)
+
+	query := regexp.QuoteMeta("SELECT count(*) FROM `countries` WHERE id = ? AND deleted_at IS NULL")
+	mock.ExpectQuery(query).WithArgs(2).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(query).WithArgs(3).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+	known, unknown := 2, 3
+	input := profile{Password: "secret123", RePassword: "secret123", DOB: "2002-09-20", CountryID: &known}
+	assert.NoError(t, binding.Validator.ValidateStruct(&input))
+
+	input.CountryID = &unknown
+	assert.Equal(t, []FieldError{{Field: "country_id", Rule: "exists", Message: "does not exist"}},
+		fieldErrors(t, binding.Validator.ValidateStruct(&input)))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestErrorWithoutFields(t *testing.T) {
+	err := Error(errors.New("unexpected EOF"))
+	assert.Equal(t, apperror.Validation, apperror.CodeOf(err))
+	assert.Equal(t, "unexpected EOF", err.Error())
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrUserNotFound       = apperror.New(apperror.NotFound, "user not found")
+	ErrEmailTaken         = apperror.New(apperror.Conflict, "email is already registered")
+	ErrDepartmentNotFound = apperror.New(apperror.Validation, "department not found")
+	ErrAlreadyVolunteer   = apperror.New(apperror.Conflict, "user is already a volunteer")
+)
+
+// User is the part of a users row written by a manual onboarding.
+type User struct {
+	ID                 int `gorm:"primaryKey"`
+	RoleID             int
+	DepartmentID       *int
+	Email              string
+	Password           string
+	Name               string
+	Surname            string
+	Gender             string
+	Dob                *time.Time
+	Mobile             string
+	CountryID          *int
+	ResidentCountryID  *int
+	VerificationStatus int
+	Status             int
+	CreatedAt          time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt          time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt          gorm.DeletedAt `gorm:"index"`
+}
+
+func (User) TableName() string {
+	return "users"
+}
+
+// Onboarding describes a volunteer added by an admin. Either UserID links an
+// existing user, or NewUser is created; its Password must already be hashed.
+// RoleID is the id of the volunteer role, which the user gets.
+type Onboarding struct {
+	UserID       int
+	NewUser      *User
+	DepartmentID int
+	AdminID      int
+	RoleID       int
+}
@@ -2,13 +2,71 @@ package domain
 
 import (
 	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+	"gorm.io/gorm"
+)
+
+// Volunteer and user statuses share the same values.
+const (
+	VolunteerStatusInactive = 0
+	VolunteerStatusActive   = 1
+)
+
+var (
+	ErrVolunteerNotFound        = apperror.New(apperror.NotFound, "volunteer not found")
+	ErrVolunteerAlreadyActive   = apperror.New(apperror.Conflict, "volunteer is already active")
+	ErrVolunteerAlreadyInactive = apperror.New(apperror.Conflict, "volunteer is already inactive")
 )
 
 type Volunteer struct {
-	ID           int       `gorm:"primaryKey"`
-	UserID       int       `gorm:"unique;notnull"`
-	DepartmentID int       `gorm:"notnull"`
-	Status       int       `gorm:"notnull"`
-	CreatedAt    time.Time `gorm:"autoCreateTime"`
-	UpdatedAt    time.Time `gorm:"autoUpdateTime"`
+	ID           int            `gorm:"primaryKey"`
+	UserID       int            `gorm:"unique;notnull"`
+	DepartmentID int            `gorm:"notnull"`
+	Status       int            `gorm:"notnull"`
+	CreatedAt    time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt    time.Time      `gorm:"autoUpdateTime"`
+	DeletedAt    gorm.DeletedAt `gorm:"index"`
+}
+
+func (Volunteer) TableName() string {
+	return "volunteer_details"
+}
+
+// VolunteerStatusChange records who deactivated or reactivated a volunteer,
+// when and why.
+type VolunteerStatusChange struct {
+	ID          int       `gorm:"primaryKey"`
+	VolunteerID int       `gorm:"index;not null"`
+	FromStatus  int       `gorm:"not null"`
+	ToStatus    int       `gorm:"not null"`
+	Reason      string    `gorm:"not null"`
+	ChangedBy   int       `gorm:"not null"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+}
+
+func (VolunteerStatusChange) TableName() string {
+	return "volunteer_status_history"
+}
+
+// VolunteerListItem is a row of the volunteer directory: a volunteer_details
+// row joined with its user, department, role and country. DeletedAt is set
+// when either the volunteer or its user is deleted.
+type VolunteerListItem struct {
+	ID             int
+	UserID         int
+	Name           string
+	Surname        string
+	Email          string
+	Gender         string
+	Mobile         string
+	RoleID         int
+	RoleName       string
+	DepartmentID   int
+	DepartmentName string
+	CountryID      int
+	CountryName    *string
+	Status         int
+	CreatedAt      time.Time
+	DeletedAt      *time.Time
 }
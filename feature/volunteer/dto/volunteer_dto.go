@@ -1,14 +1,34 @@
 package dto
 
+import "time"
+
 type VolunteerCreateDTO struct {
-	UserID       int `json:"user_id" binding:"required"`
-	DepartmentID int `json:"department_id" binding:"required"`
+	UserID       int `json:"user_id" binding:"required,exists=users"`
+	DepartmentID int `json:"department_id" binding:"required,exists=departments"`
 	Status       int `json:"status" binding:"required"`
 }
 
+// VolunteerOnboardDTO adds a volunteer without going through the
+// registration and verification requests. Either UserID links an existing
+// user, or the user is created from the remaining fields, in which case Email,
+// Name, Dob, CountryID and ResidentCountryID are required.
+type VolunteerOnboardDTO struct {
+	UserID            int        `json:"user_id"`
+	DepartmentID      int        `json:"department_id" binding:"required,exists=departments"`
+	Email             string     `json:"email" binding:"omitempty,email"`
+	Name              string     `json:"name"`
+	Surname           string     `json:"surname"`
+	Gender            string     `json:"gender" binding:"omitempty,oneof=male female other"`
+	Dob               *time.Time `json:"dob"`
+	Mobile            string     `json:"mobile" binding:"omitempty,e164" example:"+14155552671"`
+	CountryID         *int       `json:"country_id" binding:"omitempty,exists=countries"`
+	ResidentCountryID *int       `json:"resident_country_id" binding:"omitempty,exists=countries"`
+}
+
+// VolunteerUpdateDTO changes the department of a volunteer. The status is
+// changed through the deactivate and reactivate actions only.
 type VolunteerUpdateDTO struct {
-	DepartmentID int `json:"department_id"`
-	Status       int `json:"status"`
+	DepartmentID int `json:"department_id" binding:"omitempty,exists=departments"`
 }
 
 type VolunteerResponseDTO struct {
@@ -17,3 +37,59 @@ type VolunteerResponseDTO struct {
 	DepartmentID int `json:"department_id"`
 	Status       int `json:"status"`
 }
+
+// VolunteerListQuery holds the query parameters of the volunteer directory.
+// Sort is a comma separated list of keys, each optionally prefixed with "-"
+// for descending order, e.g. "role,-created_at". IncludeDeleted also lists
+// deleted volunteers.
+type VolunteerListQuery struct {
+	Search         string `form:"q"`
+	Gender         string `form:"gender"`
+	Role           string `form:"role"`
+	DepartmentID   *int   `form:"department_id"`
+	CountryID      *int   `form:"country_id"`
+	Status         *int   `form:"status"`
+	IncludeDeleted bool   `form:"include_deleted"`
+	Sort           string `form:"sort"`
+	Page           int    `form:"page"`
+	Limit          int    `form:"limit"`
+}
+
+type VolunteerListItemDTO struct {
+	ID             int        `json:"id"`
+	UserID         int        `json:"user_id"`
+	Name           string     `json:"name"`
+	Surname        string     `json:"surname"`
+	Email          string     `json:"email"`
+	Gender         string     `json:"gender"`
+	Mobile         string     `json:"mobile"`
+	RoleID         int        `json:"role_id"`
+	RoleName       string     `json:"role_name"`
+	DepartmentID   int        `json:"department_id"`
+	DepartmentName string     `json:"department_name"`
+	CountryID      int        `json:"country_id"`
+	CountryName    *string    `json:"country_name"`
+	Status         int        `json:"status"`
+	CreatedAt      time.Time  `json:"created_at"`
+	DeletedAt      *time.Time `json:"deleted_at,omitempty"`
+}
+
+type VolunteerPage struct {
+	Items []VolunteerListItemDTO `json:"items"`
+	Page  int                    `json:"page"`
+	Limit int                    `json:"limit"`
+	Total int64                  `json:"total"`
+}
+
+type VolunteerStatusChangeDTO struct {
+	Reason string `json:"reason" binding:"required,max=255"`
+}
+
+type VolunteerStatusChangeResponseDTO struct {
+	ID         int       `json:"id"`
+	FromStatus int       `json:"from_status"`
+	ToStatus   int       `json:"to_status"`
+	Reason     string    `json:"reason"`
+	ChangedBy  int       `json:"changed_by"`
+	CreatedAt  time.Time `json:"created_at"`
+}
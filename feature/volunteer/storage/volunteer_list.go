@@ -0,0 +1,181 @@
+package storage
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/database"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+	"gorm.io/gorm"
+)
+
+// volunteerSortColumns maps the sort keys accepted by the directory to
+// their columns.
+var volunteerSortColumns = map[string]string{
+	"id":         "volunteer_details.id",
+	"name":       "users.name",
+	"surname":    "users.surname",
+	"email":      "users.email",
+	"gender":     "users.gender",
+	"role":       "roles.code",
+	"department": "departments.name",
+	"country":    "countries.name",
+	"status":     "volunteer_details.status",
+	"created_at": "volunteer_details.created_at",
+}
+
+// IsVolunteerSortKey reports whether key can be used in VolunteerSort.
+func IsVolunteerSortKey(key string) bool {
+	_, ok := volunteerSortColumns[key]
+	return ok
+}
+
+type VolunteerSort struct {
+	Key  string
+	Desc bool
+}
+
+// VolunteerFilter narrows down and orders the volunteer directory. Zero
+// values mean "no filter"; deleted volunteers, and volunteers whose user is
+// deleted, are left out unless IncludeDeleted is set.
+type VolunteerFilter struct {
+	Search         string
+	Gender         string
+	Role           string
+	DepartmentID   *int
+	CountryID      *int
+	Status         *int
+	IncludeDeleted bool
+	Sort           []VolunteerSort
+	Limit          int
+	Offset         int
+}
+
+// ListVolunteers returns one page of the volunteer directory together with
+// the number of volunteers matching filter across all pages.
+func (r *VolunteerRepository) ListVolunteers(filter VolunteerFilter) ([]*domain.VolunteerListItem, int64, error) {
+	var total int64
+	if err := r.filterVolunteers(filter).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	query := r.filterVolunteers(filter).Select(
+		"volunteer_details.id, volunteer_details.user_id, users.name, users.surname, users.email, users.gender, users.mobile, " +
+			"users.role_id, roles.name AS role_name, volunteer_details.department_id, departments.name AS department_name, " +
+			"users.country_id, countries.name AS country_name, volunteer_details.status, volunteer_details.created_at, " +
+			"volunteer_details.deleted_at, users.deleted_at AS user_deleted_at",
+	)
+	for _, sort := range filter.Sort {
+		direction := " ASC"
+		if sort.Desc {
+			direction = " DESC"
+		}
+		query = query.Order(volunteerSortColumns[sort.Key] + direction)
+	}
+	// the id keeps the order stable across pages when the sort keys tie
+	query = query.Order("volunteer_details.id ASC")
+
+	rows := []*volunteerListRow{}
+	err := query.Limit(filter.Limit).Offset(filter.Offset).Scan(&rows).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	items := make([]*domain.VolunteerListItem, len(rows))
+	for i, row := range rows {
+		if row.DeletedAt == nil {
+			row.DeletedAt = row.UserDeletedAt
+		}
+		items[i] = &row.VolunteerListItem
+	}
+	return items, total, nil
+}
+
+// volunteerListRow reads both deletion times: SQLite loses the type of
+// timestamps going through COALESCE.
+type volunteerListRow struct {
+	domain.VolunteerListItem
+	UserDeletedAt *time.Time
+}
+
+func (r *VolunteerRepository) filterVolunteers(filter VolunteerFilter) *gorm.DB {
+	query := r.DB.Table("volunteer_details").
+		Joins("JOIN users ON users.id = volunteer_details.user_id").
+		Joins("JOIN departments ON departments.id = volunteer_details.department_id").
+		Joins("JOIN roles ON roles.id = users.role_id").
+		Joins("LEFT JOIN countries ON countries.id = users.country_id")
+	if !filter.IncludeDeleted {
+		query = query.Where("volunteer_details.deleted_at IS NULL").Where("users.deleted_at IS NULL")
+	}
+	if condition, args := searchCondition(database.Dialect(r.DB), filter.Search); condition != "" {
+		query = query.Where(condition, args...)
+	}
+	if filter.Gender != "" {
+		query = query.Where("users.gender = ?", filter.Gender)
+	}
+	if filter.Role != "" {
+		query = query.Where("roles.code = ?", filter.Role)
+	}
+	if filter.DepartmentID != nil {
+		query = query.Where("volunteer_details.department_id = ?", *filter.DepartmentID)
+	}
+	if filter.CountryID != nil {
+		query = query.Where("users.country_id = ?", *filter.CountryID)
+	}
+	if filter.Status != nil {
+		query = query.Where("volunteer_details.status = ?", *filter.Status)
+	}
+	return query
+}
+
+// searchCondition matches search against the name, surname and email of
+// users. Every word must match, as a prefix of a word of these columns on
+// MySQL and PostgreSQL, which use their full-text index, and anywhere in them
+// on SQLite. A numeric search also matches the volunteer and user ids. The
+// condition is empty when search holds no word.
+func searchCondition(dialect, search string) (string, []interface{}) {
+	words := strings.FieldsFunc(search, isSearchSeparator)
+	if len(words) == 0 {
+		return "", nil
+	}
+	var condition string
+	var args []interface{}
+	switch dialect {
+	case database.DriverPostgres:
+		terms := make([]string, len(words))
+		for i, word := range words {
+			terms[i] = word + ":*"
+		}
+		// the expression of the ft_users_name_email index
+		condition = "to_tsvector('simple', users.name || ' ' || users.surname || ' ' || users.email) @@ to_tsquery('simple', ?)"
+		args = append(args, strings.Join(terms, " & "))
+	case database.DriverSQLite:
+		conditions := make([]string, len(words))
+		for i, word := range words {
+			conditions[i] = "(users.name LIKE ? ESCAPE '\\' OR users.surname LIKE ? ESCAPE '\\' OR users.email LIKE ? ESCAPE '\\')"
+			pattern := "%" + likeEscaper.Replace(word) + "%"
+			args = append(args, pattern, pattern, pattern)
+		}
+		condition = strings.Join(conditions, " AND ")
+	default:
+		terms := make([]string, len(words))
+		for i, word := range words {
+			terms[i] = "+" + word + "*"
+		}
+		condition = "MATCH(users.name, users.surname, users.email) AGAINST (? IN BOOLEAN MODE)"
+		args = append(args, strings.Join(terms, " "))
+	}
+	if id, err := strconv.Atoi(strings.TrimSpace(search)); err == nil {
+		condition += " OR volunteer_details.id = ? OR users.id = ?"
+		args = append(args, id, id)
+	}
+	return condition, args
+}
+
+var likeEscaper = strings.NewReplacer("\\", "\\\\", "%", "\\%", "_", "\\_")
+
+// isSearchSeparator splits on the characters that have a meaning in
+// full-text queries, so user input cannot change the query semantics.
+func isSearchSeparator(r rune) bool {
+	return strings.ContainsRune(" \t\n+-<>()~*\"@.&|!:'\\", r)
+}
@@ -0,0 +1,118 @@
+package storage
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func setupSQLMock(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{})
+	assert.NoError(t, err)
+	return gormDB, mock
+}
+
+const volunteerJoins = "FROM `volunteer_details` JOIN users ON users.id = volunteer_details.user_id " +
+	"JOIN departments ON departments.id = volunteer_details.department_id " +
+	"JOIN roles ON roles.id = users.role_id " +
+	"LEFT JOIN countries ON countries.id = users.country_id"
+
+const notDeleted = "WHERE volunteer_details.deleted_at IS NULL AND users.deleted_at IS NULL"
+
+func TestListVolunteers(t *testing.T) {
+	t.Run("search, filters and sort", func(t *testing.T) {
+		db, mock := setupSQLMock(t)
+		repo := NewVolunteerRepository(db)
+		departmentID := 2
+
+		where := notDeleted + " AND MATCH(users.name, users.surname, users.email) AGAINST (? IN BOOLEAN MODE) AND users.gender = ? AND roles.code = ? AND volunteer_details.department_id = ?"
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) "+volunteerJoins+" "+where)).
+			WithArgs("+jane* +example* +com*", "female", "cvl", 2).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT volunteer_details.id, volunteer_details.user_id, users.name")).
+			WithArgs("+jane* +example* +com*", "female", "cvl", 2, 2, 2).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "role_name", "department_name", "country_name"}).
+				AddRow(5, 7, "Jane", "CVL", "Hanoi", nil))
+
+		items, total, err := repo.ListVolunteers(VolunteerFilter{
+			Search:       "jane@example.com",
+			Gender:       "female",
+			Role:         "cvl",
+			DepartmentID: &departmentID,
+			Sort:         []VolunteerSort{{Key: "role"}, {Key: "name", Desc: true}},
+			Limit:        2,
+			Offset:       2,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), total)
+		assert.Len(t, items, 1)
+		assert.Equal(t, "CVL", items[0].RoleName)
+		assert.Equal(t, "Hanoi", items[0].DepartmentName)
+		assert.Nil(t, items[0].CountryName)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("order and pagination", func(t *testing.T) {
+		db, mock := setupSQLMock(t)
+		repo := NewVolunteerRepository(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) " + volunteerJoins + " " + notDeleted)).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectQuery(regexp.QuoteMeta(volunteerJoins+" "+notDeleted+" ORDER BY roles.code ASC,users.name DESC,volunteer_details.id ASC LIMIT ? OFFSET ?")).
+			WithArgs(2, 2).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		_, _, err := repo.ListVolunteers(VolunteerFilter{
+			Sort:   []VolunteerSort{{Key: "role"}, {Key: "name", Desc: true}},
+			Limit:  2,
+			Offset: 2,
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("numeric search matches ids", func(t *testing.T) {
+		db, mock := setupSQLMock(t)
+		repo := NewVolunteerRepository(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta(notDeleted+" AND (MATCH(users.name, users.surname, users.email) AGAINST (? IN BOOLEAN MODE) OR volunteer_details.id = ? OR users.id = ?) AND volunteer_details.status = ?")).
+			WithArgs("+42*", 42, 42, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectQuery("SELECT volunteer_details.id").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		status := 1
+		_, _, err := repo.ListVolunteers(VolunteerFilter{Search: "42", Status: &status, Limit: 20})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("include deleted", func(t *testing.T) {
+		db, mock := setupSQLMock(t)
+		repo := NewVolunteerRepository(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) " + volunteerJoins + " WHERE volunteer_details.status = ?")).
+			WithArgs(0).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectQuery(regexp.QuoteMeta("volunteer_details.deleted_at, users.deleted_at AS user_deleted_at "+volunteerJoins+" WHERE volunteer_details.status = ?")).
+			WithArgs(0, 20).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "deleted_at", "user_deleted_at"}).AddRow(5, nil, time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)))
+
+		status := 0
+		items, _, err := repo.ListVolunteers(VolunteerFilter{Status: &status, IncludeDeleted: true, Limit: 20})
+		assert.NoError(t, err)
+		assert.Len(t, items, 1)
+		assert.NotNil(t, items[0].DeletedAt)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
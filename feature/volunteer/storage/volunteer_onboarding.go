@@ -0,0 +1,104 @@
+package storage
+
+import (
+	"errors"
+
+	requestDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/request/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// OnboardVolunteer makes a user a volunteer in one transaction, the way an
+// approved verification request does, without the user going through the
+// requests. The user is created when onboarding.NewUser is set, otherwise
+// the existing user is locked and checked. The user gets the volunteer role,
+// the department and a verified email; pending requests of the user are
+// cancelled and an approved verification request reviewed by the admin is
+// recorded so the audit trail is complete.
+func (r *VolunteerRepository) OnboardVolunteer(onboarding domain.Onboarding) (*domain.Volunteer, error) {
+	var volunteer *domain.Volunteer
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		var department int64
+		if err := tx.Table("departments").Where("id = ? AND deleted_at IS NULL", onboarding.DepartmentID).Count(&department).Error; err != nil {
+			return err
+		}
+		if department == 0 {
+			return domain.ErrDepartmentNotFound
+		}
+
+		user, err := onboardUser(tx, onboarding)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Model(user).Updates(map[string]interface{}{
+			"role_id":             onboarding.RoleID,
+			"department_id":       onboarding.DepartmentID,
+			"verification_status": 1,
+			"status":              domain.VolunteerStatusActive,
+		}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&requestDomain.Request{}).
+			Where("user_id = ? AND status = ?", user.ID, requestDomain.RequestStatusPending).
+			Update("status", requestDomain.RequestStatusCancelled).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&requestDomain.Request{
+			UserID:     user.ID,
+			Type:       requestDomain.RequestTypeVerification,
+			Status:     requestDomain.RequestStatusApproved,
+			VerifierID: &onboarding.AdminID,
+		}).Error; err != nil {
+			return err
+		}
+
+		volunteer = &domain.Volunteer{
+			UserID:       user.ID,
+			DepartmentID: onboarding.DepartmentID,
+			Status:       domain.VolunteerStatusActive,
+		}
+		return tx.Create(volunteer).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return volunteer, nil
+}
+
+// onboardUser creates onboarding.NewUser with the volunteer role, or locks
+// the existing user and makes sure it is not a volunteer yet.
+func onboardUser(tx *gorm.DB, onboarding domain.Onboarding) (*domain.User, error) {
+	if user := onboarding.NewUser; user != nil {
+		user.RoleID = onboarding.RoleID
+		var taken int64
+		if err := tx.Model(&domain.User{}).Where("email = ?", user.Email).Count(&taken).Error; err != nil {
+			return nil, err
+		}
+		if taken > 0 {
+			return nil, domain.ErrEmailTaken
+		}
+		if err := tx.Create(user).Error; err != nil {
+			return nil, err
+		}
+		return user, nil
+	}
+
+	var user domain.User
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&user, onboarding.UserID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var volunteers int64
+	if err := tx.Model(&domain.Volunteer{}).Where("user_id = ?", user.ID).Count(&volunteers).Error; err != nil {
+		return nil, err
+	}
+	if volunteers > 0 || user.RoleID == onboarding.RoleID {
+		return nil, domain.ErrAlreadyVolunteer
+	}
+	return &user, nil
+}
@@ -0,0 +1,131 @@
+package storage
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOnboardVolunteer(t *testing.T) {
+	department := regexp.QuoteMeta("SELECT count(*) FROM `departments` WHERE id = ? AND deleted_at IS NULL")
+	lockUser := regexp.QuoteMeta("SELECT * FROM `users` WHERE `users`.`id` = ? AND `users`.`deleted_at` IS NULL ORDER BY `users`.`id` LIMIT ? FOR UPDATE")
+	volunteers := regexp.QuoteMeta("SELECT count(*) FROM `volunteer_details` WHERE user_id = ?")
+	const volunteerRoleID = 6
+
+	t.Run("existing user", func(t *testing.T) {
+		db, mock := setupSQLMock(t)
+		repo := NewVolunteerRepository(db)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(department).WithArgs(2).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectQuery(lockUser).WithArgs(7, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "role_id", "email"}).AddRow(7, 1, "jane@example.com"))
+		mock.ExpectQuery(volunteers).WithArgs(7).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `users` SET `department_id`=?,`role_id`=?,`status`=?,`verification_status`=?,`updated_at`=? WHERE `users`.`deleted_at` IS NULL AND `id` = ?")).
+			WithArgs(2, volunteerRoleID, 1, 1, sqlmock.AnyArg(), 7).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `requests` SET `status`=?,`updated_at`=? WHERE (user_id = ? AND status = ?) AND `requests`.`deleted_at` IS NULL")).
+			WithArgs(3, sqlmock.AnyArg(), 7, 0).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `requests`")).
+			WithArgs(7, "verification", 1, 9, nil, sqlmock.AnyArg(), sqlmock.AnyArg(), nil).
+			WillReturnResult(sqlmock.NewResult(11, 1))
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `volunteer_details`")).
+			WithArgs(7, 2, 1, sqlmock.AnyArg(), sqlmock.AnyArg(), nil).
+			WillReturnResult(sqlmock.NewResult(3, 1))
+		mock.ExpectCommit()
+
+		volunteer, err := repo.OnboardVolunteer(domain.Onboarding{UserID: 7, DepartmentID: 2, AdminID: 9, RoleID: volunteerRoleID})
+		assert.NoError(t, err)
+		assert.Equal(t, 3, volunteer.ID)
+		assert.Equal(t, 7, volunteer.UserID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("new user", func(t *testing.T) {
+		db, mock := setupSQLMock(t)
+		repo := NewVolunteerRepository(db)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(department).WithArgs(2).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `users` WHERE email = ?")).
+			WithArgs("jane@example.com").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `users`")).WillReturnResult(sqlmock.NewResult(12, 1))
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `users` SET")).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `requests` SET")).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `requests`")).WillReturnResult(sqlmock.NewResult(11, 1))
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `volunteer_details`")).
+			WithArgs(12, 2, 1, sqlmock.AnyArg(), sqlmock.AnyArg(), nil).
+			WillReturnResult(sqlmock.NewResult(4, 1))
+		mock.ExpectCommit()
+
+		user := &domain.User{Email: "jane@example.com", Name: "Jane", Password: "hashed"}
+		volunteer, err := repo.OnboardVolunteer(domain.Onboarding{NewUser: user, DepartmentID: 2, AdminID: 9, RoleID: volunteerRoleID})
+		assert.NoError(t, err)
+		assert.Equal(t, 12, volunteer.UserID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("already a volunteer", func(t *testing.T) {
+		db, mock := setupSQLMock(t)
+		repo := NewVolunteerRepository(db)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(department).WithArgs(2).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectQuery(lockUser).WithArgs(7, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "role_id"}).AddRow(7, 1))
+		mock.ExpectQuery(volunteers).WithArgs(7).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectRollback()
+
+		_, err := repo.OnboardVolunteer(domain.Onboarding{UserID: 7, DepartmentID: 2, AdminID: 9, RoleID: volunteerRoleID})
+		assert.ErrorIs(t, err, domain.ErrAlreadyVolunteer)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("already holds the volunteer role", func(t *testing.T) {
+		db, mock := setupSQLMock(t)
+		repo := NewVolunteerRepository(db)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(department).WithArgs(2).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectQuery(lockUser).WithArgs(7, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "role_id"}).AddRow(7, volunteerRoleID))
+		mock.ExpectQuery(volunteers).WithArgs(7).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectRollback()
+
+		_, err := repo.OnboardVolunteer(domain.Onboarding{UserID: 7, DepartmentID: 2, AdminID: 9, RoleID: volunteerRoleID})
+		assert.ErrorIs(t, err, domain.ErrAlreadyVolunteer)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("email taken", func(t *testing.T) {
+		db, mock := setupSQLMock(t)
+		repo := NewVolunteerRepository(db)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(department).WithArgs(2).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `users` WHERE email = ?")).
+			WithArgs("jane@example.com").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectRollback()
+
+		_, err := repo.OnboardVolunteer(domain.Onboarding{NewUser: &domain.User{Email: "jane@example.com"}, DepartmentID: 2, AdminID: 9, RoleID: volunteerRoleID})
+		assert.ErrorIs(t, err, domain.ErrEmailTaken)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("unknown department", func(t *testing.T) {
+		db, mock := setupSQLMock(t)
+		repo := NewVolunteerRepository(db)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(department).WithArgs(5).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectRollback()
+
+		_, err := repo.OnboardVolunteer(domain.Onboarding{UserID: 7, DepartmentID: 5, AdminID: 9, RoleID: volunteerRoleID})
+		assert.ErrorIs(t, err, domain.ErrDepartmentNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -1,6 +1,8 @@
 package storage
 
 import (
+	"errors"
+
 	"gorm.io/gorm"
 
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
@@ -11,7 +13,12 @@ type VolunteerRepositoryInterface interface {
 	CreateVolunteer(volunteer *domain.Volunteer) error
 	UpdateVolunteer(volunteer *domain.Volunteer) error
 	DeleteVolunteer(id int) error
+	RestoreVolunteer(id int) error
 	FindVolunteerByID(id int) (*domain.Volunteer, error)
+	ListVolunteers(filter VolunteerFilter) ([]*domain.VolunteerListItem, int64, error)
+	ChangeVolunteerStatus(id int, to int, changedBy int, reason string) error
+	ListStatusChanges(id int) ([]*domain.VolunteerStatusChange, error)
+	OnboardVolunteer(onboarding domain.Onboarding) (*domain.Volunteer, error)
 }
 
 type VolunteerRepository struct {
@@ -34,9 +41,27 @@ func (r *VolunteerRepository) DeleteVolunteer(id int) error {
 	return r.DB.Delete(&domain.Volunteer{}, id).Error
 }
 
+// RestoreVolunteer clears deleted_at of a deleted volunteer.
+func (r *VolunteerRepository) RestoreVolunteer(id int) error {
+	result := r.DB.Unscoped().Model(&domain.Volunteer{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrVolunteerNotFound
+	}
+	return nil
+}
+
 func (r *VolunteerRepository) FindVolunteerByID(id int) (*domain.Volunteer, error) {
 	var volunteer *domain.Volunteer
-	if err := r.DB.First(&volunteer, id).Error; err != nil {
+	err := r.DB.First(&volunteer, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrVolunteerNotFound
+	}
+	if err != nil {
 		return nil, err
 	}
 	return volunteer, nil
@@ -1,116 +1,96 @@
 package storage
 
 import (
+	"regexp"
 	"testing"
 
+	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/mock"
-	"gorm.io/gorm"
 
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
 )
 
-type MockDB struct {
-	mock.Mock
-	gorm.DB
-}
-
-func (m *MockDB) Create(value interface{}) *gorm.DB {
-	args := m.Called(value)
-	return args.Get(0).(*gorm.DB)
-}
-
-func (m *MockDB) Save(value interface{}) *gorm.DB {
-	args := m.Called(value)
-	return args.Get(0).(*gorm.DB)
-}
-
-func (m *MockDB) Delete(value interface{}, conds ...interface{}) *gorm.DB {
-	args := m.Called(value, conds)
-	return args.Get(0).(*gorm.DB)
-}
-
-func (m *MockDB) First(dest interface{}, conds ...interface{}) *gorm.DB {
-	args := m.Called(dest, conds)
-	return args.Get(0).(*gorm.DB)
-}
-
 func TestCreateVolunteer(t *testing.T) {
-	mockDB := new(MockDB)
-	repo := NewVolunteerRepository(&mockDB.DB)
+	db, mock := setupSQLMock(t)
+	repo := NewVolunteerRepository(db)
 	volunteer := &domain.Volunteer{
 		ID:           1,
 		UserID:       5,
 		DepartmentID: 3,
 		Status:       0,
 	}
-	mockDB.On("Create", volunteer).Return(&gorm.DB{Error: nil})
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `volunteer_details`")).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
 
 	err := repo.CreateVolunteer(volunteer)
 
 	assert.NoError(t, err)
-	mockDB.AssertExpectations(t)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestUpdateVolunteer(t *testing.T) {
-	mockDB := new(MockDB)
-	repo := NewVolunteerRepository(&mockDB.DB)
+	db, mock := setupSQLMock(t)
+	repo := NewVolunteerRepository(db)
 
 	volunteer := &domain.Volunteer{
 		ID:           1,
 		DepartmentID: 4,
 		Status:       0,
 	}
-	mockDB.On("Save", volunteer).Return(&gorm.DB{Error: nil})
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `volunteer_details` SET")).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
 
 	err := repo.UpdateVolunteer(volunteer)
 
 	assert.NoError(t, err)
-	mockDB.AssertExpectations(t)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestDeleteVolunteer(t *testing.T) {
-	mockDB := new(MockDB)
-	repo := NewVolunteerRepository(&mockDB.DB)
+	db, mock := setupSQLMock(t)
+	repo := NewVolunteerRepository(db)
 
-	mockDB.On("Delete", &domain.Volunteer{}, 1).Return(&gorm.DB{Error: nil})
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `volunteer_details` SET `deleted_at`=? WHERE `volunteer_details`.`id` = ?")).
+		WithArgs(sqlmock.AnyArg(), 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
 
 	err := repo.DeleteVolunteer(1)
 
 	assert.NoError(t, err)
-	mockDB.AssertExpectations(t)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestFindVolunteerByID(t *testing.T) {
-	mockDB := new(MockDB)
-	repo := NewVolunteerRepository(&mockDB.DB)
+	db, mock := setupSQLMock(t)
+	repo := NewVolunteerRepository(db)
 
-	volunteer := &domain.Volunteer{
-		ID:           1,
-		DepartmentID: 4,
-		Status:       0,
-	}
-	mockDB.On("First", &volunteer, 1).Return(&gorm.DB{Error: nil}).Run(func(args mock.Arguments) {
-		arg := args.Get(0).(**domain.Volunteer)
-		*arg = volunteer
-	})
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `volunteer_details` WHERE `volunteer_details`.`id` = ?")).
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "department_id", "status"}).AddRow(1, 5, 4, 0))
 
 	result, err := repo.FindVolunteerByID(1)
 
 	assert.NoError(t, err)
-	assert.Equal(t, volunteer, result)
-	mockDB.AssertExpectations(t)
+	assert.Equal(t, 1, result.ID)
+	assert.Equal(t, 4, result.DepartmentID)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestFindVolunteerByID_NotFound(t *testing.T) {
-	mockDB := new(MockDB)
-	repo := NewVolunteerRepository(&mockDB.DB)
+	db, mock := setupSQLMock(t)
+	repo := NewVolunteerRepository(db)
 
-	mockDB.On("First", mock.Anything, 1).Return(&gorm.DB{Error: gorm.ErrRecordNotFound})
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `volunteer_details` WHERE `volunteer_details`.`id` = ?")).
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
 
 	result, err := repo.FindVolunteerByID(1)
 
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, domain.ErrVolunteerNotFound)
 	assert.Nil(t, result)
-	mockDB.AssertExpectations(t)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -0,0 +1,133 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/database/databasetest"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func seedVolunteers(t *testing.T, db *gorm.DB) {
+	databasetest.Exec(t, db,
+		"INSERT INTO roles (id, code, name) VALUES (1, 'applicant', 'applicant'), (2, 'cvl', 'CVL')",
+		"INSERT INTO departments (id, name, address, status) VALUES (1, 'Hanoi', 'Hoan Kiem', 1)",
+		"INSERT INTO countries (id, name, status) VALUES (1, 'Vietnam', 1)",
+		`INSERT INTO users (id, role_id, email, password, name, surname, gender, dob, mobile, country_id, resident_country_id, status) VALUES
+			(1, 2, 'jane.doe@example.com', 'x', 'Jane', 'Doe', 'female', '1990-01-01', '1', 1, 1, 1),
+			(2, 2, 'john@example.org', 'x', 'John', 'Smith', 'male', '1990-01-01', '2', 1, 1, 1),
+			(3, 2, 'jan_e@example.com', 'x', 'Jan', 'Eyre', 'female', '1990-01-01', '3', 1, 1, 1)`,
+		"INSERT INTO volunteer_details (id, user_id, department_id, status) VALUES (1, 1, 1, 1), (2, 2, 1, 1), (3, 3, 1, 0)",
+	)
+}
+
+func TestListVolunteersSQLite(t *testing.T) {
+	db := databasetest.Open(t)
+	seedVolunteers(t, db)
+	repo := NewVolunteerRepository(db)
+
+	t.Run("every word matches", func(t *testing.T) {
+		items, total, err := repo.ListVolunteers(VolunteerFilter{Search: "jane example", Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+		require.Len(t, items, 1)
+		assert.Equal(t, "Jane", items[0].Name)
+		assert.Equal(t, "CVL", items[0].RoleName)
+		assert.Equal(t, "Hanoi", items[0].DepartmentName)
+		assert.Equal(t, "Vietnam", *items[0].CountryName)
+		assert.False(t, items[0].CreatedAt.IsZero())
+		assert.Nil(t, items[0].DeletedAt)
+	})
+
+	t.Run("wildcards are literal", func(t *testing.T) {
+		items, _, err := repo.ListVolunteers(VolunteerFilter{Search: "jan_e", Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		assert.Equal(t, "Jan", items[0].Name)
+	})
+
+	t.Run("numeric search and filters", func(t *testing.T) {
+		status := 1
+		items, total, err := repo.ListVolunteers(VolunteerFilter{
+			Search: "2",
+			Status: &status,
+			Sort:   []VolunteerSort{{Key: "name", Desc: true}},
+			Limit:  10,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+		require.Len(t, items, 1)
+		assert.Equal(t, 2, items[0].ID)
+	})
+
+	t.Run("deleted volunteers", func(t *testing.T) {
+		require.NoError(t, repo.DeleteVolunteer(1))
+
+		_, total, err := repo.ListVolunteers(VolunteerFilter{Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), total)
+
+		items, total, err := repo.ListVolunteers(VolunteerFilter{IncludeDeleted: true, Sort: []VolunteerSort{{Key: "id"}}, Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), total)
+		require.NotNil(t, items[0].DeletedAt)
+	})
+}
+
+func TestChangeVolunteerStatusSQLite(t *testing.T) {
+	db := databasetest.Open(t)
+	seedVolunteers(t, db)
+	repo := NewVolunteerRepository(db)
+
+	require.NoError(t, repo.ChangeVolunteerStatus(3, 1, 1, "documents checked"))
+
+	changes, err := repo.ListStatusChanges(3)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, 0, changes[0].FromStatus)
+	assert.Equal(t, 1, changes[0].ToStatus)
+	volunteer, err := repo.FindVolunteerByID(3)
+	require.NoError(t, err)
+	assert.Equal(t, 1, volunteer.Status)
+}
+
+func TestOnboardNewVolunteerSQLite(t *testing.T) {
+	db := databasetest.Open(t)
+	seedVolunteers(t, db)
+	databasetest.Exec(t, db, "INSERT INTO roles (id, code, name) VALUES (3, 'volunteer', 'Volunteer')")
+	repo := NewVolunteerRepository(db)
+
+	dob := time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)
+	country := 1
+	volunteer, err := repo.OnboardVolunteer(domain.Onboarding{
+		NewUser: &domain.User{
+			Email:             "new@example.com",
+			Password:          "hashed",
+			Name:              "New",
+			Dob:               &dob,
+			CountryID:         &country,
+			ResidentCountryID: &country,
+		},
+		DepartmentID: 1,
+		AdminID:      1,
+		RoleID:       3,
+	})
+	require.NoError(t, err)
+
+	var user domain.User
+	require.NoError(t, db.First(&user, volunteer.UserID).Error)
+	assert.Equal(t, 3, user.RoleID)
+	assert.Equal(t, 1, *user.DepartmentID)
+	assert.Equal(t, 1, user.VerificationStatus)
+	var approved int64
+	require.NoError(t, db.Table("requests").Where("user_id = ? AND verifier_id = 1 AND status = 1", user.ID).Count(&approved).Error)
+	assert.Equal(t, int64(1), approved)
+
+	// deleted departments take no new volunteers
+	databasetest.Exec(t, db, "UPDATE departments SET deleted_at = CURRENT_TIMESTAMP WHERE id = 1")
+	_, err = repo.OnboardVolunteer(domain.Onboarding{UserID: 3, DepartmentID: 1, AdminID: 1, RoleID: 3})
+	assert.ErrorIs(t, err, domain.ErrDepartmentNotFound)
+}
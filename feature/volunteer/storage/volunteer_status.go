@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"errors"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ChangeVolunteerStatus moves a volunteer to status to, applies the same
+// status to its user account and records the change, in one transaction.
+// It fails with ErrVolunteerAlreadyActive or ErrVolunteerAlreadyInactive when
+// the volunteer has that status already.
+func (r *VolunteerRepository) ChangeVolunteerStatus(id int, to int, changedBy int, reason string) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		var volunteer domain.Volunteer
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&volunteer, id).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.ErrVolunteerNotFound
+		}
+		if err != nil {
+			return err
+		}
+		from := volunteer.Status
+		if from == to {
+			if to == domain.VolunteerStatusActive {
+				return domain.ErrVolunteerAlreadyActive
+			}
+			return domain.ErrVolunteerAlreadyInactive
+		}
+
+		if err := tx.Model(&volunteer).Update("status", to).Error; err != nil {
+			return err
+		}
+		if err := tx.Table("users").Where("id = ?", volunteer.UserID).Update("status", to).Error; err != nil {
+			return err
+		}
+		return tx.Create(&domain.VolunteerStatusChange{
+			VolunteerID: volunteer.ID,
+			FromStatus:  from,
+			ToStatus:    to,
+			Reason:      reason,
+			ChangedBy:   changedBy,
+		}).Error
+	})
+}
+
+// ListStatusChanges returns the status history of a volunteer, newest first.
+func (r *VolunteerRepository) ListStatusChanges(id int) ([]*domain.VolunteerStatusChange, error) {
+	changes := []*domain.VolunteerStatusChange{}
+	err := r.DB.Where("volunteer_id = ?", id).Order("created_at DESC").Order("id DESC").Find(&changes).Error
+	return changes, err
+}
@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChangeVolunteerStatus(t *testing.T) {
+	lock := regexp.QuoteMeta("SELECT * FROM `volunteer_details` WHERE `volunteer_details`.`id` = ? AND `volunteer_details`.`deleted_at` IS NULL ORDER BY `volunteer_details`.`id` LIMIT ? FOR UPDATE")
+
+	t.Run("deactivate", func(t *testing.T) {
+		db, mock := setupSQLMock(t)
+		repo := NewVolunteerRepository(db)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lock).WithArgs(5, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "department_id", "status"}).AddRow(5, 7, 2, 1))
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `volunteer_details` SET `status`=?,`updated_at`=? WHERE `volunteer_details`.`deleted_at` IS NULL AND `id` = ?")).
+			WithArgs(0, sqlmock.AnyArg(), 5).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `users` SET `status`=? WHERE id = ?")).
+			WithArgs(0, 7).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `volunteer_status_history` (`volunteer_id`,`from_status`,`to_status`,`reason`,`changed_by`,`created_at`) VALUES (?,?,?,?,?,?)")).
+			WithArgs(5, 1, 0, "moved abroad", 9, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		assert.NoError(t, repo.ChangeVolunteerStatus(5, domain.VolunteerStatusInactive, 9, "moved abroad"))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("already inactive", func(t *testing.T) {
+		db, mock := setupSQLMock(t)
+		repo := NewVolunteerRepository(db)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lock).WithArgs(5, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "department_id", "status"}).AddRow(5, 7, 2, 0))
+		mock.ExpectRollback()
+
+		err := repo.ChangeVolunteerStatus(5, domain.VolunteerStatusInactive, 9, "moved abroad")
+		assert.ErrorIs(t, err, domain.ErrVolunteerAlreadyInactive)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("unknown volunteer", func(t *testing.T) {
+		db, mock := setupSQLMock(t)
+		repo := NewVolunteerRepository(db)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lock).WithArgs(5, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectRollback()
+
+		err := repo.ChangeVolunteerStatus(5, domain.VolunteerStatusActive, 9, "back")
+		assert.ErrorIs(t, err, domain.ErrVolunteerNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRestoreVolunteer(t *testing.T) {
+	db, mock := setupSQLMock(t)
+	repo := NewVolunteerRepository(db)
+
+	restore := regexp.QuoteMeta("UPDATE `volunteer_details` SET `deleted_at`=?,`updated_at`=? WHERE id = ? AND deleted_at IS NOT NULL")
+	mock.ExpectBegin()
+	mock.ExpectExec(restore).WithArgs(nil, sqlmock.AnyArg(), 5).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec(restore).WithArgs(nil, sqlmock.AnyArg(), 6).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	assert.NoError(t, repo.RestoreVolunteer(5))
+	assert.ErrorIs(t, repo.RestoreVolunteer(6), domain.ErrVolunteerNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,52 @@
+package transport
+
+import (
+	"net/http"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/validation"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type OnboardingHandler struct {
+	usecase usecase.OnboardingUsecaseInterface
+}
+
+func NewOnboardingHandler(usecase usecase.OnboardingUsecaseInterface) *OnboardingHandler {
+	return &OnboardingHandler{usecase: usecase}
+}
+
+// OnboardVolunteer godoc
+// @Summary Onboard volunteer
+// @Description Make an existing user, or a new one, a volunteer without registration and verification requests. An approved verification request is recorded for the audit trail. A new user is emailed a link to choose a password.
+// @Accept json
+// @Produce json
+// @Tags admin
+// @Param request body dto.VolunteerOnboardDTO true "Existing user_id, or the new user"
+// @Success 201 {object} dto.VolunteerResponseDTO
+// @Failure 400 {object} apperror.Response "Invalid input or unknown department"
+// @Failure 404 {object} apperror.Response "User not found"
+// @Failure 409 {object} apperror.Response "User is already a volunteer or email is taken"
+// @Security bearerToken
+// @Router /api/v1/admin/volunteers/onboard [post]
+func (h *OnboardingHandler) OnboardVolunteer(c *gin.Context) {
+	userId, exists := c.Get("userId")
+	if !exists {
+		apperror.Abort(c, apperror.ErrUnauthorized)
+		return
+	}
+	var input dto.VolunteerOnboardDTO
+	if err := c.ShouldBindJSON(&input); err != nil {
+		apperror.Abort(c, validation.Error(err))
+		return
+	}
+
+	volunteer, err := h.usecase.OnboardVolunteer(userId.(int), input)
+	if err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, volunteer)
+}
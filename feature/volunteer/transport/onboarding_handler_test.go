@@ -0,0 +1,69 @@
+package transport
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockOnboardingUsecase struct {
+	mock.Mock
+}
+
+func (m *MockOnboardingUsecase) OnboardVolunteer(adminID int, input dto.VolunteerOnboardDTO) (*dto.VolunteerResponseDTO, error) {
+	args := m.Called(adminID, input)
+	if args.Get(0) != nil {
+		return args.Get(0).(*dto.VolunteerResponseDTO), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func TestOnboardVolunteer(t *testing.T) {
+	mockUsecase := new(MockOnboardingUsecase)
+	handler := NewOnboardingHandler(mockUsecase)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.Use(apperror.Middleware())
+	r.Use(func(c *gin.Context) { c.Set("userId", 9) })
+	r.POST("/api/v1/admin/volunteers/onboard", handler.OnboardVolunteer)
+
+	mockUsecase.On("OnboardVolunteer", 9, dto.VolunteerOnboardDTO{UserID: 7, DepartmentID: 2}).
+		Return(&dto.VolunteerResponseDTO{ID: 3, UserID: 7, DepartmentID: 2, Status: 1}, nil)
+	mockUsecase.On("OnboardVolunteer", 9, dto.VolunteerOnboardDTO{UserID: 8, DepartmentID: 2}).Return(nil, domain.ErrAlreadyVolunteer)
+	mockUsecase.On("OnboardVolunteer", 9, dto.VolunteerOnboardDTO{UserID: 99, DepartmentID: 2}).Return(nil, domain.ErrUserNotFound)
+	mockUsecase.On("OnboardVolunteer", 9, dto.VolunteerOnboardDTO{DepartmentID: 2}).Return(nil, usecase.ErrInvalidOnboarding)
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+	}{
+		{"onboarded", `{"user_id":7,"department_id":2}`, http.StatusCreated},
+		{"already a volunteer", `{"user_id":8,"department_id":2}`, http.StatusConflict},
+		{"unknown user", `{"user_id":99,"department_id":2}`, http.StatusNotFound},
+		{"no user", `{"department_id":2}`, http.StatusBadRequest},
+		{"missing department", `{"user_id":7}`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, "/api/v1/admin/volunteers/onboard", strings.NewReader(tt.body))
+			assert.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.status, rr.Code)
+		})
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/validation"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/usecase"
 	"github.com/gin-gonic/gin"
@@ -19,21 +21,23 @@ func NewVolunteerHandler(volUsecase usecase.VolunteerUsecaseInterface) *Voluntee
 
 // CreateVolunteer godoc
 // @Summary Create volunteer
-// @Description Create volunteer
+// @Description Create volunteer. Admins only.
 // @Produce json
 // @Tags volunteer
 // @Param request body dto.VolunteerCreateDTO true "Create Volunteer Request"
 // @Success 201 {string} message "Volunteer created successfully"
+// @Failure 403 {object} apperror.Response "Not an admin"
+// @Security bearerToken
 // @Router /api/v1/volunteer/ [post]
 func (h *VolunteerHandler) CreateVolunteer(c *gin.Context) {
 	var input dto.VolunteerCreateDTO
 	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apperror.Abort(c, validation.Error(err))
 		return
 	}
 
 	if err := h.VolUsecaseH.CreateVolunteer(input); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apperror.Abort(c, err)
 		return
 	}
 
@@ -42,28 +46,30 @@ func (h *VolunteerHandler) CreateVolunteer(c *gin.Context) {
 
 // UpdateVolunteer godoc
 // @Summary Update volunteer
-// @Description Update volunteer
+// @Description Update volunteer. Volunteers can update their own record, admins anyone's.
 // @Produce json
 // @Tags volunteer
 // @Param id path int true "Volunteer ID"
 // @Param request body dto.VolunteerUpdateDTO true "Update Volunteer Request"
 // @Success 200 {string} message "Volunteer updated successfully"
+// @Failure 403 {object} apperror.Response "Not the volunteer nor an admin"
+// @Security bearerToken
 // @Router /api/v1/volunteer/{id} [put]
 func (h *VolunteerHandler) UpdateVolunteer(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid volunteer ID"})
+		apperror.Abort(c, apperror.New(apperror.Validation, "Invalid volunteer ID"))
 		return
 	}
 
 	var input dto.VolunteerUpdateDTO
 	if err = c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apperror.Abort(c, validation.Error(err))
 		return
 	}
 
 	if err := h.VolUsecaseH.UpdateVolunteer(id, input); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apperror.Abort(c, err)
 		return
 	}
 
@@ -72,21 +78,22 @@ func (h *VolunteerHandler) UpdateVolunteer(c *gin.Context) {
 
 // DeleteVolunteer godoc
 // @Summary Delete volunteer
-// @Description Delete volunteer
+// @Description Delete a volunteer, who can be restored until purged
 // @Produce json
-// @Tags volunteer
+// @Tags admin
 // @Param id path int true "Volunteer ID"
 // @Success 200 {string} message "Volunteer deleted successfully"
-// @Router /api/v1/volunteer/{id} [delete]
+// @Security bearerToken
+// @Router /api/v1/admin/volunteers/{id} [delete]
 func (h *VolunteerHandler) DeleteVolunteer(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid volunteer ID"})
+		apperror.Abort(c, apperror.New(apperror.Validation, "Invalid volunteer ID"))
 		return
 	}
 
 	if err = h.VolUsecaseH.DeleteVolunteer(id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apperror.Abort(c, err)
 		return
 	}
 
@@ -95,24 +102,180 @@ func (h *VolunteerHandler) DeleteVolunteer(c *gin.Context) {
 
 // FindVolunteerByID godoc
 // @Summary Find volunteer by ID
-// @Description Find volunteer by ID
+// @Description Find volunteer by ID. Volunteers can read their own record, admins anyone's.
 // @Produce json
 // @Tags volunteer
 // @Param id path int true "Volunteer ID"
 // @Success 200 {object} domain.Volunteer
+// @Failure 403 {object} apperror.Response "Not the volunteer nor an admin"
+// @Security bearerToken
 // @Router /api/v1/volunteer/{id} [get]
 func (h *VolunteerHandler) FindVolunteerByID(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid id"})
+		apperror.Abort(c, apperror.New(apperror.Validation, "Invalid id"))
 		return
 	}
 
 	volunteer, err := h.VolUsecaseH.FindVolunteerByID(id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Volunteer not found"})
+		apperror.Abort(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, volunteer)
 }
+
+// ListVolunteers godoc
+// @Summary List volunteers
+// @Description Volunteer directory with search, filters, multi-column sorting and pagination
+// @Produce json
+// @Tags admin
+// @Param q query string false "Search by name, surname or email, or by volunteer or user ID"
+// @Param gender query string false "Gender"
+// @Param role query string false "Role code, e.g. com, cvl or mnvc"
+// @Param department_id query int false "Department ID"
+// @Param country_id query int false "Country ID"
+// @Param status query int false "Volunteer status"
+// @Param include_deleted query bool false "Also list deleted volunteers"
+// @Param sort query string false "Comma separated keys among id, name, surname, email, gender, role, department, country, status, created_at; prefix with - for descending order"
+// @Param page query int false "Page number, starting at 1"
+// @Param limit query int false "Page size, 1 to 100 (default 20)"
+// @Success 200 {object} dto.VolunteerPage
+// @Failure 400 {object} apperror.Response "Invalid query"
+// @Security bearerToken
+// @Router /api/v1/admin/volunteers [get]
+func (h *VolunteerHandler) ListVolunteers(c *gin.Context) {
+	var query dto.VolunteerListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		apperror.Abort(c, validation.Error(err))
+		return
+	}
+	page, err := h.VolUsecaseH.ListVolunteers(query)
+	if err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, page)
+}
+
+// DeactivateVolunteer godoc
+// @Summary Deactivate volunteer
+// @Description Deactivate a volunteer and its user account, recording the reason
+// @Produce json
+// @Tags admin
+// @Param id path int true "Volunteer ID"
+// @Param request body dto.VolunteerStatusChangeDTO true "Deactivation reason"
+// @Success 200 {string} message "Volunteer deactivated successfully"
+// @Failure 404 {object} apperror.Response "Volunteer not found"
+// @Failure 409 {object} apperror.Response "Volunteer is already inactive"
+// @Security bearerToken
+// @Router /api/v1/admin/volunteers/{id}/deactivate [post]
+func (h *VolunteerHandler) DeactivateVolunteer(c *gin.Context) {
+	h.changeStatus(c, h.VolUsecaseH.DeactivateVolunteer, "Volunteer deactivated successfully")
+}
+
+// ReactivateVolunteer godoc
+// @Summary Reactivate volunteer
+// @Description Reactivate a volunteer and its user account, recording the reason
+// @Produce json
+// @Tags admin
+// @Param id path int true "Volunteer ID"
+// @Param request body dto.VolunteerStatusChangeDTO true "Reactivation reason"
+// @Success 200 {string} message "Volunteer reactivated successfully"
+// @Failure 404 {object} apperror.Response "Volunteer not found"
+// @Failure 409 {object} apperror.Response "Volunteer is already active"
+// @Security bearerToken
+// @Router /api/v1/admin/volunteers/{id}/reactivate [post]
+func (h *VolunteerHandler) ReactivateVolunteer(c *gin.Context) {
+	h.changeStatus(c, h.VolUsecaseH.ReactivateVolunteer, "Volunteer reactivated successfully")
+}
+
+func (h *VolunteerHandler) changeStatus(c *gin.Context, change func(int, int, dto.VolunteerStatusChangeDTO) error, message string) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apperror.Abort(c, apperror.New(apperror.Validation, "Invalid volunteer ID"))
+		return
+	}
+	userId, exists := c.Get("userId")
+	if !exists {
+		apperror.Abort(c, apperror.ErrUnauthorized)
+		return
+	}
+	var input dto.VolunteerStatusChangeDTO
+	if err := c.ShouldBindJSON(&input); err != nil {
+		apperror.Abort(c, validation.Error(err))
+		return
+	}
+	if err := change(id, userId.(int), input); err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": message})
+}
+
+// RestoreVolunteer godoc
+// @Summary Restore volunteer
+// @Description Restore a deleted volunteer
+// @Produce json
+// @Tags admin
+// @Param id path int true "Volunteer ID"
+// @Success 200 {string} message "Volunteer restored successfully"
+// @Failure 404 {object} apperror.Response "No deleted volunteer with this ID"
+// @Security bearerToken
+// @Router /api/v1/admin/volunteers/{id}/restore [post]
+func (h *VolunteerHandler) RestoreVolunteer(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apperror.Abort(c, apperror.New(apperror.Validation, "Invalid volunteer ID"))
+		return
+	}
+	userId, exists := c.Get("userId")
+	if !exists {
+		apperror.Abort(c, apperror.ErrUnauthorized)
+		return
+	}
+	if err := h.VolUsecaseH.RestoreVolunteer(id, userId.(int)); err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Volunteer restored successfully"})
+}
+
+// ListStatusChanges godoc
+// @Summary Volunteer status history
+// @Description Deactivations and reactivations of a volunteer, newest first
+// @Produce json
+// @Tags admin
+// @Param id path int true "Volunteer ID"
+// @Success 200 {array} dto.VolunteerStatusChangeResponseDTO
+// @Failure 404 {object} apperror.Response "Volunteer not found"
+// @Security bearerToken
+// @Router /api/v1/admin/volunteers/{id}/status-history [get]
+func (h *VolunteerHandler) ListStatusChanges(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apperror.Abort(c, apperror.New(apperror.Validation, "Invalid volunteer ID"))
+		return
+	}
+	changes, err := h.VolUsecaseH.ListStatusChanges(id)
+	if err != nil {
+		apperror.Abort(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, changes)
+}
+
+// Owner finds the user of the volunteer in the id path parameter, for
+// middleware.RequireOwnerOrRole.
+func (h *VolunteerHandler) Owner(c *gin.Context) (int, error) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return 0, apperror.New(apperror.Validation, "Invalid volunteer ID")
+	}
+	volunteer, err := h.VolUsecaseH.FindVolunteerByID(id)
+	if err != nil {
+		return 0, err
+	}
+	return volunteer.UserID, nil
+}
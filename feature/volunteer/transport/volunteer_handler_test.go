@@ -1,12 +1,13 @@
 package transport
 
 import (
-	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
 
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -32,9 +33,43 @@ func (m *MockVolunteerUsecase) DeleteVolunteer(id int) error {
 	return args.Error(0)
 }
 
+func (m *MockVolunteerUsecase) RestoreVolunteer(id int, adminID int) error {
+	args := m.Called(id, adminID)
+	return args.Error(0)
+}
+
 func (m *MockVolunteerUsecase) FindVolunteerByID(id int) (*dto.VolunteerResponseDTO, error) {
 	args := m.Called(id)
-	return args.Get(0).(*dto.VolunteerResponseDTO), args.Error(1)
+	if args.Get(0) != nil {
+		return args.Get(0).(*dto.VolunteerResponseDTO), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockVolunteerUsecase) DeactivateVolunteer(id int, adminID int, input dto.VolunteerStatusChangeDTO) error {
+	args := m.Called(id, adminID, input)
+	return args.Error(0)
+}
+
+func (m *MockVolunteerUsecase) ReactivateVolunteer(id int, adminID int, input dto.VolunteerStatusChangeDTO) error {
+	args := m.Called(id, adminID, input)
+	return args.Error(0)
+}
+
+func (m *MockVolunteerUsecase) ListStatusChanges(id int) ([]dto.VolunteerStatusChangeResponseDTO, error) {
+	args := m.Called(id)
+	if args.Get(0) != nil {
+		return args.Get(0).([]dto.VolunteerStatusChangeResponseDTO), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockVolunteerUsecase) ListVolunteers(query dto.VolunteerListQuery) (*dto.VolunteerPage, error) {
+	args := m.Called(query)
+	if args.Get(0) != nil {
+		return args.Get(0).(*dto.VolunteerPage), args.Error(1)
+	}
+	return nil, args.Error(1)
 }
 
 func TestCreateVolunteer(t *testing.T) {
@@ -43,17 +78,18 @@ func TestCreateVolunteer(t *testing.T) {
 
 	gin.SetMode(gin.TestMode)
 	r := gin.Default()
+	r.Use(apperror.Middleware())
 	r.POST("/api/v1/volunteer", handler.CreateVolunteer)
 
 	t.Run("success", func(t *testing.T) {
 		mockInput := dto.VolunteerCreateDTO{
 			UserID:       1,
 			DepartmentID: 2,
-			Status:       0,
+			Status:       1,
 		}
 		mockUsecase.On("CreateVolunteer", mockInput).Return(nil)
 
-		body := `{"user_id":1,"department_id":2,"status":"Active"}`
+		body := `{"user_id":1,"department_id":2,"status":1}`
 		req, err := http.NewRequest(http.MethodPost, "/api/v1/volunteer", strings.NewReader(body))
 		assert.NoError(t, err)
 		req.Header.Set("Content-Type", "application/json")
@@ -84,12 +120,12 @@ func TestUpdateVolunteer(t *testing.T) {
 
 	gin.SetMode(gin.TestMode)
 	r := gin.Default()
+	r.Use(apperror.Middleware())
 	r.PUT("/api/v1/volunteer/:id", handler.UpdateVolunteer)
 
 	t.Run("success", func(t *testing.T) {
 		mockInput := dto.VolunteerUpdateDTO{
 			DepartmentID: 2,
-			Status:       1,
 		}
 		mockUsecase.On("UpdateVolunteer", 1, mockInput).Return(nil)
 
@@ -124,6 +160,7 @@ func TestDeleteVolunteer(t *testing.T) {
 
 	gin.SetMode(gin.TestMode)
 	r := gin.Default()
+	r.Use(apperror.Middleware())
 	r.DELETE("/api/v1/volunteer/:id", handler.DeleteVolunteer)
 
 	t.Run("success", func(t *testing.T) {
@@ -156,6 +193,7 @@ func TestFindVolunteerByID(t *testing.T) {
 
 	gin.SetMode(gin.TestMode)
 	r := gin.Default()
+	r.Use(apperror.Middleware())
 	r.GET("/api/v1/volunteer/:id", handler.FindVolunteerByID)
 
 	t.Run("success", func(t *testing.T) {
@@ -178,9 +216,9 @@ func TestFindVolunteerByID(t *testing.T) {
 	})
 
 	t.Run("not found", func(t *testing.T) {
-		mockUsecase.On("FindVolunteerByID", 1).Return(nil, errors.New("volunteer not found"))
+		mockUsecase.On("FindVolunteerByID", 2).Return(nil, domain.ErrVolunteerNotFound)
 
-		req, err := http.NewRequest(http.MethodGet, "/api/v1/volunteer/1", nil)
+		req, err := http.NewRequest(http.MethodGet, "/api/v1/volunteer/2", nil)
 		assert.NoError(t, err)
 
 		rr := httptest.NewRecorder()
@@ -188,4 +226,4 @@ func TestFindVolunteerByID(t *testing.T) {
 
 		assert.Equal(t, http.StatusNotFound, rr.Code)
 	})
-}
\ No newline at end of file
+}
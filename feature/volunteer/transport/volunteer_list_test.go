@@ -0,0 +1,50 @@
+package transport
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListVolunteers(t *testing.T) {
+	mockUsecase := new(MockVolunteerUsecase)
+	handler := NewVolunteerHandler(mockUsecase)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.Use(apperror.Middleware())
+	r.GET("/api/v1/admin/volunteers", handler.ListVolunteers)
+
+	departmentID := 2
+	mockUsecase.On("ListVolunteers", dto.VolunteerListQuery{
+		Search:       "jane",
+		Gender:       "female",
+		DepartmentID: &departmentID,
+		Sort:         "role,-name",
+		Page:         2,
+	}).Return(&dto.VolunteerPage{Items: []dto.VolunteerListItemDTO{{ID: 5, RoleName: "CVL"}}, Page: 2, Limit: 20, Total: 21}, nil)
+	mockUsecase.On("ListVolunteers", dto.VolunteerListQuery{Sort: "password"}).Return(nil, usecase.ErrInvalidVolunteerQuery)
+
+	rr := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/admin/volunteers?q=jane&gender=female&department_id=2&sort=role,-name&page=2", nil)
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"role_name":"CVL"`)
+	assert.Contains(t, rr.Body.String(), `"total":21`)
+
+	rr = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/api/v1/admin/volunteers?sort=password", nil)
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	rr = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/api/v1/admin/volunteers?page=abc", nil)
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
@@ -0,0 +1,92 @@
+package transport
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChangeVolunteerStatus(t *testing.T) {
+	mockUsecase := new(MockVolunteerUsecase)
+	handler := NewVolunteerHandler(mockUsecase)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.Use(apperror.Middleware())
+	r.Use(func(c *gin.Context) {
+		if c.GetHeader("X-User") != "" {
+			c.Set("userId", 9)
+		}
+	})
+	r.POST("/api/v1/admin/volunteers/:id/deactivate", handler.DeactivateVolunteer)
+	r.POST("/api/v1/admin/volunteers/:id/reactivate", handler.ReactivateVolunteer)
+	r.POST("/api/v1/admin/volunteers/:id/restore", handler.RestoreVolunteer)
+
+	mockUsecase.On("DeactivateVolunteer", 1, 9, dto.VolunteerStatusChangeDTO{Reason: "moved abroad"}).Return(nil)
+	mockUsecase.On("DeactivateVolunteer", 2, 9, dto.VolunteerStatusChangeDTO{Reason: "moved abroad"}).Return(domain.ErrVolunteerAlreadyInactive)
+	mockUsecase.On("ReactivateVolunteer", 3, 9, dto.VolunteerStatusChangeDTO{Reason: "back"}).Return(domain.ErrVolunteerNotFound)
+	mockUsecase.On("RestoreVolunteer", 1, 9).Return(nil)
+	mockUsecase.On("RestoreVolunteer", 2, 9).Return(domain.ErrVolunteerNotFound)
+
+	tests := []struct {
+		name   string
+		path   string
+		body   string
+		user   bool
+		status int
+	}{
+		{"deactivated", "/api/v1/admin/volunteers/1/deactivate", `{"reason":"moved abroad"}`, true, http.StatusOK},
+		{"already inactive", "/api/v1/admin/volunteers/2/deactivate", `{"reason":"moved abroad"}`, true, http.StatusConflict},
+		{"not found", "/api/v1/admin/volunteers/3/reactivate", `{"reason":"back"}`, true, http.StatusNotFound},
+		{"missing reason", "/api/v1/admin/volunteers/1/deactivate", `{}`, true, http.StatusBadRequest},
+		{"unauthenticated", "/api/v1/admin/volunteers/1/deactivate", `{"reason":"moved abroad"}`, false, http.StatusUnauthorized},
+		{"restored", "/api/v1/admin/volunteers/1/restore", "", true, http.StatusOK},
+		{"not deleted", "/api/v1/admin/volunteers/2/restore", "", true, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			assert.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+			if tt.user {
+				req.Header.Set("X-User", "1")
+			}
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.status, rr.Code)
+		})
+	}
+}
+
+func TestListStatusChanges(t *testing.T) {
+	mockUsecase := new(MockVolunteerUsecase)
+	handler := NewVolunteerHandler(mockUsecase)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.Use(apperror.Middleware())
+	r.GET("/api/v1/admin/volunteers/:id/status-history", handler.ListStatusChanges)
+
+	mockUsecase.On("ListStatusChanges", 1).Return([]dto.VolunteerStatusChangeResponseDTO{{ID: 1, FromStatus: 1, ToStatus: 0, Reason: "moved abroad", ChangedBy: 9}}, nil)
+	mockUsecase.On("ListStatusChanges", 2).Return(nil, domain.ErrVolunteerNotFound)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/admin/volunteers/1/status-history", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"reason":"moved abroad"`)
+
+	req, _ = http.NewRequest(http.MethodGet, "/api/v1/admin/volunteers/2/status-history", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/storage"
+)
+
+const (
+	defaultVolunteerPageSize = 20
+	maxVolunteerPageSize     = 100
+)
+
+// ErrInvalidVolunteerQuery is returned when the directory query parameters are invalid.
+var ErrInvalidVolunteerQuery = apperror.New(apperror.Validation, "invalid volunteer query")
+
+// ListVolunteers returns one page of the volunteer directory.
+func (u *VolunteerUsecase) ListVolunteers(query dto.VolunteerListQuery) (*dto.VolunteerPage, error) {
+	filter, err := toVolunteerFilter(query)
+	if err != nil {
+		return nil, err
+	}
+	items, total, err := u.VolunteerRepo.ListVolunteers(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &dto.VolunteerPage{
+		Items: make([]dto.VolunteerListItemDTO, 0, len(items)),
+		Page:  filter.Offset/filter.Limit + 1,
+		Limit: filter.Limit,
+		Total: total,
+	}
+	for _, item := range items {
+		page.Items = append(page.Items, dto.VolunteerListItemDTO{
+			ID:             item.ID,
+			UserID:         item.UserID,
+			Name:           item.Name,
+			Surname:        item.Surname,
+			Email:          item.Email,
+			Gender:         item.Gender,
+			Mobile:         item.Mobile,
+			RoleID:         item.RoleID,
+			RoleName:       item.RoleName,
+			DepartmentID:   item.DepartmentID,
+			DepartmentName: item.DepartmentName,
+			CountryID:      item.CountryID,
+			CountryName:    item.CountryName,
+			Status:         item.Status,
+			CreatedAt:      item.CreatedAt,
+			DeletedAt:      item.DeletedAt,
+		})
+	}
+	return page, nil
+}
+
+func toVolunteerFilter(query dto.VolunteerListQuery) (storage.VolunteerFilter, error) {
+	filter := storage.VolunteerFilter{
+		Search:         strings.TrimSpace(query.Search),
+		Gender:         query.Gender,
+		Role:           query.Role,
+		DepartmentID:   query.DepartmentID,
+		CountryID:      query.CountryID,
+		Status:         query.Status,
+		IncludeDeleted: query.IncludeDeleted,
+		Limit:          query.Limit,
+	}
+	switch {
+	case filter.Limit == 0:
+		filter.Limit = defaultVolunteerPageSize
+	case filter.Limit < 0 || filter.Limit > maxVolunteerPageSize:
+		return filter, fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidVolunteerQuery, maxVolunteerPageSize)
+	}
+	switch {
+	case query.Page == 0:
+	case query.Page < 0:
+		return filter, fmt.Errorf("%w: page must be positive", ErrInvalidVolunteerQuery)
+	default:
+		filter.Offset = (query.Page - 1) * filter.Limit
+	}
+
+	seen := map[string]bool{}
+	for _, key := range strings.Split(query.Sort, ",") {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			continue
+		}
+		sort := storage.VolunteerSort{Key: strings.TrimPrefix(key, "-"), Desc: strings.HasPrefix(key, "-")}
+		if !storage.IsVolunteerSortKey(sort.Key) {
+			return filter, fmt.Errorf("%w: unknown sort key %q", ErrInvalidVolunteerQuery, sort.Key)
+		}
+		if seen[sort.Key] {
+			return filter, fmt.Errorf("%w: sort key %q is repeated", ErrInvalidVolunteerQuery, sort.Key)
+		}
+		seen[sort.Key] = true
+		filter.Sort = append(filter.Sort, sort)
+	}
+	return filter, nil
+}
@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListVolunteers(t *testing.T) {
+	t.Run("maps the query to a filter", func(t *testing.T) {
+		mockRepo := new(MockVolunteerRepository)
+		usecase := NewVolunteerUsecase(mockRepo, new(MockAuditRecorder))
+		departmentID := 2
+
+		mockRepo.On("ListVolunteers", storage.VolunteerFilter{
+			Search:       "jane",
+			Gender:       "female",
+			Role:         "cvl",
+			DepartmentID: &departmentID,
+			Sort:         []storage.VolunteerSort{{Key: "role"}, {Key: "created_at", Desc: true}},
+			Limit:        10,
+			Offset:       20,
+		}).Return([]*domain.VolunteerListItem{{ID: 5, UserID: 7, Name: "Jane", RoleName: "CVL", DepartmentName: "Hanoi"}}, int64(21), nil)
+
+		page, err := usecase.ListVolunteers(dto.VolunteerListQuery{
+			Search:       " jane ",
+			Gender:       "female",
+			Role:         "cvl",
+			DepartmentID: &departmentID,
+			Sort:         "role,-created_at",
+			Page:         3,
+			Limit:        10,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 3, page.Page)
+		assert.Equal(t, int64(21), page.Total)
+		assert.Equal(t, "CVL", page.Items[0].RoleName)
+		assert.Equal(t, "Hanoi", page.Items[0].DepartmentName)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		mockRepo := new(MockVolunteerRepository)
+		usecase := NewVolunteerUsecase(mockRepo, new(MockAuditRecorder))
+		mockRepo.On("ListVolunteers", storage.VolunteerFilter{Limit: 20}).Return([]*domain.VolunteerListItem{}, int64(0), nil)
+
+		page, err := usecase.ListVolunteers(dto.VolunteerListQuery{})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, page.Page)
+		assert.NotNil(t, page.Items)
+	})
+
+	t.Run("invalid queries", func(t *testing.T) {
+		usecase := NewVolunteerUsecase(new(MockVolunteerRepository), new(MockAuditRecorder))
+		for _, query := range []dto.VolunteerListQuery{
+			{Sort: "password"},
+			{Sort: "name,-name"},
+			{Limit: 101},
+			{Page: -1},
+		} {
+			_, err := usecase.ListVolunteers(query)
+			assert.ErrorIs(t, err, ErrInvalidVolunteerQuery, query)
+		}
+	})
+}
@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/apperror"
+	auditUsecase "github.com/cesc1802/onboarding-and-volunteer-service/feature/audit/usecase"
+	roleDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/role/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/storage"
+)
+
+// ErrInvalidOnboarding is returned when an onboarding neither links an
+// existing user nor describes a new one, or does both.
+var ErrInvalidOnboarding = apperror.New(apperror.Validation, "give either user_id or the email, name, dob, country_id and resident_country_id of a new user")
+
+// PasswordHasher hashes the placeholder password of onboarded users.
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+}
+
+// PasswordSetupSender emails a user a link to choose a password.
+type PasswordSetupSender interface {
+	ForgotPassword(email string) error
+}
+
+// RoleResolver resolves the id of a role from its code.
+type RoleResolver interface {
+	RoleID(code string) (int, error)
+}
+
+type OnboardingUsecaseInterface interface {
+	OnboardVolunteer(adminID int, input dto.VolunteerOnboardDTO) (*dto.VolunteerResponseDTO, error)
+}
+
+type OnboardingUsecase struct {
+	repo      storage.VolunteerRepositoryInterface
+	hasher    PasswordHasher
+	passwords PasswordSetupSender
+	audit     AuditRecorder
+	roles     RoleResolver
+}
+
+func NewOnboardingUsecase(repo storage.VolunteerRepositoryInterface, hasher PasswordHasher, passwords PasswordSetupSender, audit AuditRecorder, roles RoleResolver) *OnboardingUsecase {
+	return &OnboardingUsecase{repo: repo, hasher: hasher, passwords: passwords, audit: audit, roles: roles}
+}
+
+// OnboardVolunteer adds a volunteer on behalf of an admin, skipping the
+// registration and verification requests. A new user gets a random password
+// it never sees and is emailed a link to choose its own.
+func (u *OnboardingUsecase) OnboardVolunteer(adminID int, input dto.VolunteerOnboardDTO) (*dto.VolunteerResponseDTO, error) {
+	roleID, err := u.roles.RoleID(roleDomain.RoleCodeVolunteer)
+	if err != nil {
+		return nil, err
+	}
+	onboarding := domain.Onboarding{
+		UserID:       input.UserID,
+		DepartmentID: input.DepartmentID,
+		AdminID:      adminID,
+		RoleID:       roleID,
+	}
+	newUser := input.Email != "" || input.Name != ""
+	switch {
+	case input.UserID != 0 && newUser:
+		return nil, ErrInvalidOnboarding
+	case input.UserID == 0:
+		user, err := u.newUser(input)
+		if err != nil {
+			return nil, err
+		}
+		onboarding.NewUser = user
+	}
+
+	volunteer, err := u.repo.OnboardVolunteer(onboarding)
+	if err != nil {
+		return nil, err
+	}
+	recordChange(u.audit, auditUsecase.Entry{
+		ActorID:    &adminID,
+		Action:     "volunteer.onboard",
+		EntityType: "volunteer",
+		EntityID:   strconv.Itoa(volunteer.ID),
+		After: map[string]interface{}{
+			"user_id":       volunteer.UserID,
+			"department_id": volunteer.DepartmentID,
+			"status":        volunteer.Status,
+			"new_user":      onboarding.NewUser != nil,
+		},
+	})
+	if onboarding.NewUser != nil {
+		// the user can ask for another link, so a delivery failure does not fail the onboarding
+		if err := u.passwords.ForgotPassword(onboarding.NewUser.Email); err != nil {
+			log.Printf("could not send password setup email to user %d: %v", volunteer.UserID, err)
+		}
+	}
+	return &dto.VolunteerResponseDTO{
+		ID:           volunteer.ID,
+		UserID:       volunteer.UserID,
+		DepartmentID: volunteer.DepartmentID,
+		Status:       volunteer.Status,
+	}, nil
+}
+
+func (u *OnboardingUsecase) newUser(input dto.VolunteerOnboardDTO) (*domain.User, error) {
+	email := strings.TrimSpace(input.Email)
+	name := strings.TrimSpace(input.Name)
+	if email == "" || name == "" || input.Dob == nil || input.CountryID == nil || input.ResidentCountryID == nil {
+		return nil, ErrInvalidOnboarding
+	}
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	password, err := u.hasher.Hash(hex.EncodeToString(secret))
+	if err != nil {
+		return nil, err
+	}
+	return &domain.User{
+		Email:             email,
+		Password:          password,
+		Name:              name,
+		Surname:           strings.TrimSpace(input.Surname),
+		Gender:            input.Gender,
+		Dob:               input.Dob,
+		Mobile:            input.Mobile,
+		CountryID:         input.CountryID,
+		ResidentCountryID: input.ResidentCountryID,
+	}, nil
+}
@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	auditUsecase "github.com/cesc1802/onboarding-and-volunteer-service/feature/audit/usecase"
+	roleDomain "github.com/cesc1802/onboarding-and-volunteer-service/feature/role/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPasswordHasher struct {
+	mock.Mock
+}
+
+func (m *MockPasswordHasher) Hash(password string) (string, error) {
+	args := m.Called(password)
+	return args.String(0), args.Error(1)
+}
+
+type MockPasswordSetupSender struct {
+	mock.Mock
+}
+
+func (m *MockPasswordSetupSender) ForgotPassword(email string) error {
+	args := m.Called(email)
+	return args.Error(0)
+}
+
+// fakeRoles resolves role codes from a fixed map.
+type fakeRoles map[string]int
+
+func (f fakeRoles) RoleID(code string) (int, error) {
+	id, ok := f[code]
+	if !ok {
+		return 0, roleDomain.ErrRoleCodeUnknown
+	}
+	return id, nil
+}
+
+var testRoles = fakeRoles{roleDomain.RoleCodeVolunteer: 6}
+
+func TestOnboardVolunteer(t *testing.T) {
+	t.Run("existing user", func(t *testing.T) {
+		repo := new(MockVolunteerRepository)
+		passwords := new(MockPasswordSetupSender)
+		audit := new(MockAuditRecorder)
+		usecase := NewOnboardingUsecase(repo, new(MockPasswordHasher), passwords, audit, testRoles)
+
+		repo.On("OnboardVolunteer", domain.Onboarding{UserID: 7, DepartmentID: 2, AdminID: 9, RoleID: 6}).
+			Return(&domain.Volunteer{ID: 3, UserID: 7, DepartmentID: 2, Status: 1}, nil)
+		audit.On("Record", mock.MatchedBy(func(entry auditUsecase.Entry) bool {
+			return entry.Action == "volunteer.onboard" && *entry.ActorID == 9 && entry.EntityID == "3"
+		})).Return(nil)
+
+		volunteer, err := usecase.OnboardVolunteer(9, dto.VolunteerOnboardDTO{UserID: 7, DepartmentID: 2})
+		assert.NoError(t, err)
+		assert.Equal(t, &dto.VolunteerResponseDTO{ID: 3, UserID: 7, DepartmentID: 2, Status: 1}, volunteer)
+		passwords.AssertNotCalled(t, "ForgotPassword", mock.Anything)
+		audit.AssertExpectations(t)
+	})
+
+	t.Run("new user", func(t *testing.T) {
+		repo := new(MockVolunteerRepository)
+		hasher := new(MockPasswordHasher)
+		passwords := new(MockPasswordSetupSender)
+		audit := new(MockAuditRecorder)
+		usecase := NewOnboardingUsecase(repo, hasher, passwords, audit, testRoles)
+
+		hasher.On("Hash", mock.AnythingOfType("string")).Return("hashed", nil)
+		repo.On("OnboardVolunteer", mock.MatchedBy(func(o domain.Onboarding) bool {
+			return o.UserID == 0 && o.NewUser != nil && o.NewUser.Email == "jane@example.com" &&
+				o.NewUser.Name == "Jane" && o.NewUser.Password == "hashed" && o.AdminID == 9 && o.RoleID == 6
+		})).Return(&domain.Volunteer{ID: 4, UserID: 12, DepartmentID: 2, Status: 1}, nil)
+		passwords.On("ForgotPassword", "jane@example.com").Return(errors.New("smtp down"))
+		audit.On("Record", mock.Anything).Return(errors.New("db down"))
+
+		dob := time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)
+		country := 1
+		volunteer, err := usecase.OnboardVolunteer(9, dto.VolunteerOnboardDTO{
+			DepartmentID:      2,
+			Email:             " jane@example.com",
+			Name:              "Jane",
+			Dob:               &dob,
+			CountryID:         &country,
+			ResidentCountryID: &country,
+		})
+		assert.NoError(t, err, "a failed password email does not fail the onboarding")
+		assert.Equal(t, 12, volunteer.UserID)
+		passwords.AssertExpectations(t)
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		repo := new(MockVolunteerRepository)
+		usecase := NewOnboardingUsecase(repo, new(MockPasswordHasher), new(MockPasswordSetupSender), new(MockAuditRecorder), testRoles)
+
+		_, err := usecase.OnboardVolunteer(9, dto.VolunteerOnboardDTO{UserID: 7, DepartmentID: 2, Email: "jane@example.com"})
+		assert.ErrorIs(t, err, ErrInvalidOnboarding)
+		_, err = usecase.OnboardVolunteer(9, dto.VolunteerOnboardDTO{DepartmentID: 2, Email: "jane@example.com"})
+		assert.ErrorIs(t, err, ErrInvalidOnboarding)
+		_, err = usecase.OnboardVolunteer(9, dto.VolunteerOnboardDTO{DepartmentID: 2, Email: "jane@example.com", Name: "Jane"})
+		assert.ErrorIs(t, err, ErrInvalidOnboarding, "a new user needs a date of birth and countries")
+		repo.AssertNotCalled(t, "OnboardVolunteer", mock.Anything)
+	})
+
+	t.Run("already a volunteer", func(t *testing.T) {
+		repo := new(MockVolunteerRepository)
+		usecase := NewOnboardingUsecase(repo, new(MockPasswordHasher), new(MockPasswordSetupSender), new(MockAuditRecorder), testRoles)
+
+		repo.On("OnboardVolunteer", mock.Anything).Return(nil, domain.ErrAlreadyVolunteer)
+
+		_, err := usecase.OnboardVolunteer(9, dto.VolunteerOnboardDTO{UserID: 7, DepartmentID: 2})
+		assert.ErrorIs(t, err, domain.ErrAlreadyVolunteer)
+	})
+
+	t.Run("volunteer role missing", func(t *testing.T) {
+		repo := new(MockVolunteerRepository)
+		usecase := NewOnboardingUsecase(repo, new(MockPasswordHasher), new(MockPasswordSetupSender), new(MockAuditRecorder), fakeRoles{})
+
+		_, err := usecase.OnboardVolunteer(9, dto.VolunteerOnboardDTO{UserID: 7, DepartmentID: 2})
+		assert.ErrorIs(t, err, roleDomain.ErrRoleCodeUnknown)
+		repo.AssertNotCalled(t, "OnboardVolunteer", mock.Anything)
+	})
+}
@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"testing"
+
+	auditUsecase "github.com/cesc1802/onboarding-and-volunteer-service/feature/audit/usecase"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDeactivateAndReactivateVolunteer(t *testing.T) {
+	mockRepo := new(MockVolunteerRepository)
+	mockAudit := new(MockAuditRecorder)
+	usecase := NewVolunteerUsecase(mockRepo, mockAudit)
+
+	mockRepo.On("ChangeVolunteerStatus", 5, domain.VolunteerStatusInactive, 9, "moved abroad").Return(nil)
+	mockRepo.On("ChangeVolunteerStatus", 5, domain.VolunteerStatusActive, 9, "back").Return(domain.ErrVolunteerAlreadyActive)
+	mockAudit.On("Record", mock.MatchedBy(func(entry auditUsecase.Entry) bool {
+		return entry.Action == "volunteer.deactivate" && *entry.ActorID == 9 && entry.EntityID == "5" &&
+			assert.ObjectsAreEqual(map[string]interface{}{"status": domain.VolunteerStatusActive}, entry.Before) &&
+			assert.ObjectsAreEqual(map[string]interface{}{"status": domain.VolunteerStatusInactive, "reason": "moved abroad"}, entry.After)
+	})).Return(nil).Once()
+
+	assert.NoError(t, usecase.DeactivateVolunteer(5, 9, dto.VolunteerStatusChangeDTO{Reason: " moved abroad "}))
+	assert.ErrorIs(t, usecase.ReactivateVolunteer(5, 9, dto.VolunteerStatusChangeDTO{Reason: "back"}), domain.ErrVolunteerAlreadyActive)
+	mockRepo.AssertExpectations(t)
+	mockAudit.AssertExpectations(t)
+}
+
+func TestListStatusChanges(t *testing.T) {
+	mockRepo := new(MockVolunteerRepository)
+	usecase := NewVolunteerUsecase(mockRepo, new(MockAuditRecorder))
+
+	mockRepo.On("FindVolunteerByID", 5).Return(&domain.Volunteer{ID: 5}, nil)
+	mockRepo.On("ListStatusChanges", 5).Return([]*domain.VolunteerStatusChange{
+		{ID: 2, VolunteerID: 5, FromStatus: 0, ToStatus: 1, Reason: "back", ChangedBy: 9},
+		{ID: 1, VolunteerID: 5, FromStatus: 1, ToStatus: 0, Reason: "moved abroad", ChangedBy: 9},
+	}, nil)
+
+	changes, err := usecase.ListStatusChanges(5)
+	assert.NoError(t, err)
+	assert.Len(t, changes, 2)
+	assert.Equal(t, "back", changes[0].Reason)
+	assert.Equal(t, 9, changes[1].ChangedBy)
+}
+
+func TestRestoreVolunteer(t *testing.T) {
+	mockRepo := new(MockVolunteerRepository)
+	mockAudit := new(MockAuditRecorder)
+	usecase := NewVolunteerUsecase(mockRepo, mockAudit)
+
+	mockRepo.On("RestoreVolunteer", 5).Return(nil)
+	mockRepo.On("RestoreVolunteer", 6).Return(domain.ErrVolunteerNotFound)
+	mockAudit.On("Record", mock.MatchedBy(func(entry auditUsecase.Entry) bool {
+		return entry.Action == "volunteer.restore" && *entry.ActorID == 9 && entry.EntityID == "5"
+	})).Return(nil).Once()
+
+	assert.NoError(t, usecase.RestoreVolunteer(5, 9))
+	assert.ErrorIs(t, usecase.RestoreVolunteer(6, 9), domain.ErrVolunteerNotFound)
+	mockRepo.AssertExpectations(t)
+	mockAudit.AssertExpectations(t)
+}
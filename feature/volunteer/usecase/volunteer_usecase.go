@@ -1,6 +1,11 @@
 package usecase
 
 import (
+	"log"
+	"strconv"
+	"strings"
+
+	auditUsecase "github.com/cesc1802/onboarding-and-volunteer-service/feature/audit/usecase"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/storage"
@@ -10,15 +15,26 @@ type VolunteerUsecaseInterface interface {
 	CreateVolunteer(input dto.VolunteerCreateDTO) error
 	UpdateVolunteer(id int, input dto.VolunteerUpdateDTO) error
 	DeleteVolunteer(id int) error
+	RestoreVolunteer(id int, adminID int) error
 	FindVolunteerByID(id int) (*dto.VolunteerResponseDTO, error)
+	ListVolunteers(query dto.VolunteerListQuery) (*dto.VolunteerPage, error)
+	DeactivateVolunteer(id int, adminID int, input dto.VolunteerStatusChangeDTO) error
+	ReactivateVolunteer(id int, adminID int, input dto.VolunteerStatusChangeDTO) error
+	ListStatusChanges(id int) ([]dto.VolunteerStatusChangeResponseDTO, error)
+}
+
+// AuditRecorder appends the changes made by admins to the audit log.
+type AuditRecorder interface {
+	Record(entry auditUsecase.Entry) error
 }
 
 type VolunteerUsecase struct {
 	VolunteerRepo storage.VolunteerRepositoryInterface
+	audit         AuditRecorder
 }
 
-func NewVolunteerUsecase(volunteerRepo storage.VolunteerRepositoryInterface) *VolunteerUsecase {
-	return &VolunteerUsecase{VolunteerRepo: volunteerRepo}
+func NewVolunteerUsecase(volunteerRepo storage.VolunteerRepositoryInterface, audit AuditRecorder) *VolunteerUsecase {
+	return &VolunteerUsecase{VolunteerRepo: volunteerRepo, audit: audit}
 }
 
 func (u *VolunteerUsecase) CreateVolunteer(input dto.VolunteerCreateDTO) error {
@@ -36,15 +52,90 @@ func (u *VolunteerUsecase) UpdateVolunteer(id int, input dto.VolunteerUpdateDTO)
 		return err
 	}
 	volunteer.DepartmentID = input.DepartmentID
-	volunteer.Status = input.Status
 
 	return u.VolunteerRepo.UpdateVolunteer(volunteer)
 }
 
+// DeactivateVolunteer deactivates a volunteer and its user account.
+func (u *VolunteerUsecase) DeactivateVolunteer(id int, adminID int, input dto.VolunteerStatusChangeDTO) error {
+	return u.changeStatus("volunteer.deactivate", id, domain.VolunteerStatusInactive, adminID, input)
+}
+
+// ReactivateVolunteer reactivates a volunteer and its user account.
+func (u *VolunteerUsecase) ReactivateVolunteer(id int, adminID int, input dto.VolunteerStatusChangeDTO) error {
+	return u.changeStatus("volunteer.reactivate", id, domain.VolunteerStatusActive, adminID, input)
+}
+
+func (u *VolunteerUsecase) changeStatus(action string, id int, to int, adminID int, input dto.VolunteerStatusChangeDTO) error {
+	reason := strings.TrimSpace(input.Reason)
+	if err := u.VolunteerRepo.ChangeVolunteerStatus(id, to, adminID, reason); err != nil {
+		return err
+	}
+	// ChangeVolunteerStatus rejects no-op changes, so the volunteer had the
+	// other status before.
+	from := domain.VolunteerStatusActive
+	if to == domain.VolunteerStatusActive {
+		from = domain.VolunteerStatusInactive
+	}
+	recordChange(u.audit, auditUsecase.Entry{
+		ActorID:    &adminID,
+		Action:     action,
+		EntityType: "volunteer",
+		EntityID:   strconv.Itoa(id),
+		Before:     map[string]interface{}{"status": from},
+		After:      map[string]interface{}{"status": to, "reason": reason},
+	})
+	return nil
+}
+
+// recordChange audits a change made by an admin. The change is already
+// committed, so a failure to record it is only logged.
+func recordChange(audit AuditRecorder, entry auditUsecase.Entry) {
+	if err := audit.Record(entry); err != nil {
+		log.Printf("record audit event %s for %s %s: %v", entry.Action, entry.EntityType, entry.EntityID, err)
+	}
+}
+
+func (u *VolunteerUsecase) ListStatusChanges(id int) ([]dto.VolunteerStatusChangeResponseDTO, error) {
+	if _, err := u.VolunteerRepo.FindVolunteerByID(id); err != nil {
+		return nil, err
+	}
+	changes, err := u.VolunteerRepo.ListStatusChanges(id)
+	if err != nil {
+		return nil, err
+	}
+	response := make([]dto.VolunteerStatusChangeResponseDTO, 0, len(changes))
+	for _, change := range changes {
+		response = append(response, dto.VolunteerStatusChangeResponseDTO{
+			ID:         change.ID,
+			FromStatus: change.FromStatus,
+			ToStatus:   change.ToStatus,
+			Reason:     change.Reason,
+			ChangedBy:  change.ChangedBy,
+			CreatedAt:  change.CreatedAt,
+		})
+	}
+	return response, nil
+}
+
 func (u *VolunteerUsecase) DeleteVolunteer(id int) error {
 	return u.VolunteerRepo.DeleteVolunteer(id)
 }
 
+// RestoreVolunteer brings back a deleted volunteer.
+func (u *VolunteerUsecase) RestoreVolunteer(id int, adminID int) error {
+	if err := u.VolunteerRepo.RestoreVolunteer(id); err != nil {
+		return err
+	}
+	recordChange(u.audit, auditUsecase.Entry{
+		ActorID:    &adminID,
+		Action:     "volunteer.restore",
+		EntityType: "volunteer",
+		EntityID:   strconv.Itoa(id),
+	})
+	return nil
+}
+
 func (u *VolunteerUsecase) FindVolunteerByID(id int) (*dto.VolunteerResponseDTO, error) {
 	volunteer, err := u.VolunteerRepo.FindVolunteerByID(id)
 	if err != nil {
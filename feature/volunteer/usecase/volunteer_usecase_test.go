@@ -4,8 +4,10 @@ import (
 	"errors"
 	"testing"
 
+	auditUsecase "github.com/cesc1802/onboarding-and-volunteer-service/feature/audit/usecase"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/domain"
 	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/dto"
+	"github.com/cesc1802/onboarding-and-volunteer-service/feature/volunteer/storage"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -30,14 +32,54 @@ func (m *MockVolunteerRepository) DeleteVolunteer(id int) error {
 	return args.Error(0)
 }
 
+func (m *MockVolunteerRepository) RestoreVolunteer(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
 func (m *MockVolunteerRepository) FindVolunteerByID(id int) (*domain.Volunteer, error) {
 	args := m.Called(id)
-	return args.Get(0).(*domain.Volunteer), args.Error(1)
+	if args.Get(0) != nil {
+		return args.Get(0).(*domain.Volunteer), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockVolunteerRepository) ChangeVolunteerStatus(id int, to int, changedBy int, reason string) error {
+	args := m.Called(id, to, changedBy, reason)
+	return args.Error(0)
+}
+
+func (m *MockVolunteerRepository) ListStatusChanges(id int) ([]*domain.VolunteerStatusChange, error) {
+	args := m.Called(id)
+	return args.Get(0).([]*domain.VolunteerStatusChange), args.Error(1)
+}
+
+func (m *MockVolunteerRepository) OnboardVolunteer(onboarding domain.Onboarding) (*domain.Volunteer, error) {
+	args := m.Called(onboarding)
+	if args.Get(0) != nil {
+		return args.Get(0).(*domain.Volunteer), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockVolunteerRepository) ListVolunteers(filter storage.VolunteerFilter) ([]*domain.VolunteerListItem, int64, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]*domain.VolunteerListItem), args.Get(1).(int64), args.Error(2)
+}
+
+type MockAuditRecorder struct {
+	mock.Mock
+}
+
+func (m *MockAuditRecorder) Record(entry auditUsecase.Entry) error {
+	args := m.Called(entry)
+	return args.Error(0)
 }
 
 func TestCreateVolunteer(t *testing.T) {
 	mockRepo := new(MockVolunteerRepository)
-	usecase := NewVolunteerUsecase(mockRepo)
+	usecase := NewVolunteerUsecase(mockRepo, new(MockAuditRecorder))
 
 	input := dto.VolunteerCreateDTO{
 		UserID:       1,
@@ -55,11 +97,10 @@ func TestCreateVolunteer(t *testing.T) {
 
 func TestUpdateVolunteer(t *testing.T) {
 	mockRepo := new(MockVolunteerRepository)
-	usecase := NewVolunteerUsecase(mockRepo)
+	usecase := NewVolunteerUsecase(mockRepo, new(MockAuditRecorder))
 
 	input := dto.VolunteerUpdateDTO{
 		DepartmentID: 4,
-		Status:       1,
 	}
 	volunteer := &domain.Volunteer{
 		ID:           1,
@@ -74,12 +115,14 @@ func TestUpdateVolunteer(t *testing.T) {
 	err := usecase.UpdateVolunteer(1, input)
 
 	assert.NoError(t, err)
+	assert.Equal(t, 4, volunteer.DepartmentID)
+	assert.Equal(t, 0, volunteer.Status, "the status is only changed by deactivate and reactivate")
 	mockRepo.AssertExpectations(t)
 }
 
 func TestDeleteVolunteer(t *testing.T) {
 	mockRepo := new(MockVolunteerRepository)
-	usecase := NewVolunteerUsecase(mockRepo)
+	usecase := NewVolunteerUsecase(mockRepo, new(MockAuditRecorder))
 
 	mockRepo.On("DeleteVolunteer", 1).Return(nil)
 
@@ -91,7 +134,7 @@ func TestDeleteVolunteer(t *testing.T) {
 
 func TestFindVolunteerByID(t *testing.T) {
 	mockRepo := new(MockVolunteerRepository)
-	usecase := NewVolunteerUsecase(mockRepo)
+	usecase := NewVolunteerUsecase(mockRepo, new(MockAuditRecorder))
 
 	volunteer := &domain.Volunteer{
 		ID:           1,
@@ -116,7 +159,7 @@ func TestFindVolunteerByID(t *testing.T) {
 
 func TestFindVolunteerByID_NotFound(t *testing.T) {
 	mockRepo := new(MockVolunteerRepository)
-	usecase := NewVolunteerUsecase(mockRepo)
+	usecase := NewVolunteerUsecase(mockRepo, new(MockAuditRecorder))
 
 	mockRepo.On("FindVolunteerByID", 1).Return(nil, errors.New("record not found"))
 
@@ -1,8 +1,18 @@
+// Package migration holds the SQL migrations of every supported database and
+// applies them.
+//
+// Each dialect has a directory named after its GORM dialector, holding a pair
+// of files per version:
+//
+//	mysql/000001_init.up.sql
+//	mysql/000001_init.down.sql
+//
+// Applied versions are recorded in the schema_migrations table.
 package migration
 
 import (
 	"embed"
 )
 
-//go:embed *.sql
+//go:embed mysql postgres sqlite
 var FS embed.FS
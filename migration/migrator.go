@@ -0,0 +1,387 @@
+package migration
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrDirty          = errors.New("migration: a migration failed halfway, fix the database then force its version")
+	ErrUnknownVersion = errors.New("migration: unknown version")
+)
+
+var fileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// createTable is plain enough SQL for every dialect.
+const createTable = `CREATE TABLE IF NOT EXISTS schema_migrations (
+    version BIGINT NOT NULL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    dirty BOOLEAN NOT NULL DEFAULT FALSE,
+    applied_at TIMESTAMP NOT NULL
+)`
+
+// Migration is a version of the schema, with the SQL that applies it and
+// the SQL that reverts it.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+func (m Migration) String() string {
+	return fmt.Sprintf("%06d_%s", m.Version, m.Name)
+}
+
+// Record is a row of schema_migrations. A dirty record is a migration that
+// failed halfway, on a database that cannot roll back its schema changes.
+type Record struct {
+	Version   int64 `gorm:"primaryKey;autoIncrement:false"`
+	Name      string
+	Dirty     bool
+	AppliedAt time.Time
+}
+
+func (Record) TableName() string {
+	return "schema_migrations"
+}
+
+// Status is the state of a migration in the database. Versions recorded in
+// the database without a file, e.g. after switching to an older build, have
+// Missing set.
+type Status struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	Dirty     bool
+	Missing   bool
+	AppliedAt *time.Time
+}
+
+// Load reads the migrations of dialect from fsys, oldest first. Every
+// version needs both an up and a down file.
+func Load(fsys fs.FS, dialect string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dialect)
+	if err != nil {
+		return nil, fmt.Errorf("migration: no migrations for %s: %w", dialect, err)
+	}
+
+	byVersion := map[int64]*Migration{}
+	sides := map[int64]map[string]bool{}
+	for _, entry := range entries {
+		match := fileName.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			return nil, fmt.Errorf("migration: unexpected file %s/%s", dialect, entry.Name())
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil || version == 0 {
+			return nil, fmt.Errorf("migration: invalid version in %s/%s", dialect, entry.Name())
+		}
+		content, err := fs.ReadFile(fsys, path.Join(dialect, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+			sides[version] = map[string]bool{}
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration: version %d of %s has two names, %s and %s", version, dialect, migration.Name, match[2])
+		}
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+		sides[version][match[3]] = true
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for version, migration := range byVersion {
+		if !sides[version]["up"] || !sides[version]["down"] {
+			return nil, fmt.Errorf("migration: %s/%s needs both an up and a down file", dialect, migration)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Migrator applies and reverts the migrations of a database.
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+// New loads the migrations of the dialect of db from fsys, usually FS.
+func New(db *gorm.DB, fsys fs.FS) (*Migrator, error) {
+	migrations, err := Load(fsys, db.Dialector.Name())
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Status lists the migrations and whether they were applied, oldest first.
+func (m *Migrator) Status() ([]Status, error) {
+	records, err := m.records()
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := Status{Version: migration.Version, Name: migration.Name}
+		if record, ok := records[migration.Version]; ok {
+			appliedAt := record.AppliedAt
+			status.Applied, status.Dirty, status.AppliedAt = true, record.Dirty, &appliedAt
+			delete(records, migration.Version)
+		}
+		statuses = append(statuses, status)
+	}
+	for _, record := range records {
+		appliedAt := record.AppliedAt
+		statuses = append(statuses, Status{
+			Version:   record.Version,
+			Name:      record.Name,
+			Applied:   true,
+			Dirty:     record.Dirty,
+			Missing:   true,
+			AppliedAt: &appliedAt,
+		})
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+	return statuses, nil
+}
+
+// Up applies the n oldest pending migrations, or all of them when n is 0.
+func (m *Migrator) Up(n int) error {
+	records, err := m.cleanRecords()
+	if err != nil {
+		return err
+	}
+	applied := 0
+	for _, migration := range m.migrations {
+		if n > 0 && applied == n {
+			break
+		}
+		if _, ok := records[migration.Version]; ok {
+			continue
+		}
+		if err := m.apply(migration); err != nil {
+			return err
+		}
+		applied++
+	}
+	if applied == 0 {
+		log.Println("migration: no pending migration")
+	}
+	return nil
+}
+
+// Down reverts the n newest applied migrations, or all of them when n is 0.
+func (m *Migrator) Down(n int) error {
+	records, err := m.cleanRecords()
+	if err != nil {
+		return err
+	}
+	versions := appliedVersions(records)
+	if n > 0 && n < len(versions) {
+		versions = versions[:n]
+	}
+	for _, version := range versions {
+		if err := m.revert(version); err != nil {
+			return err
+		}
+	}
+	if len(versions) == 0 {
+		log.Println("migration: no applied migration")
+	}
+	return nil
+}
+
+// Goto applies or reverts migrations until version is the newest applied
+// one. Version 0 reverts every migration.
+func (m *Migrator) Goto(version int64) error {
+	if err := m.checkVersion(version); err != nil {
+		return err
+	}
+	records, err := m.cleanRecords()
+	if err != nil {
+		return err
+	}
+	for _, applied := range appliedVersions(records) {
+		if applied <= version {
+			break
+		}
+		if err := m.revert(applied); err != nil {
+			return err
+		}
+	}
+	for _, migration := range m.migrations {
+		if migration.Version > version {
+			break
+		}
+		if _, ok := records[migration.Version]; ok {
+			continue
+		}
+		if err := m.apply(migration); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Force records the migrations up to version as applied and the others as
+// pending, without running any SQL. It clears dirty records, once the
+// database was fixed by hand after a failed migration, and adopts
+// databases that were migrated by other means.
+func (m *Migrator) Force(version int64) error {
+	if err := m.checkVersion(version); err != nil {
+		return err
+	}
+	records, err := m.records()
+	if err != nil {
+		return err
+	}
+	return m.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("version > ? OR dirty = ?", version, true).Delete(&Record{}).Error; err != nil {
+			return err
+		}
+		for _, migration := range m.migrations {
+			if migration.Version > version {
+				break
+			}
+			if record, ok := records[migration.Version]; ok && !record.Dirty {
+				continue
+			}
+			record := Record{Version: migration.Version, Name: migration.Name, AppliedAt: time.Now()}
+			if err := tx.Create(&record).Error; err != nil {
+				return err
+			}
+		}
+		log.Printf("migration: forced version %d", version)
+		return nil
+	})
+}
+
+// apply runs the up SQL of migration. Its record is created dirty and
+// cleaned once the SQL succeeded. Where schema changes are transactional the
+// whole migration rolls back on failure; MySQL commits each schema change,
+// so the dirty record stays and blocks further migrations.
+func (m *Migrator) apply(migration Migration) error {
+	err := m.db.Transaction(func(tx *gorm.DB) error {
+		record := Record{Version: migration.Version, Name: migration.Name, Dirty: true, AppliedAt: time.Now()}
+		if err := tx.Create(&record).Error; err != nil {
+			return err
+		}
+		if err := exec(tx, migration.Up); err != nil {
+			return err
+		}
+		return tx.Model(&Record{}).Where("version = ?", migration.Version).Update("dirty", false).Error
+	})
+	if err != nil {
+		return fmt.Errorf("migration: apply %s: %w", migration, err)
+	}
+	log.Printf("migration: applied %s", migration)
+	return nil
+}
+
+// revert runs the down SQL of a version, the other way around from apply.
+func (m *Migrator) revert(version int64) error {
+	migration, ok := m.find(version)
+	if !ok {
+		return fmt.Errorf("%w %d: it was applied but this build has no file for it", ErrUnknownVersion, version)
+	}
+	err := m.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&Record{}).Where("version = ?", version).Update("dirty", true).Error; err != nil {
+			return err
+		}
+		if err := exec(tx, migration.Down); err != nil {
+			return err
+		}
+		return tx.Where("version = ?", version).Delete(&Record{}).Error
+	})
+	if err != nil {
+		return fmt.Errorf("migration: revert %s: %w", migration, err)
+	}
+	log.Printf("migration: reverted %s", migration)
+	return nil
+}
+
+func exec(tx *gorm.DB, sql string) error {
+	for _, statement := range split(sql) {
+		if err := tx.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// records returns the rows of schema_migrations by version, creating the
+// table on first use.
+func (m *Migrator) records() (map[int64]Record, error) {
+	if err := m.db.Exec(createTable).Error; err != nil {
+		return nil, fmt.Errorf("migration: create schema_migrations: %w", err)
+	}
+	var rows []Record
+	if err := m.db.Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	records := make(map[int64]Record, len(rows))
+	for _, row := range rows {
+		records[row.Version] = row
+	}
+	return records, nil
+}
+
+// cleanRecords returns the records, unless a migration failed halfway.
+func (m *Migrator) cleanRecords() (map[int64]Record, error) {
+	records, err := m.records()
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range records {
+		if record.Dirty {
+			return nil, fmt.Errorf("%w (version %d)", ErrDirty, record.Version)
+		}
+	}
+	return records, nil
+}
+
+func (m *Migrator) find(version int64) (Migration, bool) {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return migration, true
+		}
+	}
+	return Migration{}, false
+}
+
+func (m *Migrator) checkVersion(version int64) error {
+	if _, ok := m.find(version); !ok && version != 0 {
+		return fmt.Errorf("%w %d", ErrUnknownVersion, version)
+	}
+	return nil
+}
+
+// appliedVersions returns the versions of records, newest first.
+func appliedVersions(records map[int64]Record) []int64 {
+	versions := make([]int64, 0, len(records))
+	for version := range records {
+		versions = append(versions, version)
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i] > versions[j] })
+	return versions
+}
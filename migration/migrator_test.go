@@ -0,0 +1,230 @@
+package migration
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func openSQLite(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open("file::memory:?_foreign_keys=on"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	// Every connection to :memory: opens a database of its own.
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+	return db
+}
+
+// schema describes every table, index and trigger but schema_migrations.
+// Tables are described by their columns and foreign keys, in name order,
+// since SQLite adds back a dropped column at the end of its table.
+func schema(t *testing.T, db *gorm.DB) []string {
+	var definitions []string
+	err := db.Raw(`
+SELECT 'table ' || m.name || ': ' || (
+    SELECT group_concat(d, ', ') FROM (
+        SELECT c.name || ' ' || c.type || ' ' || c."notnull" || ' ' || COALESCE(c.dflt_value, '') || ' ' || c.pk AS d
+        FROM pragma_table_info(m.name) AS c
+        UNION ALL
+        SELECT 'fk ' || f."from" || ' ' || f."table" || '.' || f."to" || ' ' || f.on_delete
+        FROM pragma_foreign_key_list(m.name) AS f
+        ORDER BY d
+    )
+)
+FROM sqlite_master AS m
+WHERE m.type = 'table' AND m.name NOT LIKE 'sqlite_%' AND m.name <> 'schema_migrations'
+UNION ALL
+SELECT m.type || ' ' || m.name || ': ' || m.sql
+FROM sqlite_master AS m
+WHERE m.type IN ('index', 'trigger') AND m.sql IS NOT NULL
+ORDER BY 1`).Scan(&definitions).Error
+	require.NoError(t, err)
+	return definitions
+}
+
+func versions(t *testing.T, m *Migrator) []int64 {
+	statuses, err := m.Status()
+	require.NoError(t, err)
+	var applied []int64
+	for _, status := range statuses {
+		if status.Applied {
+			applied = append(applied, status.Version)
+		}
+	}
+	return applied
+}
+
+func TestSplit(t *testing.T) {
+	statements := split(`-- Leading comment; with a semicolon.
+CREATE TABLE a (
+    note VARCHAR(10) DEFAULT 'x;y', -- trailing; comment
+    ` + "`odd;name`" + ` INT
+);
+INSERT INTO a (note) VALUES ('it\'s; fine');
+
+-- +migrate StatementBegin
+CREATE TRIGGER t BEFORE DELETE ON a
+BEGIN
+    SELECT RAISE(ABORT, 'no');
+END;
+-- +migrate StatementEnd
+-- Trailing comment.
+`)
+
+	assert.Equal(t, []string{
+		"CREATE TABLE a (\n    note VARCHAR(10) DEFAULT 'x;y', \n    `odd;name` INT\n)",
+		`INSERT INTO a (note) VALUES ('it\'s; fine')`,
+		"CREATE TRIGGER t BEFORE DELETE ON a\nBEGIN\n    SELECT RAISE(ABORT, 'no');\nEND;",
+	}, statements)
+	assert.Empty(t, split("-- Nothing to do for this dialect.\n"))
+}
+
+func TestLoad(t *testing.T) {
+	mysql, err := Load(FS, "mysql")
+	require.NoError(t, err)
+	for _, dialect := range []string{"postgres", "sqlite"} {
+		migrations, err := Load(FS, dialect)
+		require.NoError(t, err)
+		require.Len(t, migrations, len(mysql), "every dialect has the same migrations")
+		for i := range mysql {
+			assert.Equal(t, mysql[i].String(), migrations[i].String())
+		}
+	}
+	for i := range mysql {
+		assert.Equal(t, int64(i+1), mysql[i].Version)
+		assert.NotEmpty(t, split(mysql[i].Up), mysql[i].String())
+		assert.NotEmpty(t, split(mysql[i].Down), mysql[i].String())
+	}
+
+	_, err = Load(fstest.MapFS{"mysql/000001_init.up.sql": {Data: []byte("SELECT 1;")}}, "mysql")
+	assert.ErrorContains(t, err, "needs both an up and a down file")
+	_, err = Load(fstest.MapFS{"mysql/init.sql": {Data: []byte("SELECT 1;")}}, "mysql")
+	assert.ErrorContains(t, err, "unexpected file")
+	_, err = Load(FS, "oracle")
+	assert.Error(t, err)
+}
+
+func TestMigrateSQLite(t *testing.T) {
+	db := openSQLite(t)
+	m, err := New(db, FS)
+	require.NoError(t, err)
+	require.NotEmpty(t, m.migrations)
+
+	// Every down reverts its up exactly.
+	for _, migration := range m.migrations {
+		before := schema(t, db)
+		require.NoError(t, m.Up(1), migration.String())
+		require.NoError(t, m.Down(1), migration.String())
+		assert.Equal(t, before, schema(t, db), "%s does not revert its up", migration)
+		require.NoError(t, m.Up(1), migration.String())
+	}
+	assert.Len(t, versions(t, m), len(m.migrations))
+	assert.NoError(t, m.Up(0), "nothing left to apply")
+
+	require.NoError(t, m.Down(0))
+	assert.Empty(t, schema(t, db))
+	assert.Empty(t, versions(t, m))
+
+	require.NoError(t, m.Up(0))
+	assert.Len(t, versions(t, m), len(m.migrations))
+}
+
+func TestMigrateSQLiteKeepsRejectNotes(t *testing.T) {
+	db := openSQLite(t)
+	m, err := New(db, FS)
+	require.NoError(t, err)
+	require.NoError(t, m.Goto(6))
+
+	for _, statement := range []string{
+		"INSERT INTO roles (id, name) VALUES (1, 'admin')",
+		"INSERT INTO countries (id, name, status) VALUES (1, 'Vietnam', 1)",
+		"INSERT INTO users (id, role_id, email, password, name, surname, gender, dob, mobile, country_id, resident_country_id, status) " +
+			"VALUES (1, 1, 'admin@example.com', 'x', 'Ada', 'Admin', 'female', '1990-01-01', '+84900000000', 1, 1, 1)",
+		"INSERT INTO requests (id, user_id, type, status, reject_notes, verifier_id) VALUES (1, 1, 'verification', 2, 'blurry scan', 1)",
+		"INSERT INTO requests (id, user_id, type, status, reject_notes) VALUES (2, 1, 'registration', 2, 'incomplete address')",
+	} {
+		require.NoError(t, db.Exec(statement).Error)
+	}
+
+	require.NoError(t, m.Up(1))
+	var body string
+	require.NoError(t, db.Raw("SELECT body FROM request_messages WHERE request_id = 1").Scan(&body).Error)
+	assert.Equal(t, "blurry scan", body)
+	var sender int
+	require.NoError(t, db.Raw("SELECT sender_id FROM request_messages WHERE request_id = 2 AND body = 'incomplete address'").Scan(&sender).Error)
+	assert.Equal(t, 1, sender, "notes without a reviewer are sent by the requester")
+
+	require.NoError(t, m.Down(1))
+	var notes string
+	require.NoError(t, db.Raw("SELECT reject_notes FROM requests WHERE id = 1").Scan(&notes).Error)
+	assert.Equal(t, "blurry scan", notes)
+}
+
+func TestGotoAndForce(t *testing.T) {
+	fsys := fstest.MapFS{
+		"sqlite/000001_a.up.sql":     {Data: []byte("CREATE TABLE a (id INTEGER);")},
+		"sqlite/000001_a.down.sql":   {Data: []byte("DROP TABLE a;")},
+		"sqlite/000002_b.up.sql":     {Data: []byte("CREATE TABLE b (id INTEGER);")},
+		"sqlite/000002_b.down.sql":   {Data: []byte("DROP TABLE b;")},
+		"sqlite/000003_bad.up.sql":   {Data: []byte("CREATE TABLE c (id INTEGER);\nCREATE TABLE a (id INTEGER);")},
+		"sqlite/000003_bad.down.sql": {Data: []byte("DROP TABLE c;")},
+	}
+	db := openSQLite(t)
+	m, err := New(db, fsys)
+	require.NoError(t, err)
+
+	require.NoError(t, m.Goto(2))
+	assert.Equal(t, []int64{1, 2}, versions(t, m))
+	require.NoError(t, m.Goto(1))
+	assert.Equal(t, []int64{1}, versions(t, m))
+	assert.Equal(t, []string{"table a: id INTEGER 0  0"}, schema(t, db))
+	assert.ErrorIs(t, m.Goto(7), ErrUnknownVersion)
+
+	// SQLite rolls back the whole failed migration, record included.
+	assert.ErrorContains(t, m.Up(0), "apply 000003_bad")
+	assert.Equal(t, []int64{1, 2}, versions(t, m))
+	assert.Len(t, schema(t, db), 2)
+
+	// MySQL would leave a dirty record behind.
+	require.NoError(t, db.Create(&Record{Version: 3, Name: "bad", Dirty: true}).Error)
+	assert.ErrorIs(t, m.Up(0), ErrDirty)
+	assert.ErrorIs(t, m.Down(1), ErrDirty)
+
+	require.NoError(t, m.Force(2))
+	statuses, err := m.Status()
+	require.NoError(t, err)
+	assert.Equal(t, []bool{true, true, false}, []bool{statuses[0].Applied, statuses[1].Applied, statuses[2].Applied})
+	assert.False(t, statuses[1].Dirty)
+
+	// Forcing records versions without running them.
+	require.NoError(t, m.Force(3))
+	assert.Equal(t, []int64{1, 2, 3}, versions(t, m))
+	assert.Len(t, schema(t, db), 2)
+	require.NoError(t, m.Force(0))
+	assert.Empty(t, versions(t, m))
+	assert.ErrorIs(t, m.Force(9), ErrUnknownVersion)
+}
+
+func TestStatusOfMissingFile(t *testing.T) {
+	db := openSQLite(t)
+	m, err := New(db, fstest.MapFS{
+		"sqlite/000001_a.up.sql":   {Data: []byte("CREATE TABLE a (id INTEGER);")},
+		"sqlite/000001_a.down.sql": {Data: []byte("DROP TABLE a;")},
+	})
+	require.NoError(t, err)
+	require.NoError(t, m.Up(0))
+	require.NoError(t, db.Create(&Record{Version: 2, Name: "newer"}).Error)
+
+	statuses, err := m.Status()
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+	assert.True(t, statuses[1].Missing)
+	assert.ErrorIs(t, m.Down(1), ErrUnknownVersion)
+}
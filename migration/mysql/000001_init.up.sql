@@ -1,12 +1,12 @@
-CREATE TABLE IF NOT EXISTS 'roles' (
-    `id` INT PRIMARY KEY AUTOINCREMENT,
+CREATE TABLE IF NOT EXISTS `roles` (
+    `id` INT PRIMARY KEY AUTO_INCREMENT,
     `name` VARCHAR(30) NOT NULL,
     `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
     `updated_at` DATETIME ON UPDATE CURRENT_TIMESTAMP
 );
 
-CREATE TABLE IF NOT EXISTS 'departments' (
-    `id` INT PRIMARY KEY AUTOINCREMENT,
+CREATE TABLE IF NOT EXISTS `departments` (
+    `id` INT PRIMARY KEY AUTO_INCREMENT,
     `name` VARCHAR(45) NOT NULL,
     `address` VARCHAR(100) NOT NULL,
     `status` TINYINT NOT NULL COMMENT '0: inactive\n1: active',
@@ -14,16 +14,16 @@ CREATE TABLE IF NOT EXISTS 'departments' (
     `updated_at` DATETIME ON UPDATE CURRENT_TIMESTAMP
 );
 
-CREATE TABLE IF NOT EXISTS 'countries' (
-    `id` INT PRIMARY KEY AUTOINCREMENT,
+CREATE TABLE IF NOT EXISTS `countries` (
+    `id` INT PRIMARY KEY AUTO_INCREMENT,
     `name` VARCHAR(45) NOT NULL,
     `status` TINYINT NOT NULL,
     `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
     `updated_at` DATETIME ON UPDATE CURRENT_TIMESTAMP
 );
 
-CREATE TABLE IF NOT EXISTS 'users' (
-    `id` INT PRIMARY KEY AUTOINCREMENT,
+CREATE TABLE IF NOT EXISTS `users` (
+    `id` INT PRIMARY KEY AUTO_INCREMENT,
     `role_id` INT NOT NULL,
     `department_id` INT DEFAULT NULL,
     `email` VARCHAR(45) NOT NULL,
@@ -49,8 +49,8 @@ CREATE TABLE IF NOT EXISTS 'users' (
     CONSTRAINT `fk_users_resident_countries` FOREIGN KEY (`resident_country_id`) REFERENCES `countries` (`id`)
 );
 
-CREATE TABLE IF NOT EXISTS 'volunteer_details' (
-    `id` INT PRIMARY KEY AUTOINCREMENT,
+CREATE TABLE IF NOT EXISTS `volunteer_details` (
+    `id` INT PRIMARY KEY AUTO_INCREMENT,
     `user_id` INT NOT NULL,
     `department_id` INT NOT NULL,
     `status` TINYINT NOT NULL,
@@ -62,8 +62,8 @@ CREATE TABLE IF NOT EXISTS 'volunteer_details' (
     CONSTRAINT `fk_volunteer_details_users` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`)
 );
 
-CREATE TABLE IF NOT EXISTS 'requests' (
-    `id` INT PRIMARY KEY AUTOINCREMENT,
+CREATE TABLE IF NOT EXISTS `requests` (
+    `id` INT PRIMARY KEY AUTO_INCREMENT,
     `user_id` INT NOT NULL,
     `type` VARCHAR(45) NOT NULL,
     `status` TINYINT NOT NULL,
@@ -77,8 +77,8 @@ CREATE TABLE IF NOT EXISTS 'requests' (
     CONSTRAINT `fk_requests_verifiers` FOREIGN KEY (`verifier_id`) REFERENCES `users` (`id`)
 );
 
-CREATE TABLE IF NOT EXISTS 'user_identities' (
-    `id` INT PRIMARY KEY AUTOINCREMENT,
+CREATE TABLE IF NOT EXISTS `user_identities` (
+    `id` INT PRIMARY KEY AUTO_INCREMENT,
     `user_id` INT NOT NULL,
     `number` VARCHAR(30) NOT NULL,
     `type` VARCHAR(45) NOT NULL,
@@ -89,4 +89,4 @@ CREATE TABLE IF NOT EXISTS 'user_identities' (
     `updated_at` DATETIME ON UPDATE CURRENT_TIMESTAMP,
     KEY `fk_user_identities_users_idx` (`user_id`),
     CONSTRAINT `fk_user_identities_users` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`)
-);
\ No newline at end of file
+);
@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS `refresh_tokens` (
+    `id` INT PRIMARY KEY AUTO_INCREMENT,
+    `user_id` INT NOT NULL,
+    `family_id` VARCHAR(64) NOT NULL,
+    `token_hash` VARCHAR(64) NOT NULL,
+    `access_jti` VARCHAR(64) NOT NULL,
+    `access_expires_at` DATETIME NOT NULL,
+    `expires_at` DATETIME NOT NULL,
+    `revoked_at` DATETIME DEFAULT NULL,
+    `replaced_by_id` INT DEFAULT NULL,
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY `uq_refresh_tokens_token_hash` (`token_hash`),
+    KEY `idx_refresh_tokens_family_id` (`family_id`),
+    KEY `fk_refresh_tokens_users_idx` (`user_id`),
+    CONSTRAINT `fk_refresh_tokens_users` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `revoked_access_tokens` (
+    `jti` VARCHAR(64) PRIMARY KEY,
+    `user_id` INT NOT NULL,
+    `expires_at` DATETIME NOT NULL,
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    KEY `idx_revoked_access_tokens_expires_at` (`expires_at`)
+);
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS `role_permissions` (
+    `id` INT PRIMARY KEY AUTO_INCREMENT,
+    `role_id` INT NOT NULL,
+    `permission` VARCHAR(100) NOT NULL,
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY `uq_role_permissions_role_permission` (`role_id`, `permission`),
+    CONSTRAINT `fk_role_permissions_roles` FOREIGN KEY (`role_id`) REFERENCES `roles` (`id`)
+);
+
+INSERT IGNORE INTO `role_permissions` (`role_id`, `permission`)
+SELECT `roles`.`id`, `permissions`.`permission`
+FROM `roles`
+CROSS JOIN (
+    SELECT 'request.review' AS `permission`
+    UNION ALL SELECT 'country.write'
+    UNION ALL SELECT 'department.write'
+    UNION ALL SELECT 'role.write'
+) AS `permissions`
+WHERE `roles`.`name` = 'admin';
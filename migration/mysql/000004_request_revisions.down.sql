@@ -0,0 +1,5 @@
+ALTER TABLE `requests` DROP FOREIGN KEY `fk_requests_parents`;
+
+ALTER TABLE `requests`
+    DROP KEY `fk_requests_parents_idx`,
+    DROP COLUMN `parent_id`;
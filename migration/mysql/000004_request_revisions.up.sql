@@ -0,0 +1,4 @@
+ALTER TABLE `requests`
+    ADD COLUMN `parent_id` INT DEFAULT NULL AFTER `verifier_id`,
+    ADD KEY `fk_requests_parents_idx` (`parent_id`),
+    ADD CONSTRAINT `fk_requests_parents` FOREIGN KEY (`parent_id`) REFERENCES `requests` (`id`);
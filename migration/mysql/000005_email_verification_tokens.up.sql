@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS `email_verification_tokens` (
+    `jti` VARCHAR(64) PRIMARY KEY,
+    `user_id` INT NOT NULL,
+    `expires_at` DATETIME NOT NULL,
+    `used_at` DATETIME DEFAULT NULL,
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    KEY `idx_email_verification_tokens_user_created` (`user_id`, `created_at`),
+    CONSTRAINT `fk_email_verification_tokens_users` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`)
+);
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS `request_messages` (
+    `id` INT AUTO_INCREMENT PRIMARY KEY,
+    `request_id` INT NOT NULL,
+    `sender_id` INT NOT NULL,
+    `body` TEXT NOT NULL,
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    KEY `idx_request_messages_request_created` (`request_id`, `created_at`),
+    CONSTRAINT `fk_request_messages_requests` FOREIGN KEY (`request_id`) REFERENCES `requests` (`id`) ON DELETE CASCADE,
+    CONSTRAINT `fk_request_messages_users` FOREIGN KEY (`sender_id`) REFERENCES `users` (`id`)
+);
+
+-- Reject notes become the first message of the thread, sent by the reviewer.
+-- Notes written before reviewers were recorded have no verifier_id; they are
+-- attributed to the requester so that none is lost.
+INSERT INTO `request_messages` (`request_id`, `sender_id`, `body`, `created_at`)
+SELECT `id`, COALESCE(`verifier_id`, `user_id`), `reject_notes`, `updated_at`
+FROM `requests`
+WHERE `reject_notes` IS NOT NULL AND `reject_notes` <> '';
+
+ALTER TABLE `requests` DROP COLUMN `reject_notes`;
@@ -0,0 +1 @@
+ALTER TABLE `users` DROP KEY `ft_users_name_email`;
@@ -0,0 +1,2 @@
+ALTER TABLE `users`
+    ADD FULLTEXT KEY `ft_users_name_email` (`name`, `surname`, `email`);
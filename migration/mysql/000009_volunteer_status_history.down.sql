@@ -0,0 +1 @@
+DROP TABLE IF EXISTS `volunteer_status_history`;
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS `volunteer_status_history` (
+    `id` INT AUTO_INCREMENT PRIMARY KEY,
+    `volunteer_id` INT NOT NULL,
+    `from_status` TINYINT NOT NULL,
+    `to_status` TINYINT NOT NULL,
+    `reason` VARCHAR(255) NOT NULL,
+    `changed_by` INT NOT NULL,
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    KEY `idx_volunteer_status_history_volunteer` (`volunteer_id`, `created_at`),
+    CONSTRAINT `fk_volunteer_status_history_volunteers` FOREIGN KEY (`volunteer_id`) REFERENCES `volunteer_details` (`id`) ON DELETE CASCADE,
+    CONSTRAINT `fk_volunteer_status_history_users` FOREIGN KEY (`changed_by`) REFERENCES `users` (`id`)
+);
@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS `audit_events` (
+    `id` INT AUTO_INCREMENT PRIMARY KEY,
+    `actor_id` INT NULL,
+    `actor_role` VARCHAR(50) NOT NULL DEFAULT '',
+    `action` VARCHAR(100) NOT NULL,
+    `entity_type` VARCHAR(50) NOT NULL DEFAULT '',
+    `entity_id` VARCHAR(50) NOT NULL DEFAULT '',
+    `before` JSON NULL,
+    `after` JSON NULL,
+    `status` SMALLINT NOT NULL,
+    `ip` VARCHAR(45) NOT NULL DEFAULT '',
+    `user_agent` VARCHAR(255) NOT NULL DEFAULT '',
+    `request_id` VARCHAR(64) NOT NULL DEFAULT '',
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    KEY `idx_audit_events_actor` (`actor_id`),
+    KEY `idx_audit_events_entity` (`entity_type`, `entity_id`),
+    KEY `idx_audit_events_action` (`action`),
+    KEY `idx_audit_events_request` (`request_id`),
+    KEY `idx_audit_events_created` (`created_at`)
+);
+
+-- The audit log is append-only.
+CREATE TRIGGER `audit_events_no_update` BEFORE UPDATE ON `audit_events`
+FOR EACH ROW SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'audit_events is append-only';
+
+CREATE TRIGGER `audit_events_no_delete` BEFORE DELETE ON `audit_events`
+FOR EACH ROW SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'audit_events is append-only';
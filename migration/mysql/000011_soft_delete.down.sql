@@ -0,0 +1,25 @@
+-- Rows that were deleted become visible again: run `purge` first to remove
+-- them for good.
+ALTER TABLE `roles`
+    DROP KEY `idx_roles_deleted_at`,
+    DROP COLUMN `deleted_at`;
+
+ALTER TABLE `departments`
+    DROP KEY `idx_departments_deleted_at`,
+    DROP COLUMN `deleted_at`;
+
+ALTER TABLE `countries`
+    DROP KEY `idx_countries_deleted_at`,
+    DROP COLUMN `deleted_at`;
+
+ALTER TABLE `volunteer_details`
+    DROP KEY `idx_volunteer_details_deleted_at`,
+    DROP COLUMN `deleted_at`;
+
+ALTER TABLE `requests`
+    DROP KEY `idx_requests_deleted_at`,
+    DROP COLUMN `deleted_at`;
+
+ALTER TABLE `users`
+    DROP KEY `idx_users_deleted_at`,
+    DROP COLUMN `deleted_at`;
@@ -0,0 +1,25 @@
+-- Deletes only stamp deleted_at so that history keeps its foreign keys;
+-- `purge` removes the rows for good once the retention window has passed.
+ALTER TABLE `users`
+    ADD COLUMN `deleted_at` DATETIME NULL,
+    ADD KEY `idx_users_deleted_at` (`deleted_at`);
+
+ALTER TABLE `requests`
+    ADD COLUMN `deleted_at` DATETIME NULL,
+    ADD KEY `idx_requests_deleted_at` (`deleted_at`);
+
+ALTER TABLE `volunteer_details`
+    ADD COLUMN `deleted_at` DATETIME NULL,
+    ADD KEY `idx_volunteer_details_deleted_at` (`deleted_at`);
+
+ALTER TABLE `countries`
+    ADD COLUMN `deleted_at` DATETIME NULL,
+    ADD KEY `idx_countries_deleted_at` (`deleted_at`);
+
+ALTER TABLE `departments`
+    ADD COLUMN `deleted_at` DATETIME NULL,
+    ADD KEY `idx_departments_deleted_at` (`deleted_at`);
+
+ALTER TABLE `roles`
+    ADD COLUMN `deleted_at` DATETIME NULL,
+    ADD KEY `idx_roles_deleted_at` (`deleted_at`);
@@ -0,0 +1,9 @@
+ALTER TABLE `user_identities` DROP FOREIGN KEY `fk_user_identities_verifier`;
+
+ALTER TABLE `user_identities`
+    DROP KEY `fk_user_identities_verifier_idx`,
+    DROP KEY `idx_user_identities_status_expiry_date`,
+    DROP COLUMN `verified_by`,
+    DROP COLUMN `verified_at`,
+    DROP COLUMN `rejection_reason`,
+    DROP COLUMN `expiry_alerted_at`;
@@ -0,0 +1,11 @@
+-- Identity documents are reviewed by an admin, and expire: verification
+-- requests can only be approved while the user holds a verified document
+-- that has not expired.
+ALTER TABLE `user_identities`
+    ADD COLUMN `verified_by` INT NULL,
+    ADD COLUMN `verified_at` DATETIME NULL,
+    ADD COLUMN `rejection_reason` VARCHAR(255) NULL,
+    ADD COLUMN `expiry_alerted_at` DATETIME NULL,
+    ADD KEY `idx_user_identities_status_expiry_date` (`status`, `expiry_date`),
+    ADD KEY `fk_user_identities_verifier_idx` (`verified_by`),
+    ADD CONSTRAINT `fk_user_identities_verifier` FOREIGN KEY (`verified_by`) REFERENCES `users` (`id`);
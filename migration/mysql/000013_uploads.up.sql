@@ -0,0 +1,5 @@
+-- Keys of the scans of identity documents in the blob store. users.avatar
+-- already exists and now holds the key of the avatar.
+ALTER TABLE `user_identities`
+    ADD COLUMN `front_scan` VARCHAR(255) NULL,
+    ADD COLUMN `back_scan` VARCHAR(255) NULL;
@@ -0,0 +1,5 @@
+-- Numbers stay sealed, and sealed numbers do not fit in the former
+-- VARCHAR(30), so `number` keeps its width.
+ALTER TABLE `user_identities`
+    DROP KEY `idx_user_identities_number_index`,
+    DROP COLUMN `number_index`;
@@ -0,0 +1,7 @@
+-- Identity numbers are sealed by the application, which makes them longer
+-- and unsearchable; number_index is their blind index. Run
+-- `reencrypt-identities` after this migration to seal the existing numbers.
+ALTER TABLE `user_identities`
+    MODIFY COLUMN `number` VARCHAR(255) NOT NULL,
+    ADD COLUMN `number_index` CHAR(64) NULL,
+    ADD KEY `idx_user_identities_number_index` (`number_index`);
@@ -0,0 +1,4 @@
+ALTER TABLE `roles`
+    DROP KEY `uq_roles_code`,
+    DROP COLUMN `code`,
+    DROP COLUMN `status`;
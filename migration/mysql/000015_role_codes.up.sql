@@ -0,0 +1,12 @@
+-- Roles are looked up by their code, which unlike their id is the same in
+-- every environment. Existing roles get their lowercased name as code. The
+-- Role model also maps a status that the table was missing.
+ALTER TABLE `roles`
+    ADD COLUMN `code` VARCHAR(30) NULL AFTER `id`,
+    ADD COLUMN `status` TINYINT NOT NULL DEFAULT 1 AFTER `name`;
+
+UPDATE `roles` SET `code` = LOWER(`name`);
+
+ALTER TABLE `roles`
+    MODIFY COLUMN `code` VARCHAR(30) NOT NULL,
+    ADD UNIQUE KEY `uq_roles_code` (`code`);
@@ -0,0 +1,10 @@
+ALTER TABLE `roles`
+    DROP FOREIGN KEY `fk_roles_parent`;
+
+ALTER TABLE `roles`
+    DROP COLUMN `parent_id`;
+
+ALTER TABLE `role_permissions`
+    DROP FOREIGN KEY `fk_role_permissions_permissions`;
+
+DROP TABLE IF EXISTS `permissions`;
@@ -0,0 +1,2 @@
+-- Dropping the column drops its index and foreign key.
+ALTER TABLE requests DROP COLUMN parent_id;
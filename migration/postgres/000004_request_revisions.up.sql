@@ -0,0 +1,5 @@
+ALTER TABLE requests
+    ADD COLUMN parent_id INTEGER DEFAULT NULL,
+    ADD CONSTRAINT fk_requests_parents FOREIGN KEY (parent_id) REFERENCES requests (id);
+
+CREATE INDEX fk_requests_parents_idx ON requests (parent_id);
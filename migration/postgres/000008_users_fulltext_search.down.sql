@@ -0,0 +1 @@
+DROP INDEX IF EXISTS ft_users_name_email;
@@ -0,0 +1,3 @@
+-- The volunteer search matches this expression, so that it uses the index.
+CREATE INDEX ft_users_name_email ON users
+    USING GIN (to_tsvector('simple', name || ' ' || surname || ' ' || email));
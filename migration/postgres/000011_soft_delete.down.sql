@@ -0,0 +1,8 @@
+-- Rows that were deleted become visible again: run `purge` first to remove
+-- them for good. Dropping the columns drops their indexes.
+ALTER TABLE roles DROP COLUMN deleted_at;
+ALTER TABLE departments DROP COLUMN deleted_at;
+ALTER TABLE countries DROP COLUMN deleted_at;
+ALTER TABLE volunteer_details DROP COLUMN deleted_at;
+ALTER TABLE requests DROP COLUMN deleted_at;
+ALTER TABLE users DROP COLUMN deleted_at;
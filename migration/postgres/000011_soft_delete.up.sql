@@ -0,0 +1,19 @@
+-- Deletes only stamp deleted_at so that history keeps its foreign keys;
+-- `purge` removes the rows for good once the retention window has passed.
+ALTER TABLE users ADD COLUMN deleted_at TIMESTAMP NULL;
+CREATE INDEX idx_users_deleted_at ON users (deleted_at);
+
+ALTER TABLE requests ADD COLUMN deleted_at TIMESTAMP NULL;
+CREATE INDEX idx_requests_deleted_at ON requests (deleted_at);
+
+ALTER TABLE volunteer_details ADD COLUMN deleted_at TIMESTAMP NULL;
+CREATE INDEX idx_volunteer_details_deleted_at ON volunteer_details (deleted_at);
+
+ALTER TABLE countries ADD COLUMN deleted_at TIMESTAMP NULL;
+CREATE INDEX idx_countries_deleted_at ON countries (deleted_at);
+
+ALTER TABLE departments ADD COLUMN deleted_at TIMESTAMP NULL;
+CREATE INDEX idx_departments_deleted_at ON departments (deleted_at);
+
+ALTER TABLE roles ADD COLUMN deleted_at TIMESTAMP NULL;
+CREATE INDEX idx_roles_deleted_at ON roles (deleted_at);
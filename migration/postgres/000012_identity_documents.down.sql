@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_user_identities_status_expiry_date;
+
+-- Dropping verified_by drops its index and foreign key.
+ALTER TABLE user_identities
+    DROP COLUMN verified_by,
+    DROP COLUMN verified_at,
+    DROP COLUMN rejection_reason,
+    DROP COLUMN expiry_alerted_at;
@@ -0,0 +1,12 @@
+-- Identity documents are reviewed by an admin, and expire: verification
+-- requests can only be approved while the user holds a verified document
+-- that has not expired.
+ALTER TABLE user_identities
+    ADD COLUMN verified_by INTEGER NULL,
+    ADD COLUMN verified_at TIMESTAMP NULL,
+    ADD COLUMN rejection_reason VARCHAR(255) NULL,
+    ADD COLUMN expiry_alerted_at TIMESTAMP NULL,
+    ADD CONSTRAINT fk_user_identities_verifier FOREIGN KEY (verified_by) REFERENCES users (id);
+
+CREATE INDEX idx_user_identities_status_expiry_date ON user_identities (status, expiry_date);
+CREATE INDEX fk_user_identities_verifier_idx ON user_identities (verified_by);
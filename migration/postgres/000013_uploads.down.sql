@@ -0,0 +1,4 @@
+-- The scans stay in the blob store.
+ALTER TABLE user_identities
+    DROP COLUMN front_scan,
+    DROP COLUMN back_scan;
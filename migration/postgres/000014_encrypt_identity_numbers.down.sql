@@ -0,0 +1,4 @@
+-- Numbers stay sealed, and sealed numbers do not fit in the former
+-- VARCHAR(30), so `number` keeps its width. Dropping number_index drops its
+-- index.
+ALTER TABLE user_identities DROP COLUMN number_index;
@@ -0,0 +1,8 @@
+-- Identity numbers are sealed by the application, which makes them longer
+-- and unsearchable; number_index is their blind index. Run
+-- `reencrypt-identities` after this migration to seal the existing numbers.
+ALTER TABLE user_identities
+    ALTER COLUMN number TYPE VARCHAR(255),
+    ADD COLUMN number_index CHAR(64) NULL;
+
+CREATE INDEX idx_user_identities_number_index ON user_identities (number_index);
@@ -0,0 +1,4 @@
+-- Dropping code drops its index.
+ALTER TABLE roles
+    DROP COLUMN code,
+    DROP COLUMN status;
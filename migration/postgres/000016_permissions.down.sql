@@ -0,0 +1,8 @@
+-- Dropping parent_id drops its foreign key.
+ALTER TABLE roles
+    DROP COLUMN parent_id;
+
+ALTER TABLE role_permissions
+    DROP CONSTRAINT fk_role_permissions_permissions;
+
+DROP TABLE IF EXISTS permissions;
@@ -0,0 +1,29 @@
+-- permissions lists the permissions that roles can be granted, so that
+-- grants are checked against it. role_permissions joins roles to it by
+-- permission code. Permissions already granted are added to the list.
+CREATE TABLE IF NOT EXISTS permissions (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(100) NOT NULL,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uq_permissions_code UNIQUE (code)
+);
+
+INSERT INTO permissions (code, description) VALUES
+    ('request.review', 'Review registration and verification requests'),
+    ('country.write', 'Create, update and delete countries'),
+    ('department.write', 'Create, update and delete departments'),
+    ('role.write', 'Create, update and delete roles and grant them permissions')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO permissions (code)
+SELECT DISTINCT permission FROM role_permissions
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE role_permissions
+    ADD CONSTRAINT fk_role_permissions_permissions FOREIGN KEY (permission) REFERENCES permissions (code);
+
+-- A role inherits the permissions of its parent, and of the parent's parent.
+ALTER TABLE roles
+    ADD COLUMN parent_id INTEGER NULL,
+    ADD CONSTRAINT fk_roles_parent FOREIGN KEY (parent_id) REFERENCES roles (id);
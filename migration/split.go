@@ -0,0 +1,78 @@
+package migration
+
+import (
+	"strings"
+)
+
+// Markers around a statement that contains semicolons of its own, such as
+// a trigger body.
+const (
+	statementBegin = "-- +migrate StatementBegin"
+	statementEnd   = "-- +migrate StatementEnd"
+)
+
+// split cuts a migration into statements at the semicolons that end them,
+// ignoring those in quotes and comments, and drops the comments. The text
+// between statementBegin and statementEnd is kept as one statement.
+func split(sql string) []string {
+	var (
+		statements []string
+		current    strings.Builder
+		quote      byte
+		inBlock    bool
+	)
+	flush := func() {
+		if statement := strings.TrimSpace(current.String()); statement != "" {
+			statements = append(statements, statement)
+		}
+		current.Reset()
+	}
+
+	for _, line := range strings.SplitAfter(sql, "\n") {
+		if quote == 0 {
+			switch strings.TrimSpace(line) {
+			case statementBegin:
+				flush()
+				inBlock = true
+				continue
+			case statementEnd:
+				flush()
+				inBlock = false
+				continue
+			}
+		}
+		if inBlock {
+			current.WriteString(line)
+			continue
+		}
+
+		for i := 0; i < len(line); i++ {
+			c := line[i]
+			switch {
+			case quote != 0:
+				if c == '\\' && quote == '\'' && i+1 < len(line) {
+					current.WriteByte(c)
+					i++
+					c = line[i]
+				} else if c == quote {
+					quote = 0
+				}
+			case c == '-' && strings.HasPrefix(line[i:], "--"):
+				// Skip the comment but keep its line break.
+				i = len(line) - 1
+				c = line[i]
+				if c != '\n' {
+					continue
+				}
+			case c == '\'' || c == '"' || c == '`':
+				quote = c
+			case c == ';':
+				flush()
+				continue
+			}
+			current.WriteByte(c)
+		}
+	}
+	flush()
+	return statements
+}
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS user_identities;
+DROP TABLE IF EXISTS requests;
+DROP TABLE IF EXISTS volunteer_details;
+DROP TABLE IF EXISTS users;
+DROP TABLE IF EXISTS countries;
+DROP TABLE IF EXISTS departments;
+DROP TABLE IF EXISTS roles;
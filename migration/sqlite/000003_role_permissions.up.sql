@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS role_permissions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    role_id INTEGER NOT NULL,
+    permission VARCHAR(100) NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_role_permissions_roles FOREIGN KEY (role_id) REFERENCES roles (id)
+);
+
+CREATE UNIQUE INDEX uq_role_permissions_role_permission ON role_permissions (role_id, permission);
+
+INSERT OR IGNORE INTO role_permissions (role_id, permission)
+SELECT roles.id, permissions.permission
+FROM roles
+CROSS JOIN (
+    SELECT 'request.review' AS permission
+    UNION ALL SELECT 'country.write'
+    UNION ALL SELECT 'department.write'
+    UNION ALL SELECT 'role.write'
+) AS permissions
+WHERE roles.name = 'admin';
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS fk_requests_parents_idx;
+
+ALTER TABLE requests DROP COLUMN parent_id;
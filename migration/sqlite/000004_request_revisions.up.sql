@@ -0,0 +1,3 @@
+ALTER TABLE requests ADD COLUMN parent_id INTEGER DEFAULT NULL REFERENCES requests (id);
+
+CREATE INDEX fk_requests_parents_idx ON requests (parent_id);
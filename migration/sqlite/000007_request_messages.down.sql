@@ -0,0 +1,16 @@
+ALTER TABLE requests ADD COLUMN reject_notes VARCHAR(255) DEFAULT NULL;
+
+-- The first message of the reviewer becomes the reject notes again; the rest
+-- of the thread is lost, and so are the notes of requests without a reviewer.
+UPDATE requests
+SET reject_notes = (
+    SELECT substr(request_messages.body, 1, 255)
+    FROM request_messages
+    WHERE request_messages.request_id = requests.id
+      AND request_messages.sender_id = requests.verifier_id
+    ORDER BY request_messages.created_at, request_messages.id
+    LIMIT 1
+)
+WHERE verifier_id IS NOT NULL;
+
+DROP TABLE IF EXISTS request_messages;
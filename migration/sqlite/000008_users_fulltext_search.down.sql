@@ -0,0 +1 @@
+-- SQLite has no FULLTEXT indexes.
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS volunteer_status_history (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    volunteer_id INTEGER NOT NULL,
+    from_status TINYINT NOT NULL,
+    to_status TINYINT NOT NULL,
+    reason VARCHAR(255) NOT NULL,
+    changed_by INTEGER NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_volunteer_status_history_volunteers FOREIGN KEY (volunteer_id) REFERENCES volunteer_details (id) ON DELETE CASCADE,
+    CONSTRAINT fk_volunteer_status_history_users FOREIGN KEY (changed_by) REFERENCES users (id)
+);
+
+CREATE INDEX idx_volunteer_status_history_volunteer ON volunteer_status_history (volunteer_id, created_at);
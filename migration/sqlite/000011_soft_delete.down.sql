@@ -0,0 +1,19 @@
+-- Rows that were deleted become visible again: run `purge` first to remove
+-- them for good.
+DROP INDEX IF EXISTS idx_roles_deleted_at;
+ALTER TABLE roles DROP COLUMN deleted_at;
+
+DROP INDEX IF EXISTS idx_departments_deleted_at;
+ALTER TABLE departments DROP COLUMN deleted_at;
+
+DROP INDEX IF EXISTS idx_countries_deleted_at;
+ALTER TABLE countries DROP COLUMN deleted_at;
+
+DROP INDEX IF EXISTS idx_volunteer_details_deleted_at;
+ALTER TABLE volunteer_details DROP COLUMN deleted_at;
+
+DROP INDEX IF EXISTS idx_requests_deleted_at;
+ALTER TABLE requests DROP COLUMN deleted_at;
+
+DROP INDEX IF EXISTS idx_users_deleted_at;
+ALTER TABLE users DROP COLUMN deleted_at;
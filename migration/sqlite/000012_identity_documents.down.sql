@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS fk_user_identities_verifier_idx;
+DROP INDEX IF EXISTS idx_user_identities_status_expiry_date;
+
+ALTER TABLE user_identities DROP COLUMN expiry_alerted_at;
+ALTER TABLE user_identities DROP COLUMN rejection_reason;
+ALTER TABLE user_identities DROP COLUMN verified_at;
+ALTER TABLE user_identities DROP COLUMN verified_by;
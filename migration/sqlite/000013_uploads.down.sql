@@ -0,0 +1,3 @@
+-- The scans stay in the blob store.
+ALTER TABLE user_identities DROP COLUMN back_scan;
+ALTER TABLE user_identities DROP COLUMN front_scan;
@@ -0,0 +1,4 @@
+-- Numbers stay sealed.
+DROP INDEX IF EXISTS idx_user_identities_number_index;
+
+ALTER TABLE user_identities DROP COLUMN number_index;
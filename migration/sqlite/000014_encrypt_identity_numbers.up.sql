@@ -0,0 +1,7 @@
+-- Identity numbers are sealed by the application and number_index is their
+-- blind index. SQLite does not enforce VARCHAR lengths, so `number` fits
+-- sealed values as it is. Run `reencrypt-identities` after this migration to
+-- seal the existing numbers.
+ALTER TABLE user_identities ADD COLUMN number_index CHAR(64) NULL;
+
+CREATE INDEX idx_user_identities_number_index ON user_identities (number_index);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS uq_roles_code;
+
+ALTER TABLE roles DROP COLUMN status;
+ALTER TABLE roles DROP COLUMN code;
@@ -0,0 +1,10 @@
+-- Roles are looked up by their code, which unlike their id is the same in
+-- every environment. Existing roles get their lowercased name as code. The
+-- Role model also maps a status that the table was missing. SQLite cannot
+-- make an added column NOT NULL without a default, so code stays nullable.
+ALTER TABLE roles ADD COLUMN code VARCHAR(30) NULL;
+ALTER TABLE roles ADD COLUMN status SMALLINT NOT NULL DEFAULT 1;
+
+UPDATE roles SET code = LOWER(name);
+
+CREATE UNIQUE INDEX uq_roles_code ON roles (code);
@@ -0,0 +1,3 @@
+ALTER TABLE roles DROP COLUMN parent_id;
+
+DROP TABLE IF EXISTS permissions;
@@ -0,0 +1,25 @@
+-- permissions lists the permissions that roles can be granted, so that
+-- grants are checked against it. role_permissions joins roles to it by
+-- permission code. Permissions already granted are added to the list.
+-- SQLite cannot add foreign keys to existing tables, so neither
+-- role_permissions nor roles.parent_id get one; the application checks both.
+CREATE TABLE IF NOT EXISTS permissions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    code VARCHAR(100) NOT NULL,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX uq_permissions_code ON permissions (code);
+
+INSERT OR IGNORE INTO permissions (code, description) VALUES
+    ('request.review', 'Review registration and verification requests'),
+    ('country.write', 'Create, update and delete countries'),
+    ('department.write', 'Create, update and delete departments'),
+    ('role.write', 'Create, update and delete roles and grant them permissions');
+
+INSERT OR IGNORE INTO permissions (code)
+SELECT DISTINCT permission FROM role_permissions;
+
+-- A role inherits the permissions of its parent, and of the parent's parent.
+ALTER TABLE roles ADD COLUMN parent_id INTEGER NULL;